@@ -8,6 +8,7 @@ import (
 	cmdutils "gman/internal/cmd"
 	"gman/internal/display"
 	"gman/internal/errors"
+	"gman/internal/hooks"
 
 	"github.com/spf13/cobra"
 )
@@ -83,6 +84,8 @@ func runAdd(cmd *cobra.Command, args []string) error {
 	// Get absolute path for display
 	absPath, _ := filepath.Abs(path)
 	display.PrintSuccess(fmt.Sprintf("Added repository: %s -> %s", alias, absPath))
+	runHooks(hooks.RepoAdded, hooks.Repository{Alias: alias, Path: absPath})
+	refreshCodeWorkspaces()
 
 	return nil
 }
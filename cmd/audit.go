@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	cmdutils "gman/internal/cmd"
+	"gman/internal/di"
+	"gman/internal/display"
+	"gman/internal/errors"
+	"gman/internal/git"
+	"gman/internal/pager"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	auditLogRepo    string
+	auditLogCommand string
+	auditLogSince   string
+	auditLogFailed  bool
+	auditLogLimit   int
+)
+
+// auditCmd represents the audit command group
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Audit what gman changed and how big repositories grew",
+	Long: `Inspect the audit trail of state-changing git commands run by gman, or
+report the history size of repositories with 'gman audit objects'.
+
+Auditing is off by default. Enable it in the configuration:
+
+  settings:
+    audit_log: true
+
+or per invocation with GMAN_AUDIT_LOG=1.`,
+}
+
+// auditLogCmd represents the audit log viewer
+var auditLogCmd = &cobra.Command{
+	Use:   "log",
+	Short: "Show the state-changing git commands gman ran",
+	Long: `Show the audit trail: every state-changing git command gman ran or refused,
+with the repository, timestamp, user, the gman command that initiated it and
+its result. Read-only commands and fetches are not audited.
+
+Entries are appended as JSON lines to audit.log next to the configuration
+file. gman never rotates or truncates the file.
+
+Examples:
+  gman audit log                         # Last 20 entries
+  gman audit log --repo backend          # Only entries of 'backend'
+  gman audit log --since 7d              # Entries of the last week
+  gman audit log --command "work sync"   # Entries initiated by gman work sync
+  gman audit log --failed                # Failed and refused commands
+  gman audit log --limit 0 --output json # Everything, machine-readable`,
+	Args: cobra.NoArgs,
+	RunE: runAuditLog,
+}
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+	auditCmd.AddCommand(auditLogCmd)
+
+	auditLogCmd.Flags().StringVarP(&auditLogRepo, "repo", "r", "", "Only show entries of this repository alias")
+	auditLogCmd.Flags().StringVarP(&auditLogCommand, "command", "c", "", "Only show entries initiated by gman commands containing this text")
+	auditLogCmd.Flags().StringVar(&auditLogSince, "since", "", "Only show entries newer than a duration (e.g. 12h, 7d) or date (2024-01-31)")
+	auditLogCmd.Flags().BoolVar(&auditLogFailed, "failed", false, "Only show failed and refused commands")
+	auditLogCmd.Flags().IntVarP(&auditLogLimit, "limit", "n", 20, "Number of most recent entries to show (0 for all)")
+
+	pager.Enable(auditLogCmd)
+}
+
+func runAuditLog(cmd *cobra.Command, args []string) error {
+	configMgr := di.ConfigManager()
+	cfg := configMgr.GetConfig()
+
+	var repoPath string
+	if auditLogRepo != "" {
+		path, exists := cfg.Repositories[auditLogRepo]
+		if !exists {
+			return errors.NotFoundError("repository", auditLogRepo)
+		}
+		repoPath = path
+	}
+	var since time.Time
+	if auditLogSince != "" {
+		var err error
+		if since, err = parseSince(auditLogSince, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	records, err := git.ReadAuditLog(filepath.Join(configMgr.GetConfigDir(), git.AuditLogFile))
+	if err != nil {
+		return err
+	}
+
+	// Filter before limiting so --limit applies to matching entries
+	filtered := records[:0]
+	for _, record := range records {
+		if repoPath != "" && record.Repo != repoPath {
+			continue
+		}
+		if auditLogCommand != "" && !strings.Contains(record.Initiator, auditLogCommand) {
+			continue
+		}
+		if !since.IsZero() && record.Time.Before(since) {
+			continue
+		}
+		if auditLogFailed && record.Result == git.AuditOK {
+			continue
+		}
+		filtered = append(filtered, record)
+	}
+	if auditLogLimit > 0 && len(filtered) > auditLogLimit {
+		filtered = filtered[len(filtered)-auditLogLimit:]
+	}
+
+	return cmdutils.Render(filtered, func() error {
+		if len(filtered) == 0 {
+			if !cfg.Settings.AuditLog {
+				fmt.Println("No audit entries. Enable auditing with 'audit_log: true' under settings, or GMAN_AUDIT_LOG=1.")
+			} else {
+				fmt.Println("No matching audit entries.")
+			}
+			return nil
+		}
+		printAuditRecords(filtered, cfg.Repositories)
+		return nil
+	})
+}
+
+// printAuditRecords prints one line per record, with repositories shown by alias
+func printAuditRecords(records []git.AuditRecord, repositories map[string]string) {
+	aliases := make(map[string]string, len(repositories))
+	for alias, path := range repositories {
+		aliases[path] = alias
+	}
+
+	for _, record := range records {
+		repo := aliases[record.Repo]
+		if repo == "" {
+			repo = record.Repo
+		}
+
+		var result string
+		switch record.Result {
+		case git.AuditOK:
+			result = color.GreenString(display.Icon("✅", "OK") + " ok")
+		case git.AuditRefused:
+			result = color.YellowString(display.Icon("🛡️", "REFUSED") + " refused")
+		default:
+			result = color.RedString(display.Icon("❌", "ERROR")+" exit %d", record.ExitCode)
+		}
+
+		fmt.Printf("%s  %-20s %-40s %s  %s  %s\n",
+			record.Time.Format("2006-01-02 15:04:05"),
+			color.CyanString(repo),
+			color.WhiteString("git %s", strings.Join(record.Args, " ")),
+			color.HiBlackString("%s (%s)", record.Initiator, record.User),
+			result,
+			color.HiBlackString("%s", record.Error),
+		)
+	}
+}
+
+// parseSince parses a --since value: a duration such as 12h or 7d before
+// now, or a date in YYYY-MM-DD form
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	if duration, err := time.ParseDuration(value); err == nil && duration >= 0 {
+		return now.Add(-duration), nil
+	}
+	if date, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return date, nil
+	}
+	return time.Time{}, errors.NewInvalidInputError(value,
+		"--since takes a duration such as 12h or 7d, or a date such as 2024-01-31")
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	cmdutils "gman/internal/cmd"
+	"gman/internal/di"
+	"gman/internal/git"
+	"gman/internal/pager"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	auditObjectsGroup string
+	auditObjectsDirs  int
+)
+
+// auditObjectsCmd represents the repository size audit
+var auditObjectsCmd = &cobra.Command{
+	Use:   "objects",
+	Short: "Report the history size of repositories",
+	Long: `Report how big the history of every repository (or the repositories of one
+group) is: commits, objects, pack files and their size, loose objects and
+the top-level directories that contributed the most, largest first.
+
+Directory sizes add up the uncompressed size of every version of every file
+ever committed below the directory, on any branch or tag. A directory far
+bigger than its current contents usually held large binaries that could be
+removed from history; a repository with several big directories may be
+worth splitting. Files in the repository root are shown as ".".
+
+Examples:
+  gman audit objects                  # All repositories
+  gman audit objects --group backend  # One group
+  gman audit objects --dirs 10        # Ten biggest directories each
+  gman audit objects --output json`,
+	Args: cobra.NoArgs,
+	RunE: runAuditObjects,
+}
+
+func init() {
+	auditCmd.AddCommand(auditObjectsCmd)
+
+	auditObjectsCmd.Flags().StringVarP(&auditObjectsGroup, "group", "g", "", "Only report the repositories of this group")
+	auditObjectsCmd.Flags().IntVar(&auditObjectsDirs, "dirs", 3, "Number of biggest directories to show per repository (0 to skip)")
+
+	pager.Enable(auditObjectsCmd)
+}
+
+// objectAuditRecord is the history size of one repository
+type objectAuditRecord struct {
+	Alias        string              `json:"alias" yaml:"alias"`
+	Path         string              `json:"path" yaml:"path"`
+	Commits      int                 `json:"commits" yaml:"commits"`
+	Objects      int                 `json:"objects" yaml:"objects"`
+	LooseObjects int                 `json:"loose_objects" yaml:"loose_objects"`
+	Packs        int                 `json:"packs" yaml:"packs"`
+	PackSize     int64               `json:"pack_bytes" yaml:"pack_bytes"`
+	LooseSize    int64               `json:"loose_bytes" yaml:"loose_bytes"`
+	Directories  []git.DirectorySize `json:"directories,omitempty" yaml:"directories,omitempty" csv:"-"`
+	Error        string              `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+func runAuditObjects(cmd *cobra.Command, args []string) error {
+	repositories, err := execRepositories(auditObjectsGroup)
+	if err != nil {
+		return err
+	}
+
+	gitMgr := di.GitManager()
+	records := forEachRepository(repositories, func(alias, path string) objectAuditRecord {
+		record := objectAuditRecord{Alias: alias, Path: path}
+		stats, err := gitMgr.ObjectStats(path, auditObjectsDirs)
+		if err != nil {
+			record.Error = err.Error()
+		} else {
+			record.Commits, record.Objects, record.LooseObjects = stats.Commits, stats.Objects, stats.LooseObjects
+			record.Packs, record.PackSize, record.LooseSize = stats.Packs, stats.PackSize, stats.LooseSize
+			record.Directories = stats.Directories
+		}
+		return record
+	})
+
+	// Largest first; failed repositories last
+	sort.Slice(records, func(i, j int) bool {
+		if failedI, failedJ := records[i].Error != "", records[j].Error != ""; failedI != failedJ {
+			return failedJ
+		}
+		a, b := records[i].PackSize+records[i].LooseSize, records[j].PackSize+records[j].LooseSize
+		if a != b {
+			return a > b
+		}
+		return records[i].Alias < records[j].Alias
+	})
+
+	return cmdutils.Render(records, func() error {
+		printObjectAudit(records)
+		return nil
+	})
+}
+
+// printObjectAudit prints one row per repository with its biggest
+// directories below it
+func printObjectAudit(records []objectAuditRecord) {
+	if len(records) == 0 {
+		fmt.Println("No repositories to audit.")
+		return
+	}
+
+	maxAlias := len("Alias")
+	for _, record := range records {
+		maxAlias = max(maxAlias, len(record.Alias))
+	}
+
+	fmt.Printf("%-*s %9s %10s %6s %12s %12s\n", maxAlias, "Alias", "Commits", "Objects", "Packs", "Pack size", "Loose size")
+	for _, record := range records {
+		if record.Error != "" {
+			fmt.Printf("%-*s %s\n", maxAlias, record.Alias, color.RedString("%s", record.Error))
+			continue
+		}
+		fmt.Printf("%-*s %9d %10d %6d %s %12s\n", maxAlias, record.Alias,
+			record.Commits, record.Objects, record.Packs,
+			color.CyanString("%12s", formatBytes(record.PackSize)), formatBytes(record.LooseSize))
+		if len(record.Directories) > 0 {
+			dirs := make([]string, 0, len(record.Directories))
+			for _, dir := range record.Directories {
+				dirs = append(dirs, fmt.Sprintf("%s %s", dir.Path, formatBytes(dir.Size)))
+			}
+			fmt.Printf("%-*s %s\n", maxAlias, "", color.HiBlackString("└─ %s", strings.Join(dirs, ", ")))
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"gman/internal/bench"
+	cmdutils "gman/internal/cmd"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	benchRepos      int
+	benchIterations int
+	benchDir        string
+	benchKeep       bool
+)
+
+// benchCmd represents the bench command
+var benchCmd = &cobra.Command{
+	Use:   "bench",
+	Short: "Measure status, sync and search speed on synthetic repositories",
+	Long: `Generate synthetic repositories, each with a local origin remote, and measure
+how fast gman reads status (with and without fetch), syncs and searches them.
+The report lists the best and median time of each operation and the number of
+repositories handled per second, so results of different releases and machines
+can be compared. Your configured repositories are not touched.
+
+Examples:
+  gman bench                            # 20 repositories, 3 iterations
+  gman bench --repos 200 --iterations 5
+  gman bench -o json > bench.json       # Keep results for later comparison`,
+	Args: cobra.NoArgs,
+	RunE: runBench,
+}
+
+func init() {
+	rootCmd.AddCommand(benchCmd)
+
+	benchCmd.Flags().IntVar(&benchRepos, "repos", 20, "Number of synthetic repositories to generate")
+	benchCmd.Flags().IntVar(&benchIterations, "iterations", 3, "Times each operation is measured")
+	benchCmd.Flags().StringVar(&benchDir, "dir", "", "New or empty directory for the repositories (default: a temporary directory)")
+	benchCmd.Flags().BoolVar(&benchKeep, "keep", false, "Keep the generated repositories")
+}
+
+func runBench(cmd *cobra.Command, args []string) error {
+	if benchRepos <= 0 || benchIterations <= 0 {
+		return fmt.Errorf("--repos and --iterations must be positive")
+	}
+
+	baseDir, cleanup, err := benchDirectory(benchDir)
+	if err != nil {
+		return err
+	}
+	if !benchKeep {
+		defer cleanup()
+	}
+
+	structured := cmdutils.StructuredOutput()
+	if !structured {
+		fmt.Fprintf(os.Stderr, "Generating %d repositories in %s...\n", benchRepos, baseDir)
+	}
+	start := time.Now()
+	repositories, err := bench.Generate(baseDir, benchRepos)
+	if err != nil {
+		return fmt.Errorf("failed to generate repositories: %w", err)
+	}
+	if !structured {
+		fmt.Fprintf(os.Stderr, "Generated in %s, measuring %d iterations...\n\n", time.Since(start).Round(time.Millisecond), benchIterations)
+	}
+
+	results := bench.Run(repositories, benchIterations)
+	return cmdutils.Render(results, func() error {
+		printBenchReport(results)
+		if benchKeep {
+			fmt.Printf("\nRepositories kept in %s\n", baseDir)
+		}
+		return nil
+	})
+}
+
+// benchDirectory returns the directory to generate the repositories in and
+// a function removing what the benchmark created there. An existing --dir
+// must be empty so that no files of the user are removed.
+func benchDirectory(dir string) (string, func(), error) {
+	if dir == "" {
+		tempDir, err := os.MkdirTemp("", "gman-bench-*")
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to create temp directory: %w", err)
+		}
+		return tempDir, func() { os.RemoveAll(tempDir) }, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	switch {
+	case os.IsNotExist(err):
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return "", nil, fmt.Errorf("failed to create %s: %w", dir, err)
+		}
+		return dir, func() { os.RemoveAll(dir) }, nil
+	case err != nil:
+		return "", nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	case len(entries) > 0:
+		return "", nil, fmt.Errorf("%s is not empty; pass an empty or new directory to --dir", dir)
+	}
+
+	// The directory was the user's, so only its new contents are removed
+	return dir, func() {
+		entries, _ := os.ReadDir(dir)
+		for _, entry := range entries {
+			os.RemoveAll(filepath.Join(dir, entry.Name()))
+		}
+	}, nil
+}
+
+// printBenchReport prints the results with the environment they were measured in
+func printBenchReport(results []bench.Result) {
+	gitVersion := "git not found"
+	if output, err := exec.Command("git", "--version").Output(); err == nil {
+		gitVersion = strings.TrimSpace(string(output))
+	}
+	fmt.Printf("gman bench: %d repositories, %d iterations\n", benchRepos, benchIterations)
+	fmt.Printf("%s, %s %s/%s, %d CPUs\n\n", gitVersion, runtime.Version(), runtime.GOOS, runtime.GOARCH, runtime.NumCPU())
+
+	fmt.Printf("%-16s %10s %10s %10s\n",
+		color.CyanString("Operation"), color.CyanString("Best"), color.CyanString("Median"), color.CyanString("Repos/s"))
+	for _, result := range results {
+		if result.Error != "" {
+			fmt.Printf("%-16s %s\n", result.Operation, color.RedString("failed: %s", result.Error))
+			continue
+		}
+		fmt.Printf("%-16s %10s %10s %10.1f\n", result.Operation,
+			result.Best.Round(time.Millisecond), result.Median.Round(time.Millisecond), result.ReposPerSecond)
+	}
+}
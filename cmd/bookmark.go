@@ -0,0 +1,330 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	cmdutils "gman/internal/cmd"
+	"gman/internal/di"
+	"gman/internal/display"
+	"gman/internal/errors"
+	"gman/pkg/types"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var bookmarkOpenCd bool
+
+// bookmarkCmd represents the bookmark command
+var bookmarkCmd = &cobra.Command{
+	Use:   "bookmark",
+	Short: "Bookmark files and directories inside repositories",
+	Long: `Bookmark files and directories inside repositories to jump straight to them.
+
+Opening a directory bookmark changes into it, like 'gman switch'; opening
+a file bookmark opens the file in your editor ($EDITOR, $VISUAL or the first
+editor found). Bookmarks are also listed in the 'gman switch' selector,
+where a file bookmark switches to the directory containing it.
+
+Examples:
+  gman bookmark add api:deploy/k8s           # Bookmark named 'k8s'
+  gman bookmark add api:docs/runbook.md oncall
+  gman bookmark open oncall                  # Edit the runbook
+  gman bookmark open oncall --cd             # Change into api/docs
+  gman bookmark list
+  gman bookmark remove k8s`,
+}
+
+// bookmarkAddCmd adds a bookmark
+var bookmarkAddCmd = &cobra.Command{
+	Use:   "add <alias>:<path> [name]",
+	Short: "Bookmark a file or directory inside a repository",
+	Long: `Bookmark a file or directory inside a repository. The path is relative to
+the repository root; the name defaults to its last element.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runBookmarkAdd,
+}
+
+// bookmarkOpenCmd opens a bookmark
+var bookmarkOpenCmd = &cobra.Command{
+	Use:   "open <name>",
+	Short: "Jump to a bookmarked directory or edit a bookmarked file",
+	Long: `Change into a bookmarked directory, or open a bookmarked file in your editor.
+With --cd a file bookmark changes into the directory containing the file.
+
+Changing directories requires the shell integration ('gman shell-init').`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runBookmarkOpen,
+	ValidArgsFunction: completeBookmarkNames,
+}
+
+// bookmarkListCmd lists the bookmarks
+var bookmarkListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List bookmarks",
+	Long:    `List the bookmarks with the location they point at.`,
+	Aliases: []string{"ls"},
+	Args:    cobra.NoArgs,
+	RunE:    runBookmarkList,
+}
+
+// bookmarkRemoveCmd removes a bookmark
+var bookmarkRemoveCmd = &cobra.Command{
+	Use:               "remove <name>",
+	Short:             "Remove a bookmark",
+	Long:              `Remove a bookmark. The bookmarked file or directory is left untouched.`,
+	Aliases:           []string{"rm", "delete"},
+	Args:              cobra.ExactArgs(1),
+	RunE:              runBookmarkRemove,
+	ValidArgsFunction: completeBookmarkNames,
+}
+
+func init() {
+	rootCmd.AddCommand(bookmarkCmd)
+
+	bookmarkCmd.AddCommand(bookmarkAddCmd)
+	bookmarkCmd.AddCommand(bookmarkOpenCmd)
+	bookmarkCmd.AddCommand(bookmarkListCmd)
+	bookmarkCmd.AddCommand(bookmarkRemoveCmd)
+
+	bookmarkOpenCmd.Flags().BoolVar(&bookmarkOpenCd, "cd", false, "Change into the directory containing a bookmarked file instead of editing it")
+}
+
+// bookmarkRecord is a bookmark with the location it points at
+type bookmarkRecord struct {
+	Name       string `json:"name" yaml:"name"`
+	Repository string `json:"repository" yaml:"repository"`
+	Path       string `json:"path" yaml:"path"`
+	Location   string `json:"location" yaml:"location"`
+	Kind       string `json:"kind" yaml:"kind"` // "file", "directory" or "missing"
+}
+
+func runBookmarkAdd(cmd *cobra.Command, args []string) error {
+	configMgr := di.ConfigManager()
+	cfg := configMgr.GetConfig()
+
+	bookmark, err := parseBookmarkTarget(args[0], cfg.Repositories)
+	if err != nil {
+		return err
+	}
+	location := bookmarkLocation(cfg.Repositories[bookmark.Repository], bookmark)
+	if _, err := os.Stat(location); err != nil {
+		return fmt.Errorf("failed to bookmark '%s': %w", args[0], err)
+	}
+
+	name := bookmark.Repository
+	if bookmark.Path != "" {
+		name = path.Base(bookmark.Path)
+	}
+	if len(args) == 2 {
+		name = args[1]
+	}
+	if err := configMgr.AddBookmark(name, bookmark); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Bookmarked %s as '%s'\n", display.SuccessIcon(), location, name)
+	return nil
+}
+
+func runBookmarkOpen(cmd *cobra.Command, args []string) error {
+	cfg := di.ConfigManager().GetConfig()
+	bookmark, exists := cfg.Bookmarks[args[0]]
+	if !exists {
+		return errors.NotFoundError("bookmark", args[0])
+	}
+	location := bookmarkLocation(cfg.Repositories[bookmark.Repository], bookmark)
+	info, err := os.Stat(location)
+	if err != nil {
+		return fmt.Errorf("bookmark '%s' points at a missing path: %w", args[0], err)
+	}
+
+	if !info.IsDir() && !bookmarkOpenCd {
+		return editFile(location)
+	}
+
+	if !isShellIntegrationActive() {
+		return fmt.Errorf("shell integration required to change directories; add 'eval \"$(gman shell-init bash)\"' (or zsh/fish) to your rc file")
+	}
+	dir := location
+	if !info.IsDir() {
+		dir = filepath.Dir(location)
+	}
+	fmt.Printf("GMAN_CD:%s", dir)
+	return nil
+}
+
+func runBookmarkList(cmd *cobra.Command, args []string) error {
+	cfg := di.ConfigManager().GetConfig()
+
+	names := make([]string, 0, len(cfg.Bookmarks))
+	for name := range cfg.Bookmarks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	records := make([]bookmarkRecord, 0, len(names))
+	for _, name := range names {
+		bookmark := cfg.Bookmarks[name]
+		record := bookmarkRecord{
+			Name:       name,
+			Repository: bookmark.Repository,
+			Path:       bookmark.Path,
+			Location:   bookmarkLocation(cfg.Repositories[bookmark.Repository], bookmark),
+			Kind:       "missing",
+		}
+		if info, err := os.Stat(record.Location); err == nil {
+			record.Kind = "file"
+			if info.IsDir() {
+				record.Kind = "directory"
+			}
+		}
+		records = append(records, record)
+	}
+
+	return cmdutils.Render(records, func() error {
+		printBookmarks(records)
+		return nil
+	})
+}
+
+func runBookmarkRemove(cmd *cobra.Command, args []string) error {
+	if err := di.ConfigManager().RemoveBookmark(args[0]); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Removed bookmark '%s'\n", display.SuccessIcon(), args[0])
+	return nil
+}
+
+// printBookmarks prints one line per bookmark
+func printBookmarks(records []bookmarkRecord) {
+	if len(records) == 0 {
+		fmt.Println("No bookmarks. Use 'gman bookmark add <alias>:<path>' to add one.")
+		return
+	}
+
+	width := 0
+	for _, record := range records {
+		width = max(width, len(record.Name))
+	}
+	for _, record := range records {
+		target := record.Repository + ":" + record.Path
+		switch record.Kind {
+		case "directory":
+			target += "/"
+		case "missing":
+			target += " " + color.YellowString("(missing)")
+		}
+		fmt.Printf("%s  %s\n", color.CyanString("%-*s", width, record.Name), target)
+	}
+}
+
+// parseBookmarkTarget splits "<alias>:<path>" into a bookmark; the path may
+// be relative to the repository root or absolute inside the repository
+func parseBookmarkTarget(arg string, repositories map[string]string) (types.Bookmark, error) {
+	alias, relPath, ok := strings.Cut(arg, ":")
+	if !ok || alias == "" {
+		return types.Bookmark{}, fmt.Errorf("invalid bookmark target '%s': expected <alias>:<path>", arg)
+	}
+	repoPath, exists := repositories[alias]
+	if !exists {
+		return types.Bookmark{}, errors.NotFoundError("repository", alias)
+	}
+
+	// Bookmarks point at files, not lines, so a ":line" suffix is an error
+	relPath, line, err := parseOpenTarget(relPath, repoPath)
+	if err != nil {
+		return types.Bookmark{}, err
+	}
+	if line != 0 {
+		return types.Bookmark{}, fmt.Errorf("invalid bookmark target '%s': bookmarks cannot point at a line", arg)
+	}
+	return types.Bookmark{Repository: alias, Path: relPath}, nil
+}
+
+// bookmarkLocation returns the absolute path a bookmark points at
+func bookmarkLocation(repoPath string, bookmark types.Bookmark) string {
+	return filepath.Join(repoPath, filepath.FromSlash(bookmark.Path))
+}
+
+// bookmarkSwitchTargets returns the bookmarks as switch targets, a file
+// bookmark switching to the directory containing the file. Bookmarks whose
+// path is missing are skipped.
+func bookmarkSwitchTargets(cfg *types.Config, targets []types.SwitchTarget) []types.SwitchTarget {
+	names := make([]string, 0, len(cfg.Bookmarks))
+	for name := range cfg.Bookmarks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var bookmarks []types.SwitchTarget
+	for _, name := range names {
+		bookmark := cfg.Bookmarks[name]
+		repoPath, exists := cfg.Repositories[bookmark.Repository]
+		if !exists {
+			continue
+		}
+		location := bookmarkLocation(repoPath, bookmark)
+		info, err := os.Stat(location)
+		if err != nil {
+			continue
+		}
+		if !info.IsDir() {
+			location = filepath.Dir(location)
+		}
+
+		// Bookmark names may clash with repository and worktree aliases
+		alias := name
+		for counter := 1; isAliasUsed(alias, targets) || isAliasUsed(alias, bookmarks); counter++ {
+			alias = fmt.Sprintf("%s-%d", name, counter)
+		}
+		bookmarks = append(bookmarks, types.SwitchTarget{
+			Alias:       alias,
+			Path:        location,
+			Type:        "bookmark",
+			RepoAlias:   bookmark.Repository,
+			Description: fmt.Sprintf("Bookmark: %s:%s", bookmark.Repository, bookmark.Path),
+		})
+	}
+	return bookmarks
+}
+
+// editFile opens file in the editor. The editor is attached to the terminal
+// because the shell wrapper captures the output of 'gman bookmark open'.
+func editFile(file string) error {
+	editor := strings.Fields(getEditorCommand())
+	if len(editor) == 0 {
+		return fmt.Errorf("no editor found; set $EDITOR")
+	}
+
+	editCmd := exec.Command(editor[0], append(editor[1:], file)...)
+	editCmd.Stdin, editCmd.Stdout, editCmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0); err == nil {
+		defer tty.Close()
+		editCmd.Stdin, editCmd.Stdout, editCmd.Stderr = tty, tty, tty
+	}
+	if err := editCmd.Run(); err != nil {
+		return fmt.Errorf("failed to run editor '%s': %w", editor[0], err)
+	}
+	return nil
+}
+
+// completeBookmarkNames completes the first argument with bookmark names
+func completeBookmarkNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) != 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var names []string
+	for name := range di.ConfigManager().GetConfig().Bookmarks {
+		names = append(names, name)
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gman/pkg/types"
+)
+
+func TestParseBookmarkTarget(t *testing.T) {
+	repositories := map[string]string{"api": "/src/api"}
+
+	tests := []struct {
+		arg     string
+		want    types.Bookmark
+		wantErr bool
+	}{
+		{"api:docs/runbook.md", types.Bookmark{Repository: "api", Path: "docs/runbook.md"}, false},
+		{"api:./deploy/k8s/", types.Bookmark{Repository: "api", Path: "deploy/k8s"}, false},
+		{"api:", types.Bookmark{Repository: "api"}, false},
+		{"api:/src/api/cmd", types.Bookmark{Repository: "api", Path: "cmd"}, false},
+		{"api:../web", types.Bookmark{}, true},
+		{"api:main.go:12", types.Bookmark{}, true},
+		{"web:README.md", types.Bookmark{}, true},
+		{"README.md", types.Bookmark{}, true},
+	}
+	for _, tt := range tests {
+		got, err := parseBookmarkTarget(tt.arg, repositories)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseBookmarkTarget(%q) = %+v, %v; want %+v (error: %v)", tt.arg, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestBookmarkSwitchTargets(t *testing.T) {
+	repoPath := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(repoPath, "docs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repoPath, "docs", "runbook.md"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &types.Config{
+		Repositories: map[string]string{"api": repoPath},
+		Bookmarks: map[string]types.Bookmark{
+			"api":     {Repository: "api", Path: "docs"},
+			"gone":    {Repository: "api", Path: "missing"},
+			"runbook": {Repository: "api", Path: "docs/runbook.md"},
+		},
+	}
+	targets := []types.SwitchTarget{{Alias: "api", Path: repoPath, Type: "repository", RepoAlias: "api"}}
+
+	bookmarks := bookmarkSwitchTargets(cfg, targets)
+	if len(bookmarks) != 2 {
+		t.Fatalf("bookmarkSwitchTargets() = %+v; want 2 targets", bookmarks)
+	}
+	docs := filepath.Join(repoPath, "docs")
+	if bookmarks[0].Alias != "api-1" || bookmarks[0].Path != docs || bookmarks[0].Type != "bookmark" {
+		t.Errorf("clashing bookmark = %+v; want alias api-1 at %s", bookmarks[0], docs)
+	}
+	if bookmarks[1].Alias != "runbook" || bookmarks[1].Path != docs {
+		t.Errorf("file bookmark = %+v; want the containing directory %s", bookmarks[1], docs)
+	}
+}
@@ -0,0 +1,244 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"gman/internal/di"
+	"gman/internal/display"
+	"gman/internal/git"
+	"gman/internal/pager"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	changelogSince        string
+	changelogGroup        string
+	changelogOut          string
+	changelogConventional bool
+)
+
+// changelogCmd represents the changelog command
+var changelogCmd = &cobra.Command{
+	Use:   "changelog",
+	Short: "Generate a Markdown changelog across repositories",
+	Long: `Collect the commits of every repository (or the repositories of one group)
+since a tag or date and render them as one Markdown changelog, grouped by
+repository.
+
+--since takes a tag or other revision present in the repositories, a date
+(2024-01-31) or a duration (7d, 12h). Repositories without the revision are
+listed at the end of the changelog.
+
+Commits following Conventional Commits (feat: ..., fix(scope): ...) are
+sorted into sections such as Features and Bug fixes, with breaking changes
+first. Other commits are listed under Other changes, or left out with
+--conventional. Merge commits are always left out.
+
+Examples:
+  gman changelog --since v2.2.0 --group product
+  gman changelog --since 2024-01-31 --conventional
+  gman changelog --since v2.2.0 --out CHANGELOG-v2.3.0.md`,
+	Args: cobra.NoArgs,
+	RunE: runChangelog,
+}
+
+func init() {
+	rootCmd.AddCommand(changelogCmd)
+
+	changelogCmd.Flags().StringVar(&changelogSince, "since", "", "Tag, revision, date (2024-01-31) or duration (7d) to start from")
+	changelogCmd.Flags().StringVarP(&changelogGroup, "group", "g", "", "Only include repositories of this group")
+	changelogCmd.Flags().StringVar(&changelogOut, "out", "", "Write the changelog to this file instead of stdout")
+	changelogCmd.Flags().BoolVar(&changelogConventional, "conventional", false, "Only include Conventional Commits (feat:, fix:, ...)")
+	changelogCmd.MarkFlagRequired("since")
+
+	pager.Enable(changelogCmd)
+}
+
+// conventionalCommit matches "type(scope)!: description"
+var conventionalCommit = regexp.MustCompile(`^([a-zA-Z]+)(?:\(([^)]*)\))?(!)?: (.+)$`)
+
+// changelogSections are the changelog sections in order, by commit type;
+// typed commits of other types go to Other changes
+var changelogSections = []struct {
+	Title string
+	Type  string
+}{
+	{"Features", "feat"},
+	{"Bug fixes", "fix"},
+	{"Performance", "perf"},
+	{"Refactoring", "refactor"},
+	{"Documentation", "docs"},
+	{"Reverts", "revert"},
+}
+
+const (
+	breakingSection = "Breaking changes"
+	otherSection    = "Other changes"
+)
+
+// changelogEntry is one commit of the changelog
+type changelogEntry struct {
+	Commit      git.CommitSummary
+	Scope       string
+	Description string
+}
+
+// changelogRepository is the changelog of one repository
+type changelogRepository struct {
+	Alias    string
+	Sections map[string][]changelogEntry
+	Count    int
+	Skipped  string // Why the repository is not included
+}
+
+// changelog is the data rendered by renderChangelog
+type changelog struct {
+	Since        string
+	Group        string
+	Generated    time.Time
+	Repositories []changelogRepository
+}
+
+func runChangelog(cmd *cobra.Command, args []string) error {
+	repositories, err := execRepositories(changelogGroup)
+	if err != nil {
+		return err
+	}
+
+	// A value that reads as a date or duration is one; anything else is a
+	// revision looked up in each repository
+	now := time.Now()
+	after, dateErr := parseSince(changelogSince, now)
+	ref := ""
+	if dateErr != nil {
+		ref = changelogSince
+	}
+
+	gitMgr := di.GitManager()
+	log := changelog{Since: changelogSince, Group: changelogGroup, Generated: now}
+	for _, alias := range sortedAliases(repositories) {
+		path := repositories[alias]
+		repo := changelogRepository{Alias: alias}
+		if ref != "" && !gitMgr.RefExists(path, ref) {
+			repo.Skipped = fmt.Sprintf("no revision %s", ref)
+			log.Repositories = append(log.Repositories, repo)
+			continue
+		}
+		commits, err := gitMgr.CommitsSince(path, ref, after)
+		if err != nil {
+			repo.Skipped = err.Error()
+		} else {
+			repo.Sections, repo.Count = groupChangelogCommits(commits, changelogConventional)
+		}
+		log.Repositories = append(log.Repositories, repo)
+	}
+
+	var buf bytes.Buffer
+	renderChangelog(&buf, log)
+
+	if changelogOut == "" {
+		_, err := os.Stdout.Write(buf.Bytes())
+		return err
+	}
+	if err := os.WriteFile(changelogOut, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write changelog: %w", err)
+	}
+	fmt.Printf("%s Changelog for %d repositories written to %s\n", display.SuccessIcon(), len(repositories), changelogOut)
+	return nil
+}
+
+// groupChangelogCommits sorts commits into changelog sections and returns
+// the number of commits kept. With conventionalOnly, commits without a
+// Conventional Commits type are left out.
+func groupChangelogCommits(commits []git.CommitSummary, conventionalOnly bool) (map[string][]changelogEntry, int) {
+	sections := make(map[string][]changelogEntry)
+	count := 0
+	for _, commit := range commits {
+		entry := changelogEntry{Commit: commit, Description: commit.Subject}
+		section := otherSection
+
+		if match := conventionalCommit.FindStringSubmatch(commit.Subject); match != nil {
+			entry.Scope = match[2]
+			entry.Description = match[4]
+			for _, s := range changelogSections {
+				if strings.EqualFold(s.Type, match[1]) {
+					section = s.Title
+				}
+			}
+			if match[3] == "!" {
+				section = breakingSection
+			}
+		} else if conventionalOnly {
+			continue
+		}
+
+		sections[section] = append(sections[section], entry)
+		count++
+	}
+	return sections, count
+}
+
+// renderChangelog writes the changelog as Markdown
+func renderChangelog(w io.Writer, log changelog) {
+	scope := "all repositories"
+	if log.Group != "" {
+		scope = fmt.Sprintf("group `%s`", log.Group)
+	}
+	total := 0
+	for _, repo := range log.Repositories {
+		total += repo.Count
+	}
+	fmt.Fprintf(w, "# Changelog\n\n")
+	fmt.Fprintf(w, "Changes since %s in %s: %d commits, generated %s.\n\n",
+		log.Since, scope, total, log.Generated.Format("2006-01-02"))
+
+	titles := []string{breakingSection}
+	for _, s := range changelogSections {
+		titles = append(titles, s.Title)
+	}
+	titles = append(titles, otherSection)
+
+	var skipped []changelogRepository
+	for _, repo := range log.Repositories {
+		if repo.Skipped != "" {
+			skipped = append(skipped, repo)
+			continue
+		}
+		if repo.Count == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "## %s\n\n", repo.Alias)
+		for _, title := range titles {
+			entries := repo.Sections[title]
+			if len(entries) == 0 {
+				continue
+			}
+			fmt.Fprintf(w, "### %s\n\n", title)
+			for _, entry := range entries {
+				scope := ""
+				if entry.Scope != "" {
+					scope = fmt.Sprintf("**%s:** ", entry.Scope)
+				}
+				fmt.Fprintf(w, "- %s%s (`%s`)\n", scope, entry.Description, entry.Commit.Hash)
+			}
+			fmt.Fprintln(w)
+		}
+	}
+	if total == 0 {
+		fmt.Fprintf(w, "No changes.\n\n")
+	}
+
+	if len(skipped) > 0 {
+		fmt.Fprintf(w, "## Not included\n\n")
+		for _, repo := range skipped {
+			fmt.Fprintf(w, "- **%s**: %s\n", repo.Alias, repo.Skipped)
+		}
+	}
+}
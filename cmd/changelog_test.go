@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"gman/internal/git"
+)
+
+func TestChangelog(t *testing.T) {
+	commits := []git.CommitSummary{
+		{Hash: "a1", Subject: "feat(auth): add token refresh"},
+		{Hash: "b2", Subject: "fix: handle empty config"},
+		{Hash: "c3", Subject: "refactor!: drop the v1 API"},
+		{Hash: "d4", Subject: "chore: bump dependencies"},
+		{Hash: "e5", Subject: "Update README"},
+	}
+
+	sections, count := groupChangelogCommits(commits, true)
+	if count != 4 || len(sections[otherSection]) != 1 {
+		t.Fatalf("expected 4 typed commits with chore under %s, got %d: %+v", otherSection, count, sections)
+	}
+
+	sections, count = groupChangelogCommits(commits, false)
+	log := changelog{
+		Since:     "v2.2.0",
+		Group:     "product",
+		Generated: time.Date(2024, 5, 10, 9, 0, 0, 0, time.UTC),
+		Repositories: []changelogRepository{
+			{Alias: "api", Sections: sections, Count: count},
+			{Alias: "web", Skipped: "no revision v2.2.0"},
+		},
+	}
+	var buf bytes.Buffer
+	renderChangelog(&buf, log)
+	output := buf.String()
+
+	expected := []string{
+		"Changes since v2.2.0 in group `product`: 5 commits",
+		"## api\n\n### Breaking changes\n\n- drop the v1 API (`c3`)",
+		"### Features\n\n- **auth:** add token refresh (`a1`)",
+		"### Bug fixes\n\n- handle empty config (`b2`)",
+		"### Other changes\n\n- bump dependencies (`d4`)\n- Update README (`e5`)",
+		"- **web**: no revision v2.2.0",
+	}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected changelog to contain %q, got:\n%s", want, output)
+		}
+	}
+}
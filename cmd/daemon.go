@@ -0,0 +1,280 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"time"
+
+	"gman/internal/cache"
+	"gman/internal/daemon"
+	"gman/internal/di"
+	"gman/internal/display"
+	"gman/pkg/types"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	daemonForeground    bool
+	daemonInterval      time.Duration
+	daemonFetchInterval time.Duration
+	noDaemon            bool
+)
+
+// daemonCmd represents the daemon command group
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Run a background process keeping repository status warm",
+	Long: `Run an optional background process that keeps the status of every repository,
+the switch targets and the search index warm. While it runs, 'gman work status',
+'gman prompt-data' and 'gman switch' answer from its memory over a unix socket
+instead of running git in every repository, which keeps them instant with
+hundreds of repositories.
+
+The daemon re-reads local status every --interval and fetches remotes every
+--fetch-interval. Commands fall back to running git directly when no daemon
+is running, when it does not know a repository yet, or with --no-daemon.
+
+Examples:
+  gman daemon start                      # Start in the background
+  gman daemon start --interval 30s --fetch-interval 10m
+  gman daemon status                     # Show whether it runs and how fresh it is
+  gman daemon refresh                    # Fetch and re-read everything now
+  gman daemon stop`,
+}
+
+var daemonStartCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Start the daemon in the background",
+	Args:  cobra.NoArgs,
+	RunE:  runDaemonStart,
+}
+
+var daemonStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the running daemon",
+	Args:  cobra.NoArgs,
+	RunE:  runDaemonStop,
+}
+
+var daemonStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show whether the daemon is running",
+	Args:  cobra.NoArgs,
+	RunE:  runDaemonStatus,
+}
+
+var daemonRefreshCmd = &cobra.Command{
+	Use:   "refresh",
+	Short: "Make the daemon fetch and re-read all repositories now",
+	Args:  cobra.NoArgs,
+	RunE:  runDaemonRefresh,
+}
+
+func init() {
+	rootCmd.AddCommand(daemonCmd)
+	daemonCmd.AddCommand(daemonStartCmd, daemonStopCmd, daemonStatusCmd, daemonRefreshCmd)
+
+	daemonStartCmd.Flags().BoolVar(&daemonForeground, "foreground", false, "Run in the foreground instead of detaching")
+	daemonStartCmd.Flags().DurationVar(&daemonInterval, "interval", 15*time.Second, "How often local status is re-read")
+	daemonStartCmd.Flags().DurationVar(&daemonFetchInterval, "fetch-interval", 5*time.Minute, "How often remotes are fetched")
+
+	rootCmd.PersistentFlags().BoolVar(&noDaemon, "no-daemon", false, "Run git directly even when 'gman daemon' is running")
+}
+
+// daemonSocket returns the socket of the daemon for the loaded configuration
+func daemonSocket() string {
+	return daemon.SocketPath(di.ConfigManager().GetConfigDir())
+}
+
+func runDaemonStart(cmd *cobra.Command, args []string) error {
+	if daemonInterval <= 0 || daemonFetchInterval <= 0 {
+		return fmt.Errorf("--interval and --fetch-interval must be positive")
+	}
+	socketPath := daemonSocket()
+	if daemon.Running(socketPath) {
+		fmt.Printf("%s gman daemon is already running (%s)\n", display.WarningIcon(), socketPath)
+		return nil
+	}
+
+	if daemonForeground {
+		server := daemon.NewServer(socketPath, daemonRefresh, daemonInterval, daemonFetchInterval)
+		return server.Run()
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate the gman executable: %w", err)
+	}
+	child := exec.Command(executable, "daemon", "start", "--foreground",
+		"--interval", daemonInterval.String(), "--fetch-interval", daemonFetchInterval.String())
+	if cfgFile != "" {
+		child.Args = append(child.Args, "--config", cfgFile)
+	}
+	daemon.Detach(child)
+	if err := child.Start(); err != nil {
+		return fmt.Errorf("failed to start daemon: %w", err)
+	}
+	child.Process.Release()
+
+	// The first refresh runs before the socket accepts requests
+	deadline := time.Now().Add(30 * time.Second)
+	for time.Now().Before(deadline) {
+		if daemon.Running(socketPath) {
+			fmt.Printf("%s gman daemon started (%s)\n", display.SuccessIcon(), socketPath)
+			return nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return fmt.Errorf("daemon did not start within 30s; run 'gman daemon start --foreground' to see why")
+}
+
+func runDaemonStop(cmd *cobra.Command, args []string) error {
+	if err := daemon.Call(daemonSocket(), daemon.MethodStop, nil); err != nil {
+		fmt.Printf("%s gman daemon is not running\n", display.WarningIcon())
+		return nil
+	}
+	fmt.Printf("%s gman daemon stopped\n", display.SuccessIcon())
+	return nil
+}
+
+func runDaemonStatus(cmd *cobra.Command, args []string) error {
+	var info daemon.Info
+	if err := daemon.Call(daemonSocket(), daemon.MethodPing, &info); err != nil {
+		fmt.Println("gman daemon is not running")
+		return nil
+	}
+	fmt.Printf("gman daemon is running (pid %d, since %s)\n", info.PID, info.Started.Format("2006-01-02 15:04:05"))
+	fmt.Printf("  Repositories: %d\n", info.Repositories)
+	fmt.Printf("  Status read:  %s ago\n", time.Since(info.UpdatedAt).Round(time.Second))
+	if !info.Fetched.IsZero() {
+		fmt.Printf("  Last fetch:   %s ago\n", time.Since(info.Fetched).Round(time.Second))
+	}
+	if info.LastError != "" {
+		fmt.Printf("  %s Last refresh failed: %s\n", display.WarningIcon(), info.LastError)
+	}
+	return nil
+}
+
+func runDaemonRefresh(cmd *cobra.Command, args []string) error {
+	var info daemon.Info
+	if err := daemon.Call(daemonSocket(), daemon.MethodRefresh, &info); err != nil {
+		return err
+	}
+	if info.LastError != "" {
+		return fmt.Errorf("daemon refresh failed: %s", info.LastError)
+	}
+	fmt.Printf("%s Refreshed %d repositories\n", display.SuccessIcon(), info.Repositories)
+	return nil
+}
+
+// daemonRefresh rebuilds the daemon state from the current configuration.
+// It also keeps the status cache and the search indexes up to date.
+func daemonRefresh(fetch bool) (*daemon.State, error) {
+	configMgr := di.ConfigManager()
+	if err := configMgr.Load(); err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	cfg := configMgr.GetConfig()
+	gitMgr := di.GitManager()
+
+	var statuses []types.RepoStatus
+	var err error
+	if fetch {
+		statuses, err = gitMgr.GetAllRepoStatus(cfg.Repositories)
+	} else {
+		statuses, err = gitMgr.GetAllRepoStatusNoFetch(cfg.Repositories)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository status: %w", err)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Alias < statuses[j].Alias
+	})
+	_ = cache.NewStatusCache(statuses).Save(cache.StatusCachePath(configMgr.GetConfigDir()))
+
+	targets, err := collectSwitchTargets(cfg.Repositories)
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect switch targets: %w", err)
+	}
+
+	if fetch {
+		// Fetched statuses show which repositories failed or fell behind
+		notifyProblems("daemon", statusNotifyRepositories(statuses))
+
+		// Indexes are only kept fresh, never created: 'gman tools index update' opts in
+		store := indexStore()
+		indexed := make(map[string]string)
+		for alias, path := range cfg.Repositories {
+			if store.Exists(alias) {
+				indexed[alias] = path
+			}
+		}
+		store.UpdateAll(gitMgr, indexed, 0)
+	}
+
+	state := &daemon.State{UpdatedAt: time.Now(), Targets: targets}
+	for _, status := range statuses {
+		state.Statuses = append(state.Statuses, daemon.NewStatus(status))
+	}
+	return state, nil
+}
+
+// daemonState returns the running daemon's state, or nil when commands
+// should run git directly
+func daemonState() *daemon.State {
+	if noDaemon {
+		return nil
+	}
+	state, ok := daemon.FetchState(daemonSocket())
+	if !ok {
+		return nil
+	}
+	return state
+}
+
+// daemonStatuses returns the daemon's statuses of repositories, or false
+// when the daemon is not running or does not know all of them yet
+func daemonStatuses(repositories map[string]string) ([]types.RepoStatus, time.Time, bool) {
+	state := daemonState()
+	if state == nil {
+		return nil, time.Time{}, false
+	}
+
+	statuses := make([]types.RepoStatus, 0, len(repositories))
+	for _, status := range state.Statuses {
+		if path, ok := repositories[status.Alias]; ok && path == status.Path {
+			statuses = append(statuses, status.RepoStatus())
+		}
+	}
+	if len(statuses) != len(repositories) {
+		return nil, time.Time{}, false
+	}
+	return statuses, state.UpdatedAt, true
+}
+
+// daemonSwitchTargets returns the daemon's switch targets, or false when
+// the daemon is not running or its repositories differ from repositories
+func daemonSwitchTargets(repositories map[string]string) ([]types.SwitchTarget, bool) {
+	state := daemonState()
+	if state == nil {
+		return nil, false
+	}
+
+	known := 0
+	for _, target := range state.Targets {
+		if target.Type != "repository" {
+			continue
+		}
+		if path, ok := repositories[target.Alias]; !ok || path != target.Path {
+			return nil, false
+		}
+		known++
+	}
+	if known != len(repositories) {
+		return nil, false
+	}
+	return state.Targets, true
+}
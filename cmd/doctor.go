@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gman/internal/di"
+	"gman/internal/display"
+	"gman/internal/errors"
+	"gman/internal/git"
+	"gman/internal/interactive"
+	"gman/pkg/types"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	doctorFix   bool
+	doctorFetch bool
+	doctorGroup string
+	doctorEnv   bool
+)
+
+// doctorCmd represents the doctor command
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the environment, find repository problems and apply recovery actions",
+	Long: `Check the environment gman relies on, then every repository.
+
+The environment checks cover the git version, the optional tools fzf, ripgrep
+and fd, shell integration, the configuration file, the SSH agent, the git
+credential helper and the background daemon. Each problem comes with a fix.
+
+The repository checks find problems that block gman commands: unfinished
+merges, uncommitted changes in repositories that are behind their remote,
+failing fetches and unreadable repositories. Each problem is shown with its
+recovery plan.
+
+With --fix, gman applies the plans. Safe actions such as retrying a fetch run
+right away; for the others you pick an action and confirm it. Actions that
+may discard work always ask, and are skipped with --non-interactive.
+
+Examples:
+  gman doctor                     # Show problems and recovery plans
+  gman doctor --env               # Only check the environment
+  gman doctor --fetch             # Also fetch to find unreachable remotes
+  gman doctor --fix               # Apply recovery actions
+  gman doctor --fix --group web   # Only repositories of one group`,
+	Args: cobra.NoArgs,
+	RunE: runDoctorCommand,
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+
+	doctorCmd.Flags().BoolVar(&doctorFix, "fix", false, "Apply recovery actions")
+	doctorCmd.Flags().BoolVar(&doctorFetch, "fetch", false, "Fetch remotes to check that they are reachable")
+	doctorCmd.Flags().StringVar(&doctorGroup, "group", "", "Only check repositories of this group")
+	doctorCmd.Flags().BoolVar(&doctorEnv, "env", false, "Only check the environment, not the repositories")
+}
+
+// doctorProblem is a problem found in one repository
+type doctorProblem struct {
+	Alias string
+	Path  string
+	Err   *errors.GmanError
+	// Plan is nil when no action can resolve the problem
+	Plan *errors.RecoveryPlan
+}
+
+func runDoctorCommand(cmd *cobra.Command, args []string) error {
+	failed := printEnvironmentChecks(environmentChecks())
+	if doctorEnv {
+		if failed {
+			return fmt.Errorf("environment checks failed")
+		}
+		return nil
+	}
+	return runDoctor(cmd, args)
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	repositories, err := execRepositories(doctorGroup)
+	if err != nil {
+		return err
+	}
+
+	gitMgr := di.GitManager()
+	var statuses []types.RepoStatus
+	if doctorFetch {
+		statuses, err = gitMgr.GetAllRepoStatus(repositories)
+	} else {
+		statuses, err = gitMgr.GetAllRepoStatusNoFetch(repositories)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get repository status: %w", err)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Alias < statuses[j].Alias
+	})
+
+	var problems []doctorProblem
+	for _, status := range statuses {
+		problems = append(problems, diagnoseRepository(gitMgr, status)...)
+	}
+	if len(problems) == 0 {
+		fmt.Printf("%s No problems found in %d repositories\n", display.SuccessIcon(), len(statuses))
+		return nil
+	}
+
+	for _, problem := range problems {
+		printRecoveryPlan(problem)
+	}
+	if !doctorFix {
+		fmt.Printf("\n%d problems found. Run 'gman doctor --fix' to apply recovery actions.\n", len(problems))
+		return nil
+	}
+
+	fmt.Println()
+	fixed := 0
+	for _, problem := range problems {
+		if applyRecoveryPlan(gitMgr, problem) {
+			fixed++
+		}
+	}
+	fmt.Printf("\n%d of %d problems resolved\n", fixed, len(problems))
+	return nil
+}
+
+// diagnoseRepository returns the problems of one repository
+func diagnoseRepository(gitMgr *git.Manager, status types.RepoStatus) []doctorProblem {
+	var found []*errors.GmanError
+	if status.Error != nil {
+		found = append(found, errors.CreateUserFriendlyError(status.Error, status.Path))
+	} else {
+		if gitMgr.MergeInProgress(status.Path) {
+			found = append(found, errors.NewMergeConflictError(status.Path))
+		}
+		if status.Workspace == types.Dirty && status.SyncStatus.Behind > 0 {
+			found = append(found, errors.NewWorkspaceNotCleanError(status.Path).
+				WithSuggestion("Uncommitted changes block 'gman work sync' while the branch is behind"))
+		}
+		if syncErr := status.SyncStatus.SyncError; syncErr != nil {
+			gErr, ok := errors.As(syncErr)
+			if !ok {
+				gErr = errors.NewRemoteUnreachableError("origin", status.Path).WithCause(syncErr)
+			}
+			found = append(found, gErr)
+		}
+	}
+
+	problems := make([]doctorProblem, 0, len(found))
+	for _, gErr := range found {
+		problems = append(problems, doctorProblem{
+			Alias: status.Alias,
+			Path:  status.Path,
+			Err:   gErr,
+			Plan:  errors.NewRecoveryPlan(gErr),
+		})
+	}
+	return problems
+}
+
+// printRecoveryPlan shows a problem with its actions and manual alternatives
+func printRecoveryPlan(problem doctorProblem) {
+	fmt.Printf("%s %s: %s\n", display.WarningIcon(), problem.Alias, problem.Err.Error())
+	if problem.Plan != nil {
+		for i, action := range problem.Plan.Actions {
+			fmt.Printf("   %d. %s (%s) [%s]\n", i+1, action.Description, action.Command(), action.SafeLevel)
+		}
+	}
+	for _, suggestion := range problem.Err.Suggestions {
+		fmt.Printf("   - %s\n", suggestion)
+	}
+}
+
+// applyRecoveryPlan runs one action of the problem's plan and reports
+// whether the problem was resolved
+func applyRecoveryPlan(gitMgr *git.Manager, problem doctorProblem) bool {
+	if problem.Plan == nil {
+		fmt.Printf("%s %s: no automatic recovery, see the suggestions above\n", display.WarningIcon(), problem.Alias)
+		return false
+	}
+
+	for _, action := range problem.Plan.Actions {
+		if action.CanAutoExec() {
+			return runRecoveryAction(gitMgr, problem, action)
+		}
+	}
+
+	if interactive.NonInteractive() {
+		fmt.Printf("%s %s: skipped, recovery actions need confirmation\n", display.WarningIcon(), problem.Alias)
+		return false
+	}
+
+	action, ok := chooseRecoveryAction(problem)
+	if !ok {
+		fmt.Printf("   Skipped %s\n", problem.Alias)
+		return false
+	}
+	if action.SafeLevel == errors.SafeLevelDangerous {
+		fmt.Printf("   %s This may discard work in %s\n", display.WarningIcon(), problem.Path)
+	}
+	defaultYes := action.SafeLevel == errors.SafeLevelSafe
+	if defaultYes {
+		fmt.Printf("   Run '%s' in %s? [Y/n]: ", action.Command(), problem.Alias)
+	} else {
+		fmt.Printf("   Run '%s' in %s? [y/N]: ", action.Command(), problem.Alias)
+	}
+	if !askConfirmation(defaultYes) {
+		fmt.Printf("   Skipped %s\n", problem.Alias)
+		return false
+	}
+	return runRecoveryAction(gitMgr, problem, action)
+}
+
+// chooseRecoveryAction asks which action of the plan to run; a plan with a
+// single action needs no choice
+func chooseRecoveryAction(problem doctorProblem) (errors.RecoveryAction, bool) {
+	actions := problem.Plan.Actions
+	if len(actions) == 1 {
+		return actions[0], true
+	}
+
+	fmt.Printf("   Choose an action for %s [1-%d, Enter to skip]: ", problem.Alias, len(actions))
+	input, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	choice, err := strconv.Atoi(strings.TrimSpace(input))
+	if err != nil || choice < 1 || choice > len(actions) {
+		return errors.RecoveryAction{}, false
+	}
+	return actions[choice-1], true
+}
+
+// runRecoveryAction runs action in the problem's repository
+func runRecoveryAction(gitMgr *git.Manager, problem doctorProblem, action errors.RecoveryAction) bool {
+	if _, err := gitMgr.RunCommand(problem.Path, action.Args...); err != nil {
+		fmt.Printf("%s %s: '%s' failed: %v\n", display.ErrorIcon(), problem.Alias, action.Command(), err)
+		return false
+	}
+	fmt.Printf("%s %s: %s\n", display.SuccessIcon(), problem.Alias, action.Description)
+	return true
+}
+
+// OfferRecovery offers 'gman doctor --fix' after a command failed with an
+// error that has a recovery plan
+func OfferRecovery(err error) {
+	gErr, ok := errors.As(err)
+	if !ok || errors.NewRecoveryPlan(gErr) == nil || interactive.NonInteractive() ||
+		errors.ErrorFormat() == errors.ErrorFormatJSON {
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "\nRecovery actions are available. Run 'gman doctor --fix' now? [y/N]: ")
+	if !askConfirmation(false) {
+		return
+	}
+	doctorFix = true
+	if err := runDoctor(doctorCmd, nil); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+	}
+}
@@ -0,0 +1,276 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gman/internal/daemon"
+	"gman/internal/di"
+	"gman/internal/display"
+	"gman/internal/external"
+	"gman/internal/git"
+)
+
+// minGitVersion is the oldest git with every worktree feature gman uses;
+// 'git worktree list --porcelain' reports prunable worktrees since 2.31
+var minGitVersion = [2]int{2, 31}
+
+// envCheckLevel is the outcome of an environment check
+type envCheckLevel int
+
+const (
+	envOK envCheckLevel = iota
+	envInfo
+	envWarn
+	envFail
+)
+
+// envCheck is the result of one environment check with an actionable fix
+type envCheck struct {
+	Name   string
+	Level  envCheckLevel
+	Detail string
+	Fix    string
+	Notes  []string // Further diagnostics, one per line
+}
+
+// environmentChecks verifies the tools, integrations and configuration gman
+// relies on
+func environmentChecks() []envCheck {
+	checks := []envCheck{checkGitVersion()}
+	for _, tool := range []*external.Tool{external.FZF, external.RipGrep, external.FD} {
+		checks = append(checks, checkTool(tool))
+	}
+	return append(checks,
+		checkShellIntegration(),
+		checkConfiguration(),
+		checkSSHAgent(),
+		checkCredentialHelper(),
+		checkDaemon(),
+	)
+}
+
+// printEnvironmentChecks prints the checks and reports whether one failed
+func printEnvironmentChecks(checks []envCheck) bool {
+	failed := false
+	fmt.Println("Environment:")
+	for _, check := range checks {
+		var icon string
+		switch check.Level {
+		case envOK:
+			icon = display.SuccessIcon()
+		case envInfo:
+			icon = display.Icon("ℹ️ ", "INFO")
+		case envWarn:
+			icon = display.WarningIcon()
+		default:
+			icon = display.ErrorIcon()
+			failed = true
+		}
+		fmt.Printf("%s %s: %s\n", icon, check.Name, check.Detail)
+		for _, note := range check.Notes {
+			fmt.Printf("   %s\n", note)
+		}
+		if check.Fix != "" && check.Level >= envWarn {
+			prefix := display.Icon("💡", "FIX")
+			for _, line := range strings.Split(strings.TrimSpace(check.Fix), "\n") {
+				fmt.Printf("   %s %s\n", prefix, strings.TrimSpace(line))
+				prefix = strings.Repeat(" ", len([]rune(prefix)))
+			}
+		}
+	}
+	fmt.Println()
+	return failed
+}
+
+var gitVersionPattern = regexp.MustCompile(`(\d+)\.(\d+)`)
+
+func checkGitVersion() envCheck {
+	check := envCheck{Name: "git"}
+	output, err := exec.Command("git", "--version").Output()
+	if err != nil {
+		check.Level = envFail
+		check.Detail = "not found"
+		check.Fix = "Install git 2.31 or newer: https://git-scm.com/downloads"
+		return check
+	}
+
+	check.Detail = strings.TrimSpace(string(output))
+	supported, ok := gitVersionSupported(check.Detail)
+	if !ok {
+		check.Level = envWarn
+		check.Fix = "Could not read the git version; gman needs git 2.31 or newer"
+		return check
+	}
+	if !supported {
+		check.Level = envWarn
+		check.Fix = fmt.Sprintf("Upgrade to git %d.%d or newer; older versions do not report stale worktrees",
+			minGitVersion[0], minGitVersion[1])
+	}
+	return check
+}
+
+// gitVersionSupported reports whether the output of 'git --version' names a
+// version of at least minGitVersion; ok is false when it names none
+func gitVersionSupported(version string) (supported, ok bool) {
+	match := gitVersionPattern.FindStringSubmatch(version)
+	if match == nil {
+		return false, false
+	}
+	major, _ := strconv.Atoi(match[1])
+	minor, _ := strconv.Atoi(match[2])
+	return major > minGitVersion[0] || (major == minGitVersion[0] && minor >= minGitVersion[1]), true
+}
+
+func checkTool(tool *external.Tool) envCheck {
+	check := envCheck{Name: tool.Name}
+	version, err := tool.GetVersion()
+	if err != nil {
+		check.Level = envWarn
+		check.Detail = "not installed (optional: " + tool.Description + ")"
+		check.Fix = tool.GetInstallInstructions()
+		return check
+	}
+	check.Detail, _, _ = strings.Cut(version, "\n")
+	return check
+}
+
+func checkShellIntegration() envCheck {
+	check := envCheck{Name: "shell integration", Detail: "active"}
+	if !isShellIntegrationActive() {
+		check.Level = envWarn
+		check.Detail = "not active; 'gman switch' cannot change directories"
+		check.Fix = "Add eval \"$(gman shell-init)\" to your shell configuration and restart the shell"
+		check.Notes = strings.Split(getShellIntegrationDiagnostics(), "\n")
+	}
+	return check
+}
+
+func checkConfiguration() envCheck {
+	configMgr := di.ConfigManager()
+	cfg := configMgr.GetConfig()
+	check := envCheck{
+		Name:   "configuration",
+		Detail: fmt.Sprintf("%s (%d repositories, %d groups)", configMgr.GetConfigDir(), len(cfg.Repositories), len(cfg.Groups)),
+	}
+
+	for _, alias := range sortedAliases(cfg.Repositories) {
+		path := cfg.Repositories[alias]
+		if _, err := os.Stat(path); err != nil {
+			check.Notes = append(check.Notes, fmt.Sprintf("repository '%s': %s does not exist", alias, path))
+		} else if _, err := os.Stat(filepath.Join(path, ".git")); err != nil {
+			check.Notes = append(check.Notes, fmt.Sprintf("repository '%s': %s is not a git repository", alias, path))
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(cfg.Groups)) {
+		for _, alias := range cfg.Groups[name].Repositories {
+			if _, exists := cfg.Repositories[alias]; !exists {
+				check.Notes = append(check.Notes, fmt.Sprintf("group '%s': unknown repository '%s'", name, alias))
+			}
+		}
+	}
+	if template := cfg.Settings.WorktreeNameTemplate; template != "" {
+		if _, err := git.WorktreeName(template, "repo", "repo", "branch"); err != nil {
+			check.Notes = append(check.Notes, err.Error())
+		}
+	}
+	if baseDir := cfg.Settings.WorktreeBaseDir; baseDir != "" {
+		if _, err := expandWorktreeBaseDir(baseDir); err != nil {
+			check.Notes = append(check.Notes, err.Error())
+		}
+	}
+
+	if len(check.Notes) > 0 {
+		check.Level = envWarn
+		check.Fix = "Fix the entries above with 'gman repo remove', 'gman repo group' or by editing the configuration file"
+	}
+	return check
+}
+
+func checkSSHAgent() envCheck {
+	check := envCheck{Name: "SSH agent"}
+	if os.Getenv("SSH_AUTH_SOCK") == "" {
+		check.Level = envInfo
+		check.Detail = "not running; SSH remotes use keys without an agent or prompt for passphrases"
+		return check
+	}
+
+	// ssh-add -l exits with 1 when the agent has no keys and 2 when it cannot
+	// be reached
+	err := exec.Command("ssh-add", "-l").Run()
+	switch {
+	case err == nil:
+		check.Detail = "running with keys loaded"
+	case exitCode(err) == 1:
+		check.Level = envWarn
+		check.Detail = "running without keys"
+		check.Fix = "Load your key with 'ssh-add', or bulk fetches over SSH may prompt or fail"
+	case exitCode(err) == 2:
+		check.Level = envWarn
+		check.Detail = "SSH_AUTH_SOCK is set but the agent does not answer"
+		check.Fix = "Restart the agent: eval \"$(ssh-agent)\" && ssh-add"
+	default:
+		check.Level = envInfo
+		check.Detail = "could not be checked: " + err.Error()
+	}
+	return check
+}
+
+// exitCode returns the exit code of a command that failed, or -1 when it
+// could not be run
+func exitCode(err error) int {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
+
+func checkCredentialHelper() envCheck {
+	check := envCheck{Name: "credentials"}
+	output, err := exec.Command("git", "config", "--get-all", "credential.helper").Output()
+	helpers := strings.Fields(strings.TrimSpace(string(output)))
+	if err != nil || len(helpers) == 0 {
+		check.Level = envWarn
+		check.Detail = "no git credential helper configured"
+		check.Fix = "HTTPS remotes will prompt for every repository; configure one, e.g.\n" +
+			"git config --global credential.helper cache (or osxkeychain, manager, store)"
+		return check
+	}
+	check.Detail = "credential.helper = " + strings.Join(helpers, ", ")
+	return check
+}
+
+func checkDaemon() envCheck {
+	check := envCheck{Name: "daemon"}
+	socket := daemonSocket()
+	if _, err := os.Stat(socket); err != nil {
+		check.Level = envInfo
+		check.Detail = "not running (optional: 'gman daemon start' keeps status answers instant)"
+		return check
+	}
+
+	var info daemon.Info
+	if err := daemon.Call(socket, daemon.MethodPing, &info); err != nil {
+		check.Level = envWarn
+		check.Detail = "stale socket " + socket
+		check.Fix = "Run 'gman daemon start' to replace it, or remove the socket file"
+		return check
+	}
+	check.Detail = fmt.Sprintf("running (pid %d), status read %s ago", info.PID, time.Since(info.UpdatedAt).Round(time.Second))
+	if info.LastError != "" {
+		check.Level = envWarn
+		check.Detail += "; last refresh failed: " + info.LastError
+		check.Fix = "Run 'gman daemon refresh' and check 'gman logs' for details"
+	}
+	return check
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"gman/internal/errors"
+	"gman/internal/git"
+	"gman/pkg/types"
+)
+
+func TestDiagnoseRepository(t *testing.T) {
+	repoDir := filepath.Join(t.TempDir(), "repo")
+	if err := createBasicTestRepo(repoDir); err != nil {
+		t.Fatalf("Failed to create test repo: %v", err)
+	}
+	gitMgr := git.NewManager()
+
+	clean := types.RepoStatus{Alias: "repo", Path: repoDir, Workspace: types.Dirty}
+	if problems := diagnoseRepository(gitMgr, clean); len(problems) != 0 {
+		t.Errorf("Expected dirty repository in sync to have no problems, got %d", len(problems))
+	}
+
+	behind := clean
+	behind.SyncStatus = types.SyncStatus{
+		Behind:    2,
+		SyncError: fmt.Errorf("failed to fetch from remote: %w", errors.NewNetworkTimeoutError("git fetch", "30s")),
+	}
+	problems := diagnoseRepository(gitMgr, behind)
+	if len(problems) != 2 {
+		t.Fatalf("Expected 2 problems, got %d", len(problems))
+	}
+	if problems[0].Err.Type != errors.ErrTypeWorkspaceNotClean {
+		t.Errorf("Expected dirty workspace first, got %s", problems[0].Err.Type)
+	}
+	if problems[1].Plan == nil || !problems[1].Plan.Actions[0].CanAutoExec() {
+		t.Error("Expected fetch timeout to have an automatic retry")
+	}
+}
+
+func TestGitVersionSupported(t *testing.T) {
+	tests := []struct {
+		version   string
+		supported bool
+		ok        bool
+	}{
+		{"git version 2.39.5", true, true},
+		{"git version 2.31.0.windows.1", true, true},
+		{"git version 2.30.2", false, true},
+		{"git version 1.9.1", false, true},
+		{"git version 3.0.0", true, true},
+		{"unknown", false, false},
+	}
+	for _, tt := range tests {
+		supported, ok := gitVersionSupported(tt.version)
+		if supported != tt.supported || ok != tt.ok {
+			t.Errorf("gitVersionSupported(%q) = %v, %v, want %v, %v", tt.version, supported, ok, tt.supported, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	cmdutils "gman/internal/cmd"
+	"gman/internal/di"
+	"gman/internal/pager"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var duGroup string
+
+// duCmd represents the du command
+var duCmd = &cobra.Command{
+	Use:   "du",
+	Short: "Show how much disk space repositories and their worktrees use",
+	Long: `Report the disk usage of every repository (or the repositories of one
+group): the working tree, the .git directory and the linked worktrees,
+largest first, with totals.
+
+Sizes count the bytes of regular files; symlinks are not followed. The
+objects of linked worktrees live in the repository's .git directory and are
+counted there once.
+
+Examples:
+  gman du                    # All repositories
+  gman du --group backend    # One group
+  gman du --output csv       # For a spreadsheet`,
+	Args: cobra.NoArgs,
+	RunE: runDu,
+}
+
+func init() {
+	rootCmd.AddCommand(duCmd)
+
+	duCmd.Flags().StringVarP(&duGroup, "group", "g", "", "Only report the repositories of this group")
+
+	pager.Enable(duCmd)
+}
+
+// diskUsageRecord is the disk usage of one repository, in bytes
+type diskUsageRecord struct {
+	Alias     string `json:"alias" yaml:"alias"`
+	Path      string `json:"path" yaml:"path"`
+	WorkTree  int64  `json:"worktree_bytes" yaml:"worktree_bytes"`
+	GitDir    int64  `json:"git_bytes" yaml:"git_bytes"`
+	Worktrees int64  `json:"linked_worktrees_bytes" yaml:"linked_worktrees_bytes"`
+	Total     int64  `json:"total_bytes" yaml:"total_bytes"`
+	Error     string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+func runDu(cmd *cobra.Command, args []string) error {
+	repositories, err := execRepositories(duGroup)
+	if err != nil {
+		return err
+	}
+
+	records := forEachRepository(repositories, repositoryDiskUsage)
+
+	// Largest first; failed repositories last
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Total != records[j].Total {
+			return records[i].Total > records[j].Total
+		}
+		return records[i].Alias < records[j].Alias
+	})
+
+	return cmdutils.Render(records, func() error {
+		printDiskUsage(records)
+		return nil
+	})
+}
+
+// repositoryDiskUsage measures the working tree, .git directory and linked
+// worktrees of a repository. Linked worktrees nested inside the working tree
+// or another worktree are only counted as worktrees.
+func repositoryDiskUsage(alias, path string) diskUsageRecord {
+	record := diskUsageRecord{Alias: alias, Path: path}
+
+	// A repository whose worktrees cannot be listed still has a size
+	var linked []string
+	if worktrees, err := di.GitManager().ListWorktrees(path); err == nil {
+		for i, wt := range worktrees {
+			if i > 0 && wt.Prunable == "" {
+				linked = append(linked, wt.Path)
+			}
+		}
+	}
+
+	var err error
+	if record.WorkTree, err = dirSize(path, true, linked...); err != nil {
+		record.Error = err.Error()
+		return record
+	}
+	if record.GitDir, err = dirSize(filepath.Join(path, ".git"), false); err != nil {
+		record.Error = err.Error()
+		return record
+	}
+	for _, wtPath := range linked {
+		if size, err := dirSize(wtPath, true, linked...); err == nil {
+			record.Worktrees += size
+		}
+	}
+
+	record.Total = record.WorkTree + record.GitDir + record.Worktrees
+	return record
+}
+
+// dirSize returns the bytes of the regular files below root, leaving out a
+// top-level .git entry with skipGit and the directories in skip below root
+func dirSize(root string, skipGit bool, skip ...string) (int64, error) {
+	gitPath := filepath.Join(root, ".git")
+	skipped := make(map[string]bool, len(skip))
+	for _, dir := range skip {
+		skipped[filepath.Clean(dir)] = true
+	}
+	var size int64
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			// Unreadable entries are skipped, like du does with a warning
+			if d != nil && d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if skipGit && path == gitPath {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() && path != root && skipped[path] {
+			return fs.SkipDir
+		}
+		if d.Type().IsRegular() {
+			if info, err := d.Info(); err == nil {
+				size += info.Size()
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, fmt.Errorf("%s does not exist", root)
+		}
+		return 0, fmt.Errorf("failed to measure %s: %w", root, err)
+	}
+	return size, nil
+}
+
+// printDiskUsage prints the disk usage table with a totals row
+func printDiskUsage(records []diskUsageRecord) {
+	if len(records) == 0 {
+		fmt.Println("No repositories to measure.")
+		return
+	}
+
+	maxAlias := len("Alias")
+	for _, record := range records {
+		maxAlias = max(maxAlias, len(record.Alias))
+	}
+
+	fmt.Printf("%-*s %12s %12s %12s %12s\n", maxAlias, "Alias", "Work tree", ".git", "Worktrees", "Total")
+	var total diskUsageRecord
+	for _, record := range records {
+		if record.Error != "" {
+			fmt.Printf("%-*s %s\n", maxAlias, record.Alias, color.RedString("%s", record.Error))
+			continue
+		}
+		fmt.Printf("%-*s %12s %12s %12s %12s\n", maxAlias, record.Alias,
+			formatBytes(record.WorkTree), formatBytes(record.GitDir), formatBytes(record.Worktrees),
+			color.CyanString("%12s", formatBytes(record.Total)))
+		total.WorkTree += record.WorkTree
+		total.GitDir += record.GitDir
+		total.Worktrees += record.Worktrees
+		total.Total += record.Total
+	}
+	fmt.Printf("%-*s %12s %12s %12s %12s\n", maxAlias, "Total",
+		formatBytes(total.WorkTree), formatBytes(total.GitDir), formatBytes(total.Worktrees), formatBytes(total.Total))
+}
+
+// formatBytes formats a size with binary units, e.g. 1.5 GiB
+func formatBytes(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
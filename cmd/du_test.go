@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDirSize(t *testing.T) {
+	root := t.TempDir()
+	os.WriteFile(filepath.Join(root, "a.txt"), make([]byte, 100), 0644)
+	os.MkdirAll(filepath.Join(root, "sub"), 0755)
+	os.WriteFile(filepath.Join(root, "sub", "b.txt"), make([]byte, 50), 0644)
+	os.MkdirAll(filepath.Join(root, ".git", "objects"), 0755)
+	os.WriteFile(filepath.Join(root, ".git", "objects", "pack"), make([]byte, 1000), 0644)
+	os.Symlink(filepath.Join(root, "a.txt"), filepath.Join(root, "link"))
+
+	if size, err := dirSize(root, true); err != nil || size != 150 {
+		t.Errorf("dirSize(skipGit) = %d, %v; want 150", size, err)
+	}
+	if size, err := dirSize(root, false); err != nil || size != 1150 {
+		t.Errorf("dirSize() = %d, %v; want 1150", size, err)
+	}
+	// Nested worktrees are measured on their own
+	if size, err := dirSize(root, true, filepath.Join(root, "sub")); err != nil || size != 100 {
+		t.Errorf("dirSize(skip sub) = %d, %v; want 100", size, err)
+	}
+	if size, err := dirSize(filepath.Join(root, "sub"), true, filepath.Join(root, "sub")); err != nil || size != 50 {
+		t.Errorf("dirSize(sub, skip sub) = %d, %v; want 50", size, err)
+	}
+	if _, err := dirSize(filepath.Join(root, "missing"), true); err == nil {
+		t.Error("Expected an error for a missing directory")
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := map[int64]string{
+		0:               "0 B",
+		1023:            "1023 B",
+		1536:            "1.5 KiB",
+		5 * 1024 * 1024: "5.0 MiB",
+		3 << 30:         "3.0 GiB",
+	}
+	for size, want := range tests {
+		if got := formatBytes(size); got != want {
+			t.Errorf("formatBytes(%d) = %q, want %q", size, got, want)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"sync"
+	"time"
+
+	"gman/internal/di"
+	"gman/internal/repository"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	execGroup string
+	execJobs  int
+)
+
+// execCmd represents the exec command
+var execCmd = &cobra.Command{
+	Use:   "exec [--group g] [--jobs N] -- <cmd> [args...]",
+	Short: "Run a command in every repository concurrently",
+	Long: `Run a command in the working directory of every managed repository (or
+the repositories of one group) concurrently. Output is streamed line by line,
+prefixed with the repository alias, and a summary table of exit codes is
+printed at the end. gman exits non-zero if the command failed anywhere.
+
+The command is run directly, without a shell; use 'gman foreach --shell' for
+pipes and redirections. Put -- before the command so its flags are not taken
+as gman flags.
+
+Examples:
+  gman exec -- git status --short
+  gman exec --group backend -- make test
+  gman exec --jobs 1 -- npm ci          # One repository at a time`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runExec,
+}
+
+func init() {
+	rootCmd.AddCommand(execCmd)
+
+	execCmd.Flags().StringVar(&execGroup, "group", "", "Only run in repositories of this group")
+	execCmd.Flags().IntVarP(&execJobs, "jobs", "j", 0, "Number of repositories to run in parallel (default: parallel_jobs setting)")
+}
+
+// execResult is the outcome of running a command in one repository
+type execResult struct {
+	alias    string
+	exitCode int
+	duration time.Duration
+	err      error // set when the command could not be started
+}
+
+func runExec(cmd *cobra.Command, args []string) error {
+	repositories, err := execRepositories(execGroup)
+	if err != nil {
+		return err
+	}
+
+	results := runInRepositories(repositories, execJobs, func(alias, path string) (*exec.Cmd, error) {
+		command := exec.Command(args[0], args[1:]...)
+		command.Dir = path
+		return command, nil
+	})
+	return printExecSummary(results)
+}
+
+// execRepositories returns the repositories selected by the group flag
+func execRepositories(group string) (map[string]string, error) {
+	configMgr := di.ConfigManager()
+	cfg := configMgr.GetConfig()
+	if len(cfg.Repositories) == 0 {
+		return nil, fmt.Errorf("no repositories configured. Use 'gman repo add' to add repositories")
+	}
+
+	filter := repository.NewFilter(configMgr)
+	repositories, err := filter.FilterByGroup(cfg.Repositories, group)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter repositories: %w", err)
+	}
+	return repositories, nil
+}
+
+// forEachRepository calls fn for every repository, parallel_jobs at a time,
+// and returns the results in alias order
+func forEachRepository[T any](repositories map[string]string, fn func(alias, path string) T) []T {
+	aliases := sortedAliases(repositories)
+	results := make([]T, len(aliases))
+	semaphore := make(chan struct{}, max(di.ConfigManager().GetConfig().Settings.ParallelJobs, 1))
+	var wg sync.WaitGroup
+	for i, alias := range aliases {
+		wg.Add(1)
+		go func(i int, alias string) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			results[i] = fn(alias, repositories[alias])
+		}(i, alias)
+	}
+	wg.Wait()
+	return results
+}
+
+// runInRepositories runs the command built by build in every repository,
+// at most jobs at a time, streaming prefixed output as it arrives
+func runInRepositories(repositories map[string]string, jobs int, build func(alias, path string) (*exec.Cmd, error)) []execResult {
+	if jobs <= 0 {
+		jobs = di.ConfigManager().GetConfig().Settings.ParallelJobs
+	}
+	if jobs <= 0 {
+		jobs = 5
+	}
+
+	aliases := sortedAliases(repositories)
+	width := 0
+	for _, alias := range aliases {
+		width = max(width, len(alias))
+	}
+
+	// Serialize writes so lines from different repositories never interleave
+	var outputMu sync.Mutex
+	resultChan := make(chan execResult, len(aliases))
+	semaphore := make(chan struct{}, jobs)
+	var wg sync.WaitGroup
+
+	for i, alias := range aliases {
+		wg.Add(1)
+		go func(alias, path string, prefixColor *color.Color) {
+			defer wg.Done()
+			semaphore <- struct{}{}        // Acquire semaphore
+			defer func() { <-semaphore }() // Release semaphore
+
+			prefix := prefixColor.Sprintf("[%-*s]", width, alias)
+			start := time.Now()
+			exitCode, err := runPrefixed(build, alias, path, prefix, &outputMu)
+			resultChan <- execResult{alias: alias, exitCode: exitCode, duration: time.Since(start), err: err}
+		}(alias, repositories[alias], execPrefixColors[i%len(execPrefixColors)])
+	}
+
+	go func() {
+		wg.Wait()
+		close(resultChan)
+	}()
+
+	var results []execResult
+	for result := range resultChan {
+		results = append(results, result)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].alias < results[j].alias
+	})
+	return results
+}
+
+// execPrefixColors tell the output of neighbouring repositories apart
+var execPrefixColors = []*color.Color{
+	color.New(color.FgCyan),
+	color.New(color.FgMagenta),
+	color.New(color.FgYellow),
+	color.New(color.FgBlue),
+	color.New(color.FgGreen),
+}
+
+// runPrefixed runs one command, copying its stdout and stderr line by line
+// with prefix. It returns the exit code, or -1 with an error when the command
+// could not be run at all.
+func runPrefixed(build func(alias, path string) (*exec.Cmd, error), alias, path, prefix string, outputMu *sync.Mutex) (int, error) {
+	command, err := build(alias, path)
+	if err != nil {
+		return -1, err
+	}
+
+	stdout, err := command.StdoutPipe()
+	if err != nil {
+		return -1, err
+	}
+	stderr, err := command.StderrPipe()
+	if err != nil {
+		return -1, err
+	}
+	if err := command.Start(); err != nil {
+		return -1, err
+	}
+
+	var copyWG sync.WaitGroup
+	copyWG.Add(2)
+	go copyPrefixed(&copyWG, stdout, os.Stdout, prefix, outputMu)
+	go copyPrefixed(&copyWG, stderr, os.Stderr, prefix, outputMu)
+	copyWG.Wait()
+
+	if err := command.Wait(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitCode(), nil
+		}
+		return -1, err
+	}
+	return 0, nil
+}
+
+// copyPrefixed copies r to w line by line, prefixing every line
+func copyPrefixed(wg *sync.WaitGroup, r io.Reader, w io.Writer, prefix string, outputMu *sync.Mutex) {
+	defer wg.Done()
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		outputMu.Lock()
+		fmt.Fprintf(w, "%s %s\n", prefix, scanner.Text())
+		outputMu.Unlock()
+	}
+	// Drain whatever is left (e.g. an overlong line) so the command never blocks
+	io.Copy(io.Discard, r)
+}
+
+// printExecSummary prints the exit code table and fails if any command failed
+func printExecSummary(results []execResult) error {
+	width := len("REPOSITORY")
+	for _, result := range results {
+		width = max(width, len(result.alias))
+	}
+
+	fmt.Println()
+	fmt.Printf("%-*s  %-6s  %s\n", width, "REPOSITORY", "EXIT", "DURATION")
+
+	var failed int
+	for _, result := range results {
+		duration := result.duration.Round(10 * time.Millisecond).String()
+		switch {
+		case result.err != nil:
+			failed++
+			fmt.Printf("%-*s  %s  %s\n", width, result.alias, color.RedString("%-6s", "error"), result.err)
+		case result.exitCode != 0:
+			failed++
+			fmt.Printf("%-*s  %s  %s\n", width, result.alias, color.RedString("%-6d", result.exitCode), duration)
+		default:
+			fmt.Printf("%-*s  %s  %s\n", width, result.alias, color.GreenString("%-6d", 0), duration)
+		}
+	}
+
+	fmt.Printf("\n%d succeeded, %d failed\n", len(results)-failed, failed)
+	if failed > 0 {
+		return fmt.Errorf("command failed in %d repositories", failed)
+	}
+	return nil
+}
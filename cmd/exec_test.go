@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"os/exec"
+	"testing"
+)
+
+func TestRunInRepositories(t *testing.T) {
+	repositories := map[string]string{
+		"ok":     t.TempDir(),
+		"failed": t.TempDir(),
+		"broken": t.TempDir(),
+	}
+
+	results := runInRepositories(repositories, 2, func(alias, path string) (*exec.Cmd, error) {
+		switch alias {
+		case "failed":
+			return exec.Command("sh", "-c", "echo boom >&2; exit 3"), nil
+		case "broken":
+			return exec.Command("gman-test-no-such-command"), nil
+		}
+		command := exec.Command("pwd")
+		command.Dir = path
+		return command, nil
+	})
+
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	// Results are sorted by alias
+	want := []struct {
+		alias    string
+		exitCode int
+		startErr bool
+	}{
+		{"broken", -1, true},
+		{"failed", 3, false},
+		{"ok", 0, false},
+	}
+	for i, w := range want {
+		got := results[i]
+		if got.alias != w.alias || got.exitCode != w.exitCode || (got.err != nil) != w.startErr {
+			t.Errorf("result %d = %+v, want alias %s exit %d (start error %v)", i, got, w.alias, w.exitCode, w.startErr)
+		}
+	}
+
+	if err := printExecSummary(results); err == nil {
+		t.Error("expected the summary to report the failures")
+	}
+}
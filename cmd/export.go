@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"gman/internal/di"
+	"gman/internal/display"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportZoxide   bool
+	exportAutojump bool
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Share repository and worktree paths with directory jumpers",
+	Long: `Feed the paths of all managed repositories and their worktrees into the
+database of a directory jumper, so it knows your repositories as well as gman
+does. Paths already in the database get one more visit, so running the export
+again is harmless.
+
+Without a flag the paths are printed, one per line, for any other tool.
+
+Examples:
+  gman export --zoxide                # zoxide add <path> for every path
+  gman export --autojump              # autojump --add <path> for every path
+  gman export | fzf                   # Plain list of paths`,
+	Args: cobra.NoArgs,
+	RunE: runExport,
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+
+	exportCmd.Flags().BoolVar(&exportZoxide, "zoxide", false, "Add every path to zoxide's database")
+	exportCmd.Flags().BoolVar(&exportAutojump, "autojump", false, "Add every path to autojump's database")
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	cfg := di.ConfigManager().GetConfig()
+	if len(cfg.Repositories) == 0 {
+		return fmt.Errorf("no repositories configured. Use 'gman repo add' to add repositories")
+	}
+
+	targets, err := collectSwitchTargets(cfg.Repositories)
+	if err != nil {
+		return fmt.Errorf("failed to collect switch targets: %w", err)
+	}
+
+	paths := make([]string, 0, len(targets))
+	for _, target := range targets {
+		paths = append(paths, target.Path)
+	}
+	sort.Strings(paths)
+
+	if !exportZoxide && !exportAutojump {
+		for _, path := range paths {
+			fmt.Println(path)
+		}
+		return nil
+	}
+
+	if exportZoxide {
+		if err := exportPaths("zoxide", []string{"add"}, paths); err != nil {
+			return err
+		}
+	}
+	if exportAutojump {
+		if err := exportPaths("autojump", []string{"--add"}, paths); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportPaths runs tool with args and each path in turn, stopping at the
+// first failure
+func exportPaths(tool string, args []string, paths []string) error {
+	if _, err := exec.LookPath(tool); err != nil {
+		return fmt.Errorf("%s is not installed or not in PATH", tool)
+	}
+
+	for _, path := range paths {
+		toolArgs := append(append([]string{}, args...), path)
+		if output, err := exec.Command(tool, toolArgs...).CombinedOutput(); err != nil {
+			return fmt.Errorf("%s failed for %s: %s", tool, path, strings.TrimSpace(string(output)))
+		}
+	}
+
+	fmt.Printf("%s Exported %d paths to %s\n", display.SuccessIcon(), len(paths), tool)
+	return nil
+}
@@ -7,8 +7,12 @@ import (
 	"strings"
 
 	cmdutils "gman/internal/cmd"
+	"gman/internal/display"
+	"gman/internal/errors"
 	"gman/internal/external"
 	"gman/internal/fzf"
+	"gman/internal/index"
+	"gman/internal/interactive"
 	"gman/internal/repository"
 
 	"github.com/fatih/color"
@@ -16,8 +20,19 @@ import (
 )
 
 var (
-	findGroupFilter string
-	findEditor      string
+	findGroupFilter  string
+	findEditor       string
+	findContext      int
+	findContentPrint bool
+	findUseIndex     bool
+	findRepo         string
+	findRef          string
+
+	// Commit search filters passed through to git log
+	findAuthor string
+	findSince  string
+	findUntil  string
+	findPath   string
 )
 
 // findCmd represents the find command
@@ -35,7 +50,11 @@ Examples:
   gman find file --group backend        # Search files in backend group
   gman find content "TODO"              # Search file content with ripgrep
   gman find commit                      # Browse all commits with git log
-  gman find commit "fix bug"            # Search commits matching "fix bug"`,
+  gman find commit "fix bug"            # Search commits matching "fix bug"
+  gman find content "TODO" --json       # Structured results for editors and scripts
+  gman find file --repo backend --ref release/2.3  # Files of a branch that is not checked out
+  gman find ref release/2.3             # Find repos with a branch or tag
+  gman find symbol NewManager           # Find symbol definitions with ctags`,
 }
 
 // findFileCmd represents the find file command
@@ -72,6 +91,8 @@ Examples:
   gman find commit                  # Browse all commits
   gman find commit "fix bug"        # Search for commits with "fix bug"
   gman find commit --group backend  # Search only in backend repositories
+  gman find commit --author alice --since "2 weeks ago"
+  gman find commit --path internal/api --until 2024-06-30
   
 Key bindings in fzf:
   Enter       - Print selected commit hash
@@ -80,18 +101,26 @@ Key bindings in fzf:
 	RunE: runFindCommit,
 }
 
-// findContentCmd represents the find content command using rg
+// findContentCmd represents the find content command using rg (or git grep)
 var findContentCmd = &cobra.Command{
 	Use:   "content <pattern>",
 	Short: "Search file contents across repositories using ripgrep",
 	Long: `Search for text within files across all managed repositories using ripgrep.
 This provides real-time content search with regex support and is much faster
-than traditional file indexing approaches.
+than traditional file indexing approaches. When ripgrep is not installed,
+git grep is used instead (tracked files only). git grep reads the pattern
+as a Perl-compatible regex, like ripgrep, when git was built with PCRE;
+otherwise as an extended regex without \d, lazy quantifiers or (?i).
+
+Results are handed to fzf for selection. With --print, or when fzf is not
+available, matches are printed grouped by repository and file, with
+--context lines around each match.
 
 Examples:
   gman find content "TODO"               # Search for TODO comments
   gman find content "func.*Error"       # Search using regex patterns
   gman find content "import.*react" --group frontend  # Search in specific group
+  gman find content "panic\(" --print -C 2           # Grouped output with context
   
 Key bindings in fzf:
   Enter       - Print selected file path with line number
@@ -109,18 +138,40 @@ func init() {
 
 	// Common flags
 	findCmd.PersistentFlags().StringVar(&findGroupFilter, "group", "", "Filter by repository group")
+	findCmd.PersistentFlags().BoolVar(&findJSON, "json", false, "Print results as JSON instead of selecting interactively")
+	findCmd.PersistentFlags().StringVar(&findRepo, "repo", "", "Only search this repository alias")
 
 	// File-specific flags
 	findFileCmd.Flags().StringVar(&findEditor, "editor", "", "Editor to use when opening files (default: $EDITOR)")
+	findFileCmd.Flags().BoolVar(&findUseIndex, "index", false, "Search the persistent index instead of walking repositories")
+	findFileCmd.Flags().StringVar(&findRef, "ref", "", "Search the files of this branch, tag or commit without checking it out")
+
+	// Commit-specific flags
+	findCommitCmd.Flags().BoolVar(&findUseIndex, "index", false, "Search the persistent index instead of running git log")
+	findCommitCmd.Flags().StringVar(&findRef, "ref", "", "Search the history of this branch, tag or commit instead of all refs")
+	findCommitCmd.Flags().StringVar(&findAuthor, "author", "", "Only commits by authors matching this pattern")
+	findCommitCmd.Flags().StringVar(&findSince, "since", "", "Only commits more recent than this date (e.g. 2024-01-01, \"2 weeks ago\")")
+	findCommitCmd.Flags().StringVar(&findUntil, "until", "", "Only commits older than this date")
+	findCommitCmd.Flags().StringVar(&findPath, "path", "", "Only commits touching this path")
 	
 	// Content-specific flags
 	findContentCmd.Flags().StringVar(&findEditor, "editor", "", "Editor to use when opening files (default: $EDITOR)")
+	findContentCmd.Flags().IntVarP(&findContext, "context", "C", 0, "Lines of context to show around matches (with --print)")
+	findContentCmd.Flags().BoolVar(&findContentPrint, "print", false, "Print matches grouped by repository instead of launching fzf")
+	findContentCmd.Flags().StringVar(&findRef, "ref", "", "Search the content of this branch, tag or commit with git grep")
 }
 
 func runFindFile(cmd *cobra.Command, args []string) error {
 	// Use consolidated manager access pattern
 	mgrs := cmdutils.GetManagers()
 	cfg := mgrs.Config.GetConfig()
+	scopeRepos, scopeGroup, err := findScope(cfg.Repositories)
+	if err != nil {
+		return err
+	}
+	if err := checkFindRef(); err != nil {
+		return err
+	}
 
 	// Get initial search query
 	var initialQuery string
@@ -144,14 +195,36 @@ func runFindFile(cmd *cobra.Command, args []string) error {
 		fmt.Fprintf(os.Stderr, "%s\n", color.BlueString("🔍 Searching files with optimized tools..."))
 	}
 
-	// Search for files using intelligent search strategy
-	results, err := searcher.SearchFiles(initialQuery, cfg.Repositories, findGroupFilter)
-	if err != nil {
-		return fmt.Errorf("failed to search files: %w", err)
+	if findUseIndex && findRef != "" {
+		return fmt.Errorf("--ref cannot be combined with --index")
+	}
+
+	// Search for files at a ref, in the persistent index or with the intelligent search strategy
+	var results []external.FileResult
+	if findRef != "" {
+		results, err = external.SearchFilesAtRef(initialQuery, findRef, scopeRepos, scopeGroup)
+		if err != nil {
+			return err
+		}
+	} else if findUseIndex {
+		repositories, err := repository.NewFilter(mgrs.Config).FilterByGroup(scopeRepos, scopeGroup)
+		if err != nil {
+			return fmt.Errorf("failed to filter repositories: %w", err)
+		}
+		results = index.SearchFiles(loadSearchIndexes(repositories), initialQuery)
+	} else {
+		results, err = searcher.SearchFiles(initialQuery, scopeRepos, scopeGroup)
+		if err != nil {
+			return fmt.Errorf("failed to search files: %w", err)
+		}
+	}
+
+	if findJSON {
+		return printSearchJSON(fileResultsJSON(results))
 	}
 
 	if len(results) == 0 {
-		fmt.Printf("%s No files found", color.YellowString("⚠️"))
+		fmt.Printf("%s No files found", display.WarningIcon())
 		if initialQuery != "" {
 			fmt.Printf(" matching '%s'", initialQuery)
 		}
@@ -162,8 +235,20 @@ func runFindFile(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	// Without a terminal to select in, list every match instead
+	if interactive.NonInteractive() {
+		for _, result := range results {
+			if findRef != "" {
+				fmt.Println(result.DisplayText)
+			} else {
+				fmt.Println(result.FullPath)
+			}
+		}
+		return nil
+	}
+
 	fmt.Fprintf(os.Stderr, "%s Found %d files. Starting selection...\n", 
-		color.GreenString("✅"), len(results))
+		display.SuccessIcon(), len(results))
 
 	// Use intelligent selection (fzf or fallback)
 	prompt := fmt.Sprintf("📁 Select a file to view (%d results)", len(results))
@@ -176,15 +261,20 @@ func runFindFile(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("selection failed: %w", err)
 	}
 
-	// Output the selected file path
+	// Output the selected file path; files at a ref are not on disk, so
+	// print the command that shows them instead
+	if findRef != "" {
+		fmt.Printf("git -C %s show %s:%s\n", scopeRepos[selectedFile.RepoAlias], findRef, selectedFile.RelativePath)
+		return nil
+	}
 	fmt.Println(selectedFile.FullPath)
 	return nil
 }
 
 func runFindCommit(cmd *cobra.Command, args []string) error {
-	// Check if fzf is available
-	if !fzf.IsAvailable() {
-		fmt.Fprintf(os.Stderr, "%s\n", color.RedString("❌ fzf not found"))
+	// Check if fzf is available (not needed for JSON output)
+	if !findJSON && !interactive.NonInteractive() && !fzf.IsAvailable() {
+		fmt.Fprintf(os.Stderr, "%s\n", color.RedString(display.Icon("❌", "ERROR")+" fzf not found"))
 		fmt.Fprintf(os.Stderr, "%s\n\n", fzf.GetInstallInstructions())
 		return fmt.Errorf("fzf is required for this command")
 	}
@@ -192,6 +282,13 @@ func runFindCommit(cmd *cobra.Command, args []string) error {
 	// Use consolidated manager access pattern
 	mgrs := cmdutils.GetManagers()
 	cfg := mgrs.Config.GetConfig()
+	scopeRepos, scopeGroup, err := findScope(cfg.Repositories)
+	if err != nil {
+		return err
+	}
+	if err := checkFindRef(); err != nil {
+		return err
+	}
 
 	// Get initial search query
 	var initialQuery string
@@ -199,50 +296,80 @@ func runFindCommit(cmd *cobra.Command, args []string) error {
 		initialQuery = args[0]
 	}
 
-	fmt.Fprintf(os.Stderr, "%s\n", color.BlueString("🔍 Searching commits with real-time git log..."))
+	if findUseIndex {
+		fmt.Fprintf(os.Stderr, "%s\n", color.BlueString("🔍 Searching commits in the persistent index..."))
+	} else {
+		fmt.Fprintf(os.Stderr, "%s\n", color.BlueString("🔍 Searching commits with real-time git log..."))
+	}
+
+	if findUseIndex && (commitFilterArgs() != nil || findRef != "") {
+		return fmt.Errorf("--author, --since, --until, --path and --ref are not supported with --index")
+	}
 
 	// Use consolidated repository filtering
 	filter := repository.NewFilter(mgrs.Config)
-	repositories, err := filter.FilterByGroup(cfg.Repositories, findGroupFilter)
+	repositories, err := filter.FilterByGroup(scopeRepos, scopeGroup)
 	if err != nil {
 		return fmt.Errorf("failed to filter repositories: %w", err)
 	}
 
+	if findJSON {
+		if !findUseIndex {
+			return printSearchJSON(searchCommitsJSON(repositories, initialQuery))
+		}
+		var results []searchResultJSON
+		for _, match := range index.SearchCommits(loadSearchIndexes(repositories), initialQuery, 100) {
+			results = append(results, commitResultJSON(match.RepoAlias, match.RepoPath, match.Commit))
+		}
+		return printSearchJSON(results)
+	}
+
 	// Collect commits from all repositories using git log
 	var allCommits []string
 	var totalCommits int
 
-	for alias, path := range repositories {
-		// Build git log command - search commit messages if query provided
-		gitArgs := []string{"log", "--oneline", "--all", "--decorate", "--color=always", "-n", "100"}
-		if initialQuery != "" {
-			gitArgs = append(gitArgs, fmt.Sprintf("--grep=%s", initialQuery))
-		}
-
-		// Execute git log
-		gitCmd := exec.Command("git", gitArgs...)
-		gitCmd.Dir = path
-		output, err := gitCmd.Output()
-		if err != nil {
-			// Skip repositories that don't have commits or have errors
-			continue
+	if findUseIndex {
+		for _, match := range index.SearchCommits(loadSearchIndexes(repositories), initialQuery, 100) {
+			// Same "[alias] hash subject" layout as git log --oneline for the preview
+			commitEntry := fmt.Sprintf("[%s] %s %s", color.CyanString(match.RepoAlias),
+				color.YellowString(match.Commit.ShortHash()), match.Commit.Subject)
+			allCommits = append(allCommits, commitEntry)
+			totalCommits++
 		}
+	} else {
+		for alias, path := range repositories {
+			// Build git log command - search commit messages if query provided
+			gitArgs := []string{"log", "--oneline", commitRevisionArg(), "--decorate", "--color=always", "-n", "100"}
+			if initialQuery != "" {
+				gitArgs = append(gitArgs, fmt.Sprintf("--grep=%s", initialQuery))
+			}
+			gitArgs = append(gitArgs, commitFilterArgs()...)
+
+			// Execute git log
+			gitCmd := exec.Command("git", gitArgs...)
+			gitCmd.Dir = path
+			output, err := gitCmd.Output()
+			if err != nil {
+				// Skip repositories that don't have commits or have errors
+				continue
+			}
 
-		if len(output) > 0 {
-			lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-			for _, line := range lines {
-				if line != "" {
-					// Prefix each commit with repository alias
-					commitEntry := fmt.Sprintf("[%s] %s", color.CyanString(alias), line)
-					allCommits = append(allCommits, commitEntry)
-					totalCommits++
+			if len(output) > 0 {
+				lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+				for _, line := range lines {
+					if line != "" {
+						// Prefix each commit with repository alias
+						commitEntry := fmt.Sprintf("[%s] %s", color.CyanString(alias), line)
+						allCommits = append(allCommits, commitEntry)
+						totalCommits++
+					}
 				}
 			}
 		}
 	}
 
 	if len(allCommits) == 0 {
-		fmt.Printf("%s No commits found", color.YellowString("⚠️"))
+		fmt.Printf("%s No commits found", display.WarningIcon())
 		if initialQuery != "" {
 			fmt.Printf(" matching '%s'", initialQuery)
 		}
@@ -253,6 +380,14 @@ func runFindCommit(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	// Without a terminal to select in, list every match instead
+	if interactive.NonInteractive() {
+		for _, commit := range allCommits {
+			fmt.Println(commit)
+		}
+		return nil
+	}
+
 	// Create fzf finder
 	finder, err := fzf.NewFinder()
 	if err != nil {
@@ -268,6 +403,9 @@ func runFindCommit(cmd *cobra.Command, args []string) error {
 	if findGroupFilter != "" {
 		statsInfo += fmt.Sprintf(" in group '%s'", findGroupFilter)
 	}
+	if filters := describeCommitFilters(); filters != "" {
+		statsInfo += " [" + filters + "]"
+	}
 	opts.Header = statsInfo + " | Press Enter to select, Ctrl-C to cancel"
 
 	// Set up preview command for commit details
@@ -291,7 +429,7 @@ func runFindCommit(cmd *cobra.Command, args []string) error {
 		fi
 	`
 
-	fmt.Fprintf(os.Stderr, "%s\n", color.GreenString("✅ Ready. Launching fzf..."))
+	fmt.Fprintf(os.Stderr, "%s\n", color.GreenString(display.Icon("✅", "OK")+" Ready. Launching fzf..."))
 
 	// Launch fzf with commit data
 	selection, err := finder.FindSingle(allCommits, opts)
@@ -343,35 +481,121 @@ func getEditorCommand() string {
 	return ""
 }
 
+// findScope resolves --repo and --group into the repositories and group
+// filter handed to the searchers
+func findScope(repositories map[string]string) (map[string]string, string, error) {
+	if findRepo == "" {
+		return repositories, findGroupFilter, nil
+	}
+	if findGroupFilter != "" {
+		return nil, "", fmt.Errorf("--repo and --group are mutually exclusive")
+	}
+	path, exists := repositories[findRepo]
+	if !exists {
+		return nil, "", errors.NotFoundError("repository", findRepo)
+	}
+	return map[string]string{findRepo: path}, "", nil
+}
+
+// checkFindRef rejects a --ref git would read as an option, since the ref
+// is passed to git log, ls-tree and grep among their options
+func checkFindRef() error {
+	if strings.HasPrefix(findRef, "-") {
+		return fmt.Errorf("invalid --ref '%s': refs cannot start with '-'", findRef)
+	}
+	return nil
+}
+
+// commitRevisionArg selects the history searched by commit search: the
+// given --ref, or all refs
+func commitRevisionArg() string {
+	if findRef != "" {
+		return findRef
+	}
+	return "--all"
+}
+
+// commitFilterArgs translates the commit search filters into git log
+// arguments. The path filter is a pathspec and must stay last.
+func commitFilterArgs() []string {
+	var args []string
+	if findAuthor != "" {
+		args = append(args, "--author="+findAuthor)
+	}
+	if findSince != "" {
+		args = append(args, "--since="+findSince)
+	}
+	if findUntil != "" {
+		args = append(args, "--until="+findUntil)
+	}
+	if findPath != "" {
+		args = append(args, "--", findPath)
+	}
+	return args
+}
+
+// describeCommitFilters summarizes the active commit filters for the fzf header
+func describeCommitFilters() string {
+	var parts []string
+	if findAuthor != "" {
+		parts = append(parts, "author: "+findAuthor)
+	}
+	if findSince != "" {
+		parts = append(parts, "since: "+findSince)
+	}
+	if findUntil != "" {
+		parts = append(parts, "until: "+findUntil)
+	}
+	if findPath != "" {
+		parts = append(parts, "path: "+findPath)
+	}
+	return strings.Join(parts, ", ")
+}
+
 func runFindContent(cmd *cobra.Command, args []string) error {
-	// Check dependencies
-	missingTools := external.CheckDependencies(external.RipGrep, external.FZF)
-	if len(missingTools) > 0 {
-		fmt.Fprintf(os.Stderr, "%s\n", color.RedString("❌ Missing required tools: %s", strings.Join(missingTools, ", ")))
-		fmt.Fprintf(os.Stderr, "%s\n", external.GetMissingToolsMessage(external.RipGrep, external.FZF))
-		return fmt.Errorf("required tools not available")
+	if findContext < 0 {
+		return fmt.Errorf("--context must not be negative")
+	}
+	if findContext > 0 && findRef != "" {
+		// Context is read from the working tree, which may differ from the ref
+		return fmt.Errorf("--context cannot be combined with --ref")
 	}
 
 	// Use consolidated manager access pattern
 	mgrs := cmdutils.GetManagers()
 	cfg := mgrs.Config.GetConfig()
+	scopeRepos, scopeGroup, err := findScope(cfg.Repositories)
+	if err != nil {
+		return err
+	}
+	if err := checkFindRef(); err != nil {
+		return err
+	}
 
 	// Get search pattern (required)
 	searchPattern := args[0]
 
-	// Initialize rg searcher
-	searcher := external.NewRGSearcher()
+	// Initialize the best available searcher (ripgrep, falling back to git grep);
+	// refs that are not checked out can only be searched with git grep
+	searcher, backend := external.NewContentSearcher()
+	if findRef != "" {
+		searcher, backend = external.NewGitGrepSearcherAtRef(findRef), "git grep at "+findRef
+	}
 	
-	fmt.Fprintf(os.Stderr, "%s\n", color.BlueString("🔍 Searching content with ripgrep..."))
+	fmt.Fprintf(os.Stderr, "%s\n", color.BlueString("🔍 Searching content with %s...", backend))
 
-	// Search for content using rg
-	results, err := searcher.SearchContent(searchPattern, cfg.Repositories, findGroupFilter)
+	// Search for content
+	results, err := searcher.SearchContent(searchPattern, scopeRepos, scopeGroup)
 	if err != nil {
 		return fmt.Errorf("failed to search content: %w", err)
 	}
 
+	if findJSON {
+		return printSearchJSON(contentResultsJSON(results))
+	}
+
 	if len(results) == 0 {
-		fmt.Printf("%s No content found", color.YellowString("⚠️"))
+		fmt.Printf("%s No content found", display.WarningIcon())
 		fmt.Printf(" matching '%s'", searchPattern)
 		if findGroupFilter != "" {
 			fmt.Printf(" in group '%s'", findGroupFilter)
@@ -380,6 +604,19 @@ func runFindContent(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	return presentContentResults(searcher, results, fmt.Sprintf("Found %d matches for '%s'", len(results), searchPattern))
+}
+
+// presentContentResults prints grouped results (with --print or without fzf)
+// or lets the user pick one in fzf and prints its path:line
+func presentContentResults(searcher external.ContentSearcher, results []external.ContentResult, statsInfo string) error {
+	// Print grouped results when asked to, when fzf is not available or
+	// when there is no terminal to select in
+	if findContentPrint || !external.FZF.IsAvailable() || interactive.NonInteractive() {
+		printContentGroups(results, findContext)
+		return nil
+	}
+
 	// Format results for fzf
 	fzfInput := searcher.FormatForFZF(results)
 	fzfLines := strings.Split(fzfInput, "\n")
@@ -398,7 +635,6 @@ func runFindContent(cmd *cobra.Command, args []string) error {
 	opts.Border = true
 
 	// Add header with stats
-	statsInfo := fmt.Sprintf("Found %d matches for '%s'", len(results), searchPattern)
 	if findGroupFilter != "" {
 		statsInfo += fmt.Sprintf(" in group '%s'", findGroupFilter)
 	}
@@ -414,7 +650,7 @@ func runFindContent(cmd *cobra.Command, args []string) error {
 		opts.BindKeys = []string{editorBinding}
 	}
 
-	fmt.Fprintf(os.Stderr, "%s\n", color.GreenString("✅ Search complete. Launching fzf..."))
+	fmt.Fprintf(os.Stderr, "%s\n", color.GreenString(display.Icon("✅", "OK")+" Search complete. Launching fzf..."))
 
 	// Launch fzf
 	selection, err := finder.FindSingle(fzfLines, opts)
@@ -438,6 +674,79 @@ func runFindContent(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// printContentGroups prints content matches grouped by repository and file,
+// grep-style: "N:" marks matching lines, "N-" context lines, "--" gaps
+func printContentGroups(results []external.ContentResult, context int) {
+	for _, group := range external.GroupContentByRepository(results) {
+		fmt.Printf("%s %s\n", color.CyanString(group.RepoAlias), color.HiBlackString("(%d matches)", len(group.Results)))
+
+		for start := 0; start < len(group.Results); {
+			// Collect the matches of one file
+			end := start
+			for end < len(group.Results) && group.Results[end].FilePath == group.Results[start].FilePath {
+				end++
+			}
+			fileResults := group.Results[start:end]
+			start = end
+
+			fmt.Printf("  %s\n", color.YellowString(fileResults[0].FilePath))
+
+			if context == 0 {
+				for _, result := range fileResults {
+					fmt.Printf("    %s %s\n", color.GreenString("%d:", result.LineNumber), result.LineContent)
+				}
+				continue
+			}
+
+			matched := make(map[int]bool, len(fileResults))
+			for _, result := range fileResults {
+				matched[result.LineNumber] = true
+			}
+			first := fileResults[0].LineNumber - context
+			last := fileResults[len(fileResults)-1].LineNumber + context
+			lines, err := external.ReadLineRange(fileResults[0].FullPath, first, last)
+			if err != nil {
+				// Fall back to the match lines alone
+				for _, result := range fileResults {
+					fmt.Printf("    %s %s\n", color.GreenString("%d:", result.LineNumber), result.LineContent)
+				}
+				continue
+			}
+
+			prev := 0
+			for n := first; n <= last; n++ {
+				if !isWithinContext(n, fileResults, context) {
+					continue
+				}
+				text, exists := lines[n]
+				if !exists {
+					continue
+				}
+				if prev != 0 && n != prev+1 {
+					fmt.Printf("    %s\n", color.HiBlackString("--"))
+				}
+				if matched[n] {
+					fmt.Printf("    %s %s\n", color.GreenString("%d:", n), text)
+				} else {
+					fmt.Printf("    %s %s\n", color.HiBlackString("%d-", n), text)
+				}
+				prev = n
+			}
+		}
+		fmt.Println()
+	}
+}
+
+// isWithinContext reports whether line n is within context lines of any match
+func isWithinContext(n int, results []external.ContentResult, context int) bool {
+	for _, result := range results {
+		if n >= result.LineNumber-context && n <= result.LineNumber+context {
+			return true
+		}
+	}
+	return false
+}
+
 // Helper function to check if a command exists
 func commandExists(cmd string) bool {
 	_, err := exec.LookPath(cmd)
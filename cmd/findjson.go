@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"gman/internal/external"
+	"gman/internal/git"
+	"gman/internal/index"
+)
+
+// findJSON switches find commands to structured JSON output for editors and scripts
+var findJSON bool
+
+// searchResultJSON is one search hit in --json output. Fields that do not
+// apply to a search kind are omitted.
+type searchResultJSON struct {
+	Repo     string     `json:"repo"`
+	RepoPath string     `json:"repo_path,omitempty"`
+	Path     string     `json:"path,omitempty"`
+	FullPath string     `json:"full_path,omitempty"`
+	Line     int        `json:"line,omitempty"`
+	Column   int        `json:"column,omitempty"`
+	Commit   string     `json:"commit,omitempty"`
+	Author   string     `json:"author,omitempty"`
+	Date     *time.Time `json:"date,omitempty"`
+	Subject  string     `json:"subject,omitempty"`
+	Ref      string     `json:"ref,omitempty"`
+	Kind     string     `json:"kind,omitempty"`
+	Display  string     `json:"display"`
+}
+
+// printSearchJSON writes results as an indented JSON array ("[]" when empty)
+func printSearchJSON(results []searchResultJSON) error {
+	if results == nil {
+		results = []searchResultJSON{}
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(results); err != nil {
+		return fmt.Errorf("failed to encode search results: %w", err)
+	}
+	return nil
+}
+
+// fileResultsJSON converts file search results
+func fileResultsJSON(results []external.FileResult) []searchResultJSON {
+	converted := make([]searchResultJSON, 0, len(results))
+	for _, result := range results {
+		converted = append(converted, searchResultJSON{
+			Repo:     result.RepoAlias,
+			Path:     result.RelativePath,
+			FullPath: result.FullPath,
+			Display:  result.DisplayText,
+		})
+	}
+	return converted
+}
+
+// contentResultsJSON converts content and symbol search results
+func contentResultsJSON(results []external.ContentResult) []searchResultJSON {
+	converted := make([]searchResultJSON, 0, len(results))
+	for _, result := range results {
+		converted = append(converted, searchResultJSON{
+			Repo:     result.RepoAlias,
+			Path:     result.FilePath,
+			FullPath: result.FullPath,
+			Line:     result.LineNumber,
+			Column:   result.MatchColumn,
+			Display:  result.DisplayText,
+		})
+	}
+	return converted
+}
+
+// commitResultJSON converts a commit hit
+func commitResultJSON(alias, repoPath string, commit index.CommitEntry) searchResultJSON {
+	result := searchResultJSON{
+		Repo:     alias,
+		RepoPath: repoPath,
+		Commit:   commit.Hash,
+		Author:   commit.Author,
+		Subject:  commit.Subject,
+		Display:  fmt.Sprintf("[%s] %s %s", alias, commit.ShortHash(), commit.Subject),
+	}
+	if !commit.Date.IsZero() {
+		date := commit.Date
+		result.Date = &date
+	}
+	return result
+}
+
+// searchCommitsJSON runs the same git log query as the interactive commit
+// search with a machine readable format
+func searchCommitsJSON(repositories map[string]string, query string) []searchResultJSON {
+	var results []searchResultJSON
+	for _, alias := range sortedAliases(repositories) {
+		path := repositories[alias]
+		gitArgs := []string{"log", commitRevisionArg(), "--format=%H%x1f%an%x1f%aI%x1f%s", "-n", "100"}
+		if query != "" {
+			gitArgs = append(gitArgs, fmt.Sprintf("--grep=%s", query))
+		}
+		gitArgs = append(gitArgs, commitFilterArgs()...)
+
+		gitCmd := exec.Command("git", gitArgs...)
+		gitCmd.Dir = path
+		output, err := gitCmd.Output()
+		if err != nil {
+			// Skip repositories that don't have commits or have errors
+			continue
+		}
+
+		for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+			fields := strings.SplitN(line, "\x1f", 4)
+			if len(fields) < 4 {
+				continue
+			}
+			date, _ := time.Parse(time.RFC3339, fields[2])
+			results = append(results, commitResultJSON(alias, path, index.CommitEntry{
+				Hash: fields[0], Author: fields[1], Date: date, Subject: fields[3],
+			}))
+		}
+	}
+	return results
+}
+
+// refResultsJSON converts branch and tag matches
+func refResultsJSON(matches []refMatch) []searchResultJSON {
+	converted := make([]searchResultJSON, 0, len(matches))
+	for _, match := range matches {
+		name := match.ref.Name
+		if match.ref.Kind == git.RefRemoteBranch {
+			name = match.ref.Remote + "/" + name
+		}
+		converted = append(converted, searchResultJSON{
+			Repo:     match.alias,
+			RepoPath: match.path,
+			Ref:      name,
+			Kind:     match.ref.Kind,
+			Display:  fmt.Sprintf("[%s] %s %s", match.alias, match.ref.Kind, name),
+		})
+	}
+	return converted
+}
+
+// sortedAliases returns repository aliases in alphabetical order
+func sortedAliases(repositories map[string]string) []string {
+	aliases := make([]string, 0, len(repositories))
+	for alias := range repositories {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	return aliases
+}
@@ -0,0 +1,282 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	cmdutils "gman/internal/cmd"
+	"gman/internal/display"
+	"gman/internal/git"
+	"gman/internal/interactive"
+	"gman/internal/repository"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	findRefTagsOnly     bool
+	findRefBranchesOnly bool
+	findRefSwitch       bool
+	findRefDelete       bool
+	findRefWorktree     bool
+	findRefForce        bool
+	findRefYes          bool
+)
+
+// findRefCmd represents the find ref command
+var findRefCmd = &cobra.Command{
+	Use:     "ref <pattern>",
+	Aliases: []string{"branch", "tag"},
+	Short:   "Search branch and tag names across repositories",
+	Long: `Search branch and tag names across all managed repositories, e.g. to find
+every repository that has a release/2.3 branch. Local branches, remote-tracking
+branches and tags are matched case-insensitively against the pattern.
+
+With an action flag the pattern must be an exact branch name, and the action is
+applied in every repository that has that branch:
+  --switch     check the branch out (remote-only branches get a tracking branch)
+  --delete     delete the local branch (unmerged branches need --force);
+               the branches are listed and confirmed first unless --yes is given
+  --worktree   create a worktree next to the repository, named <repo>-<branch>
+
+Examples:
+  gman tools find ref release/2.3              # Which repos have it?
+  gman tools find ref hotfix --branches        # Only branches
+  gman tools find ref v1.4 --tags              # Only tags
+  gman tools find ref release/2.3 --switch     # Switch every repo that has it
+  gman tools find ref old-spike --delete --force
+  gman tools find ref release/2.3 --worktree --group backend`,
+	Args: cobra.ExactArgs(1),
+	RunE: runFindRef,
+}
+
+func init() {
+	findCmd.AddCommand(findRefCmd)
+
+	findRefCmd.Flags().BoolVar(&findRefTagsOnly, "tags", false, "Only match tags")
+	findRefCmd.Flags().BoolVar(&findRefBranchesOnly, "branches", false, "Only match branches")
+	findRefCmd.Flags().BoolVar(&findRefSwitch, "switch", false, "Switch to the branch in every repository that has it")
+	findRefCmd.Flags().BoolVar(&findRefDelete, "delete", false, "Delete the local branch in every repository that has it")
+	findRefCmd.Flags().BoolVar(&findRefWorktree, "worktree", false, "Create a worktree for the branch in every repository that has it")
+	findRefCmd.Flags().BoolVar(&findRefForce, "force", false, "Delete branches even if they are not fully merged")
+	findRefCmd.Flags().BoolVarP(&findRefYes, "yes", "y", false, "Delete without confirmation")
+}
+
+// refMatch is a branch or tag found in a repository
+type refMatch struct {
+	alias string
+	path  string
+	ref   git.RefInfo
+}
+
+func runFindRef(cmd *cobra.Command, args []string) error {
+	pattern := args[0]
+
+	actions := 0
+	for _, set := range []bool{findRefSwitch, findRefDelete, findRefWorktree} {
+		if set {
+			actions++
+		}
+	}
+	if actions > 1 {
+		return fmt.Errorf("--switch, --delete and --worktree are mutually exclusive")
+	}
+	if findRefTagsOnly && findRefBranchesOnly {
+		return fmt.Errorf("--tags and --branches are mutually exclusive")
+	}
+	if actions > 0 && findRefTagsOnly {
+		return fmt.Errorf("actions only apply to branches")
+	}
+
+	mgrs := cmdutils.GetManagers()
+	cfg := mgrs.Config.GetConfig()
+
+	filter := repository.NewFilter(mgrs.Config)
+	scopeRepos, scopeGroup, err := findScope(cfg.Repositories)
+	if err != nil {
+		return err
+	}
+	repositories, err := filter.FilterByGroup(scopeRepos, scopeGroup)
+	if err != nil {
+		return fmt.Errorf("failed to filter repositories: %w", err)
+	}
+
+	matches := collectRefMatches(mgrs.Git, repositories, pattern, actions > 0)
+	if findJSON && actions == 0 {
+		return printSearchJSON(refResultsJSON(matches))
+	}
+	if len(matches) == 0 {
+		fmt.Printf("%s No branches or tags found matching '%s'", display.WarningIcon(), pattern)
+		if findGroupFilter != "" {
+			fmt.Printf(" in group '%s'", findGroupFilter)
+		}
+		fmt.Println()
+		return nil
+	}
+
+	if actions == 0 {
+		printRefMatches(matches)
+		return nil
+	}
+	if findRefDelete && !findRefYes {
+		printRefMatches(matches)
+		if interactive.NonInteractive() {
+			return interactive.ErrUnavailable("confirming the deletion", "pass --yes to delete the branches")
+		}
+		fmt.Printf("Delete these branches? [y/N]: ")
+		if !askConfirmation(false) {
+			fmt.Println("Deletion cancelled.")
+			return nil
+		}
+	}
+	return applyRefAction(mgrs.Git, matches)
+}
+
+// collectRefMatches lists refs of every repository and keeps the matching ones.
+// With exact set, only branches named exactly pattern match, and a local
+// branch hides the remote-tracking branch of the same name.
+func collectRefMatches(gitMgr *git.Manager, repositories map[string]string, pattern string, exact bool) []refMatch {
+	lowerPattern := strings.ToLower(pattern)
+	var matches []refMatch
+
+	for alias, path := range repositories {
+		refs, err := gitMgr.ListRefs(path)
+		if err != nil {
+			slog.Warn("failed to list refs", "repo", alias, "error", err)
+			continue
+		}
+
+		var repoMatches []refMatch
+		hasLocal := false
+		for _, ref := range refs {
+			isTag := ref.Kind == git.RefTag
+			if (findRefTagsOnly && !isTag) || ((findRefBranchesOnly || exact) && isTag) {
+				continue
+			}
+			if exact {
+				if ref.Name != pattern {
+					continue
+				}
+			} else if !strings.Contains(strings.ToLower(ref.Name), lowerPattern) {
+				continue
+			}
+			if ref.Kind == git.RefLocalBranch {
+				hasLocal = true
+			}
+			repoMatches = append(repoMatches, refMatch{alias: alias, path: path, ref: ref})
+		}
+
+		if exact && len(repoMatches) > 0 {
+			// One action per repository: prefer the local branch
+			chosen := repoMatches[0]
+			for _, match := range repoMatches {
+				if !hasLocal || match.ref.Kind == git.RefLocalBranch {
+					chosen = match
+					break
+				}
+			}
+			repoMatches = []refMatch{chosen}
+		}
+		matches = append(matches, repoMatches...)
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].alias != matches[j].alias {
+			return matches[i].alias < matches[j].alias
+		}
+		if matches[i].ref.Kind != matches[j].ref.Kind {
+			return matches[i].ref.Kind < matches[j].ref.Kind
+		}
+		return matches[i].ref.Name < matches[j].ref.Name
+	})
+	return matches
+}
+
+// printRefMatches prints matches grouped by repository
+func printRefMatches(matches []refMatch) {
+	repos := 0
+	currentAlias := ""
+	for _, match := range matches {
+		if match.alias != currentAlias {
+			currentAlias = match.alias
+			repos++
+			fmt.Printf("%s %s\n", color.CyanString(match.alias), color.HiBlackString(match.path))
+		}
+
+		name := match.ref.Name
+		if match.ref.Kind == git.RefRemoteBranch {
+			name = match.ref.Remote + "/" + name
+		}
+		fmt.Printf("  %-7s %s\n", match.ref.Kind, name)
+	}
+	fmt.Printf("\nFound %d refs in %d repositories\n", len(matches), repos)
+}
+
+// applyRefAction switches to, deletes or creates a worktree for the matched
+// branch in each repository
+func applyRefAction(gitMgr *git.Manager, matches []refMatch) error {
+	// Switches and deletions can be undone with gman undo
+	operation := "find ref --switch"
+	if findRefDelete {
+		operation = "find ref --delete"
+	}
+	recorder := newUndoRecorder(operation)
+	defer saveUndoRecord(recorder)
+
+	var failed int
+	for _, match := range matches {
+		branch := match.ref.Name
+		var err error
+		var done string
+
+		switch {
+		case findRefSwitch:
+			recorder.Before(match.alias, match.path)
+			err = gitMgr.SwitchBranch(match.path, branch)
+			done = fmt.Sprintf("switched to %s", branch)
+		case findRefDelete:
+			if match.ref.Kind != git.RefLocalBranch {
+				fmt.Printf("⏭️  %s: only %s/%s exists, remote branches are not deleted\n", match.alias, match.ref.Remote, branch)
+				continue
+			}
+			recorder.Before(match.alias, match.path)
+			recorder.DeletingBranch(match.alias, branch)
+			err = gitMgr.DeleteBranch(match.path, branch, findRefForce)
+			done = fmt.Sprintf("deleted %s", branch)
+		case findRefWorktree:
+			var worktreePath string
+			worktreePath, err = branchWorktreePath(cmdutils.GetManagers().Config.GetConfig().Settings, match.alias, match.path, branch)
+			if err == nil {
+				err = createRefWorktree(gitMgr, match, worktreePath)
+			}
+			done = fmt.Sprintf("worktree for %s at %s", branch, worktreePath)
+		}
+
+		if err != nil {
+			failed++
+			fmt.Printf("%s %s: %v\n", display.ErrorIcon(), match.alias, err)
+			continue
+		}
+		fmt.Printf("%s %s: %s\n", display.SuccessIcon(), match.alias, done)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("action failed in %d repositories", failed)
+	}
+	return nil
+}
+
+// createRefWorktree creates a worktree for the matched branch, setting up a
+// tracking branch first when only the remote-tracking branch exists
+func createRefWorktree(gitMgr *git.Manager, match refMatch, worktreePath string) error {
+	if match.ref.Kind == git.RefRemoteBranch {
+		upstream := match.ref.Remote + "/" + match.ref.Name
+		if _, err := gitMgr.RunCommand(match.path, "branch", "--track", match.ref.Name, upstream); err != nil {
+			return fmt.Errorf("failed to create tracking branch: %w", err)
+		}
+	}
+	return gitMgr.AddWorktree(match.path, worktreePath, match.ref.Name)
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	cmdutils "gman/internal/cmd"
+	"gman/internal/display"
+	"gman/internal/external"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	findSymbolPartial bool
+	findSymbolKind    string
+	findSymbolBackend string
+)
+
+// findSymbolCmd represents the find symbol command
+var findSymbolCmd = &cobra.Command{
+	Use:   "symbol <name>",
+	Short: "Find symbol definitions across repositories",
+	Long: `Find where functions, types and other symbols are defined across all managed
+repositories. Tracked files are indexed with universal-ctags; Go modules can use
+gopls instead via --backend gopls or 'symbol_backend: gopls' under settings.
+
+Results use the same fzf selection and --print output as 'find content'.
+
+Examples:
+  gman tools find symbol NewManager              # Exact name
+  gman tools find symbol Manager --partial       # Names containing "Manager"
+  gman tools find symbol Config --kind struct --print
+  gman tools find symbol Handler --backend gopls --group backend`,
+	Args: cobra.ExactArgs(1),
+	RunE: runFindSymbol,
+}
+
+func init() {
+	findCmd.AddCommand(findSymbolCmd)
+
+	findSymbolCmd.Flags().BoolVar(&findSymbolPartial, "partial", false, "Match symbol names containing the given name")
+	findSymbolCmd.Flags().StringVar(&findSymbolKind, "kind", "", "Only symbols of this kind (e.g. function, struct, method)")
+	findSymbolCmd.Flags().StringVar(&findSymbolBackend, "backend", "", "Symbol backend: ctags or gopls (default from settings, else ctags)")
+	findSymbolCmd.Flags().BoolVar(&findContentPrint, "print", false, "Print definitions grouped by repository instead of launching fzf")
+	findSymbolCmd.Flags().IntVarP(&findContext, "context", "C", 0, "Lines of context to show around definitions (with --print)")
+}
+
+func runFindSymbol(cmd *cobra.Command, args []string) error {
+	if findContext < 0 {
+		return fmt.Errorf("--context must not be negative")
+	}
+
+	mgrs := cmdutils.GetManagers()
+	cfg := mgrs.Config.GetConfig()
+	name := args[0]
+
+	backend := findSymbolBackend
+	if backend == "" {
+		backend = cfg.Settings.SymbolBackend
+	}
+	searcher, err := external.NewSymbolSearcher(backend)
+	if err != nil {
+		return err
+	}
+	searcher.Partial = findSymbolPartial
+	searcher.Kind = findSymbolKind
+
+	fmt.Fprintf(os.Stderr, "%s\n", color.BlueString("🔍 Searching symbols with %s...", searcher.Backend))
+
+	scopeRepos, scopeGroup, err := findScope(cfg.Repositories)
+	if err != nil {
+		return err
+	}
+	results, err := searcher.SearchContent(name, scopeRepos, scopeGroup)
+	if err != nil {
+		return fmt.Errorf("failed to search symbols: %w", err)
+	}
+
+	if findJSON {
+		return printSearchJSON(contentResultsJSON(results))
+	}
+
+	if len(results) == 0 {
+		fmt.Printf("%s No definitions found for '%s'", display.WarningIcon(), name)
+		if findGroupFilter != "" {
+			fmt.Printf(" in group '%s'", findGroupFilter)
+		}
+		fmt.Println()
+		return nil
+	}
+
+	return presentContentResults(searcher, results, fmt.Sprintf("Found %d definitions of '%s'", len(results), name))
+}
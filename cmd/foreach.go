@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"gman/internal/di"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	foreachGroup string
+	foreachJobs  int
+	foreachShell bool
+)
+
+// foreachCmd represents the foreach command
+var foreachCmd = &cobra.Command{
+	Use:   "foreach '<template command>'",
+	Short: "Run a templated command in every repository",
+	Long: `Run a command built from a template in every managed repository (or the
+repositories of one group), like 'gman exec', with per-repository values
+substituted for these placeholders:
+
+  {alias}       repository alias
+  {path}        repository path
+  {branch}      current branch
+  {remote_url}  URL of the origin remote (empty when there is none)
+
+Other text in braces is left untouched. Without --shell the template is split
+into words like a shell would (quotes and backslashes work) and run directly.
+With --shell it is run by sh -c, so pipes and redirections work; substituted
+values are quoted for the shell, so do not put quotes around placeholders.
+
+Examples:
+  gman foreach 'echo {alias} is on {branch}'
+  gman foreach 'git log -1 --format=%s {branch}'
+  gman foreach --shell 'git ls-files | wc -l > /tmp/{alias}.count'
+  gman foreach --group backend 'tar czf /backup/{alias}.tgz -C {path} .'`,
+	Args: cobra.ExactArgs(1),
+	RunE: runForeach,
+}
+
+func init() {
+	rootCmd.AddCommand(foreachCmd)
+
+	foreachCmd.Flags().StringVar(&foreachGroup, "group", "", "Only run in repositories of this group")
+	foreachCmd.Flags().IntVarP(&foreachJobs, "jobs", "j", 0, "Number of repositories to run in parallel (default: parallel_jobs setting)")
+	foreachCmd.Flags().BoolVar(&foreachShell, "shell", false, "Run the command with sh -c (pipes, redirections)")
+}
+
+// foreachPlaceholder matches {name} placeholders in a template
+var foreachPlaceholder = regexp.MustCompile(`\{(alias|path|branch|remote_url)\}`)
+
+func runForeach(cmd *cobra.Command, args []string) error {
+	template := args[0]
+
+	// Split the template once up front instead of failing per repository
+	var words []string
+	if !foreachShell {
+		var err error
+		words, err = splitCommandLine(template)
+		if err != nil {
+			return fmt.Errorf("invalid command template: %w", err)
+		}
+		if len(words) == 0 {
+			return fmt.Errorf("empty command template")
+		}
+	}
+
+	repositories, err := execRepositories(foreachGroup)
+	if err != nil {
+		return err
+	}
+
+	results := runInRepositories(repositories, foreachJobs, func(alias, path string) (*exec.Cmd, error) {
+		values, err := foreachValues(template, alias, path)
+		if err != nil {
+			return nil, err
+		}
+
+		var command *exec.Cmd
+		if foreachShell {
+			script := expandPlaceholders(template, values, shellQuote)
+			command = exec.Command("sh", "-c", script)
+		} else {
+			argv := make([]string, len(words))
+			for i, word := range words {
+				argv[i] = expandPlaceholders(word, values, nil)
+			}
+			command = exec.Command(argv[0], argv[1:]...)
+		}
+		command.Dir = path
+		return command, nil
+	})
+	return printExecSummary(results)
+}
+
+// foreachValues resolves the placeholders used in template for a repository.
+// Git is only asked for values the template needs.
+func foreachValues(template, alias, path string) (map[string]string, error) {
+	values := map[string]string{"alias": alias, "path": path}
+	gitMgr := di.GitManager()
+
+	if strings.Contains(template, "{branch}") {
+		branch, err := gitMgr.GetCurrentBranch(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current branch: %w", err)
+		}
+		values["branch"] = branch
+	}
+	if strings.Contains(template, "{remote_url}") {
+		// Repositories without an origin get an empty value
+		remoteURL, _ := gitMgr.GetRemoteURL(path)
+		values["remote_url"] = remoteURL
+	}
+	return values, nil
+}
+
+// expandPlaceholders substitutes the values for their placeholders, passing
+// each value through quote when it is not nil
+func expandPlaceholders(s string, values map[string]string, quote func(string) string) string {
+	return foreachPlaceholder.ReplaceAllStringFunc(s, func(match string) string {
+		value := values[match[1:len(match)-1]]
+		if quote != nil {
+			return quote(value)
+		}
+		return value
+	})
+}
+
+// shellQuote quotes s as a single sh word
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// splitCommandLine splits s into words the way sh does for simple commands:
+// whitespace separates words, single quotes keep everything literal, double
+// quotes and backslashes escape. Expansions and operators are not supported.
+func splitCommandLine(s string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		case c == '\'':
+			end := strings.IndexByte(s[i+1:], '\'')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated single quote")
+			}
+			word.WriteString(s[i+1 : i+1+end])
+			i += end + 1
+			inWord = true
+		case c == '"':
+			i++
+			for ; i < len(s) && s[i] != '"'; i++ {
+				if s[i] == '\\' && i+1 < len(s) && strings.IndexByte("\"\\$`", s[i+1]) >= 0 {
+					i++
+				}
+				word.WriteByte(s[i])
+			}
+			if i >= len(s) {
+				return nil, fmt.Errorf("unterminated double quote")
+			}
+			inWord = true
+		case c == '\\':
+			if i+1 >= len(s) {
+				return nil, fmt.Errorf("trailing backslash")
+			}
+			i++
+			word.WriteByte(s[i])
+			inWord = true
+		default:
+			word.WriteByte(c)
+			inWord = true
+		}
+	}
+
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitCommandLine(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+		err   bool
+	}{
+		{"git status --short", []string{"git", "status", "--short"}, false},
+		{`echo 'a  b' "c \"d\"" e\ f`, []string{"echo", "a  b", `c "d"`, "e f"}, false},
+		{`printf '%s\n' ''`, []string{"printf", `%s\n`, ""}, false},
+		{"  ", nil, false},
+		{"echo 'open", nil, true},
+		{`echo "open`, nil, true},
+		{`echo \`, nil, true},
+	}
+
+	for _, tt := range tests {
+		got, err := splitCommandLine(tt.input)
+		if (err != nil) != tt.err {
+			t.Errorf("splitCommandLine(%q) error = %v, want error %v", tt.input, err, tt.err)
+			continue
+		}
+		if !tt.err && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitCommandLine(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestExpandPlaceholders(t *testing.T) {
+	values := map[string]string{"alias": "api", "path": "/src/it's here"}
+
+	if got := expandPlaceholders("{alias}:{path} {other}", values, nil); got != "api:/src/it's here {other}" {
+		t.Errorf("unexpected expansion: %q", got)
+	}
+	if got := expandPlaceholders("cd {path}", values, shellQuote); got != `cd '/src/it'\''s here'` {
+		t.Errorf("unexpected shell expansion: %q", got)
+	}
+}
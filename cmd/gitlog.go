@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"gman/internal/di"
+	"gman/internal/display"
+	"gman/internal/errors"
+	"gman/internal/git"
+	"gman/internal/pager"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	gitLogLimit      int
+	gitLogRepo       string
+	gitLogFailedOnly bool
+	gitLogReplay     bool
+)
+
+// gitLogCmd represents the git command log viewer
+var gitLogCmd = &cobra.Command{
+	Use:   "git-log",
+	Short: "Show the git commands gman has executed",
+	Long: `Show the audit log of git commands gman executed on your behalf, with the
+repository, arguments, duration and exit status of each invocation.
+
+Recording is off by default. Enable it in the configuration:
+
+  settings:
+    git_command_log: true
+
+or per invocation with GMAN_GIT_LOG=1. Entries are stored as JSON lines in
+git_commands.log next to the configuration file.
+
+Examples:
+  gman tools git-log                    # Last 20 commands
+  gman tools git-log --limit 100
+  gman tools git-log --repo backend     # Only commands run in 'backend'
+  gman tools git-log --failed           # Only failed commands
+  gman tools git-log --replay           # Print copy-pasteable git commands`,
+	Args: cobra.NoArgs,
+	RunE: runGitLog,
+}
+
+func init() {
+	toolsCmd.AddCommand(gitLogCmd)
+
+	gitLogCmd.Flags().IntVarP(&gitLogLimit, "limit", "n", 20, "Number of most recent commands to show (0 for all)")
+	gitLogCmd.Flags().StringVarP(&gitLogRepo, "repo", "r", "", "Only show commands run in this repository alias")
+	gitLogCmd.Flags().BoolVar(&gitLogFailedOnly, "failed", false, "Only show commands that failed")
+	gitLogCmd.Flags().BoolVar(&gitLogReplay, "replay", false, "Print only the replayable git command lines")
+
+	pager.Enable(gitLogCmd)
+}
+
+func runGitLog(cmd *cobra.Command, args []string) error {
+	configMgr := di.ConfigManager()
+	cfg := configMgr.GetConfig()
+	logPath := filepath.Join(configMgr.GetConfigDir(), git.CommandLogFile)
+
+	var repoPath string
+	if gitLogRepo != "" {
+		path, exists := cfg.Repositories[gitLogRepo]
+		if !exists {
+			return errors.NotFoundError("repository", gitLogRepo)
+		}
+		repoPath = path
+	}
+
+	records, err := git.ReadCommandLog(logPath, 0)
+	if err != nil {
+		return err
+	}
+
+	// Filter before limiting so --limit applies to matching entries
+	filtered := records[:0]
+	for _, record := range records {
+		if repoPath != "" && record.Repo != repoPath {
+			continue
+		}
+		if gitLogFailedOnly && record.ExitCode == 0 {
+			continue
+		}
+		filtered = append(filtered, record)
+	}
+	if gitLogLimit > 0 && len(filtered) > gitLogLimit {
+		filtered = filtered[len(filtered)-gitLogLimit:]
+	}
+
+	if len(filtered) == 0 {
+		if !cfg.Settings.GitCommandLog {
+			fmt.Println("No git commands recorded. Enable recording with 'git_command_log: true' under settings, or GMAN_GIT_LOG=1.")
+		} else {
+			fmt.Println("No matching git commands recorded.")
+		}
+		return nil
+	}
+
+	if gitLogReplay {
+		for _, record := range filtered {
+			fmt.Println(record.CommandLine())
+		}
+		return nil
+	}
+
+	// Map paths back to aliases for readability
+	aliases := make(map[string]string, len(cfg.Repositories))
+	for alias, path := range cfg.Repositories {
+		aliases[path] = alias
+	}
+
+	for _, record := range filtered {
+		repo := aliases[record.Repo]
+		if repo == "" {
+			repo = record.Repo
+		}
+
+		result := color.GreenString(display.Icon("✅", "OK")+" ok")
+		if record.ExitCode != 0 {
+			result = color.RedString(display.Icon("❌", "ERROR")+" exit %d", record.ExitCode)
+		}
+
+		fmt.Printf("%s  %-20s %s  %s  %s\n",
+			record.Time.Format("2006-01-02 15:04:05"),
+			color.CyanString(repo),
+			color.WhiteString("git %s", strings.Join(record.Args, " ")),
+			color.HiBlackString("%dms", record.DurationMs),
+			result,
+		)
+	}
+
+	return nil
+}
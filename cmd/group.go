@@ -4,8 +4,13 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
+	cmdutils "gman/internal/cmd"
 	"gman/internal/di"
+	"gman/internal/display"
+	"gman/internal/pager"
+	"gman/pkg/types"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -41,9 +46,11 @@ Examples:
 
 // groupListCmd lists all groups
 var groupListCmd = &cobra.Command{
-	Use:     "list",
-	Short:   "List all repository groups",
-	Long:    `Display all configured repository groups with their repositories.`,
+	Use:   "list",
+	Short: "List all repository groups",
+	Long: `Display all configured repository groups with their repositories.
+
+Use --output json or --output yaml for machine-readable output.`,
 	Aliases: []string{"ls"},
 	RunE:    runGroupList,
 }
@@ -90,6 +97,8 @@ func init() {
 
 	// Add flags
 	groupCreateCmd.Flags().StringVarP(&groupDescription, "desc", "d", "", "Group description")
+
+	pager.Enable(groupListCmd)
 }
 
 func runGroupCreate(cmd *cobra.Command, args []string) error {
@@ -106,7 +115,7 @@ func runGroupCreate(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Printf("%s Created group '%s' with %d repositories\n",
-		color.GreenString("✅"), groupName, len(repositories))
+		display.SuccessIcon(), groupName, len(repositories))
 
 	if groupDescription != "" {
 		fmt.Printf("   Description: %s\n", groupDescription)
@@ -123,6 +132,9 @@ func runGroupList(cmd *cobra.Command, args []string) error {
 	configMgr := di.ConfigManager()
 
 	groups := configMgr.GetGroups()
+	if cmdutils.StructuredOutput() {
+		return cmdutils.Render(groupRecords(groups), nil)
+	}
 	if len(groups) == 0 {
 		fmt.Println("No groups configured. Use 'gman group create' to create groups.")
 		return nil
@@ -140,7 +152,7 @@ func runGroupList(cmd *cobra.Command, args []string) error {
 	for _, name := range groupNames {
 		group := groups[name]
 		fmt.Printf("%s %s (%d repositories)\n",
-			color.YellowString("📁"),
+			color.YellowString(display.Icon("📁", "GROUP")),
 			color.GreenString(name),
 			len(group.Repositories))
 
@@ -170,7 +182,7 @@ func runGroupDelete(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("%s Deleted group '%s'\n", color.GreenString("✅"), groupName)
+	fmt.Printf("%s Deleted group '%s'\n", display.SuccessIcon(), groupName)
 	return nil
 }
 
@@ -188,7 +200,8 @@ func runGroupAdd(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Printf("%s Added %d repositories to group '%s': %s\n",
-		color.GreenString("✅"), len(repositories), groupName, strings.Join(repositories, ", "))
+		display.SuccessIcon(), len(repositories), groupName, strings.Join(repositories, ", "))
+	refreshCodeWorkspaces()
 
 	return nil
 }
@@ -207,7 +220,37 @@ func runGroupRemove(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Printf("%s Removed %d repositories from group '%s': %s\n",
-		color.GreenString("✅"), len(repositories), groupName, strings.Join(repositories, ", "))
+		display.SuccessIcon(), len(repositories), groupName, strings.Join(repositories, ", "))
+	refreshCodeWorkspaces()
 
 	return nil
 }
+
+// groupRecord is the machine-readable form of a repository group
+type groupRecord struct {
+	Name         string    `json:"name" yaml:"name"`
+	Description  string    `json:"description,omitempty" yaml:"description,omitempty"`
+	Repositories []string  `json:"repositories" yaml:"repositories"`
+	CreatedAt    time.Time `json:"created_at" yaml:"created_at"`
+}
+
+// groupRecords returns the groups sorted by name
+func groupRecords(groups map[string]types.Group) []groupRecord {
+	records := make([]groupRecord, 0, len(groups))
+	for name, group := range groups {
+		repositories := group.Repositories
+		if repositories == nil {
+			repositories = []string{}
+		}
+		records = append(records, groupRecord{
+			Name:         name,
+			Description:  group.Description,
+			Repositories: repositories,
+			CreatedAt:    group.CreatedAt,
+		})
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Name < records[j].Name
+	})
+	return records
+}
@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"log/slog"
+	"time"
+
+	"gman/internal/di"
+	"gman/internal/hooks"
+)
+
+// runHooks runs the hooks configured for event; a failing hook only warns
+func runHooks(event string, repositories ...hooks.Repository) {
+	commands := di.ConfigManager().GetConfig().Hooks[event]
+	if len(commands) == 0 {
+		return
+	}
+	payload := hooks.Payload{Event: event, Time: time.Now(), Repositories: repositories}
+	if payload.Repositories == nil {
+		payload.Repositories = []hooks.Repository{}
+	}
+	if err := hooks.Run(commands, payload); err != nil {
+		slog.Warn("hook failed", "error", err)
+	}
+}
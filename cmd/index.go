@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"time"
+
+	"gman/internal/di"
+	"gman/internal/display"
+	"gman/internal/errors"
+	"gman/internal/index"
+	"gman/internal/repository"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var indexGroupFilter string
+
+// indexCmd represents the search index command group
+var indexCmd = &cobra.Command{
+	Use:   "index",
+	Short: "Manage the persistent search index",
+	Long: `Manage the persistent search index of file paths and commit metadata.
+
+The index lets 'gman tools find file --index' and 'gman tools find commit --index'
+answer from disk instead of spawning fd or git log in every repository. It is
+updated incrementally: file paths are only re-listed when HEAD or the git index
+changed, and only commits added since the last update are read. Repositories
+that already have an index are refreshed automatically after 'gman work sync'.
+
+Examples:
+  gman tools index update                # Build or refresh all indexes
+  gman tools index update --group backend
+  gman tools index status                # Show indexed repositories
+  gman tools index clear                 # Remove all indexes`,
+}
+
+var indexUpdateCmd = &cobra.Command{
+	Use:   "update [alias...]",
+	Short: "Build or incrementally refresh the search index",
+	RunE:  runIndexUpdate,
+}
+
+var indexStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show which repositories are indexed and how fresh they are",
+	Args:  cobra.NoArgs,
+	RunE:  runIndexStatus,
+}
+
+var indexClearCmd = &cobra.Command{
+	Use:   "clear [alias...]",
+	Short: "Remove search indexes",
+	RunE:  runIndexClear,
+}
+
+func init() {
+	toolsCmd.AddCommand(indexCmd)
+	indexCmd.AddCommand(indexUpdateCmd)
+	indexCmd.AddCommand(indexStatusCmd)
+	indexCmd.AddCommand(indexClearCmd)
+
+	indexUpdateCmd.Flags().StringVar(&indexGroupFilter, "group", "", "Only index repositories in this group")
+}
+
+// indexStore returns the store located in the configuration directory
+func indexStore() *index.Store {
+	return index.NewStore(index.DefaultDir(di.ConfigManager().GetConfigDir()))
+}
+
+// selectIndexRepositories resolves explicit aliases or the group filter to repositories
+func selectIndexRepositories(aliases []string, group string) (map[string]string, error) {
+	configMgr := di.ConfigManager()
+	cfg := configMgr.GetConfig()
+
+	if len(aliases) > 0 {
+		selected := make(map[string]string, len(aliases))
+		for _, alias := range aliases {
+			path, exists := cfg.Repositories[alias]
+			if !exists {
+				return nil, errors.NotFoundError("repository", alias)
+			}
+			selected[alias] = path
+		}
+		return selected, nil
+	}
+
+	filter := repository.NewFilter(configMgr)
+	return filter.FilterByGroup(cfg.Repositories, group)
+}
+
+func runIndexUpdate(cmd *cobra.Command, args []string) error {
+	repositories, err := selectIndexRepositories(args, indexGroupFilter)
+	if err != nil {
+		return err
+	}
+	if len(repositories) == 0 {
+		fmt.Println("No repositories to index.")
+		return nil
+	}
+
+	start := time.Now()
+	results := indexStore().UpdateAll(di.GitManager(), repositories, 0)
+
+	var failed int
+	for _, result := range results {
+		switch {
+		case result.Error != nil:
+			failed++
+			fmt.Printf("%s %s: %v\n", display.ErrorIcon(), result.Alias, result.Error)
+		case result.Stats.Unchanged:
+			fmt.Printf("%s %s: up to date\n", display.SuccessIcon(), result.Alias)
+		case result.Stats.Rebuilt:
+			fmt.Printf("%s %s: rebuilt (%d files, %d commits; history was rewritten)\n",
+				display.SuccessIcon(), result.Alias, len(result.Index.Files), len(result.Index.Commits))
+		default:
+			fmt.Printf("%s %s: %d files, +%d commits\n",
+				display.SuccessIcon(), result.Alias, len(result.Index.Files), result.Stats.NewCommits)
+		}
+	}
+
+	fmt.Printf("\nIndexed %d repositories in %v", len(results)-failed, time.Since(start).Round(time.Millisecond))
+	if failed > 0 {
+		fmt.Printf(", %d failed\n", failed)
+		return fmt.Errorf("indexing failed for %d repositories", failed)
+	}
+	fmt.Println()
+	return nil
+}
+
+func runIndexStatus(cmd *cobra.Command, args []string) error {
+	cfg := di.ConfigManager().GetConfig()
+	store := indexStore()
+
+	aliases := make([]string, 0, len(cfg.Repositories))
+	for alias := range cfg.Repositories {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+
+	for _, alias := range aliases {
+		if !store.Exists(alias) {
+			fmt.Printf("  %-20s %s\n", alias, color.HiBlackString("not indexed"))
+			continue
+		}
+		idx, err := store.Load(alias)
+		if err != nil {
+			fmt.Printf("  %-20s %s\n", alias, color.RedString("%v", err))
+			continue
+		}
+		fmt.Printf("  %-20s %6d files %6d commits  updated %s\n",
+			color.CyanString(alias), len(idx.Files), len(idx.Commits),
+			idx.UpdatedAt.Format("2006-01-02 15:04"))
+	}
+	return nil
+}
+
+func runIndexClear(cmd *cobra.Command, args []string) error {
+	store := indexStore()
+	if len(args) == 0 {
+		if err := store.Clear(); err != nil {
+			return fmt.Errorf("failed to clear search index: %w", err)
+		}
+		fmt.Printf("%s Search index cleared\n", display.SuccessIcon())
+		return nil
+	}
+
+	for _, alias := range args {
+		if err := store.Remove(alias); err != nil {
+			return fmt.Errorf("failed to remove index for '%s': %w", alias, err)
+		}
+	}
+	fmt.Printf("%s Removed index for %d repositories\n", display.SuccessIcon(), len(args))
+	return nil
+}
+
+// loadSearchIndexes returns the indexes of the given repositories as stored
+// on disk, building missing ones on the fly. Existing indexes are not
+// refreshed here; that happens on sync and 'gman tools index update'.
+func loadSearchIndexes(repositories map[string]string) []*index.RepoIndex {
+	store := indexStore()
+	indexes := make([]*index.RepoIndex, 0, len(repositories))
+	missing := make(map[string]string)
+
+	for alias, path := range repositories {
+		idx, err := store.Load(alias)
+		if err != nil || idx.Path != path {
+			missing[alias] = path
+			continue
+		}
+		indexes = append(indexes, idx)
+	}
+
+	if len(missing) > 0 {
+		fmt.Fprintf(os.Stderr, "%s\n", color.BlueString("📇 Indexing %d repositories...", len(missing)))
+		for _, result := range store.UpdateAll(di.GitManager(), missing, 0) {
+			if result.Error != nil {
+				slog.Warn("failed to index repository", "repo", result.Alias, "error", result.Error)
+				continue
+			}
+			indexes = append(indexes, result.Index)
+		}
+	}
+
+	sort.Slice(indexes, func(i, j int) bool {
+		return indexes[i].Alias < indexes[j].Alias
+	})
+	return indexes
+}
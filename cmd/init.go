@@ -3,12 +3,14 @@ package cmd
 import (
 	"bufio"
 	"fmt"
+	"log/slog"
 	"os"
 	"os/user"
 	"path/filepath"
 	"strings"
 
 	"gman/internal/di"
+	"gman/internal/display"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -90,9 +92,9 @@ func runInitShell(cmd *cobra.Command, args []string) error {
 	if !initForce {
 		hasIntegration, err := checkExistingIntegration(configFile)
 		if err != nil {
-			fmt.Printf("Warning: Could not check existing integration: %v\n", err)
+			slog.Warn("could not check existing integration", "error", err)
 		} else if hasIntegration {
-			fmt.Printf("%s\n", color.YellowString("⚠️  Shell integration already exists."))
+			fmt.Printf("%s\n", color.YellowString(display.Icon("⚠️ ", "WARN")+" Shell integration already exists."))
 			fmt.Println("Use --force to overwrite, or remove manually and try again.")
 			return nil
 		}
@@ -104,7 +106,7 @@ func runInitShell(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to add shell integration: %w", err)
 	}
 	
-	fmt.Printf("%s Shell integration added successfully!\n", color.GreenString("✅"))
+	fmt.Printf("%s Shell integration added successfully!\n", display.SuccessIcon())
 	fmt.Println()
 	fmt.Printf("To activate the integration, either:\n")
 	fmt.Printf("1. Restart your terminal\n")
@@ -229,7 +231,7 @@ func getShellIntegrationCode(shell string) string {
 set -gx GMAN_SHELL_INTEGRATION 1
 
 function gman
-    if test "$argv[1]" = "switch" -o "$argv[1]" = "sw" -o "$argv[1]" = "cd"
+    if test "$argv[1]" = "switch" -o "$argv[1]" = "sw" -o "$argv[1]" = "cd"; or test "$argv[1]" = "bookmark" -a "$argv[2]" = "open"
         set output (command gman $argv 2>&1)
         set exit_code $status
         
@@ -267,8 +269,8 @@ end
 export GMAN_SHELL_INTEGRATION=1
 
 gman() {
-    # Check if the first argument is 'switch' or its aliases
-    if [[ "$1" == "switch" || "$1" == "sw" || "$1" == "cd" ]]; then
+    # Check if the first argument is 'switch' or its aliases, or 'bookmark open'
+    if [[ "$1" == "switch" || "$1" == "sw" || "$1" == "cd" || ( "$1" == "bookmark" && "$2" == "open" ) ]]; then
         local output gman_cd_line
         # For switch commands, capture output to handle GMAN_CD
         output=$(command gman "$@" 2>&1)
@@ -337,11 +339,11 @@ func checkDependencies() {
 	}
 	
 	if len(available) > 0 {
-		fmt.Printf("%s Available: %s\n", color.GreenString("✅"), strings.Join(available, ", "))
+		fmt.Printf("%s Available: %s\n", display.SuccessIcon(), strings.Join(available, ", "))
 	}
 	
 	if len(missing) > 0 {
-		fmt.Printf("%s Missing: %s\n", color.YellowString("⚠️"), strings.Join(missing, ", "))
+		fmt.Printf("%s Missing: %s\n", display.WarningIcon(), strings.Join(missing, ", "))
 		fmt.Println()
 		fmt.Printf("These tools are optional but greatly enhance gman's search capabilities:\n")
 		for _, dep := range dependencies {
@@ -356,7 +358,7 @@ func checkDependencies() {
 		fmt.Printf("You can install all dependencies at once with:\n")
 		fmt.Printf("  %s\n", color.CyanString("./scripts/setup-dependencies.sh"))
 	} else {
-		fmt.Printf("%s All optional dependencies are available!\n", color.GreenString("🚀"))
+		fmt.Printf("%s All optional dependencies are available!\n", display.SuccessIcon())
 	}
 }
 
@@ -1,8 +1,13 @@
 package cmd
 
 import (
+	"fmt"
+	"os"
+
+	cmdutils "gman/internal/cmd"
 	"gman/internal/di"
 	"gman/internal/display"
+	"gman/internal/pager"
 
 	"github.com/spf13/cobra"
 )
@@ -12,14 +17,33 @@ var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all configured repositories",
 	Long: `List all repositories configured in gman with their aliases and paths.
-This shows the mapping between repository aliases and their local filesystem paths.`,
+This shows the mapping between repository aliases and their local filesystem paths.
+
+Use --output json or --output yaml for machine-readable output, or --format
+to print one line per repository from a Go template over .Alias and .Path.
+
+Use --limit and --page to page through long lists, in alias order.
+
+Examples:
+  gman repo list --limit 50 --page 2
+  gman repo list --format '{{.Alias}}'
+  gman repo list --format '{{.Alias}}: {{.Path}}'`,
 	Aliases: []string{"ls"},
 	RunE:    runList,
 }
 
+var (
+	listFormat     string
+	listPagination cmdutils.Pagination
+)
+
 func init() {
 	// Command is now available via: gman repo list
 	// Removed direct rootCmd registration to avoid duplication
+	listCmd.Flags().StringVar(&listFormat, "format", "", "Print each repository with a Go template, e.g. '{{.Alias}} {{.Path}}'")
+	cmdutils.AddPaginationFlags(listCmd, &listPagination)
+
+	pager.Enable(listCmd)
 }
 
 func runList(cmd *cobra.Command, args []string) error {
@@ -28,6 +52,52 @@ func runList(cmd *cobra.Command, args []string) error {
 	configMgr := di.ConfigManager()
 
 	cfg := configMgr.GetConfig()
-	display.PrintRepositoryList(cfg.Repositories)
-	return nil
+	if err := listPagination.Validate(); err != nil {
+		return err
+	}
+	repositories := pageRepositories(cfg.Repositories, listPagination)
+
+	if listFormat != "" {
+		return cmdutils.RenderFormat(os.Stdout, listFormat, repositoryRecords(repositories))
+	}
+	return cmdutils.Render(repositoryRecords(repositories), func() error {
+		display.PrintRepositoryList(repositories)
+		printPageFooter(listPagination, len(cfg.Repositories))
+		return nil
+	})
+}
+
+// pageRepositories returns the repositories on the page selected with
+// --limit and --page, counted in alias order
+func pageRepositories(repositories map[string]string, p cmdutils.Pagination) map[string]string {
+	if p.Limit <= 0 {
+		return repositories
+	}
+	page := make(map[string]string, p.Limit)
+	for _, alias := range cmdutils.Paginate(sortedAliases(repositories), p) {
+		page[alias] = repositories[alias]
+	}
+	return page
+}
+
+// printPageFooter tells table readers on stderr that they see one page
+func printPageFooter(p cmdutils.Pagination, total int) {
+	if footer := p.Footer(total); footer != "" {
+		fmt.Fprintln(os.Stderr, footer)
+	}
+}
+
+// repositoryRecord is the machine-readable form of a configured repository
+type repositoryRecord struct {
+	Alias string `json:"alias" yaml:"alias"`
+	Path  string `json:"path" yaml:"path"`
+}
+
+// repositoryRecords returns the repositories sorted by alias
+func repositoryRecords(repositories map[string]string) []repositoryRecord {
+	records := make([]repositoryRecord, 0, len(repositories))
+	for _, alias := range sortedAliases(repositories) {
+		records = append(records, repositoryRecord{Alias: alias, Path: repositories[alias]})
+	}
+	return records
 }
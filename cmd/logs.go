@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"gman/internal/di"
+	"gman/internal/logging"
+	"gman/internal/pager"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	logsLimit int
+	logsLevel string
+	logsPath  bool
+)
+
+// logsCmd represents the logs command
+var logsCmd = &cobra.Command{
+	Use:   "logs",
+	Short: "Show recent entries of the gman log file",
+	Long: `Show the most recent entries of gman's log file, which keeps every
+diagnostic gman emits (including the debug messages printed with --debug).
+
+Logging to the file is off by default. Enable it in the configuration:
+
+  settings:
+    log_file: true
+
+or per invocation with GMAN_LOG_FILE=<path>. The file lives at
+~/.local/state/gman/gman.log ($XDG_STATE_HOME is honored) and is rotated
+at 1 MiB, keeping three old files.
+
+Examples:
+  gman logs                    # Last 50 entries
+  gman logs -n 200 --level warn
+  gman logs --path             # Print the log file location`,
+	Args: cobra.NoArgs,
+	RunE: runLogs,
+}
+
+func init() {
+	rootCmd.AddCommand(logsCmd)
+
+	logsCmd.Flags().IntVarP(&logsLimit, "limit", "n", 50, "Number of most recent entries to show (0 for all)")
+	logsCmd.Flags().StringVar(&logsLevel, "level", "debug", "Minimum level to show: debug, info, warn or error")
+	logsCmd.Flags().BoolVar(&logsPath, "path", false, "Print the log file path and exit")
+
+	pager.Enable(logsCmd)
+}
+
+func runLogs(cmd *cobra.Command, args []string) error {
+	logPath := logFilePath(true)
+	if logsPath {
+		fmt.Println(logPath)
+		return nil
+	}
+
+	var minLevel slog.Level
+	if err := minLevel.UnmarshalText([]byte(strings.ToUpper(logsLevel))); err != nil {
+		return fmt.Errorf("invalid level '%s': use debug, info, warn or error", logsLevel)
+	}
+
+	entries, err := logging.ReadEntries(logPath)
+	if err != nil {
+		return err
+	}
+
+	filtered := entries[:0]
+	for _, entry := range entries {
+		if entry.Level >= minLevel {
+			filtered = append(filtered, entry)
+		}
+	}
+	if logsLimit > 0 && len(filtered) > logsLimit {
+		filtered = filtered[len(filtered)-logsLimit:]
+	}
+
+	if len(filtered) == 0 {
+		fmt.Fprintf(os.Stderr, "No log entries in %s\n", logPath)
+		if !di.ConfigManager().GetConfig().Settings.LogFile && os.Getenv("GMAN_LOG_FILE") == "" {
+			fmt.Fprintln(os.Stderr, "Enable file logging with 'log_file: true' in the settings or GMAN_LOG_FILE=<path>.")
+		}
+		return nil
+	}
+
+	for _, entry := range filtered {
+		fmt.Println(entry.String())
+	}
+	return nil
+}
@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	goerrors "errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+
+	"gman/internal/cache"
+	"gman/internal/di"
+	"gman/internal/display"
+	"gman/internal/errors"
+	"gman/internal/snapshot"
+	"gman/internal/undo"
+
+	"github.com/spf13/cobra"
+)
+
+var repoMoveNoMove bool
+
+// repoMoveCmd represents the repo move command
+var repoMoveCmd = &cobra.Command{
+	Use:   "move <alias> <new-path>",
+	Short: "Move a repository to a new path",
+	Long: `Move a repository directory to a new path and update everything that
+refers to it: the configuration (including aliases of worktrees inside the
+repository), the links between the repository and its linked worktrees, the
+switch history, snapshots and the undo journal.
+
+Linked worktrees inside the repository directory move with it; worktrees
+elsewhere stay where they are and are reconnected to the new location.
+
+With --no-move the directory is not touched: use it after moving the
+repository yourself, e.g. to another disk, to re-point gman at it.
+
+Examples:
+  gman repo move backend ~/src/work/backend
+  mv ~/code/api /mnt/data/api && gman repo move api /mnt/data/api --no-move`,
+	Aliases: []string{"mv"},
+	Args:    cobra.ExactArgs(2),
+	RunE:    runRepoMove,
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return removeCmd.ValidArgsFunction(cmd, args, toComplete)
+		}
+		return nil, cobra.ShellCompDirectiveFilterDirs
+	},
+}
+
+func init() {
+	repoMoveCmd.Flags().BoolVar(&repoMoveNoMove, "no-move", false, "Only re-point gman at a repository already moved to <new-path>")
+}
+
+func runRepoMove(cmd *cobra.Command, args []string) error {
+	alias := args[0]
+	configMgr := di.ConfigManager()
+	cfg := configMgr.GetConfig()
+	oldPath, exists := cfg.Repositories[alias]
+	if !exists {
+		return errors.NotFoundError("repository", alias)
+	}
+	oldPath = filepath.Clean(oldPath)
+	newPath, err := filepath.Abs(args[1])
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", args[1], err)
+	}
+	if newPath == oldPath {
+		return fmt.Errorf("repository '%s' is already at %s", alias, newPath)
+	}
+
+	if repoMoveNoMove {
+		if _, err := os.Stat(filepath.Join(newPath, ".git")); err != nil {
+			return fmt.Errorf("'%s' is not a git repository", newPath)
+		}
+	} else {
+		if err := moveRepositoryDirectory(alias, oldPath, newPath); err != nil {
+			return err
+		}
+		fmt.Printf("%s Moved %s to %s\n", display.SuccessIcon(), oldPath, newPath)
+	}
+
+	// Reconnect the linked worktrees: those inside the repository moved
+	// along and are passed by their new path
+	gitMgr := di.GitManager()
+	worktrees, err := gitMgr.ListWorktrees(newPath)
+	if err != nil {
+		slog.Warn("failed to list worktrees", "path", newPath, "error", err)
+	}
+	var movedWorktrees []string
+	var history []cache.SwitchRecord
+	for i, wt := range worktrees {
+		if i == 0 {
+			continue // The main worktree
+		}
+		if path, moved := movedPath(wt.Path, oldPath, newPath); moved {
+			movedWorktrees = append(movedWorktrees, path)
+			history = append(history,
+				cache.SwitchRecord{Alias: worktreeTargetAlias(alias, wt.Path), Path: wt.Path},
+				cache.SwitchRecord{Alias: worktreeTargetAlias(alias, path), Path: path})
+		}
+	}
+	if len(worktrees) > 1 {
+		if err := gitMgr.RepairWorktrees(newPath, movedWorktrees...); err != nil {
+			fmt.Printf("%s %v\n", display.WarningIcon(), err)
+		} else {
+			fmt.Printf("%s Reconnected %d linked worktrees\n", display.SuccessIcon(), len(worktrees)-1)
+		}
+	}
+
+	var aliases []string
+	for repoAlias, path := range cfg.Repositories {
+		if moved, ok := movedPath(path, oldPath, newPath); ok {
+			cfg.Repositories[repoAlias] = moved
+			aliases = append(aliases, repoAlias)
+		}
+	}
+	if err := configMgr.Save(); err != nil {
+		return fmt.Errorf("failed to update configuration (run 'gman repo move %s %s --no-move' to retry): %w", alias, newPath, err)
+	}
+	slices.Sort(aliases)
+	display.PrintSuccess(fmt.Sprintf("Updated the path of %s", strings.Join(aliases, ", ")))
+
+	// The repository moved; failing to update the history only warns
+	history = append(history, cache.SwitchRecord{Alias: alias, Path: oldPath}, cache.SwitchRecord{Alias: alias, Path: newPath})
+	rewriteMovedHistory(configMgr.GetConfigDir(), oldPath, newPath, history)
+	refreshCodeWorkspaces()
+	return nil
+}
+
+// moveRepositoryDirectory renames the repository directory to newPath
+func moveRepositoryDirectory(alias, oldPath, newPath string) error {
+	if _, err := os.Stat(newPath); err == nil {
+		return fmt.Errorf("path '%s' already exists", newPath)
+	}
+	if _, moved := movedPath(newPath, oldPath, newPath); moved {
+		return fmt.Errorf("cannot move %s into itself", oldPath)
+	}
+	if err := os.MkdirAll(filepath.Dir(newPath), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(newPath), err)
+	}
+	if err := os.Rename(oldPath, newPath); err != nil {
+		if goerrors.Is(err, syscall.EXDEV) {
+			return fmt.Errorf("cannot move %s to another file system: move it yourself, then run 'gman repo move %s %s --no-move'", oldPath, alias, newPath)
+		}
+		return fmt.Errorf("failed to move repository: %w", err)
+	}
+	return nil
+}
+
+// rewriteMovedHistory updates the switch history, snapshots and undo
+// journal after a repository moved from oldPath to newPath. history holds
+// pairs of switch records before and after the move.
+func rewriteMovedHistory(configDir, oldPath, newPath string, history []cache.SwitchRecord) {
+	frecencyPath := cache.FrecencyPath(configDir)
+	if frecency, err := cache.LoadFrecency(frecencyPath); err != nil {
+		slog.Warn("failed to update switch history", "error", err)
+	} else {
+		for i := 0; i+1 < len(history); i += 2 {
+			frecency.Move(history[i], history[i+1])
+		}
+		if err := frecency.Save(frecencyPath); err != nil {
+			slog.Warn("failed to update switch history", "error", err)
+		}
+	}
+
+	recentPath := cache.RecentPath(configDir)
+	if recent, err := cache.LoadRecent(recentPath); err != nil {
+		slog.Warn("failed to update recent switches", "error", err)
+	} else {
+		for i := 0; i+1 < len(history); i += 2 {
+			recent.Move(history[i], history[i+1])
+		}
+		if err := recent.Save(recentPath); err != nil {
+			slog.Warn("failed to update recent switches", "error", err)
+		}
+	}
+
+	snapshotPath := snapshot.StorePath(configDir)
+	if store, err := snapshot.Load(snapshotPath); err != nil {
+		slog.Warn("failed to update snapshots", "error", err)
+	} else {
+		changed := false
+		for _, snap := range store.Snapshots {
+			for i := range snap.Repositories {
+				if path, moved := movedPath(snap.Repositories[i].Path, oldPath, newPath); moved {
+					snap.Repositories[i].Path = path
+					changed = true
+				}
+			}
+		}
+		if changed {
+			if err := store.Save(snapshotPath); err != nil {
+				slog.Warn("failed to update snapshots", "error", err)
+			}
+		}
+	}
+
+	journalPath := undo.JournalPath(configDir)
+	if journal, err := undo.Load(journalPath); err != nil {
+		slog.Warn("failed to update undo journal", "error", err)
+	} else {
+		changed := false
+		for _, entry := range journal.Entries {
+			for i := range entry.Repositories {
+				if path, moved := movedPath(entry.Repositories[i].Path, oldPath, newPath); moved {
+					entry.Repositories[i].Path = path
+					changed = true
+				}
+			}
+		}
+		if changed {
+			if err := journal.Save(journalPath); err != nil {
+				slog.Warn("failed to update undo journal", "error", err)
+			}
+		}
+	}
+}
+
+// movedPath returns where path is after moving the directory from to to,
+// and whether path is inside from at all
+func movedPath(path, from, to string) (string, bool) {
+	path = filepath.Clean(path)
+	if path == from {
+		return to, true
+	}
+	if rest, ok := strings.CutPrefix(path, from+string(filepath.Separator)); ok {
+		return filepath.Join(to, rest), true
+	}
+	return path, false
+}
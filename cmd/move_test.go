@@ -0,0 +1,23 @@
+package cmd
+
+import "testing"
+
+func TestMovedPath(t *testing.T) {
+	tests := []struct {
+		path      string
+		wantPath  string
+		wantMoved bool
+	}{
+		{"/src/api", "/data/api", true},
+		{"/src/api/", "/data/api", true},
+		{"/src/api/worktrees/feature", "/data/api/worktrees/feature", true},
+		{"/src/api-feature", "/src/api-feature", false},
+		{"/src", "/src", false},
+	}
+	for _, tt := range tests {
+		got, moved := movedPath(tt.path, "/src/api", "/data/api")
+		if got != tt.wantPath || moved != tt.wantMoved {
+			t.Errorf("movedPath(%q) = %q, %v; want %q, %v", tt.path, got, moved, tt.wantPath, tt.wantMoved)
+		}
+	}
+}
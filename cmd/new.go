@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"gman/internal/di"
+	"gman/internal/display"
+	"gman/internal/errors"
+	"gman/internal/external"
+	"gman/internal/hooks"
+	"gman/internal/repository"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	newTemplate     string
+	newPath         string
+	newGroup        string
+	newMessage      string
+	newRemote       string
+	newCreateRemote bool
+	newPublic       bool
+)
+
+// newCmd represents the new command
+var newCmd = &cobra.Command{
+	Use:   "new <alias>",
+	Short: "Create a repository from a template and register it",
+	Long: `Create a new repository from a template: copy the template, run git init,
+commit everything as the initial commit and add the repository to gman
+under the alias.
+
+--template is a local directory or the URL of a git repository, which is
+cloned first. The template's own history is not copied. The repository is
+created in ./<alias> unless --path is given.
+
+Optionally the repository gets a remote and is pushed:
+  --remote URL       Add URL as origin, e.g. an empty repository created on
+                     the forge beforehand
+  --create-remote    Create the repository on GitHub with the GitHub CLI
+                     (gh), named after the alias; private unless --public
+
+Examples:
+  gman new billing --template ~/templates/go-service
+  gman new billing --template git@github.com:acme/go-service-template.git --group backend
+  gman new billing --template ~/templates/go-service --create-remote`,
+	Args: cobra.ExactArgs(1),
+	RunE: runNew,
+}
+
+func init() {
+	rootCmd.AddCommand(newCmd)
+
+	newCmd.Flags().StringVarP(&newTemplate, "template", "t", "", "Template directory or git URL")
+	newCmd.Flags().StringVar(&newPath, "path", "", "Directory of the new repository (default: ./<alias>)")
+	newCmd.Flags().StringVarP(&newGroup, "group", "g", "", "Add the repository to this group")
+	newCmd.Flags().StringVarP(&newMessage, "message", "m", "Initial commit", "Message of the initial commit")
+	newCmd.Flags().StringVar(&newRemote, "remote", "", "Add this URL as origin and push")
+	newCmd.Flags().BoolVar(&newCreateRemote, "create-remote", false, "Create the remote repository on GitHub with gh and push")
+	newCmd.Flags().BoolVar(&newPublic, "public", false, "Make the repository created by --create-remote public")
+	newCmd.MarkFlagRequired("template")
+	newCmd.MarkFlagDirname("path")
+}
+
+func runNew(cmd *cobra.Command, args []string) error {
+	alias := args[0]
+	configMgr := di.ConfigManager()
+	cfg := configMgr.GetConfig()
+	if _, exists := cfg.Repositories[alias]; exists {
+		return errors.NewRepoAlreadyExistsError(alias)
+	}
+	if _, exists := cfg.Groups[newGroup]; newGroup != "" && !exists {
+		return fmt.Errorf("group '%s' not found", newGroup)
+	}
+	switch {
+	case newRemote != "" && newCreateRemote:
+		return fmt.Errorf("pass either --remote or --create-remote, not both")
+	case newPublic && !newCreateRemote:
+		return fmt.Errorf("--public only applies to --create-remote")
+	case newCreateRemote && !external.GitHubCLI.IsAvailable():
+		return fmt.Errorf("--create-remote needs the GitHub CLI (gh)\n%s", external.GitHubCLI.GetInstallInstructions())
+	}
+
+	target := newPath
+	if target == "" {
+		target = alias
+	}
+	path, err := filepath.Abs(target)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", target, err)
+	}
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("path '%s' already exists", path)
+	}
+
+	// Until the repository is registered, a failure removes what was created
+	if err := createFromTemplate(newTemplate, path, newMessage); err != nil {
+		os.RemoveAll(path)
+		return err
+	}
+	if err := configMgr.AddRepository(alias, path); err != nil {
+		os.RemoveAll(path)
+		return err
+	}
+	display.PrintSuccess(fmt.Sprintf("Created repository: %s -> %s", alias, path))
+	runHooks(hooks.RepoAdded, hooks.Repository{Alias: alias, Path: path})
+
+	if newGroup != "" {
+		if err := configMgr.AddToGroup(newGroup, []string{alias}); err != nil {
+			return fmt.Errorf("failed to add %s to group '%s': %w", alias, newGroup, err)
+		}
+		fmt.Printf("%s Added to group %s\n", display.SuccessIcon(), newGroup)
+	}
+	refreshCodeWorkspaces()
+
+	switch {
+	case newRemote != "":
+		gitMgr := di.GitManager()
+		if _, err := gitMgr.RunCommand(path, "remote", "add", "origin", newRemote); err != nil {
+			return fmt.Errorf("failed to add remote: %w", err)
+		}
+		if err := gitMgr.PushChanges(path, false, true); err != nil {
+			return fmt.Errorf("repository created, but the push to %s failed: %w", newRemote, err)
+		}
+		fmt.Printf("%s Pushed to %s\n", display.SuccessIcon(), newRemote)
+	case newCreateRemote:
+		if err := createGitHubRemote(alias, path, newPublic); err != nil {
+			return err
+		}
+		fmt.Printf("%s Created the GitHub repository %s and pushed\n", display.SuccessIcon(), alias)
+	}
+	return nil
+}
+
+// createFromTemplate fills path from the template, a directory or a git
+// URL, and commits it as a new repository
+func createFromTemplate(template, path, message string) error {
+	source := template
+	if info, err := os.Stat(template); err != nil || !info.IsDir() {
+		// Not a local directory: clone the template without its history
+		tempDir, err := os.MkdirTemp("", "gman-template-")
+		if err != nil {
+			return fmt.Errorf("failed to create temporary directory: %w", err)
+		}
+		defer os.RemoveAll(tempDir)
+
+		source = filepath.Join(tempDir, "template")
+		if err := di.GitManager().CloneShallow(template, source); err != nil {
+			return fmt.Errorf("failed to clone template '%s': %w", template, err)
+		}
+	}
+
+	if err := repository.CopyTemplate(source, path); err != nil {
+		return fmt.Errorf("failed to copy template: %w", err)
+	}
+
+	gitMgr := di.GitManager()
+	if _, err := gitMgr.RunCommand(path, "init"); err != nil {
+		return fmt.Errorf("failed to initialize repository: %w", err)
+	}
+	if err := gitMgr.CommitChanges(path, message, true); err != nil {
+		return fmt.Errorf("failed to create the initial commit: %w", err)
+	}
+	return nil
+}
+
+// createGitHubRemote creates the GitHub repository name with the GitHub
+// CLI, adds it as origin and pushes
+func createGitHubRemote(name, path string, public bool) error {
+	visibility := "--private"
+	if public {
+		visibility = "--public"
+	}
+	command := exec.Command(external.GitHubCLI.Command, "repo", "create", name, visibility,
+		"--source", path, "--remote", "origin", "--push")
+	command.Stdout = os.Stdout
+	command.Stderr = os.Stderr
+	if err := command.Run(); err != nil {
+		return fmt.Errorf("repository created, but creating the GitHub repository failed: %w", err)
+	}
+	return nil
+}
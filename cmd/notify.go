@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"log/slog"
+
+	"gman/internal/di"
+	"gman/internal/notify"
+	"gman/pkg/types"
+)
+
+// notifyProblems reports repositories that failed or fell far behind to
+// the configured webhooks; a failing webhook only warns
+func notifyProblems(source string, repositories []notify.Repository) {
+	configMgr := di.ConfigManager()
+	cfg := configMgr.GetConfig()
+	if !cfg.Notifications.Enabled() {
+		return
+	}
+	notifier := notify.New(cfg.Notifications, cfg.Groups, configMgr.GetConfigDir())
+	if count, err := notifier.Notify(source, repositories); err != nil {
+		slog.Warn("notification failed", "error", err)
+	} else if count > 0 {
+		slog.Debug("notification sent", "source", source, "problems", count)
+	}
+}
+
+// statusNotifyRepositories describes statuses to the notifier
+func statusNotifyRepositories(statuses []types.RepoStatus) []notify.Repository {
+	repositories := make([]notify.Repository, 0, len(statuses))
+	for _, status := range statuses {
+		repo := notify.Repository{Alias: status.Alias, Behind: status.SyncStatus.Behind}
+		if status.Error != nil {
+			repo.Error = status.Error.Error()
+		} else if status.SyncStatus.SyncError != nil {
+			repo.Error = status.SyncStatus.SyncError.Error()
+		}
+		repositories = append(repositories, repo)
+	}
+	return repositories
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"gman/internal/di"
+	"gman/internal/interactive"
 	"gman/pkg/types"
 
 	"github.com/spf13/cobra"
@@ -106,8 +107,14 @@ func offerSetup() error {
 	fmt.Println("It looks like this is your first time using gman.")
 	fmt.Println("Would you like to run the setup wizard to get started? (Y/n)")
 
-	var response string
-	fmt.Scanln(&response)
+	// Never start a wizard without someone at the keyboard
+	response := "n"
+	if interactive.NonInteractive() {
+		fmt.Println("Skipping the setup wizard (running non-interactively).")
+	} else {
+		response = ""
+		fmt.Scanln(&response)
+	}
 
 	if response == "" || response == "y" || response == "Y" || response == "yes" {
 		fmt.Println()
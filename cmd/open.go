@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"gman/internal/di"
+	"gman/internal/git"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	openBranch   bool
+	openRef      string
+	openPrintURL bool
+)
+
+// openCmd represents the open command
+var openCmd = &cobra.Command{
+	Use:   "open <alias> [path[:line]]",
+	Short: "Open a repository's remote page in the browser",
+	Long: `Open the web page of a repository's origin remote (GitHub, GitLab, Bitbucket
+or any GitHub-compatible forge) in the default browser.
+
+SSH and scp-style remotes (git@host:owner/repo.git) are converted to their
+https web URLs automatically. With a path, the file is opened on the current
+branch; append :line to deep-link to a specific line.
+
+Examples:
+  gman open backend                     # Repository home page
+  gman open backend --branch            # Current branch
+  gman open backend cmd/root.go:42      # File on the current branch at line 42
+  gman open backend README.md --ref v1.2.0
+  gman open backend --print             # Print the URL instead of opening it`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runOpen,
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) != 0 {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+
+		cfg := di.ConfigManager().GetConfig()
+		var aliases []string
+		for alias := range cfg.Repositories {
+			aliases = append(aliases, alias)
+		}
+		return aliases, cobra.ShellCompDirectiveNoFileComp
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(openCmd)
+
+	openCmd.Flags().BoolVarP(&openBranch, "branch", "b", false, "Open the current branch instead of the repository home page")
+	openCmd.Flags().StringVar(&openRef, "ref", "", "Branch, tag or commit to link to (default: current branch)")
+	openCmd.Flags().BoolVarP(&openPrintURL, "print", "p", false, "Print the URL instead of opening a browser")
+}
+
+func runOpen(cmd *cobra.Command, args []string) error {
+	// Configuration is already loaded by root command's PersistentPreRunE
+	cfg := di.ConfigManager().GetConfig()
+	if len(cfg.Repositories) == 0 {
+		return fmt.Errorf("no repositories configured. Use 'gman repo add' to add repositories")
+	}
+
+	alias := args[0]
+	repoPath, exists := cfg.Repositories[alias]
+	if !exists {
+		matched, err := fuzzyMatchRepository(alias, cfg.Repositories)
+		if err != nil {
+			return err
+		}
+		alias = matched
+		repoPath = cfg.Repositories[matched]
+	}
+
+	gitMgr := di.GitManager()
+	remoteURL, err := gitMgr.GetRemoteURL(repoPath)
+	if err != nil {
+		return fmt.Errorf("repository '%s' has no origin remote: %w", alias, err)
+	}
+
+	webURL, err := git.RemoteToWebURL(remoteURL)
+	if err != nil {
+		return fmt.Errorf("failed to build web URL for '%s': %w", alias, err)
+	}
+
+	var filePath string
+	var line int
+	if len(args) == 2 {
+		filePath, line, err = parseOpenTarget(args[1], repoPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	ref := openRef
+	if ref == "" && (openBranch || filePath != "") {
+		ref, err = gitMgr.GetCurrentBranch(repoPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	target := git.WebURLForRef(webURL, ref, filePath, line)
+
+	if openPrintURL {
+		fmt.Println(target)
+		return nil
+	}
+
+	if err := openBrowser(target); err != nil {
+		fmt.Println(target)
+		return fmt.Errorf("failed to open browser: %w", err)
+	}
+
+	fmt.Printf("🌐 Opened %s\n", color.CyanString(target))
+	return nil
+}
+
+// parseOpenTarget splits "path[:line]" and returns the path relative to the
+// repository root with forward slashes, as used in forge URLs
+func parseOpenTarget(target, repoPath string) (string, int, error) {
+	line := 0
+	if idx := strings.LastIndex(target, ":"); idx > 0 {
+		if n, err := strconv.Atoi(target[idx+1:]); err == nil {
+			if n <= 0 {
+				return "", 0, fmt.Errorf("invalid line number %d", n)
+			}
+			line = n
+			target = target[:idx]
+		}
+	}
+
+	if filepath.IsAbs(target) {
+		rel, err := filepath.Rel(repoPath, target)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			return "", 0, fmt.Errorf("path '%s' is outside of repository %s", target, repoPath)
+		}
+		target = rel
+	}
+
+	target = filepath.ToSlash(filepath.Clean(target))
+	if strings.HasPrefix(target, "../") || target == ".." {
+		return "", 0, fmt.Errorf("path '%s' is outside of the repository", target)
+	}
+	if target == "." {
+		target = ""
+	}
+
+	return target, line, nil
+}
+
+// openBrowser opens url with the platform's default handler
+func openBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return cmd.Start()
+}
@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	cmdutils "gman/internal/cmd"
+	"gman/internal/codeowners"
+	"gman/internal/di"
+	"gman/internal/display"
+	"gman/internal/pager"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ownersGroup   string
+	ownersUnowned bool
+)
+
+// ownersCmd represents the owners command
+var ownersCmd = &cobra.Command{
+	Use:   "owners [path-glob]",
+	Short: "Look up CODEOWNERS across repositories",
+	Long: `Answer who owns the files matching a path glob in every repository (or the
+repositories of one group), from their CODEOWNERS files, and list the files
+nobody owns.
+
+CODEOWNERS is read from .github/, the repository root or docs/, like
+GitHub does. The last matching rule decides the owners. The path glob uses
+the same rules as CODEOWNERS patterns: without a slash it matches at any
+depth, a leading slash anchors it to the repository root and ** matches any
+number of directories. Only tracked files are considered.
+
+Examples:
+  gman owners '*.proto'                   # Who owns the protobuf files
+  gman owners /deploy/ --group services   # Who reviews deployment changes
+  gman owners --unowned                   # Files without owners
+  gman owners 'src/**' --unowned --output csv`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runOwners,
+}
+
+func init() {
+	rootCmd.AddCommand(ownersCmd)
+
+	ownersCmd.Flags().StringVarP(&ownersGroup, "group", "g", "", "Only look in repositories of this group")
+	ownersCmd.Flags().BoolVar(&ownersUnowned, "unowned", false, "List the files without owners")
+
+	pager.Enable(ownersCmd)
+}
+
+// ownerRecord is the ownership of one file
+type ownerRecord struct {
+	Repository string   `json:"repository" yaml:"repository"`
+	Path       string   `json:"path" yaml:"path"`
+	Owners     []string `json:"owners" yaml:"owners"`
+	Source     string   `json:"source,omitempty" yaml:"source,omitempty"` // CODEOWNERS file and line of the deciding rule
+}
+
+// repoOwnership is the ownership of the matching files of one repository
+type repoOwnership struct {
+	alias      string
+	codeowners string // CODEOWNERS location; empty when the repository has none
+	records    []ownerRecord
+	err        error
+}
+
+func runOwners(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 && !ownersUnowned {
+		return fmt.Errorf("pass a path glob, --unowned or both")
+	}
+	var glob *codeowners.Pattern
+	if len(args) == 1 {
+		pattern, err := codeowners.NewPattern(args[0])
+		if err != nil {
+			return err
+		}
+		glob = &pattern
+	}
+	repositories, err := execRepositories(ownersGroup)
+	if err != nil {
+		return err
+	}
+
+	results := forEachRepository(repositories, func(alias, path string) repoOwnership {
+		return repositoryOwnership(alias, path, glob)
+	})
+
+	records := []ownerRecord{}
+	for _, result := range results {
+		if result.err != nil {
+			fmt.Fprintf(os.Stderr, "%s %s: %v\n", display.ErrorIcon(), result.alias, result.err)
+			continue
+		}
+		records = append(records, result.records...)
+	}
+	return cmdutils.Render(records, func() error {
+		printOwners(results)
+		return nil
+	})
+}
+
+// repositoryOwnership looks up the owners of the tracked files of a
+// repository matching glob (all files when nil), keeping only the unowned
+// ones with --unowned
+func repositoryOwnership(alias, path string, glob *codeowners.Pattern) repoOwnership {
+	result := repoOwnership{alias: alias}
+	file, err := codeowners.Find(path)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	if file != nil {
+		result.codeowners = file.Path
+	}
+
+	output, err := di.GitManager().RunCommand(path, "ls-files", "-z")
+	if err != nil {
+		result.err = fmt.Errorf("failed to list tracked files: %w", err)
+		return result
+	}
+	for _, relPath := range strings.Split(output, "\x00") {
+		if relPath == "" || (glob != nil && !glob.Match(relPath)) {
+			continue
+		}
+		record := ownerRecord{Repository: alias, Path: relPath, Owners: []string{}}
+		if file != nil {
+			if rule, ok := file.Owner(relPath); ok {
+				record.Owners = rule.Owners
+				record.Source = fmt.Sprintf("%s:%d", file.Path, rule.Line)
+			}
+		}
+		if ownersUnowned && len(record.Owners) > 0 {
+			continue
+		}
+		result.records = append(result.records, record)
+	}
+	return result
+}
+
+// printOwners prints the files of each repository with their owners and a
+// fleet-wide count per owner
+func printOwners(results []repoOwnership) {
+	counts := make(map[string]int)
+	var repos, files int
+	for _, result := range results {
+		if result.err != nil || len(result.records) == 0 {
+			continue
+		}
+		repos++
+		files += len(result.records)
+
+		source := color.HiBlackString("(%s)", result.codeowners)
+		if result.codeowners == "" {
+			source = color.YellowString("(no CODEOWNERS)")
+		}
+		fmt.Printf("%s %s\n", color.New(color.Bold).Sprint(result.alias), source)
+		if ownersUnowned && result.codeowners == "" {
+			// Every file of the repository would be listed
+			fmt.Printf("  %s\n\n", fileCount(len(result.records)))
+			counts[""] += len(result.records)
+			continue
+		}
+
+		width := 0
+		for _, record := range result.records {
+			width = max(width, len(record.Path))
+		}
+		for _, record := range result.records {
+			owners := strings.Join(record.Owners, " ")
+			counts[owners]++
+			if owners == "" {
+				owners = color.YellowString("(unowned)")
+			}
+			fmt.Printf("  %-*s  %s\n", width, record.Path, owners)
+		}
+		fmt.Println()
+	}
+
+	if files == 0 {
+		if ownersUnowned {
+			fmt.Printf("%s Every matching file has an owner.\n", display.SuccessIcon())
+		} else {
+			fmt.Println("No matching files.")
+		}
+		return
+	}
+	printOwnerCounts(counts, files, repos)
+}
+
+// printOwnerCounts prints how many files each set of owners has, most first
+func printOwnerCounts(counts map[string]int, files, repos int) {
+	owners := make([]string, 0, len(counts))
+	for owner := range counts {
+		owners = append(owners, owner)
+	}
+	sort.Slice(owners, func(i, j int) bool {
+		if counts[owners[i]] != counts[owners[j]] {
+			return counts[owners[i]] > counts[owners[j]]
+		}
+		return owners[i] < owners[j]
+	})
+
+	fmt.Printf("%s in %d repositories:\n", fileCount(files), repos)
+	for _, owner := range owners {
+		label := owner
+		if label == "" {
+			label = color.YellowString("(unowned)")
+		}
+		fmt.Printf("  %5d  %s\n", counts[owner], label)
+	}
+}
+
+// fileCount formats a number of files, e.g. "1 file" or "12 files"
+func fileCount(n int) string {
+	if n == 1 {
+		return "1 file"
+	}
+	return fmt.Sprintf("%d files", n)
+}
@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	cmdutils "gman/internal/cmd"
+	"gman/internal/di"
+	"gman/internal/display"
+	"gman/internal/git"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	patchGroup    string
+	patchThreeWay bool
+	patchDryRun   bool
+)
+
+// Outcomes of applying patches to a repository
+const (
+	patchApplied   = "applied"
+	patchConflicts = "conflicts"
+	patchFailed    = "failed"
+)
+
+// patchExtensions are the files of a patch directory that are applied
+var patchExtensions = []string{".patch", ".diff", ".mbox", ".eml"}
+
+// patchCmd represents the patch command group
+var patchCmd = &cobra.Command{
+	Use:   "patch",
+	Short: "Apply patches across repositories",
+}
+
+// patchApplyCmd represents the patch apply command
+var patchApplyCmd = &cobra.Command{
+	Use:   "apply <file-or-dir>",
+	Short: "Apply the same patch series to every repository",
+	Long: `Apply a patch, or a directory of patches, to every repository (or the
+repositories of one group) and report per repository whether it applied,
+conflicted or failed. Useful for vendored code and configuration files
+duplicated across services.
+
+A directory is applied in file name order, as written by git format-patch,
+and may hold .patch, .diff, .mbox and .eml files. Mailboxes from git
+format-patch are committed with git am, keeping their messages and authors;
+plain diffs only change the working tree.
+
+A patch that does not apply leaves the repository as it was. With --3way,
+git falls back to a three-way merge instead and leaves conflict markers to
+resolve; for mailboxes git am stays in progress until 'git am --continue'
+or 'git am --abort'.
+
+--dry-run applies the patches in a temporary worktree of HEAD, so
+uncommitted changes are not taken into account and nothing is changed.
+
+Commits made by git am can be undone with gman undo.
+
+Examples:
+  gman patch apply fix-logging.patch --dry-run
+  gman patch apply ./outgoing --group services
+  gman patch apply update-ci.mbox --3way`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPatchApply,
+}
+
+func init() {
+	rootCmd.AddCommand(patchCmd)
+	patchCmd.AddCommand(patchApplyCmd)
+
+	patchApplyCmd.Flags().StringVarP(&patchGroup, "group", "g", "", "Only apply to repositories of this group")
+	patchApplyCmd.Flags().BoolVar(&patchThreeWay, "3way", false, "Fall back to a three-way merge and leave conflicts to resolve")
+	patchApplyCmd.Flags().BoolVar(&patchDryRun, "dry-run", false, "Report how the patches would apply without changing anything")
+}
+
+// patchRecord is the outcome of applying the patches to one repository
+type patchRecord struct {
+	Alias     string   `json:"alias" yaml:"alias"`
+	Status    string   `json:"status" yaml:"status"`
+	Applied   int      `json:"applied" yaml:"applied"`
+	Conflicts []string `json:"conflicts,omitempty" yaml:"conflicts,omitempty"`
+	Error     string   `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+func runPatchApply(cmd *cobra.Command, args []string) error {
+	patches, err := patchFiles(args[0])
+	if err != nil {
+		return err
+	}
+	mailbox, err := git.PatchesAreMailboxes(patches)
+	if err != nil {
+		return err
+	}
+	gitMgr := di.GitManager()
+	// Every repository would reject the same file names
+	for _, patch := range patches {
+		if err := gitMgr.ValidateArguments(patch); err != nil {
+			return fmt.Errorf("cannot pass patch file %s to git: rename it without characters like ( ) & $ ;", patch)
+		}
+	}
+	repositories, err := execRepositories(patchGroup)
+	if err != nil {
+		return err
+	}
+
+	if mailbox && !patchDryRun {
+		recorder := newUndoRecorder("patch apply")
+		for alias, path := range repositories {
+			recorder.Before(alias, path)
+		}
+		defer saveUndoRecord(recorder)
+	}
+
+	records := make([]patchRecord, 0, len(repositories))
+	for _, alias := range sortedAliases(repositories) {
+		path := repositories[alias]
+		var result git.PatchResult
+		if patchDryRun {
+			result, err = gitMgr.CheckPatches(path, patches, mailbox, patchThreeWay)
+		} else {
+			result, err = gitMgr.ApplyPatches(path, patches, mailbox, patchThreeWay)
+		}
+
+		record := patchRecord{Alias: alias, Status: patchApplied, Applied: result.Applied, Conflicts: result.Conflicts}
+		switch {
+		case err != nil:
+			record.Status, record.Error = patchFailed, err.Error()
+		case len(result.Conflicts) > 0:
+			record.Status = patchConflicts
+		}
+		records = append(records, record)
+	}
+
+	renderErr := cmdutils.Render(records, func() error {
+		printPatchResults(records, len(patches), mailbox)
+		return nil
+	})
+	if renderErr != nil {
+		return renderErr
+	}
+
+	var failed int
+	for _, record := range records {
+		if record.Status != patchApplied {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("patches did not apply cleanly to %d of %d repositories", failed, len(records))
+	}
+	return nil
+}
+
+// patchFiles returns the patch file, or the patch files of a directory in
+// name order, as absolute paths
+func patchFiles(target string) ([]string, error) {
+	target, err := filepath.Abs(target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", target, err)
+	}
+	info, err := os.Stat(target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read patch: %w", err)
+	}
+	if !info.IsDir() {
+		return []string{target}, nil
+	}
+
+	entries, err := os.ReadDir(target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read patch directory: %w", err)
+	}
+	var patches []string
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.Type().IsRegular() && slices.Contains(patchExtensions, ext) {
+			patches = append(patches, filepath.Join(target, entry.Name()))
+		}
+	}
+	if len(patches) == 0 {
+		return nil, fmt.Errorf("no patches (%s) in %s", strings.Join(patchExtensions, ", "), target)
+	}
+	sort.Strings(patches)
+	return patches, nil
+}
+
+// printPatchResults prints one line per repository and a summary
+func printPatchResults(records []patchRecord, patches int, mailbox bool) {
+	verb := "Applied"
+	if patchDryRun {
+		verb = "Would apply"
+	}
+	var applied, conflicted, failed int
+	for _, record := range records {
+		switch record.Status {
+		case patchApplied:
+			applied++
+			fmt.Printf("%s %s: %s %d of %d patches\n", display.SuccessIcon(), record.Alias, strings.ToLower(verb), record.Applied, patches)
+		case patchConflicts:
+			conflicted++
+			fmt.Printf("%s %s: conflicts after %d of %d patches in %s\n", display.WarningIcon(), record.Alias,
+				record.Applied, patches, strings.Join(record.Conflicts, ", "))
+		default:
+			failed++
+			fmt.Printf("%s %s: %s\n", display.ErrorIcon(), record.Alias, color.RedString("%s", record.Error))
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf("%s cleanly to %d, conflicts in %d, failed in %d of %d repositories\n",
+		verb, applied, conflicted, failed, len(records))
+	if conflicted > 0 && !patchDryRun {
+		if mailbox {
+			fmt.Println("Resolve the conflicts, then run 'git am --continue' (or 'git am --abort') in each repository.")
+		} else {
+			fmt.Println("Resolve the conflict markers in each repository.")
+		}
+	}
+	if patchDryRun {
+		fmt.Println("Dry run: no repository was changed.")
+	}
+}
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	goerrors "errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+
+	cmdutils "gman/internal/cmd"
+	"gman/internal/di"
+	"gman/internal/display"
+	"gman/internal/plugin"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// pluginCmd represents the plugin command
+var pluginCmd = &cobra.Command{
+	Use:   "plugin",
+	Short: "List external gman plugins",
+	Long: `Extend gman with plugins: any executable named gman-<name> on PATH runs as
+'gman <name>', receiving the remaining arguments. Built-in commands take
+precedence over plugins of the same name.
+
+Plugins get gman's context in environment variables:
+  GMAN_BIN          Path of the gman executable, to call back into gman
+  GMAN_CONFIG       Configuration file
+  GMAN_CONFIG_DIR   Directory of the configuration and gman's state files
+  GMAN_REPO         Alias of the repository containing the working directory
+  GMAN_REPO_PATH    Path of that repository
+
+GMAN_REPO and GMAN_REPO_PATH are only set inside a managed repository.
+Plugins can read repositories and status as JSON with, e.g.,
+'$GMAN_BIN repo list --output json'.
+
+Global flags such as --config must follow the plugin name and are passed
+to the plugin unchanged.
+
+Examples:
+  gman plugin list
+  gman deploy staging          # Runs gman-deploy staging`,
+}
+
+// pluginListCmd represents the plugin list command
+var pluginListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the gman-<name> plugins found on PATH",
+	Args:  cobra.NoArgs,
+	RunE:  runPluginList,
+}
+
+func init() {
+	rootCmd.AddCommand(pluginCmd)
+	pluginCmd.AddCommand(pluginListCmd)
+}
+
+// pluginRecord is one plugin as listed by 'gman plugin list'
+type pluginRecord struct {
+	Name     string   `json:"name" yaml:"name"`
+	Path     string   `json:"path" yaml:"path"`
+	Hidden   bool     `json:"hidden_by_builtin" yaml:"hidden_by_builtin"`
+	Shadowed []string `json:"shadowed,omitempty" yaml:"shadowed,omitempty" csv:"-"`
+}
+
+func runPluginList(cmd *cobra.Command, args []string) error {
+	var records []pluginRecord
+	for _, p := range plugin.List(os.Getenv("PATH")) {
+		records = append(records, pluginRecord{
+			Name:     p.Name,
+			Path:     p.Path,
+			Hidden:   isBuiltinCommand(p.Name),
+			Shadowed: p.Shadowed,
+		})
+	}
+
+	return cmdutils.Render(records, func() error {
+		if len(records) == 0 {
+			fmt.Printf("No plugins found. Put an executable named %s<name> on PATH to add 'gman <name>'.\n", plugin.Prefix)
+			return nil
+		}
+		maxName := len("Plugin")
+		for _, record := range records {
+			maxName = max(maxName, len(record.Name))
+		}
+		fmt.Printf("%-*s %s\n", maxName, "Plugin", "Path")
+		for _, record := range records {
+			fmt.Printf("%-*s %s\n", maxName, record.Name, record.Path)
+			if record.Hidden {
+				fmt.Printf("%-*s %s\n", maxName, "", color.YellowString("%s hidden by the built-in command 'gman %s'", display.WarningIcon(), record.Name))
+			}
+			for _, path := range record.Shadowed {
+				fmt.Printf("%-*s %s\n", maxName, "", color.YellowString("%s shadows %s", display.WarningIcon(), path))
+			}
+		}
+		return nil
+	})
+}
+
+// isBuiltinCommand reports whether name is a gman command or alias
+func isBuiltinCommand(name string) bool {
+	if name == "help" {
+		return true
+	}
+	for _, command := range rootCmd.Commands() {
+		if command.Name() == name || command.HasAlias(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// runPlugin runs 'gman <name> args...' as the plugin gman-<name> when name
+// is not a built-in command. It reports whether a plugin ran, and its exit
+// code.
+func runPlugin(args []string) (bool, int) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") || isBuiltinCommand(args[0]) {
+		return false, 0
+	}
+	path, ok := plugin.Lookup(args[0], os.Getenv("PATH"))
+	if !ok {
+		return false, 0
+	}
+
+	command := exec.Command(path, args[1:]...)
+	command.Stdin = os.Stdin
+	command.Stdout = os.Stdout
+	command.Stderr = os.Stderr
+	command.Env = append(os.Environ(), pluginEnv()...)
+
+	// Ctrl-C reaches the plugin directly; gman waits for it to exit
+	signal.Ignore(os.Interrupt)
+	if err := command.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if goerrors.As(err, &exitErr) {
+			return true, exitErr.ExitCode()
+		}
+		fmt.Fprintf(os.Stderr, "Error: failed to run plugin %s: %v\n", path, err)
+		return true, 1
+	}
+	return true, 0
+}
+
+// pluginEnv returns the environment variables describing gman's context
+// to a plugin
+func pluginEnv() []string {
+	configMgr := di.ConfigManager()
+	if err := configMgr.Load(); err != nil {
+		slog.Debug("plugin runs without configuration", "error", err)
+	}
+
+	env := []string{
+		"GMAN_CONFIG=" + configMgr.GetConfigPath(),
+		"GMAN_CONFIG_DIR=" + configMgr.GetConfigDir(),
+	}
+	if executable, err := os.Executable(); err == nil {
+		env = append(env, "GMAN_BIN="+executable)
+	}
+	if cwd, err := os.Getwd(); err == nil {
+		if alias, path := repoForPath(configMgr.GetConfig().Repositories, cwd); alias != "" {
+			env = append(env, "GMAN_REPO="+alias, "GMAN_REPO_PATH="+path)
+		}
+	}
+	return env
+}
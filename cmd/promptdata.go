@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"gman/internal/cache"
+	"gman/internal/di"
+	"gman/pkg/types"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	promptDataPath string
+	promptDataJSON bool
+)
+
+// promptDataCmd represents the prompt-data command
+var promptDataCmd = &cobra.Command{
+	Use:   "prompt-data",
+	Short: "Print prompt segment data for the repository of a directory",
+	Long: `Print the alias, groups, ahead/behind counts and dirty state of the managed
+repository that owns a directory, for starship, powerlevel10k and similar
+prompts. The data comes from a running 'gman daemon' or the status cache, so
+the command never runs git and stays fast; 'gman work status' and the daemon
+refresh the cache.
+
+Outside of a managed repository nothing is printed. A repository missing from
+the cache prints just its alias and groups.
+
+Examples:
+  gman prompt-data                        # e.g. "backend (core) ⇡1 ⇣2 ✚"
+  gman prompt-data --path ~/src/backend
+  gman prompt-data --json                 # For scripted prompt segments
+
+Starship:
+  [custom.gman]
+  command = "gman prompt-data"
+  when = "true"`,
+	Args: cobra.NoArgs,
+	RunE: runPromptData,
+}
+
+func init() {
+	rootCmd.AddCommand(promptDataCmd)
+
+	promptDataCmd.Flags().StringVar(&promptDataPath, "path", ".", "Directory to describe")
+	promptDataCmd.Flags().BoolVar(&promptDataJSON, "json", false, "Print the data as JSON")
+}
+
+// promptData is the prompt segment data of one repository
+type promptData struct {
+	Alias   string    `json:"alias"`
+	Groups  []string  `json:"groups"`
+	Branch  string    `json:"branch,omitempty"`
+	Ahead   int       `json:"ahead"`
+	Behind  int       `json:"behind"`
+	Dirty   bool      `json:"dirty"`
+	Stashed bool      `json:"stashed"`
+	Cached  bool      `json:"cached"`
+	Updated time.Time `json:"updated_at,omitzero"`
+}
+
+func runPromptData(cmd *cobra.Command, args []string) error {
+	configMgr := di.ConfigManager()
+	cfg := configMgr.GetConfig()
+
+	alias, _ := repoForPath(cfg.Repositories, promptDataPath)
+	if alias == "" {
+		return nil
+	}
+
+	data := promptData{Alias: alias, Groups: groupsOf(cfg.Groups, alias)}
+	if statuses, updated, ok := daemonStatuses(map[string]string{alias: cfg.Repositories[alias]}); ok {
+		status := statuses[0]
+		data.Branch = status.Branch
+		data.Ahead = status.SyncStatus.Ahead
+		data.Behind = status.SyncStatus.Behind
+		data.Dirty = status.Workspace == types.Dirty
+		data.Stashed = status.Workspace == types.Stashed
+		data.Cached = true
+		data.Updated = updated
+	} else if statusCache, err := cache.LoadStatusCache(cache.StatusCachePath(configMgr.GetConfigDir())); err == nil {
+		if entry, exists := statusCache.Entries[alias]; exists && entry.Path == cfg.Repositories[alias] {
+			data.Branch = entry.Branch
+			data.Ahead = entry.Ahead
+			data.Behind = entry.Behind
+			data.Dirty = entry.Dirty
+			data.Stashed = entry.Stashed
+			data.Cached = true
+			data.Updated = entry.UpdatedAt
+		}
+	}
+
+	if promptDataJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		return encoder.Encode(data)
+	}
+	fmt.Println(data.String())
+	return nil
+}
+
+// groupsOf returns the sorted names of the groups containing alias
+func groupsOf(groups map[string]types.Group, alias string) []string {
+	names := []string{}
+	for name, group := range groups {
+		for _, repo := range group.Repositories {
+			if repo == alias {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// String renders the data as a compact prompt segment, e.g. "backend (core) ⇡1 ⇣2 ✚"
+func (d promptData) String() string {
+	parts := []string{d.Alias}
+	if len(d.Groups) > 0 {
+		parts = append(parts, "("+strings.Join(d.Groups, ",")+")")
+	}
+	if d.Ahead > 0 {
+		parts = append(parts, fmt.Sprintf("⇡%d", d.Ahead))
+	}
+	if d.Behind > 0 {
+		parts = append(parts, fmt.Sprintf("⇣%d", d.Behind))
+	}
+	if d.Dirty {
+		parts = append(parts, "✚")
+	}
+	if d.Stashed {
+		parts = append(parts, "≡")
+	}
+	return strings.Join(parts, " ")
+}
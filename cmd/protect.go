@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+
+	"gman/internal/di"
+	"gman/internal/display"
+
+	"github.com/spf13/cobra"
+)
+
+// protectCmd represents the protect command
+var protectCmd = &cobra.Command{
+	Use:   "protect <alias>",
+	Short: "Refuse destructive git commands in a repository",
+	Long: `Mark a repository as protected. gman then refuses destructive git commands
+in it: force pushes, forced branch deletion, stash clear, forced worktree
+removal, hard resets and aborting merges.
+
+Pass --allow-destructive to a single command to override the protection.
+--safe (or settings.safe_mode) applies the same rules to every repository.
+Commands run by 'gman exec' and 'gman foreach' are not checked.
+
+Examples:
+  gman repo protect production-api
+  gman repo unprotect production-api`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runProtect,
+	ValidArgsFunction: removeCmd.ValidArgsFunction,
+}
+
+// unprotectCmd represents the unprotect command
+var unprotectCmd = &cobra.Command{
+	Use:               "unprotect <alias>",
+	Short:             "Allow destructive git commands in a repository again",
+	Args:              cobra.ExactArgs(1),
+	RunE:              runProtect,
+	ValidArgsFunction: removeCmd.ValidArgsFunction,
+}
+
+func runProtect(cmd *cobra.Command, args []string) error {
+	alias := args[0]
+	protected := cmd.Name() == "protect"
+
+	if err := di.ConfigManager().SetProtected(alias, protected); err != nil {
+		return err
+	}
+	if protected {
+		display.PrintSuccess(fmt.Sprintf("Protected repository: %s", alias))
+	} else {
+		display.PrintSuccess(fmt.Sprintf("Removed protection of repository: %s", alias))
+	}
+	return nil
+}
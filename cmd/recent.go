@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"gman/internal/cache"
+	cmdutils "gman/internal/cmd"
+	"gman/internal/di"
+	"gman/pkg/types"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	recentListLimit int
+	recentSince     string
+)
+
+// recentCmd represents the recent command
+var recentCmd = &cobra.Command{
+	Use:   "recent",
+	Short: "List the repositories and worktrees you switched to most recently",
+	Long: `List the repositories and worktrees 'gman switch' went to, most recent
+first, with how often each was switched to.
+
+Every switch is recorded in recent.json next to the configuration file,
+with the target, the time and the directory the switch started from.
+Switches to repositories that were removed from gman or deleted from disk
+are pruned from the history.
+
+Examples:
+  gman recent                 # Last 10 targets
+  gman recent --limit 0       # Everything recorded
+  gman recent --since 7d      # Targets of the last week
+  gman recent --output json`,
+	Args: cobra.NoArgs,
+	RunE: runRecent,
+}
+
+func init() {
+	rootCmd.AddCommand(recentCmd)
+
+	recentCmd.Flags().IntVarP(&recentListLimit, "limit", "n", 10, "Number of targets to show (0 for all)")
+	recentCmd.Flags().StringVar(&recentSince, "since", "", "Only show targets switched to after a duration (e.g. 12h, 7d) or date (2024-01-31)")
+}
+
+// recentRecord is a recently switched to target
+type recentRecord struct {
+	Alias      string    `json:"alias" yaml:"alias"`
+	Repository string    `json:"repository" yaml:"repository"`
+	Path       string    `json:"path" yaml:"path"`
+	Source     string    `json:"source,omitempty" yaml:"source,omitempty"`
+	LastSwitch time.Time `json:"last_switch" yaml:"last_switch"`
+	Switches   int       `json:"switches" yaml:"switches"`
+}
+
+func runRecent(cmd *cobra.Command, args []string) error {
+	var since time.Time
+	if recentSince != "" {
+		var err error
+		if since, err = parseSince(recentSince, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	configMgr := di.ConfigManager()
+	cfg := configMgr.GetConfig()
+	path := cache.RecentPath(configMgr.GetConfigDir())
+	store, err := loadRecent(path, cfg)
+	if err != nil {
+		return err
+	}
+
+	pruned := store.Prune(func(entry cache.RecentSwitch) bool {
+		if _, exists := cfg.Repositories[entry.Alias]; !exists {
+			return false
+		}
+		_, err := os.Stat(entry.Path)
+		return err == nil
+	})
+	if pruned > 0 {
+		slog.Debug("pruned switches to deleted repositories", "count", pruned)
+		if err := store.Save(path); err != nil {
+			slog.Warn("failed to prune recent switch history", "error", err)
+		}
+	}
+
+	targets := store.Recent(since, recentListLimit)
+	records := make([]recentRecord, 0, len(targets))
+	for _, target := range targets {
+		records = append(records, recentRecord{
+			Alias:      target.Target(),
+			Repository: target.Alias,
+			Path:       target.Path,
+			Source:     target.Source,
+			LastSwitch: target.Time,
+			Switches:   target.Switches,
+		})
+	}
+
+	return cmdutils.Render(records, func() error {
+		printRecent(records)
+		return nil
+	})
+}
+
+// printRecent prints one line per target, most recent first
+func printRecent(records []recentRecord) {
+	if len(records) == 0 {
+		fmt.Println("No recent switches.")
+		return
+	}
+
+	width := len("Target")
+	for _, record := range records {
+		width = max(width, len(record.Alias))
+	}
+	now := time.Now()
+	fmt.Printf("%-*s %8s %9s  %s\n", width, "Target", "Last", "Switches", "Path")
+	for _, record := range records {
+		last := "just now"
+		if age := now.Sub(record.LastSwitch); age >= time.Minute {
+			last = formatAge(age) + " ago"
+		}
+		fmt.Printf("%s %8s %9d  %s\n", color.CyanString("%-*s", width, record.Alias),
+			last, record.Switches, color.HiBlackString("%s", record.Path))
+	}
+}
+
+// loadRecent reads the switch history, seeded with the recent usage list
+// of the configuration when it is still empty
+func loadRecent(path string, cfg *types.Config) (*cache.RecentStore, error) {
+	store, err := cache.LoadRecent(path)
+	if err != nil {
+		return nil, err
+	}
+	store.Seed(cfg.RecentUsage, cfg.Repositories)
+	return store, nil
+}
+
+// recordRecentSwitch adds a switch to target to the history. Failures are
+// only logged so that switching never fails because of the history.
+func recordRecentSwitch(configDir string, cfg *types.Config, target *types.SwitchTarget) {
+	path := cache.RecentPath(configDir)
+	store, err := loadRecent(path, cfg)
+	if err != nil {
+		slog.Warn("failed to record switch", "error", err)
+		return
+	}
+
+	entry := cache.RecentSwitch{Alias: target.Alias, Path: target.Path, Time: time.Now()}
+	if target.Type != "repository" && target.RepoAlias != "" {
+		entry.Alias, entry.Worktree = target.RepoAlias, target.Alias
+	}
+	if cwd, err := os.Getwd(); err == nil {
+		entry.Source = cwd
+	}
+	store.Record(entry)
+	if err := store.Save(path); err != nil {
+		slog.Warn("failed to record switch", "error", err)
+	}
+}
@@ -0,0 +1,369 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	cmdutils "gman/internal/cmd"
+	"gman/internal/di"
+	"gman/internal/display"
+	"gman/internal/interactive"
+	"gman/pkg/types"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	releaseGroup   string
+	releaseVersion string
+	releaseMessage string
+	releaseDryRun  bool
+	releaseNoPush  bool
+	releaseYes     bool
+)
+
+// releaseCmd represents the release command
+var releaseCmd = &cobra.Command{
+	Use:   "release",
+	Short: "Tag and push a release across a group of repositories",
+	Long: `Release the repositories of a group under one version, in dependency order.
+
+gman release first fetches and verifies every repository: it must be on a
+branch, have no uncommitted changes, not be behind its remote and not have
+the tag yet. Nothing is changed unless every repository passes.
+
+Then, for each repository in order:
+  1. Run its version_command, if configured, and commit the changed files
+  2. Create the annotated tag
+  3. Push the branch and the tag to origin
+
+A failure stops the release; repositories after it are left untouched.
+
+Order and version commands are configured per repository:
+
+  repository_options:
+    api:
+      depends_on: [shared]          # Released after shared
+      version_command: ./scripts/bump-version {version}
+
+Examples:
+  gman release --group product --version v2.3.0
+  gman release --group product --version v2.3.0 --dry-run   # Verify and show the plan
+  gman release --group product --version v2.3.0 --no-push   # Tag locally only`,
+	Args: cobra.NoArgs,
+	RunE: runRelease,
+}
+
+func init() {
+	rootCmd.AddCommand(releaseCmd)
+
+	releaseCmd.Flags().StringVarP(&releaseGroup, "group", "g", "", "Release the repositories of this group")
+	releaseCmd.Flags().StringVar(&releaseVersion, "version", "", "Version to release, used as the tag name (e.g. v2.3.0)")
+	releaseCmd.Flags().StringVarP(&releaseMessage, "message", "m", "", "Tag message (default: \"Release <version>\")")
+	releaseCmd.Flags().BoolVar(&releaseDryRun, "dry-run", false, "Verify the repositories and show the plan without changing anything")
+	releaseCmd.Flags().BoolVar(&releaseNoPush, "no-push", false, "Create the tags without pushing them")
+	releaseCmd.Flags().BoolVarP(&releaseYes, "yes", "y", false, "Release without confirmation")
+	releaseCmd.MarkFlagRequired("group")
+	releaseCmd.MarkFlagRequired("version")
+}
+
+// releaseRecord is the release outcome of one repository
+type releaseRecord struct {
+	Alias   string `json:"alias" yaml:"alias"`
+	Branch  string `json:"branch" yaml:"branch"`
+	Commit  string `json:"commit,omitempty" yaml:"commit,omitempty"`
+	Tag     string `json:"tag" yaml:"tag"`
+	Bumped  bool   `json:"bumped" yaml:"bumped"`
+	Pushed  bool   `json:"pushed" yaml:"pushed"`
+	Status  string `json:"status" yaml:"status"` // released, tagged, failed, skipped or planned
+	Error   string `json:"error,omitempty" yaml:"error,omitempty"`
+	path    string
+	command string
+}
+
+func runRelease(cmd *cobra.Command, args []string) error {
+	repositories, err := execRepositories(releaseGroup)
+	if err != nil {
+		return err
+	}
+	if len(repositories) == 0 {
+		return fmt.Errorf("group '%s' has no repositories", releaseGroup)
+	}
+
+	if err := exec.Command("git", "check-ref-format", "refs/tags/"+releaseVersion).Run(); err != nil {
+		return fmt.Errorf("invalid version '%s': it must be a valid tag name", releaseVersion)
+	}
+	message := releaseMessage
+	if message == "" {
+		message = "Release " + releaseVersion
+	}
+	// Tagging and pushing would reject them only after the first repositories
+	// were released
+	if err := di.GitManager().ValidateArguments(releaseVersion, message); err != nil {
+		return fmt.Errorf("invalid release: %w", err)
+	}
+
+	options := di.ConfigManager().GetConfig().RepositoryOptions
+	dependencies := make(map[string][]string)
+	for alias := range repositories {
+		dependencies[alias] = options[alias].DependsOn
+	}
+	order, err := releaseOrder(sortedAliases(repositories), dependencies)
+	if err != nil {
+		return err
+	}
+
+	// Verify everything before changing anything
+	gitMgr := di.GitManager()
+	fmt.Printf("Verifying %d repositories...\n", len(order))
+	statuses, err := gitMgr.GetAllRepoStatus(repositories)
+	if err != nil {
+		return fmt.Errorf("failed to get repository status: %w", err)
+	}
+	records := make([]releaseRecord, 0, len(order))
+	var problems int
+	for _, alias := range order {
+		i := slices.IndexFunc(statuses, func(s types.RepoStatus) bool { return s.Alias == alias })
+		record := releaseRecord{Alias: alias, Tag: releaseVersion, Status: "planned", path: repositories[alias], command: options[alias].VersionCommand}
+		if i < 0 {
+			record.Error = "status unavailable"
+		} else {
+			record.Branch = statuses[i].Branch
+			record.Error = verifyReleasable(statuses[i])
+		}
+		if record.Error != "" {
+			problems++
+			record.Status = "failed"
+		}
+		records = append(records, record)
+	}
+
+	printReleasePlan(records)
+	if problems > 0 {
+		return fmt.Errorf("%d repositories are not ready for release", problems)
+	}
+	if releaseDryRun {
+		fmt.Println("Dry run: nothing was changed.")
+		return nil
+	}
+	if !releaseYes {
+		if interactive.NonInteractive() {
+			return interactive.ErrUnavailable("confirming the release", "pass --yes to release")
+		}
+		fmt.Printf("Release %s in %d repositories? [y/N]: ", releaseVersion, len(records))
+		if !askConfirmation(false) {
+			fmt.Println("Release cancelled.")
+			return nil
+		}
+	}
+
+	var failed bool
+	for i := range records {
+		record := &records[i]
+		if failed {
+			record.Status = "skipped"
+			continue
+		}
+		if err := releaseRepository(record, message); err != nil {
+			failed = true
+			record.Status = "failed"
+			record.Error = err.Error()
+			fmt.Printf("%s %s: %v\n", display.ErrorIcon(), record.Alias, err)
+			continue
+		}
+		fmt.Printf("%s %s: %s at %s\n", display.SuccessIcon(), record.Alias, record.Status, record.Commit)
+	}
+
+	fmt.Println()
+	if err := cmdutils.Render(records, func() error {
+		printReleaseSummary(records)
+		return nil
+	}); err != nil {
+		return err
+	}
+	if failed {
+		return fmt.Errorf("release of %s failed", releaseVersion)
+	}
+	return nil
+}
+
+// verifyReleasable returns why a repository cannot be released, or ""
+func verifyReleasable(status types.RepoStatus) string {
+	gitMgr := di.GitManager()
+	switch {
+	case status.Error != nil:
+		return status.Error.Error()
+	case status.Branch == "HEAD":
+		return "HEAD is detached"
+	case status.SyncStatus.SyncError != nil:
+		return status.SyncStatus.SyncError.Error()
+	case status.SyncStatus.Behind > 0:
+		return fmt.Sprintf("%d commits behind the remote", status.SyncStatus.Behind)
+	case gitMgr.RefExists(status.Path, "refs/tags/"+releaseVersion):
+		return fmt.Sprintf("tag %s already exists", releaseVersion)
+	}
+	if dirty, err := gitMgr.HasUncommittedChanges(status.Path); err != nil {
+		return err.Error()
+	} else if dirty {
+		return "uncommitted changes"
+	}
+	return ""
+}
+
+// releaseRepository bumps, tags and pushes one repository
+func releaseRepository(record *releaseRecord, message string) error {
+	gitMgr := di.GitManager()
+	if record.command != "" {
+		if err := runVersionCommand(record); err != nil {
+			return err
+		}
+		dirty, err := gitMgr.HasUncommittedChanges(record.path)
+		if err != nil {
+			return err
+		}
+		if dirty {
+			if err := gitMgr.CommitChanges(record.path, message, true); err != nil {
+				return fmt.Errorf("failed to commit version files: %w", err)
+			}
+			record.Bumped = true
+		}
+	}
+
+	if _, err := gitMgr.RunCommand(record.path, "tag", "-a", releaseVersion, "-m", message); err != nil {
+		return fmt.Errorf("failed to create tag: %w", err)
+	}
+	commit, err := gitMgr.RunCommand(record.path, "rev-parse", "--short", "HEAD")
+	if err != nil {
+		return fmt.Errorf("failed to read HEAD: %w", err)
+	}
+	record.Commit = commit
+	record.Status = "tagged"
+	if releaseNoPush {
+		return nil
+	}
+
+	if _, err := gitMgr.RunCommand(record.path, "push", "origin", record.Branch, "refs/tags/"+releaseVersion); err != nil {
+		return fmt.Errorf("failed to push: %w", err)
+	}
+	record.Pushed = true
+	record.Status = "released"
+	return nil
+}
+
+// runVersionCommand runs the repository's version_command through the
+// shell, with {version}, {alias} and {path} replaced by quoted values
+func runVersionCommand(record *releaseRecord) error {
+	script := strings.NewReplacer(
+		"{version}", shellQuote(releaseVersion),
+		"{alias}", shellQuote(record.Alias),
+		"{path}", shellQuote(record.path),
+	).Replace(record.command)
+
+	command := exec.Command("sh", "-c", script)
+	command.Dir = record.path
+	command.Env = append(os.Environ(), "GMAN_RELEASE_VERSION="+releaseVersion)
+	output, err := command.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("version command failed: %w\n%s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// releaseOrder sorts the aliases so that every repository comes after the
+// repositories it depends on. Dependencies outside aliases are ignored;
+// independent repositories keep alphabetical order.
+func releaseOrder(aliases []string, dependencies map[string][]string) ([]string, error) {
+	pending := make(map[string]int)
+	dependents := make(map[string][]string)
+	for _, alias := range aliases {
+		pending[alias] = 0
+	}
+	for _, alias := range aliases {
+		for _, dependency := range dependencies[alias] {
+			if _, selected := pending[dependency]; selected && dependency != alias {
+				pending[alias]++
+				dependents[dependency] = append(dependents[dependency], alias)
+			}
+		}
+	}
+
+	var ready, order []string
+	for _, alias := range aliases {
+		if pending[alias] == 0 {
+			ready = append(ready, alias)
+		}
+	}
+	for len(ready) > 0 {
+		slices.Sort(ready)
+		alias := ready[0]
+		ready = ready[1:]
+		order = append(order, alias)
+		for _, dependent := range dependents[alias] {
+			if pending[dependent]--; pending[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	if len(order) < len(aliases) {
+		var cycle []string
+		for _, alias := range aliases {
+			if pending[alias] > 0 {
+				cycle = append(cycle, alias)
+			}
+		}
+		return nil, fmt.Errorf("circular depends_on between repositories: %s", strings.Join(cycle, ", "))
+	}
+	return order, nil
+}
+
+// printReleasePlan prints the verified repositories in release order
+func printReleasePlan(records []releaseRecord) {
+	fmt.Printf("Release %s:\n", releaseVersion)
+	for i, record := range records {
+		if record.Error != "" {
+			fmt.Printf("  %d. %s %s: %s\n", i+1, display.ErrorIcon(), record.Alias, record.Error)
+			continue
+		}
+		steps := []string{"tag " + record.Branch}
+		if record.command != "" {
+			steps = append([]string{"run " + record.command}, steps...)
+		}
+		if !releaseNoPush {
+			steps = append(steps, "push")
+		}
+		fmt.Printf("  %d. %s %s: %s\n", i+1, display.SuccessIcon(), record.Alias, strings.Join(steps, ", "))
+	}
+}
+
+// printReleaseSummary prints the consolidated outcome of the release
+func printReleaseSummary(records []releaseRecord) {
+	maxAlias := len("Repository")
+	for _, record := range records {
+		maxAlias = max(maxAlias, len(record.Alias))
+	}
+	fmt.Printf("%-*s %-20s %-9s %-7s %s\n", maxAlias, "Repository", "Branch", "Commit", "Bumped", "Status")
+	var released int
+	for _, record := range records {
+		status := record.Status
+		switch record.Status {
+		case "released", "tagged":
+			released++
+			status = color.GreenString(status)
+		case "failed":
+			status = color.RedString(status)
+		default:
+			status = color.YellowString(status)
+		}
+		bumped := "no"
+		if record.Bumped {
+			bumped = "yes"
+		}
+		fmt.Printf("%-*s %-20s %-9s %-7s %s\n", maxAlias, record.Alias, record.Branch, record.Commit, bumped, status)
+	}
+	fmt.Printf("\n%s %d of %d repositories\n", releaseVersion, released, len(records))
+}
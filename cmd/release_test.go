@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestReleaseOrder(t *testing.T) {
+	aliases := []string{"api", "shared", "web", "worker"}
+	dependencies := map[string][]string{
+		"api":    {"shared"},
+		"web":    {"api", "shared"},
+		"worker": {"api", "unselected"},
+	}
+	order, err := releaseOrder(aliases, dependencies)
+	if err != nil {
+		t.Fatalf("releaseOrder failed: %v", err)
+	}
+	want := []string{"shared", "api", "web", "worker"}
+	if !slices.Equal(order, want) {
+		t.Errorf("releaseOrder = %v, want %v", order, want)
+	}
+
+	dependencies["shared"] = []string{"web"}
+	if _, err := releaseOrder(aliases, dependencies); err == nil {
+		t.Error("expected an error for circular dependencies")
+	}
+}
@@ -5,6 +5,8 @@ import (
 
 	"gman/internal/di"
 	"gman/internal/display"
+	"gman/internal/errors"
+	"gman/internal/hooks"
 
 	"github.com/spf13/cobra"
 )
@@ -53,7 +55,7 @@ func runRemove(cmd *cobra.Command, args []string) error {
 	cfg := configMgr.GetConfig()
 	path, exists := cfg.Repositories[alias]
 	if !exists {
-		return fmt.Errorf("repository '%s' not found", alias)
+		return errors.NotFoundError("repository", alias)
 	}
 
 	// Remove repository
@@ -62,5 +64,7 @@ func runRemove(cmd *cobra.Command, args []string) error {
 	}
 
 	display.PrintSuccess(fmt.Sprintf("Removed repository: %s (%s)", alias, path))
+	runHooks(hooks.RepoRemoved, hooks.Repository{Alias: alias, Path: path})
+	refreshCodeWorkspaces()
 	return nil
 }
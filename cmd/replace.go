@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	cmdutils "gman/internal/cmd"
+	"gman/internal/display"
+	"gman/internal/external"
+	"gman/internal/interactive"
+	"gman/internal/replace"
+	"gman/internal/repository"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	replaceGroup   string
+	replaceDryRun  bool
+	replaceYes     bool
+	replaceMessage string
+)
+
+// replaceCmd represents the replace command
+var replaceCmd = &cobra.Command{
+	Use:   "replace <pattern> <replacement>",
+	Short: "Regex search-and-replace across repositories",
+	Long: `Replace a regular expression in the tracked files of all managed repositories
+(or one group) and show a unified diff per repository before writing.
+
+The pattern uses Go regexp syntax and is applied line by line. The replacement
+may reference capture groups as $1 or ${name}. Binary files and paths excluded
+under 'search' in the configuration are skipped.
+
+With --commit, every changed repository gets a commit of just the changed
+files with the given message.
+
+Examples:
+  gman replace 'oldpkg\.Client' 'newpkg.Client' --dry-run
+  gman replace 'v1\.4\.\d+' 'v1.5.0' --group backend
+  gman replace 'Copyright 2023' 'Copyright 2024' --yes --commit "Update copyright year"`,
+	Args: cobra.ExactArgs(2),
+	RunE: runReplace,
+}
+
+func init() {
+	rootCmd.AddCommand(replaceCmd)
+
+	replaceCmd.Flags().StringVar(&replaceGroup, "group", "", "Only replace in repositories of this group")
+	replaceCmd.Flags().BoolVar(&replaceDryRun, "dry-run", false, "Show the diff without changing any file")
+	replaceCmd.Flags().BoolVarP(&replaceYes, "yes", "y", false, "Apply without asking for confirmation")
+	replaceCmd.Flags().StringVarP(&replaceMessage, "commit", "m", "", "Commit the changed files in each repository with this message")
+}
+
+// repoReplacement holds the planned changes of one repository
+type repoReplacement struct {
+	alias   string
+	path    string
+	changes []replace.FileChange
+}
+
+func runReplace(cmd *cobra.Command, args []string) error {
+	pattern, err := regexp.Compile(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid pattern: %w", err)
+	}
+	replacement := args[1]
+
+	mgrs := cmdutils.GetManagers()
+	cfg := mgrs.Config.GetConfig()
+
+	filter := repository.NewFilter(mgrs.Config)
+	repositories, err := filter.FilterByGroup(cfg.Repositories, replaceGroup)
+	if err != nil {
+		return fmt.Errorf("failed to filter repositories: %w", err)
+	}
+
+	// Plan every repository before touching anything
+	var planned []repoReplacement
+	var totalFiles, totalReplacements int
+	for _, alias := range sortedAliases(repositories) {
+		path := repositories[alias]
+		searchFilter := external.SearchFilterFor(alias)
+		changes, err := replace.Plan(mgrs.Git, path, pattern, replacement, searchFilter.IsExcluded)
+		if err != nil {
+			fmt.Printf("%s %s: %v\n", display.ErrorIcon(), alias, err)
+			continue
+		}
+		if len(changes) == 0 {
+			continue
+		}
+
+		planned = append(planned, repoReplacement{alias: alias, path: path, changes: changes})
+		totalFiles += len(changes)
+		for _, change := range changes {
+			totalReplacements += change.Replacements
+		}
+	}
+
+	if len(planned) == 0 {
+		fmt.Printf("%s No matches for '%s'\n", display.WarningIcon(), args[0])
+		return nil
+	}
+
+	for _, repo := range planned {
+		fmt.Printf("%s %s\n", color.CyanString("📁 %s", repo.alias), color.HiBlackString(repo.path))
+		for _, change := range repo.changes {
+			printColoredDiff(change.Diff)
+		}
+		fmt.Println()
+	}
+
+	summary := fmt.Sprintf("%d replacements in %d files across %d repositories", totalReplacements, totalFiles, len(planned))
+	if replaceDryRun {
+		fmt.Printf("Dry run: %s. No files were changed.\n", summary)
+		return nil
+	}
+
+	if !replaceYes {
+		if interactive.NonInteractive() {
+			return interactive.ErrUnavailable("confirming the replacement", "pass --yes to apply or --dry-run to preview")
+		}
+		fmt.Printf("Apply %s? [y/N]: ", summary)
+		if !askConfirmation(false) {
+			fmt.Println("Replacement cancelled.")
+			return nil
+		}
+	}
+
+	if replaceMessage != "" {
+		// Commits can be undone with gman undo
+		recorder := newUndoRecorder("replace --commit")
+		for _, repo := range planned {
+			recorder.Before(repo.alias, repo.path)
+		}
+		defer saveUndoRecord(recorder)
+	}
+
+	var failed int
+	for _, repo := range planned {
+		if err := applyRepoReplacement(mgrs, repo); err != nil {
+			failed++
+			fmt.Printf("%s %s: %v\n", display.ErrorIcon(), repo.alias, err)
+			continue
+		}
+		if replaceMessage != "" {
+			fmt.Printf("%s %s: %d files changed and committed\n", display.SuccessIcon(), repo.alias, len(repo.changes))
+		} else {
+			fmt.Printf("%s %s: %d files changed\n", display.SuccessIcon(), repo.alias, len(repo.changes))
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("replacement failed in %d repositories", failed)
+	}
+	return nil
+}
+
+// applyRepoReplacement writes the planned files of a repository and commits
+// them when a commit message was given
+func applyRepoReplacement(mgrs *cmdutils.Managers, repo repoReplacement) error {
+	files := make([]string, 0, len(repo.changes))
+	for _, change := range repo.changes {
+		if err := change.Apply(); err != nil {
+			return err
+		}
+		files = append(files, change.RelPath)
+	}
+
+	if replaceMessage == "" {
+		return nil
+	}
+	// Only the replaced files: changes the user staged stay staged
+	return mgrs.Git.CommitPaths(repo.path, replaceMessage, files)
+}
+
+// printColoredDiff prints a unified diff with the usual colors
+func printColoredDiff(diff string) {
+	for _, line := range strings.Split(strings.TrimSuffix(diff, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "---"), strings.HasPrefix(line, "+++"):
+			fmt.Println(color.New(color.Bold).Sprint(line))
+		case strings.HasPrefix(line, "@@"):
+			fmt.Println(color.CyanString(line))
+		case strings.HasPrefix(line, "+"):
+			fmt.Println(color.GreenString(line))
+		case strings.HasPrefix(line, "-"):
+			fmt.Println(color.RedString(line))
+		default:
+			fmt.Println(line)
+		}
+	}
+}
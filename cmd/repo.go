@@ -33,5 +33,11 @@ func init() {
 	repoCmd.AddCommand(listCmd)   // from cmd/list.go
 	repoCmd.AddCommand(groupCmd)  // from cmd/group.go
 
+	// Protection against destructive git commands, from cmd/protect.go
+	repoCmd.AddCommand(protectCmd)
+	repoCmd.AddCommand(unprotectCmd)
+
+	repoCmd.AddCommand(repoMoveCmd) // from cmd/move.go
+
 	// No need for copyCommandFlags as we're using original commands with their flags intact
 }
@@ -0,0 +1,244 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"gman/internal/di"
+	"gman/internal/display"
+	"gman/internal/git"
+	"gman/internal/pager"
+	"gman/pkg/types"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	reportGroup     string
+	reportOut       string
+	reportDays      int
+	reportStaleDays int
+)
+
+// reportCmd represents the report command
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Generate a Markdown report of all repositories",
+	Long: `Generate a Markdown summary of the managed repositories (or the repositories
+of one group) for team wikis and weekly updates. The report contains:
+
+- the status of every repository: branch, workspace, sync state, last commit
+- repositories whose fetch failed
+- commits of the last --days days
+- local branches without commits for --stale-days days
+
+Remotes are fetched first, like 'gman work status'.
+
+Examples:
+  gman report                              # Print the report
+  gman report --out report.md              # Write it to a file
+  gman report --group backend --days 14    # Two weeks of one group`,
+	Args: cobra.NoArgs,
+	RunE: runReport,
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+
+	reportCmd.Flags().StringVar(&reportGroup, "group", "", "Only report on repositories of this group")
+	reportCmd.Flags().StringVar(&reportOut, "out", "", "Write the report to this file instead of stdout")
+	reportCmd.Flags().IntVar(&reportDays, "days", 7, "Include commits of this many days")
+	reportCmd.Flags().IntVar(&reportStaleDays, "stale-days", 30, "Report local branches without commits for this many days")
+
+	pager.Enable(reportCmd)
+}
+
+// reportRepository is the report data of one repository
+type reportRepository struct {
+	Status        types.RepoStatus
+	Commits       []git.CommitSummary
+	StaleBranches []git.BranchActivity
+}
+
+// fleetReport is the data rendered by renderReport
+type fleetReport struct {
+	Generated    time.Time
+	Group        string
+	Days         int
+	StaleDays    int
+	Repositories []reportRepository
+}
+
+func runReport(cmd *cobra.Command, args []string) error {
+	repositories, err := execRepositories(reportGroup)
+	if err != nil {
+		return err
+	}
+	if reportDays <= 0 || reportStaleDays <= 0 {
+		return fmt.Errorf("--days and --stale-days must be positive")
+	}
+
+	gitMgr := di.GitManager()
+	statuses, err := gitMgr.GetAllRepoStatus(repositories)
+	if err != nil {
+		return fmt.Errorf("failed to get repository status: %w", err)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Alias < statuses[j].Alias
+	})
+
+	now := time.Now()
+	report := fleetReport{
+		Generated: now,
+		Group:     reportGroup,
+		Days:      reportDays,
+		StaleDays: reportStaleDays,
+	}
+	for _, status := range statuses {
+		entry := reportRepository{Status: status}
+		if status.Error == nil {
+			// Repositories without commits have neither; the status already says so
+			entry.Commits, _ = gitMgr.RecentCommits(status.Path, now.AddDate(0, 0, -reportDays), 20)
+			if branches, err := gitMgr.LocalBranchActivity(status.Path); err == nil {
+				entry.StaleBranches = staleBranches(branches, now.AddDate(0, 0, -reportStaleDays))
+			}
+		}
+		report.Repositories = append(report.Repositories, entry)
+	}
+
+	var buf bytes.Buffer
+	renderReport(&buf, report)
+
+	if reportOut == "" {
+		_, err := os.Stdout.Write(buf.Bytes())
+		return err
+	}
+	if err := os.WriteFile(reportOut, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	fmt.Printf("%s Report for %d repositories written to %s\n", display.SuccessIcon(), len(statuses), reportOut)
+	return nil
+}
+
+// staleBranches returns the branches without commits since cutoff, oldest first
+func staleBranches(branches []git.BranchActivity, cutoff time.Time) []git.BranchActivity {
+	var stale []git.BranchActivity
+	for _, branch := range branches {
+		if branch.LastCommit.Before(cutoff) {
+			stale = append(stale, branch)
+		}
+	}
+	sort.Slice(stale, func(i, j int) bool {
+		return stale[i].LastCommit.Before(stale[j].LastCommit)
+	})
+	return stale
+}
+
+// renderReport writes the report as Markdown
+func renderReport(w io.Writer, report fleetReport) {
+	scope := "all repositories"
+	if report.Group != "" {
+		scope = fmt.Sprintf("group `%s`", report.Group)
+	}
+	fmt.Fprintf(w, "# Repository report\n\n")
+	fmt.Fprintf(w, "Generated %s for %s (%d repositories).\n\n",
+		report.Generated.Format("2006-01-02 15:04"), scope, len(report.Repositories))
+
+	var dirty, behind, ahead, failing int
+	for _, repo := range report.Repositories {
+		if repo.Status.Workspace == types.Dirty {
+			dirty++
+		}
+		if repo.Status.SyncStatus.Behind > 0 {
+			behind++
+		}
+		if repo.Status.SyncStatus.Ahead > 0 {
+			ahead++
+		}
+		if repo.Status.Error != nil || repo.Status.SyncStatus.SyncError != nil {
+			failing++
+		}
+	}
+	fmt.Fprintf(w, "## Summary\n\n")
+	fmt.Fprintf(w, "| Repositories | Dirty | Behind | Ahead | Failing |\n")
+	fmt.Fprintf(w, "|---|---|---|---|---|\n")
+	fmt.Fprintf(w, "| %d | %d | %d | %d | %d |\n\n", len(report.Repositories), dirty, behind, ahead, failing)
+
+	fmt.Fprintf(w, "## Status\n\n")
+	fmt.Fprintf(w, "| Repository | Branch | Workspace | Sync | Last commit |\n")
+	fmt.Fprintf(w, "|---|---|---|---|---|\n")
+	for _, repo := range report.Repositories {
+		status := repo.Status
+		if status.Error != nil {
+			fmt.Fprintf(w, "| %s | | | error | |\n", markdownCell(status.Alias))
+			continue
+		}
+		lastCommit := status.LastCommit
+		if !status.CommitTime.IsZero() {
+			lastCommit += " (" + status.CommitTime.Format("2006-01-02") + ")"
+		}
+		fmt.Fprintf(w, "| %s | %s | %s | %s | %s |\n",
+			markdownCell(status.Alias),
+			markdownCell(status.Branch),
+			strings.ToLower(status.Workspace.Label()),
+			strings.ToLower(status.SyncStatus.Label()),
+			markdownCell(lastCommit))
+	}
+	fmt.Fprintln(w)
+
+	if failing > 0 {
+		fmt.Fprintf(w, "## Failing syncs\n\n")
+		for _, repo := range report.Repositories {
+			if repo.Status.Error != nil {
+				fmt.Fprintf(w, "- **%s**: %s\n", repo.Status.Alias, repo.Status.Error)
+			} else if repo.Status.SyncStatus.SyncError != nil {
+				fmt.Fprintf(w, "- **%s**: %s\n", repo.Status.Alias, repo.Status.SyncStatus.SyncError)
+			}
+		}
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintf(w, "## Recent commits (last %d days)\n\n", report.Days)
+	anyCommits := false
+	for _, repo := range report.Repositories {
+		if len(repo.Commits) == 0 {
+			continue
+		}
+		anyCommits = true
+		fmt.Fprintf(w, "### %s\n\n", repo.Status.Alias)
+		for _, commit := range repo.Commits {
+			fmt.Fprintf(w, "- `%s` %s (%s, %s)\n", commit.Hash, commit.Subject, commit.Author, commit.Time.Format("2006-01-02"))
+		}
+		fmt.Fprintln(w)
+	}
+	if !anyCommits {
+		fmt.Fprintf(w, "No commits.\n\n")
+	}
+
+	fmt.Fprintf(w, "## Stale branches (no commits for %d days)\n\n", report.StaleDays)
+	anyStale := false
+	for _, repo := range report.Repositories {
+		for _, branch := range repo.StaleBranches {
+			if !anyStale {
+				fmt.Fprintf(w, "| Repository | Branch | Last commit |\n")
+				fmt.Fprintf(w, "|---|---|---|\n")
+				anyStale = true
+			}
+			fmt.Fprintf(w, "| %s | %s | %s |\n",
+				markdownCell(repo.Status.Alias), markdownCell(branch.Name), branch.LastCommit.Format("2006-01-02"))
+		}
+	}
+	if !anyStale {
+		fmt.Fprintf(w, "No stale branches.\n")
+	}
+}
+
+// markdownCell escapes text for a Markdown table cell
+func markdownCell(s string) string {
+	return strings.ReplaceAll(s, "|", "\\|")
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"gman/internal/git"
+	"gman/pkg/types"
+)
+
+// TestRenderReport tests the Markdown sections of the repository report
+func TestRenderReport(t *testing.T) {
+	now := time.Date(2024, 5, 10, 9, 0, 0, 0, time.UTC)
+	report := fleetReport{
+		Generated: now,
+		Group:     "backend",
+		Days:      7,
+		StaleDays: 30,
+		Repositories: []reportRepository{
+			{
+				Status: types.RepoStatus{
+					Alias:      "api",
+					Branch:     "feature|x",
+					Workspace:  types.Dirty,
+					SyncStatus: types.SyncStatus{Behind: 2},
+					LastCommit: "abc1234 Fix login",
+				},
+				Commits: []git.CommitSummary{{Hash: "abc1234", Author: "Alice", Subject: "Fix login", Time: now}},
+				StaleBranches: []git.BranchActivity{
+					{Name: "old-spike", LastCommit: now.AddDate(0, -3, 0)},
+				},
+			},
+			{
+				Status: types.RepoStatus{
+					Alias:      "web",
+					Branch:     "main",
+					SyncStatus: types.SyncStatus{SyncError: fmt.Errorf("could not resolve host")},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	renderReport(&buf, report)
+	output := buf.String()
+
+	expected := []string{
+		"for group `backend` (2 repositories)",
+		"| 2 | 1 | 1 | 0 | 1 |",
+		"| api | feature\\|x | dirty | 2 behind | abc1234 Fix login |",
+		"- **web**: could not resolve host",
+		"- `abc1234` Fix login (Alice, 2024-05-10)",
+		"| api | old-spike | 2024-02-10 |",
+	}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected report to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+// TestStaleBranches tests the stale branch cutoff and ordering
+func TestStaleBranches(t *testing.T) {
+	now := time.Now()
+	branches := []git.BranchActivity{
+		{Name: "fresh", LastCommit: now},
+		{Name: "old", LastCommit: now.AddDate(0, -2, 0)},
+		{Name: "older", LastCommit: now.AddDate(-1, 0, 0)},
+	}
+
+	stale := staleBranches(branches, now.AddDate(0, 0, -30))
+	if len(stale) != 2 || stale[0].Name != "older" || stale[1].Name != "old" {
+		t.Errorf("Expected [older old], got %+v", stale)
+	}
+}
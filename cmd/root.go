@@ -2,15 +2,39 @@ package cmd
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	cmdutils "gman/internal/cmd"
+	"gman/internal/config"
 	"gman/internal/di"
+	"gman/internal/display"
+	"gman/internal/errors"
+	"gman/internal/git"
+	"gman/internal/interactive"
+	"gman/internal/logging"
+	"gman/internal/pager"
 )
 
-var cfgFile string
+var (
+	cfgFile        string
+	noColor        bool
+	asciiOut       bool
+	nonInteractive bool
+	outputFlag     string
+	errorFormat    string
+	debugLog       bool
+	noPager        bool
+	fetchTimeout   time.Duration
+	safeMode       bool
+	allowDestruct  bool
+)
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
@@ -55,12 +79,85 @@ leaving precise write operations to you and native Git commands for maximum safe
 
 💡 TIP: Use 'gman <group> --help' to see all commands in each group.`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Prompts fail fast or use their defaults; also implied when stdin is not a terminal
+		interactive.SetNonInteractive(nonInteractive)
+
+		// Diagnostics go through slog; the log file is added once settings are known
+		logging.Setup(debugLog, "")
+
+		// Commands supporting machine-readable output render through cmdutils.Render
+		if err := cmdutils.SetOutputFormat(outputFlag); err != nil {
+			return err
+		}
+
+		// With --error-format json a failure is reported as one JSON object on
+		// stderr, so cobra must not print the error and usage as text first
+		if err := errors.SetErrorFormat(errorFormat); err != nil {
+			return err
+		}
+		if errors.ErrorFormat() == errors.ErrorFormatJSON {
+			cmd.Root().SilenceErrors = true
+			cmd.Root().SilenceUsage = true
+		}
+
 		// Load configuration for all commands that need it
 		// This is done globally to avoid duplication across commands
 		configMgr := di.ConfigManager()
+		configMgr.SetConflictHandler(resolveConfigConflict)
 		if err := configMgr.Load(); err != nil {
 			return fmt.Errorf("failed to load configuration: %w", err)
 		}
+
+		// Accessible rendering: flags, settings.accessible, settings.emoji,
+		// NO_COLOR and dumb terminals
+		settings := configMgr.GetConfig().Settings
+		dumbTerminal := os.Getenv("TERM") == "dumb"
+		display.ConfigureAccessibility(
+			noColor || settings.Accessible || os.Getenv("NO_COLOR") != "" || dumbTerminal,
+			asciiOut || settings.Accessible || !settings.EmojiEnabled() || dumbTerminal,
+		)
+
+		// Keep every log message in a file when requested via config or environment
+		if logPath := logFilePath(settings.LogFile); logPath != "" {
+			if err := logging.Setup(debugLog, logPath); err != nil {
+				slog.Warn("logging to file disabled", "error", err)
+			}
+		}
+
+		// Enable the git command log when requested via config or environment
+		if settings.GitCommandLog || os.Getenv("GMAN_GIT_LOG") == "1" {
+			logPath := filepath.Join(configMgr.GetConfigDir(), git.CommandLogFile)
+			di.GitManager().SetCommandLog(git.NewCommandLog(logPath))
+		}
+
+		// Audit state-changing git commands, and which gman command ran them
+		if settings.AuditLog || os.Getenv("GMAN_AUDIT_LOG") == "1" {
+			auditPath := filepath.Join(configMgr.GetConfigDir(), git.AuditLogFile)
+			di.GitManager().SetAuditLog(git.NewAuditLog(auditPath, cmd.CommandPath()))
+		}
+
+		// Git commands are killed after settings.git_timeout(s); status
+		// fetches after --fetch-timeout when given
+		if defaultTimeout, overrides, err := settings.CommandTimeouts(); err == nil {
+			di.GitManager().SetTimeouts(defaultTimeout, overrides)
+		}
+		di.GitManager().SetFetchTimeout(fetchTimeout)
+
+		// Protected repositories, and all of them with --safe, refuse
+		// destructive git commands unless --allow-destructive is given
+		cfg := configMgr.GetConfig()
+		protection := git.Protection{SafeMode: safeMode || settings.SafeMode, Override: allowDestruct}
+		for alias, path := range cfg.Repositories {
+			if cfg.IsProtected(alias) {
+				protection.Protected = append(protection.Protected, path)
+			}
+		}
+		di.GitManager().SetProtection(protection)
+
+		// Long output goes through $PAGER when stdout is a terminal
+		if pager.Enabled(cmd) && !noPager {
+			pager.Start()
+		}
 		return nil
 	},
 }
@@ -68,9 +165,41 @@ leaving precise write operations to you and native Git commands for maximum safe
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() error {
+	// Unknown commands run the plugin gman-<name>, if there is one
+	if ran, code := runPlugin(os.Args[1:]); ran {
+		os.Exit(code)
+	}
+
+	defer logging.Close()
+	defer pager.Stop()
 	return rootCmd.Execute()
 }
 
+// resolveConfigConflict asks whether to overwrite the configuration file
+// when another gman process changed the same settings since it was loaded.
+// The other process's changes are kept unless the user says otherwise.
+func resolveConfigConflict(path string, keys []string) config.ConflictResolution {
+	fmt.Printf("%s %s was changed by another gman process while this command ran (%s).\n",
+		display.WarningIcon(), path, strings.Join(keys, ", "))
+	fmt.Print("Overwrite it with this command's changes? [y/N]: ")
+	if askConfirmation(false) {
+		return config.ConflictOverwrite
+	}
+	return config.ConflictReload
+}
+
+// logFilePath returns the log file to write, or "" when file logging is off.
+// GMAN_LOG_FILE names a file and enables logging regardless of the setting.
+func logFilePath(enabled bool) string {
+	if path := os.Getenv("GMAN_LOG_FILE"); path != "" {
+		return path
+	}
+	if enabled {
+		return logging.DefaultPath()
+	}
+	return ""
+}
+
 func init() {
 	cobra.OnInitialize(initConfig)
 
@@ -78,6 +207,16 @@ func init() {
 	// Cobra supports persistent flags, which, if defined here,
 	// will be global for your application.
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.config/gman/config.yml)")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output (also honors NO_COLOR)")
+	rootCmd.PersistentFlags().BoolVar(&asciiOut, "ascii", false, "Use plain text labels instead of emoji and disable progress animations")
+	rootCmd.PersistentFlags().StringVarP(&outputFlag, "output", "o", cmdutils.OutputTable, "Output format for list, status, group list and sync: table, json, yaml, csv or tsv")
+	rootCmd.PersistentFlags().StringVar(&errorFormat, "error-format", errors.ErrorFormatText, "Format of the error a failed command reports on stderr: text or json")
+	rootCmd.PersistentFlags().BoolVar(&debugLog, "debug", false, "Print debug diagnostics to stderr")
+	rootCmd.PersistentFlags().BoolVar(&noPager, "no-pager", false, "Do not pipe long output through $PAGER (GMAN_PAGER=cat does the same)")
+	rootCmd.PersistentFlags().DurationVar(&fetchTimeout, "fetch-timeout", 0, "Time limit of each remote fetch made by status commands (default: git_timeouts.fetch, git_timeout or 30s)")
+	rootCmd.PersistentFlags().BoolVar(&safeMode, "safe", false, "Refuse destructive git commands (force push, branch -D, stash clear, ...) in every repository")
+	rootCmd.PersistentFlags().BoolVar(&allowDestruct, "allow-destructive", false, "Run destructive git commands even in protected repositories or with --safe")
+	rootCmd.PersistentFlags().BoolVar(&nonInteractive, "non-interactive", false, "Never prompt: selections fail fast and confirmations use their defaults (implied when stdin is not a terminal)")
 
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
@@ -8,6 +8,8 @@ import (
 	"strings"
 
 	"gman/internal/di"
+	"gman/internal/hooks"
+	"gman/internal/interactive"
 
 	"github.com/spf13/cobra"
 )
@@ -117,9 +119,14 @@ func runSetupStep1() error {
 
 	// Ask for discovery path
 	fmt.Print("Enter path to search for repositories (default: current directory): ")
-	reader := bufio.NewReader(os.Stdin)
-	pathInput, _ := reader.ReadString('\n')
-	pathInput = strings.TrimSpace(pathInput)
+	var pathInput string
+	if interactive.NonInteractive() {
+		interactive.NoteDefault("current directory")
+	} else {
+		reader := bufio.NewReader(os.Stdin)
+		pathInput, _ = reader.ReadString('\n')
+		pathInput = strings.TrimSpace(pathInput)
+	}
 
 	if pathInput == "" {
 		wd, _ := os.Getwd()
@@ -351,11 +358,13 @@ func runSetupDiscover(cmd *cobra.Command, args []string) error {
 
 	cfg := configMgr.GetConfig()
 	added := 0
+	var addedRepos []hooks.Repository
 
 	for alias, path := range selectedRepos {
 		if _, exists := cfg.Repositories[alias]; !exists {
 			cfg.Repositories[alias] = path
 			added++
+			addedRepos = append(addedRepos, hooks.Repository{Alias: alias, Path: path})
 		} else {
 			fmt.Printf("⚠️  Repository '%s' already exists, skipping.\n", alias)
 		}
@@ -366,6 +375,8 @@ func runSetupDiscover(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("failed to save configuration: %w", err)
 		}
 		fmt.Printf("✅ Added %d new repositories to gman.\n", added)
+		runHooks(hooks.RepoAdded, addedRepos...)
+		refreshCodeWorkspaces()
 	} else {
 		fmt.Println("No new repositories were added.")
 	}
@@ -484,6 +495,10 @@ func selectRepositories(repos map[string]string) map[string]string {
 
 	fmt.Println()
 	fmt.Print("Selection (default: all): ")
+	if interactive.NonInteractive() {
+		interactive.NoteDefault("all")
+		return repos
+	}
 
 	reader := bufio.NewReader(os.Stdin)
 	input, _ := reader.ReadString('\n')
@@ -515,6 +530,15 @@ func selectRepositories(repos map[string]string) map[string]string {
 
 // askConfirmation asks for user confirmation
 func askConfirmation(defaultYes bool) bool {
+	if interactive.NonInteractive() {
+		if defaultYes {
+			interactive.NoteDefault("yes")
+		} else {
+			interactive.NoteDefault("no")
+		}
+		return defaultYes
+	}
+
 	reader := bufio.NewReader(os.Stdin)
 	input, _ := reader.ReadString('\n')
 	input = strings.TrimSpace(strings.ToLower(input))
@@ -528,6 +552,11 @@ func askConfirmation(defaultYes bool) bool {
 
 // askChoice asks user to choose from a list of options
 func askChoice(options []string, defaultChoice int) string {
+	if interactive.NonInteractive() {
+		interactive.NoteDefault(options[defaultChoice-1])
+		return options[defaultChoice-1]
+	}
+
 	reader := bufio.NewReader(os.Stdin)
 	input, _ := reader.ReadString('\n')
 	input = strings.TrimSpace(input)
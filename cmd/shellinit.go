@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gman/internal/cache"
+	"gman/internal/di"
+
+	"github.com/spf13/cobra"
+)
+
+var shellInitNoHook bool
+
+// cdHookVisitDebounce keeps the cd-hook from counting a visit that
+// 'gman switch' has just recorded
+const cdHookVisitDebounce = 5 * time.Second
+
+// shellInitCmd represents the shell-init command
+var shellInitCmd = &cobra.Command{
+	Use:   "shell-init <bash|zsh|fish>",
+	Short: "Print the shell integration for eval in an rc file",
+	Long: `Print the complete gman shell integration for the given shell: the wrapper
+function that lets 'gman switch' change directories, the GMAN_SHELL_INTEGRATION
+export, command completion and a cd-hook.
+
+The cd-hook runs whenever the working directory changes and exports GMAN_REPO
+with the alias of the managed repository you are in (empty outside of one),
+which is handy for prompts. Entering a repository also counts as a visit for
+the frecency ranking of 'gman switch'. Use --no-hook to leave it out.
+
+Add one line to your rc file instead of pasting the wrapper by hand:
+  bash:  eval "$(gman shell-init bash)"          # ~/.bashrc
+  zsh:   eval "$(gman shell-init zsh)"           # ~/.zshrc
+  fish:  gman shell-init fish | source           # ~/.config/fish/config.fish`,
+	ValidArgs: []string{"bash", "zsh", "fish"},
+	Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fmt.Print(shellInitScript(args[0], !shellInitNoHook))
+		return nil
+	},
+}
+
+// internalCdHookCmd is called by the shell-init cd-hook to resolve the
+// repository alias of the current directory and count the visit for frecency
+var internalCdHookCmd = &cobra.Command{
+	Use:    "internal-cd-hook <dir>",
+	Short:  "Internal command used by the shell-init cd-hook (not for direct use)",
+	Hidden: true,
+	Args:   cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		configMgr := di.ConfigManager()
+		cfg := configMgr.GetConfig()
+		alias, _ := repoForPath(cfg.Repositories, args[0])
+		if alias == "" {
+			return nil
+		}
+		fmt.Println(alias)
+
+		// Moving around inside the same repository is not a new visit, and
+		// neither is the cd right after 'gman switch' recorded one
+		if alias != os.Getenv("GMAN_REPO") {
+			frecency := loadFrecency(configMgr.GetConfigDir(), cfg.RecentUsage)
+			if time.Since(frecency.Entries[alias].LastVisit) > cdHookVisitDebounce {
+				frecency.Visit(alias, time.Now())
+				_ = frecency.Save(cache.FrecencyPath(configMgr.GetConfigDir()))
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(shellInitCmd)
+	rootCmd.AddCommand(internalCdHookCmd)
+
+	shellInitCmd.Flags().BoolVar(&shellInitNoHook, "no-hook", false, "Leave out the cd-hook that exports GMAN_REPO")
+}
+
+// repoForPath returns the managed repository containing dir. When
+// repositories are nested, the innermost one wins.
+func repoForPath(repositories map[string]string, dir string) (string, string) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", ""
+	}
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+		dir = resolved
+	}
+
+	bestAlias, bestPath := "", ""
+	for alias, path := range repositories {
+		candidate := filepath.Clean(path)
+		if resolved, err := filepath.EvalSymlinks(candidate); err == nil {
+			candidate = resolved
+		}
+		if dir != candidate && !strings.HasPrefix(dir, candidate+string(filepath.Separator)) {
+			continue
+		}
+		if len(candidate) > len(bestPath) || (len(candidate) == len(bestPath) && alias < bestAlias) {
+			bestAlias, bestPath = alias, path
+		}
+	}
+	return bestAlias, bestPath
+}
+
+// shellInitScript assembles the integration snippet for a shell
+func shellInitScript(shell string, hook bool) string {
+	var b strings.Builder
+	b.WriteString("# gman shell integration - generated by 'gman shell-init " + shell + "'\n")
+
+	switch shell {
+	case "fish":
+		b.WriteString(fishShellInit)
+		b.WriteString("command gman completion fish | source\n")
+		if hook {
+			b.WriteString(fishCdHook)
+		}
+	default: // bash/zsh
+		b.WriteString(posixShellInit)
+		fmt.Fprintf(&b, "eval \"$(command gman completion %s)\"\n", shell)
+		if hook {
+			b.WriteString(posixCdHook)
+			if shell == "zsh" {
+				b.WriteString(zshCdHookRegistration)
+			} else {
+				b.WriteString(bashCdHookRegistration)
+			}
+		}
+	}
+	return b.String()
+}
+
+const posixShellInit = `export GMAN_SHELL_INTEGRATION=1
+
+gman() {
+    if [[ "$1" == "switch" || "$1" == "sw" || "$1" == "cd" || ( "$1" == "bookmark" && "$2" == "open" ) ]]; then
+        local output gman_cd_line exit_code
+        output=$(command gman "$@" 2>&1)
+        exit_code=$?
+
+        gman_cd_line=$(echo "$output" | grep "^GMAN_CD:")
+        if [[ -n "$gman_cd_line" ]]; then
+            local target_dir="${gman_cd_line#GMAN_CD:}"
+            echo "$output" | grep -v "^GMAN_CD:" | grep -v "^$"
+            if [ -d "$target_dir" ]; then
+                cd "$target_dir" && echo "Switched to: $target_dir"
+            else
+                echo "Error: Directory not found: $target_dir" >&2
+                return 1
+            fi
+        else
+            echo "$output"
+        fi
+        return $exit_code
+    fi
+    command gman "$@"
+}
+
+`
+
+const posixCdHook = `
+__gman_cd_hook() {
+    [[ "$PWD" == "$__GMAN_LAST_PWD" ]] && return
+    __GMAN_LAST_PWD="$PWD"
+    export GMAN_REPO="$(command gman internal-cd-hook "$PWD" 2>/dev/null)"
+}
+`
+
+const bashCdHookRegistration = `if [[ ";${PROMPT_COMMAND[*]};" != *";__gman_cd_hook;"* ]]; then
+    PROMPT_COMMAND="__gman_cd_hook${PROMPT_COMMAND:+;$PROMPT_COMMAND}"
+fi
+__gman_cd_hook
+`
+
+const zshCdHookRegistration = `autoload -Uz add-zsh-hook
+add-zsh-hook chpwd __gman_cd_hook
+__gman_cd_hook
+`
+
+const fishShellInit = `set -gx GMAN_SHELL_INTEGRATION 1
+
+function gman
+    if contains -- "$argv[1]" switch sw cd; or test "$argv[1]" = bookmark -a "$argv[2]" = open
+        set -l output (command gman $argv 2>&1)
+        set -l exit_code $status
+
+        set -l gman_cd_line (string match -r '^GMAN_CD:.*' -- $output)
+        if test -n "$gman_cd_line"
+            set -l target_dir (string replace 'GMAN_CD:' '' -- $gman_cd_line)
+            string match -v -r '^GMAN_CD:|^$' -- $output
+            if test -d "$target_dir"
+                cd "$target_dir"; and echo "Switched to: $target_dir"
+            else
+                echo "Error: Directory not found: $target_dir" >&2
+                return 1
+            end
+        else
+            printf '%s\n' $output
+        end
+        return $exit_code
+    end
+    command gman $argv
+end
+
+`
+
+const fishCdHook = `
+function __gman_cd_hook --on-variable PWD
+    set -gx GMAN_REPO (command gman internal-cd-hook "$PWD" 2>/dev/null)
+end
+__gman_cd_hook
+`
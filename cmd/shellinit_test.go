@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRepoForPath(t *testing.T) {
+	root := t.TempDir()
+	outer := filepath.Join(root, "outer")
+	inner := filepath.Join(outer, "vendor", "inner")
+	sibling := filepath.Join(root, "outer-two")
+	for _, dir := range []string{inner, sibling} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	repos := map[string]string{"outer": outer, "inner": inner, "two": sibling}
+	tests := []struct {
+		dir  string
+		want string
+	}{
+		{outer, "outer"},
+		{filepath.Join(outer, "vendor"), "outer"},
+		{inner, "inner"},
+		{sibling, "two"},
+		{root, ""},
+	}
+	for _, tt := range tests {
+		if got, _ := repoForPath(repos, tt.dir); got != tt.want {
+			t.Errorf("repoForPath(%s) = %q, want %q", tt.dir, got, tt.want)
+		}
+	}
+}
+
+func TestShellInitScript(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		script := shellInitScript(shell, true)
+		for _, want := range []string{"GMAN_SHELL_INTEGRATION", "GMAN_CD:", "completion " + shell, "internal-cd-hook"} {
+			if !strings.Contains(script, want) {
+				t.Errorf("%s script is missing %q", shell, want)
+			}
+		}
+		if strings.Contains(shellInitScript(shell, false), "internal-cd-hook") {
+			t.Errorf("%s script contains the cd-hook with hook disabled", shell)
+		}
+	}
+}
@@ -0,0 +1,295 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"gman/internal/di"
+	"gman/internal/display"
+	"gman/internal/interactive"
+	"gman/internal/snapshot"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	snapshotGroup string
+	snapshotForce bool
+	snapshotYes   bool
+)
+
+// snapshotCmd represents the snapshot command group
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Save and restore the state of a set of repositories",
+	Long: `Save the checked-out branch, commit and uncommitted changes of every
+repository (or the repositories of one group) under a name, and return all
+of them to that state later. Useful for switching between multi-repository
+tasks.
+
+Examples:
+  gman snapshot save payments --group backend   # Park the current task
+  gman snapshot restore payments                # Pick it up again
+  gman snapshot list
+  gman snapshot delete payments`,
+}
+
+// snapshotSaveCmd records a snapshot
+var snapshotSaveCmd = &cobra.Command{
+	Use:   "save <name>",
+	Short: "Record the branch, commit and changes of each repository",
+	Long: `Record the checked-out branch (or commit, when detached) of each repository.
+Uncommitted changes, untracked files included, are stashed with the message
+"gman snapshot: <name>", which leaves the repositories clean for the next
+task.
+
+Examples:
+  gman snapshot save payments
+  gman snapshot save payments --group backend
+  gman snapshot save payments --force    # Replace an existing snapshot`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSnapshotSave,
+}
+
+// snapshotRestoreCmd restores a snapshot
+var snapshotRestoreCmd = &cobra.Command{
+	Use:   "restore <name>",
+	Short: "Return each repository to its recorded state",
+	Long: `Check out the recorded branch (or commit) of each repository and apply its
+stashed changes. Repositories with uncommitted changes are skipped so that no
+work is overwritten. A branch that moved since the snapshot is checked out as
+it is now, not reset.
+
+The stash entries are kept, so a snapshot can be restored more than once;
+'gman snapshot delete' drops them.
+
+Examples:
+  gman snapshot restore payments
+  gman snapshot restore payments --yes`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeSnapshotNames,
+	RunE:              runSnapshotRestore,
+}
+
+// snapshotListCmd lists snapshots
+var snapshotListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved snapshots",
+	Args:  cobra.NoArgs,
+	RunE:  runSnapshotList,
+}
+
+// snapshotDeleteCmd deletes a snapshot
+var snapshotDeleteCmd = &cobra.Command{
+	Use:               "delete <name>",
+	Short:             "Delete a snapshot and drop its stash entries",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeSnapshotNames,
+	RunE:              runSnapshotDelete,
+}
+
+func init() {
+	rootCmd.AddCommand(snapshotCmd)
+	snapshotCmd.AddCommand(snapshotSaveCmd, snapshotRestoreCmd, snapshotListCmd, snapshotDeleteCmd)
+
+	snapshotSaveCmd.Flags().StringVarP(&snapshotGroup, "group", "g", "", "Only record the repositories of this group")
+	snapshotSaveCmd.Flags().BoolVarP(&snapshotForce, "force", "f", false, "Replace an existing snapshot with the same name")
+	snapshotRestoreCmd.Flags().BoolVarP(&snapshotYes, "yes", "y", false, "Restore without confirmation")
+}
+
+func snapshotStorePath() string {
+	return snapshot.StorePath(di.ConfigManager().GetConfigDir())
+}
+
+func completeSnapshotNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) != 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	store, err := snapshot.Load(snapshotStorePath())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	names := make([]string, 0, len(store.Snapshots))
+	for _, snap := range store.Snapshots {
+		names = append(names, snap.Name)
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+func runSnapshotSave(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	repositories, err := execRepositories(snapshotGroup)
+	if err != nil {
+		return err
+	}
+
+	path := snapshotStorePath()
+	store, err := snapshot.Load(path)
+	if err != nil {
+		return err
+	}
+	old, exists := store.Get(name)
+	if exists && !snapshotForce {
+		return fmt.Errorf("snapshot '%s' already exists; use --force to replace it", name)
+	}
+
+	gitMgr := di.GitManager()
+	// Stashing would reject the name only in the first dirty repository,
+	// after earlier repositories were recorded and stashed
+	if err := gitMgr.ValidateArguments(snapshot.StashMessage(name)); err != nil {
+		return fmt.Errorf("invalid snapshot name: %w", err)
+	}
+
+	snap := snapshot.Snapshot{Name: name, Created: time.Now(), Group: snapshotGroup}
+	var failed int
+	for _, alias := range sortedAliases(repositories) {
+		repo, err := snapshot.Capture(gitMgr, name, alias, repositories[alias])
+		if err != nil {
+			failed++
+			fmt.Printf("%s %s: %v\n", display.ErrorIcon(), alias, err)
+			continue
+		}
+		snap.Repositories = append(snap.Repositories, repo)
+		state := repo.Branch
+		if state == "HEAD" {
+			state = "detached at " + repo.Head[:min(7, len(repo.Head))]
+		}
+		if repo.Stash != "" {
+			state += ", changes stashed"
+		}
+		fmt.Printf("%s %s: %s\n", display.SuccessIcon(), alias, state)
+	}
+	if len(snap.Repositories) == 0 {
+		return fmt.Errorf("no repository could be recorded")
+	}
+
+	// The stashes of the replaced snapshot are no longer reachable by name
+	if exists {
+		for _, repo := range old.Repositories {
+			if err := snapshot.Drop(gitMgr, repo); err != nil {
+				fmt.Printf("%s %s: %v\n", display.WarningIcon(), repo.Alias, err)
+			}
+		}
+	}
+	store.Put(snap)
+	if err := store.Save(path); err != nil {
+		return err
+	}
+
+	display.PrintSuccess(fmt.Sprintf("Saved snapshot '%s' of %d repositories", name, len(snap.Repositories)))
+	if failed > 0 {
+		return fmt.Errorf("failed to record %d repositories", failed)
+	}
+	return nil
+}
+
+func runSnapshotRestore(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	store, err := snapshot.Load(snapshotStorePath())
+	if err != nil {
+		return err
+	}
+	snap, ok := store.Get(name)
+	if !ok {
+		return fmt.Errorf("snapshot '%s' not found", name)
+	}
+
+	fmt.Printf("Restore snapshot '%s' from %s:\n", snap.Name, snap.Created.Format("2006-01-02 15:04"))
+	for _, repo := range snap.Repositories {
+		fmt.Printf("  %s: %s\n", repo.Alias, describeSnapshotRepository(repo))
+	}
+	if !snapshotYes {
+		if interactive.NonInteractive() {
+			return interactive.ErrUnavailable("confirming the restore", "pass --yes to restore")
+		}
+		fmt.Printf("Restore %d repositories? [y/N]: ", len(snap.Repositories))
+		if !askConfirmation(false) {
+			fmt.Println("Restore cancelled.")
+			return nil
+		}
+	}
+
+	gitMgr := di.GitManager()
+	recorder := newUndoRecorder("snapshot restore " + name)
+	defer saveUndoRecord(recorder)
+
+	var failed int
+	for _, repo := range snap.Repositories {
+		recorder.Before(repo.Alias, repo.Path)
+		note, err := snapshot.Restore(gitMgr, repo)
+		if err != nil {
+			failed++
+			fmt.Printf("%s %s: %v\n", display.ErrorIcon(), repo.Alias, err)
+			continue
+		}
+		if note != "" {
+			fmt.Printf("%s %s: restored; %s\n", display.WarningIcon(), repo.Alias, note)
+			continue
+		}
+		fmt.Printf("%s %s: restored\n", display.SuccessIcon(), repo.Alias)
+	}
+	if failed > 0 {
+		return fmt.Errorf("restore failed in %d repositories", failed)
+	}
+	return nil
+}
+
+// describeSnapshotRepository describes the recorded state of a repository
+func describeSnapshotRepository(repo snapshot.Repository) string {
+	head := repo.Head[:min(7, len(repo.Head))]
+	description := repo.Branch + " at " + head
+	if repo.Branch == "HEAD" {
+		description = "detached at " + head
+	}
+	if repo.Stash != "" {
+		description += " with stashed changes"
+	}
+	return description
+}
+
+func runSnapshotList(cmd *cobra.Command, args []string) error {
+	store, err := snapshot.Load(snapshotStorePath())
+	if err != nil {
+		return err
+	}
+	if len(store.Snapshots) == 0 {
+		fmt.Println("No snapshots saved.")
+		return nil
+	}
+	for i := len(store.Snapshots) - 1; i >= 0; i-- {
+		snap := store.Snapshots[i]
+		group := ""
+		if snap.Group != "" {
+			group = " (group " + snap.Group + ")"
+		}
+		fmt.Printf("%s  %-20s %d repositories%s\n",
+			snap.Created.Format("2006-01-02 15:04"), snap.Name, len(snap.Repositories), group)
+	}
+	return nil
+}
+
+func runSnapshotDelete(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	path := snapshotStorePath()
+	store, err := snapshot.Load(path)
+	if err != nil {
+		return err
+	}
+	snap, ok := store.Get(name)
+	if !ok {
+		return fmt.Errorf("snapshot '%s' not found", name)
+	}
+
+	gitMgr := di.GitManager()
+	for _, repo := range snap.Repositories {
+		if err := snapshot.Drop(gitMgr, repo); err != nil {
+			fmt.Printf("%s %s: %v\n", display.WarningIcon(), repo.Alias, err)
+		}
+	}
+	store.Delete(name)
+	if err := store.Save(path); err != nil {
+		return err
+	}
+	display.PrintSuccess(fmt.Sprintf("Deleted snapshot '%s'", name))
+	return nil
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	cmdutils "gman/internal/cmd"
+	"gman/internal/di"
+)
+
+func TestSnapshotSaveRejectsInvalidNameUpFront(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("GMAN_CONFIG", filepath.Join(tempDir, "config.yml"))
+
+	// A clean repository sorts before a dirty one, which stashing would reach last
+	var repositories strings.Builder
+	for _, alias := range []string{"a-clean", "b-dirty"} {
+		repoPath := filepath.Join(tempDir, alias)
+		for _, args := range [][]string{{"init", "-q", repoPath}, {"-C", repoPath, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "--allow-empty", "-m", "initial"}} {
+			if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+				t.Skipf("git %v failed: %v\n%s", args, err, output)
+			}
+		}
+		repositories.WriteString("  " + alias + ": " + repoPath + "\n")
+	}
+	dirtyFile := filepath.Join(tempDir, "b-dirty", "wip.txt")
+	if err := os.WriteFile(dirtyFile, []byte("wip\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, "config.yml"), []byte("repositories:\n"+repositories.String()), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	di.Reset()
+	if err := cmdutils.GetManagers().Config.Load(); err != nil {
+		t.Fatalf("Failed to load test config: %v", err)
+	}
+
+	err := runSnapshotSave(snapshotSaveCmd, []string{"before (v2)"})
+	if err == nil || !strings.Contains(err.Error(), "invalid snapshot name") {
+		t.Fatalf("runSnapshotSave() error = %v; want an invalid snapshot name", err)
+	}
+	if _, err := os.Stat(dirtyFile); err != nil {
+		t.Errorf("the dirty repository was stashed: %v", err)
+	}
+	if _, err := os.Stat(snapshotStorePath()); !os.IsNotExist(err) {
+		t.Errorf("a snapshot was recorded: %v", err)
+	}
+}
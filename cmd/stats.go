@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	cmdutils "gman/internal/cmd"
+	"gman/internal/di"
+	"gman/internal/git"
+	"gman/internal/pager"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statsSince string
+	statsGroup string
+)
+
+// statsCmd represents the stats command
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show commit activity and upstream lag across repositories",
+	Long: `Show activity insights for every repository (or the repositories of one
+group): commits and unique authors since --since, how far the current branch
+is behind origin and for how long, followed by fleet totals, the busiest
+repositories and the average time behind.
+
+Behind counts compare with the remote-tracking branches as of the last
+fetch; run 'gman work status' or 'gman work sync' to refresh them. Merge
+commits are not counted.
+
+Examples:
+  gman stats                     # Last 30 days
+  gman stats --since 7d          # Last week
+  gman stats --since 2024-01-01 --group backend
+  gman stats --output json       # For dashboards`,
+	Args: cobra.NoArgs,
+	RunE: runStats,
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+
+	statsCmd.Flags().StringVar(&statsSince, "since", "30d", "Count commits newer than a duration (e.g. 30d, 12h) or date (2024-01-31)")
+	statsCmd.Flags().StringVarP(&statsGroup, "group", "g", "", "Only include repositories of this group")
+
+	pager.Enable(statsCmd)
+}
+
+// repoStats are the activity statistics of one repository
+type repoStats struct {
+	Alias        string    `json:"alias" yaml:"alias"`
+	Commits      int       `json:"commits" yaml:"commits"`
+	Authors      int       `json:"authors" yaml:"authors"`
+	Behind       int       `json:"behind" yaml:"behind"`
+	BehindSince  time.Time `json:"behind_since,omitzero" yaml:"behind_since,omitempty"`
+	BehindHours  float64   `json:"behind_hours" yaml:"behind_hours"`
+	LastActivity time.Time `json:"last_activity,omitzero" yaml:"last_activity,omitempty"`
+	Error        string    `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// fleetStats are the statistics of all repositories with their aggregate
+type fleetStats struct {
+	Since            time.Time   `json:"since" yaml:"since"`
+	Repositories     []repoStats `json:"repositories" yaml:"repositories"`
+	Commits          int         `json:"commits" yaml:"commits"`
+	Authors          int         `json:"authors" yaml:"authors"`
+	ActiveRepos      int         `json:"active_repositories" yaml:"active_repositories"`
+	BehindRepos      int         `json:"behind_repositories" yaml:"behind_repositories"`
+	AvgBehindHours   float64     `json:"average_behind_hours" yaml:"average_behind_hours"`
+	BusiestRepos     []string    `json:"busiest_repositories" yaml:"busiest_repositories"`
+	MostActiveAuthor string      `json:"most_active_author,omitempty" yaml:"most_active_author,omitempty"`
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	now := time.Now()
+	since, err := parseSince(statsSince, now)
+	if err != nil {
+		return err
+	}
+	repositories, err := execRepositories(statsGroup)
+	if err != nil {
+		return err
+	}
+
+	gitMgr := di.GitManager()
+	type statsResult struct {
+		record  repoStats
+		commits []git.CommitSummary
+	}
+	results := forEachRepository(repositories, func(alias, path string) statsResult {
+		record, commits := repositoryStats(gitMgr, alias, path, since, now)
+		return statsResult{record, commits}
+	})
+
+	records := make([]repoStats, 0, len(results))
+	commitsByAuthor := make(map[string]int)
+	for _, result := range results {
+		records = append(records, result.record)
+		for _, commit := range result.commits {
+			commitsByAuthor[commit.Author]++
+		}
+	}
+
+	stats := aggregateStats(records, commitsByAuthor)
+	stats.Since = since
+
+	// Delimited formats take the per-repository rows only
+	var data any = stats
+	if format := cmdutils.OutputFormat(); format == cmdutils.OutputCSV || format == cmdutils.OutputTSV {
+		data = stats.Repositories
+	}
+	return cmdutils.Render(data, func() error {
+		printStats(stats, now)
+		return nil
+	})
+}
+
+// repositoryStats reads the statistics of one repository and returns them
+// with the counted commits
+func repositoryStats(gitMgr *git.Manager, alias, path string, since, now time.Time) (repoStats, []git.CommitSummary) {
+	record := repoStats{Alias: alias}
+	commits, err := gitMgr.CommitsSince(path, "", since)
+	if err != nil {
+		record.Error = err.Error()
+		return record, nil
+	}
+	authors := make(map[string]bool)
+	for _, commit := range commits {
+		authors[commit.Author] = true
+		if commit.Time.After(record.LastActivity) {
+			record.LastActivity = commit.Time
+		}
+	}
+	record.Commits = len(commits)
+	record.Authors = len(authors)
+
+	behind, behindSince, err := gitMgr.BehindSince(path)
+	if err != nil {
+		record.Error = err.Error()
+		return record, commits
+	}
+	if behind > 0 {
+		record.Behind = behind
+		record.BehindSince = behindSince
+		record.BehindHours = float64(int(now.Sub(behindSince).Hours()*10)) / 10
+	}
+	return record, commits
+}
+
+// aggregateStats sorts the records by activity and computes the fleet totals
+func aggregateStats(records []repoStats, commitsByAuthor map[string]int) fleetStats {
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Commits != records[j].Commits {
+			return records[i].Commits > records[j].Commits
+		}
+		return records[i].Alias < records[j].Alias
+	})
+
+	stats := fleetStats{Repositories: records, Authors: len(commitsByAuthor), BusiestRepos: []string{}}
+	var behindHours float64
+	for _, record := range records {
+		stats.Commits += record.Commits
+		if record.Commits > 0 {
+			stats.ActiveRepos++
+			if len(stats.BusiestRepos) < 3 {
+				stats.BusiestRepos = append(stats.BusiestRepos, record.Alias)
+			}
+		}
+		if record.Behind > 0 {
+			stats.BehindRepos++
+			behindHours += record.BehindHours
+		}
+	}
+	if stats.BehindRepos > 0 {
+		stats.AvgBehindHours = float64(int(behindHours/float64(stats.BehindRepos)*10)) / 10
+	}
+
+	for author, count := range commitsByAuthor {
+		best := commitsByAuthor[stats.MostActiveAuthor]
+		if count > best || (count == best && author < stats.MostActiveAuthor) {
+			stats.MostActiveAuthor = author
+		}
+	}
+	return stats
+}
+
+// printStats prints the per-repository table and the fleet summary
+func printStats(stats fleetStats, now time.Time) {
+	if len(stats.Repositories) == 0 {
+		fmt.Println("No repositories to report.")
+		return
+	}
+
+	maxAlias := len("Repository")
+	for _, record := range stats.Repositories {
+		maxAlias = max(maxAlias, len(record.Alias))
+	}
+	fmt.Printf("Activity since %s\n\n", stats.Since.Format("2006-01-02"))
+	fmt.Printf("%-*s %8s %8s %8s %12s %14s\n", maxAlias, "Repository", "Commits", "Authors", "Behind", "Behind for", "Last activity")
+	for _, record := range stats.Repositories {
+		if record.Error != "" {
+			fmt.Printf("%-*s %s\n", maxAlias, record.Alias, color.RedString("%s", record.Error))
+			continue
+		}
+		behind, behindFor := "-", "-"
+		if record.Behind > 0 {
+			behind = fmt.Sprintf("%d", record.Behind)
+			behindFor = color.YellowString("%12s", formatAge(now.Sub(record.BehindSince)))
+		}
+		lastActivity := "-"
+		if !record.LastActivity.IsZero() {
+			lastActivity = formatAge(now.Sub(record.LastActivity)) + " ago"
+		}
+		fmt.Printf("%-*s %8d %8d %8s %12s %14s\n", maxAlias, record.Alias,
+			record.Commits, record.Authors, behind, behindFor, lastActivity)
+	}
+
+	fmt.Println()
+	fmt.Printf("Commits:        %d in %d of %d repositories\n", stats.Commits, stats.ActiveRepos, len(stats.Repositories))
+	fmt.Printf("Authors:        %d", stats.Authors)
+	if stats.MostActiveAuthor != "" {
+		fmt.Printf(" (most active: %s)", stats.MostActiveAuthor)
+	}
+	fmt.Println()
+	if len(stats.BusiestRepos) > 0 {
+		fmt.Printf("Busiest:        %s\n", strings.Join(stats.BusiestRepos, ", "))
+	}
+	if stats.BehindRepos > 0 {
+		average := time.Duration(stats.AvgBehindHours * float64(time.Hour))
+		fmt.Printf("Behind origin:  %d repositories, on average for %s\n", stats.BehindRepos, formatAge(average))
+	} else {
+		fmt.Printf("Behind origin:  none\n")
+	}
+}
+
+// formatAge formats a duration coarsely, e.g. 45m, 5h, 3d or 6w
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	case d < 14*24*time.Hour:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	default:
+		return fmt.Sprintf("%dw", int(d.Hours()/(24*7)))
+	}
+}
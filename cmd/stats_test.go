@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestAggregateStats(t *testing.T) {
+	records := []repoStats{
+		{Alias: "docs", Commits: 0},
+		{Alias: "web", Commits: 4, Behind: 2, BehindHours: 10},
+		{Alias: "api", Commits: 9, Behind: 1, BehindHours: 30},
+		{Alias: "cli", Commits: 4},
+		{Alias: "ops", Commits: 1},
+	}
+	stats := aggregateStats(records, map[string]int{"alice": 10, "bob": 8, "carol": 0})
+
+	if stats.Commits != 18 || stats.ActiveRepos != 4 || stats.Authors != 3 {
+		t.Errorf("unexpected totals: %+v", stats)
+	}
+	if want := []string{"api", "cli", "web"}; !slices.Equal(stats.BusiestRepos, want) {
+		t.Errorf("BusiestRepos = %v, want %v", stats.BusiestRepos, want)
+	}
+	if stats.BehindRepos != 2 || stats.AvgBehindHours != 20 {
+		t.Errorf("expected 2 repositories behind for 20h on average, got %d, %v", stats.BehindRepos, stats.AvgBehindHours)
+	}
+	if stats.MostActiveAuthor != "alice" {
+		t.Errorf("MostActiveAuthor = %s, want alice", stats.MostActiveAuthor)
+	}
+}
+
+func TestFormatAge(t *testing.T) {
+	tests := map[time.Duration]string{
+		30 * time.Minute:    "30m",
+		5 * time.Hour:       "5h",
+		3 * 24 * time.Hour:  "3d",
+		30 * 24 * time.Hour: "4w",
+	}
+	for d, want := range tests {
+		if got := formatAge(d); got != want {
+			t.Errorf("formatAge(%v) = %s, want %s", d, got, want)
+		}
+	}
+}
@@ -2,15 +2,32 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"sort"
+	"strings"
+	"time"
 
+	"gman/internal/cache"
+	cmdutils "gman/internal/cmd"
 	"gman/internal/di"
 	"gman/internal/display"
+	"gman/internal/hooks"
+	"gman/internal/pager"
+	"gman/pkg/types"
 
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
 
-var verboseStatus bool
+var (
+	verboseStatus    bool
+	extendedStatus   bool
+	worktreeStatus   bool
+	healthStatus     bool
+	promptStatus     bool
+	statusFormat     string
+	statusPagination cmdutils.Pagination
+)
 
 // statusCmd represents the status command
 var statusCmd = &cobra.Command{
@@ -22,15 +39,65 @@ var statusCmd = &cobra.Command{
 - Sync status with remote (ahead/behind/up-to-date)
 - Last commit information
 
-Use --verbose to see detailed information including file change counts, commit times,
-remote URLs, stash counts, and branch statistics.`,
+Use --verbose to see file change counts and commit times. Use --extended to
+also see the remote and read stash counts, branch statistics and the last fetch
+time; they cost extra git calls per repository and are skipped by default.
+Use --worktrees to list each repository's linked worktrees below it, with
+their branch, workspace state and path.
+
+Use --health to score the hygiene of each repository from 0 to 100 and list
+what lowered the score: failing syncs, a branch without upstream, no fetch
+for over a week, uncommitted changes left for days and local branches
+without commits for 30 days. Lowest scores are listed first.
+
+Use --output json or --output yaml for machine-readable output; every field
+of --verbose is included, and stash and branch counts with --extended. --output csv (or tsv) prints one spreadsheet row
+per repository for reports.
+
+Use --format to print one line per repository from a Go template over the
+repository status, like docker and kubectl. Fields include .Alias, .Path,
+.Branch, .Workspace, .SyncStatus.Ahead, .SyncStatus.Behind, .FilesChanged,
+.LastCommit, .CommitTime, .RemoteURL and .StashCount (with --extended); join, upper, lower and
+json are available as functions.
+
+Use --limit and --page to read and show one page of repositories at a time,
+in alias order; only the repositories on the page are queried.
+
+While 'gman daemon' runs, status is answered from its memory instead of
+running git; use --no-daemon (or --extended) for a fresh read.
+
+Use --prompt to print a compact single-line summary for shell prompts and tmux
+status bars. It is read from the status cache refreshed by every regular status
+run, so it returns in a few milliseconds:
+
+  3✚ 2⇣ 1⇡ 1✗   (3 dirty, 2 behind, 1 ahead, 1 with errors)
+
+A trailing ? marks a cache older than 15 minutes; run status or keep
+'gman daemon' running to refresh it.
+
+Examples:
+  gman work status --format '{{.Alias}} {{.Branch}} {{.SyncStatus.Behind}}'
+  gman work status --format '{{if gt .SyncStatus.Behind 0}}{{.Path}}{{end}}'
+  gman work status --limit 50 --page 2
+  gman work status --worktrees
+  gman work status --health
+  gman work status --prompt
+  PS1='$(gman work status --prompt) \$ '`,
 	RunE: runStatus,
 }
 
 func init() {
 	// Command is now available via: gman work status
 	// Removed direct rootCmd registration to avoid duplication
-	statusCmd.Flags().BoolVarP(&verboseStatus, "verbose", "v", false, "Show detailed information (file changes, commit times, remote URLs, stash counts)")
+	statusCmd.Flags().BoolVarP(&verboseStatus, "verbose", "v", false, "Show detailed information (file changes, commit times)")
+	statusCmd.Flags().BoolVar(&extendedStatus, "extended", false, "Also show the remote, stash and branch counts and read the last fetch time (slower)")
+	statusCmd.Flags().BoolVar(&worktreeStatus, "worktrees", false, "Also show the branch and workspace state of linked worktrees")
+	statusCmd.Flags().BoolVar(&healthStatus, "health", false, "Also score each repository's hygiene and show the breakdown")
+	statusCmd.Flags().BoolVar(&promptStatus, "prompt", false, "Print a compact one-line summary from the status cache (for shell prompts)")
+	statusCmd.Flags().StringVar(&statusFormat, "format", "", "Print each repository with a Go template, e.g. '{{.Alias}} {{.Branch}}'")
+	cmdutils.AddPaginationFlags(statusCmd, &statusPagination)
+
+	pager.Enable(statusCmd)
 }
 
 func runStatus(cmd *cobra.Command, args []string) error {
@@ -39,11 +106,36 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	configMgr := di.ConfigManager()
 	cfg := configMgr.GetConfig()
 
-	// Get status for all repositories
-	gitMgr := di.GitManager()
-	statuses, err := gitMgr.GetAllRepoStatus(cfg.Repositories)
-	if err != nil {
-		return fmt.Errorf("failed to get repository status: %w", err)
+	if promptStatus {
+		return runStatusPrompt(cfg.Repositories, configMgr.GetConfigDir())
+	}
+
+	if err := statusPagination.Validate(); err != nil {
+		return err
+	}
+	if format := cmdutils.OutputFormat(); worktreeStatus && (format == cmdutils.OutputCSV || format == cmdutils.OutputTSV) {
+		return fmt.Errorf("--worktrees is not supported with %s output; use json or yaml", format)
+	}
+	repositories := pageRepositories(cfg.Repositories, statusPagination)
+	partial := len(repositories) < len(cfg.Repositories)
+
+	// Get status for the repositories on the page (all by default), from
+	// the daemon when one is running; it has no extended or worktree fields
+	var statuses []types.RepoStatus
+	var daemonUpdated time.Time
+	fromDaemon := false
+	if !extendedStatus && !worktreeStatus && !healthStatus {
+		statuses, daemonUpdated, fromDaemon = daemonStatuses(repositories)
+	}
+	if !fromDaemon {
+		gitMgr := di.GitManager()
+		gitMgr.SetExtendedStatus(extendedStatus)
+		gitMgr.SetWorktreeStatus(worktreeStatus)
+		var err error
+		statuses, err = gitMgr.GetAllRepoStatus(repositories)
+		if err != nil {
+			return fmt.Errorf("failed to get repository status: %w", err)
+		}
 	}
 
 	// Sort by alias for consistent output
@@ -51,13 +143,233 @@ func runStatus(cmd *cobra.Command, args []string) error {
 		return statuses[i].Alias < statuses[j].Alias
 	})
 
+	// Refresh the status cache used by --prompt; failures here are not fatal.
+	// A single page would make the prompt summary incomplete.
+	if !partial && !fromDaemon {
+		_ = cache.NewStatusCache(statuses).Save(cache.StatusCachePath(configMgr.GetConfigDir()))
+	}
+
+	// status-error hooks run once the status is shown
+	if failed := statusHookRepositories(statuses); len(failed) > 0 {
+		defer runHooks(hooks.StatusError, failed...)
+	}
+
+	if statusFormat != "" {
+		return cmdutils.RenderFormat(os.Stdout, statusFormat, statuses)
+	}
+
+	var healths map[string]types.Health
+	records := statusRecords(statuses)
+	if healthStatus {
+		healths = repositoryHealth(statuses)
+		for i := range records {
+			health := healths[records[i].Alias]
+			records[i].HealthScore = &health.Score
+			for _, factor := range health.Factors {
+				records[i].HealthIssues = append(records[i].HealthIssues, factor.Name+": "+factor.Detail)
+			}
+		}
+	}
+
 	// Display results
-	var displayer *display.StatusDisplayer
-	if verboseStatus {
-		displayer = display.NewSuperExtendedStatusDisplayer(cfg.Settings.ShowLastCommit)
-	} else {
-		displayer = display.NewStatusDisplayer(cfg.Settings.ShowLastCommit)
+	return cmdutils.Render(records, func() error {
+		var displayer *display.StatusDisplayer
+		if extendedStatus {
+			displayer = display.NewSuperExtendedStatusDisplayer(cfg.Settings.ShowLastCommit)
+		} else if verboseStatus {
+			displayer = display.NewExtendedStatusDisplayer(cfg.Settings.ShowLastCommit)
+		} else {
+			displayer = display.NewStatusDisplayer(cfg.Settings.ShowLastCommit)
+		}
+		displayer.Display(statuses)
+		if healthStatus {
+			printHealthBreakdown(statuses, healths)
+		}
+		printPageFooter(statusPagination, len(cfg.Repositories))
+		if fromDaemon {
+			fmt.Fprintf(os.Stderr, "Status from gman daemon, read %s ago (--no-daemon for a fresh read)\n",
+				time.Since(daemonUpdated).Round(time.Second))
+		}
+		return nil
+	})
+}
+
+// statusRecord is the machine-readable form of a repository status
+type statusRecord struct {
+	Alias          string                 `json:"alias" yaml:"alias"`
+	Path           string                 `json:"path" yaml:"path"`
+	Branch         string                 `json:"branch" yaml:"branch"`
+	Workspace      string                 `json:"workspace" yaml:"workspace"`
+	Ahead          int                    `json:"ahead" yaml:"ahead"`
+	Behind         int                    `json:"behind" yaml:"behind"`
+	SyncError      string                 `json:"sync_error,omitempty" yaml:"sync_error,omitempty"`
+	FilesChanged   int                    `json:"files_changed" yaml:"files_changed"`
+	LastCommit     string                 `json:"last_commit,omitempty" yaml:"last_commit,omitempty"`
+	CommitTime     time.Time              `json:"commit_time,omitzero" yaml:"commit_time,omitempty"`
+	RemoteURL      string                 `json:"remote_url,omitempty" yaml:"remote_url,omitempty"`
+	RemoteBranch   string                 `json:"remote_branch,omitempty" yaml:"remote_branch,omitempty"`
+	StashCount     *int                   `json:"stash_count,omitempty" yaml:"stash_count,omitempty"`         // set with --extended
+	LocalBranches  *int                   `json:"local_branches,omitempty" yaml:"local_branches,omitempty"`   // set with --extended
+	RemoteBranches *int                   `json:"remote_branches,omitempty" yaml:"remote_branches,omitempty"` // set with --extended
+	Worktrees      []worktreeStatusRecord `json:"worktrees,omitempty" yaml:"worktrees,omitempty" csv:"-"`     // set with --worktrees
+	HealthScore    *int                   `json:"health_score,omitempty" yaml:"health_score,omitempty"`       // set with --health
+	HealthIssues   []string               `json:"health_issues,omitempty" yaml:"health_issues,omitempty" csv:"-"`
+	Error          string                 `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// worktreeStatusRecord is the machine-readable form of a linked worktree status
+type worktreeStatusRecord struct {
+	Path         string `json:"path" yaml:"path"`
+	Branch       string `json:"branch,omitempty" yaml:"branch,omitempty"`
+	Detached     bool   `json:"detached,omitempty" yaml:"detached,omitempty"`
+	Workspace    string `json:"workspace" yaml:"workspace"`
+	FilesChanged int    `json:"files_changed" yaml:"files_changed"`
+	Error        string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// statusHookRepositories returns the repositories whose status could not
+// be read, for status-error hooks
+func statusHookRepositories(statuses []types.RepoStatus) []hooks.Repository {
+	var failed []hooks.Repository
+	for _, status := range statuses {
+		err := status.Error
+		if err == nil {
+			err = status.SyncStatus.SyncError
+		}
+		if err != nil {
+			failed = append(failed, hooks.Repository{Alias: status.Alias, Path: status.Path, Status: "error", Error: err.Error()})
+		}
 	}
-	displayer.Display(statuses)
+	return failed
+}
+
+// statusRecords converts statuses to records, keeping their order
+func statusRecords(statuses []types.RepoStatus) []statusRecord {
+	records := make([]statusRecord, 0, len(statuses))
+	for _, status := range statuses {
+		record := statusRecord{
+			Alias:        status.Alias,
+			Path:         status.Path,
+			Branch:       status.Branch,
+			Workspace:    strings.ToLower(status.Workspace.Label()),
+			Ahead:        status.SyncStatus.Ahead,
+			Behind:       status.SyncStatus.Behind,
+			FilesChanged: status.FilesChanged,
+			LastCommit:   status.LastCommit,
+			CommitTime:   status.CommitTime,
+			RemoteURL:    status.RemoteURL,
+			RemoteBranch: status.RemoteBranch,
+		}
+		if status.Extended {
+			record.StashCount = &status.StashCount
+			record.LocalBranches = &status.LocalBranches
+			record.RemoteBranches = &status.RemoteBranches
+		}
+		for _, wt := range status.Worktrees {
+			worktree := worktreeStatusRecord{
+				Path:         wt.Path,
+				Branch:       wt.Branch,
+				Detached:     wt.IsDetached,
+				Workspace:    strings.ToLower(wt.Workspace.Label()),
+				FilesChanged: wt.FilesChanged,
+			}
+			if wt.Error != nil {
+				worktree.Error = wt.Error.Error()
+			}
+			record.Worktrees = append(record.Worktrees, worktree)
+		}
+		if status.SyncStatus.SyncError != nil {
+			record.SyncError = status.SyncStatus.SyncError.Error()
+		}
+		if status.Error != nil {
+			record.Error = status.Error.Error()
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// promptStaleAfter is the age from which --prompt marks the cached summary as stale
+const promptStaleAfter = 15 * time.Minute
+
+// runStatusPrompt prints the one-line prompt summary, preferring the status cache
+// and falling back to a local (no-fetch) status pass when no cache exists yet
+func runStatusPrompt(repositories map[string]string, configDir string) error {
+	cachePath := cache.StatusCachePath(configDir)
+
+	statusCache, err := cache.LoadStatusCache(cachePath)
+	if err != nil {
+		statuses, err := di.GitManager().GetAllRepoStatusNoFetch(repositories)
+		if err != nil {
+			return fmt.Errorf("failed to get repository status: %w", err)
+		}
+		statusCache = cache.NewStatusCache(statuses)
+		_ = statusCache.Save(cachePath)
+	}
+
+	statusCache.Restrict(repositories)
+	fmt.Println(promptLine(statusCache))
 	return nil
 }
+
+// promptLine renders the cache summary, followed by ? when the cache is stale
+func promptLine(statusCache *cache.StatusCache) string {
+	line := statusCache.Summarize().String()
+	if statusCache.IsStale(promptStaleAfter) {
+		line = strings.TrimSpace(line + " ?")
+	}
+	return line
+}
+
+// repositoryHealth scores every repository in parallel, by alias
+func repositoryHealth(statuses []types.RepoStatus) map[string]types.Health {
+	gitMgr := di.GitManager()
+	byAlias := make(map[string]types.RepoStatus, len(statuses))
+	paths := make(map[string]string, len(statuses))
+	for _, status := range statuses {
+		byAlias[status.Alias] = status
+		paths[status.Alias] = status.Path
+	}
+
+	results := forEachRepository(paths, func(alias, _ string) types.Health {
+		return gitMgr.Health(byAlias[alias])
+	})
+	healths := make(map[string]types.Health, len(results))
+	for i, alias := range sortedAliases(paths) {
+		healths[alias] = results[i]
+	}
+	return healths
+}
+
+// printHealthBreakdown lists the health scores, lowest first, with the
+// factors that lowered each
+func printHealthBreakdown(statuses []types.RepoStatus, healths map[string]types.Health) {
+	aliases := make([]string, 0, len(statuses))
+	maxAlias := 0
+	for _, status := range statuses {
+		aliases = append(aliases, status.Alias)
+		maxAlias = max(maxAlias, len(status.Alias))
+	}
+	sort.SliceStable(aliases, func(i, j int) bool {
+		return healths[aliases[i]].Score < healths[aliases[j]].Score
+	})
+
+	fmt.Println()
+	fmt.Println("Health:")
+	for _, alias := range aliases {
+		health := healths[alias]
+		score := fmt.Sprintf("%3d", health.Score)
+		switch health.Grade() {
+		case "healthy":
+			score = color.GreenString(score)
+		case "fair":
+			score = color.YellowString(score)
+		default:
+			score = color.RedString(score)
+		}
+		fmt.Printf("  %-*s %s  %s\n", maxAlias, alias, score, health.Grade())
+		for _, factor := range health.Factors {
+			fmt.Printf("  %-*s      -%-3d %s: %s\n", maxAlias, "", factor.Penalty, factor.Name, factor.Detail)
+		}
+	}
+}
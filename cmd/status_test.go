@@ -7,9 +7,12 @@ import (
 	"os/exec"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"gman/internal/cache"
 	cmdutils "gman/internal/cmd"
 	"gman/internal/di"
+	"gman/pkg/types"
 )
 
 func TestStatusCommand(t *testing.T) {
@@ -194,6 +197,100 @@ func TestStatusCommandWithNoRepositories(t *testing.T) {
 	}
 }
 
+func TestStatusPromptFromCache(t *testing.T) {
+	// Create a temporary config directory
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.yml")
+
+	// Set environment variable to use our temp config
+	originalConfigPath := os.Getenv("GMAN_CONFIG")
+	os.Setenv("GMAN_CONFIG", configPath)
+	defer func() {
+		if originalConfigPath != "" {
+			os.Setenv("GMAN_CONFIG", originalConfigPath)
+		} else {
+			os.Unsetenv("GMAN_CONFIG")
+		}
+	}()
+
+	configData := `
+repositories:
+  dirty-repo: /path/to/dirty
+  behind-repo: /path/to/behind
+  broken-repo: /path/to/broken
+`
+	if err := os.WriteFile(configPath, []byte(configData), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	// Seed the status cache; the removed repo must not be counted
+	statusCache := &cache.StatusCache{
+		UpdatedAt: time.Now(),
+		Entries: map[string]cache.StatusEntry{
+			"dirty-repo":   {Alias: "dirty-repo", Path: "/path/to/dirty", Dirty: true, Ahead: 1},
+			"behind-repo":  {Alias: "behind-repo", Path: "/path/to/behind", Behind: 3},
+			"broken-repo":  {Alias: "broken-repo", Path: "/path/to/broken", Error: "path does not exist"},
+			"removed-repo": {Alias: "removed-repo", Path: "/path/to/removed", Dirty: true},
+		},
+	}
+	if err := statusCache.Save(cache.StatusCachePath(tempDir)); err != nil {
+		t.Fatalf("Failed to write status cache: %v", err)
+	}
+
+	// Reset DI container for testing
+	di.Reset()
+
+	mgrs := cmdutils.GetManagers()
+	if err := mgrs.Config.Load(); err != nil {
+		t.Fatalf("Failed to load test config: %v", err)
+	}
+
+	promptStatus = true
+	defer func() { promptStatus = false }()
+
+	// Capture output
+	var buf bytes.Buffer
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := runStatus(statusCmd, []string{})
+
+	// Restore stdout and get output
+	w.Close()
+	os.Stdout = oldStdout
+	buf.ReadFrom(r)
+
+	if err != nil {
+		t.Fatalf("runStatus() with --prompt returned error: %v", err)
+	}
+
+	expected := "1✚ 1⇣ 1⇡ 1✗\n"
+	if buf.String() != expected {
+		t.Errorf("runStatus() --prompt output = %q, want %q", buf.String(), expected)
+	}
+}
+
+func TestPromptLineMarksStaleCache(t *testing.T) {
+	statusCache := &cache.StatusCache{
+		UpdatedAt: time.Now().Add(-time.Hour),
+		Entries:   map[string]cache.StatusEntry{"api": {Alias: "api", Dirty: true}},
+	}
+	if got := promptLine(statusCache); got != "1✚ ?" {
+		t.Errorf("promptLine() = %q, want %q", got, "1✚ ?")
+	}
+
+	statusCache.Entries = nil
+	if got := promptLine(statusCache); got != "?" {
+		t.Errorf("promptLine() of a clean stale cache = %q, want %q", got, "?")
+	}
+
+	statusCache.UpdatedAt = time.Now()
+	if got := promptLine(statusCache); got != "" {
+		t.Errorf("promptLine() of a clean fresh cache = %q, want an empty line", got)
+	}
+}
+
 // createBasicTestRepo creates a basic Git repository for testing
 func createBasicTestRepo(repoPath string) error {
 	if err := os.MkdirAll(repoPath, 0755); err != nil {
@@ -239,4 +336,65 @@ func createBasicTestRepo(repoPath string) error {
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+// TestStatusRecords tests the machine-readable form of repository statuses
+func TestStatusRecords(t *testing.T) {
+	statuses := []types.RepoStatus{
+		{
+			Alias:      "api",
+			Path:       "/src/api",
+			Branch:     "main",
+			Workspace:  types.Dirty,
+			SyncStatus: types.SyncStatus{Ahead: 2, SyncError: fmt.Errorf("fetch failed")},
+		},
+		{Alias: "web", Path: "/src/web", Error: fmt.Errorf("not a git repository")},
+	}
+
+	records := statusRecords(statuses)
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+	if records[0].Workspace != "dirty" || records[0].Ahead != 2 || records[0].SyncError != "fetch failed" {
+		t.Errorf("Unexpected record for api: %+v", records[0])
+	}
+	if records[1].Workspace != "clean" || records[1].Error != "not a git repository" {
+		t.Errorf("Unexpected record for web: %+v", records[1])
+	}
+}
+
+func TestStatusRecordsExtendedFields(t *testing.T) {
+	statuses := []types.RepoStatus{
+		{Alias: "api", StashCount: 0, LocalBranches: 3},
+		{Alias: "web", Extended: true, StashCount: 0, LocalBranches: 3, RemoteBranches: 1},
+	}
+
+	records := statusRecords(statuses)
+	if records[0].StashCount != nil || records[0].LocalBranches != nil {
+		t.Errorf("Expected no stash or branch counts without --extended: %+v", records[0])
+	}
+	if records[1].StashCount == nil || *records[1].StashCount != 0 || *records[1].LocalBranches != 3 || *records[1].RemoteBranches != 1 {
+		t.Errorf("Expected stash and branch counts with --extended: %+v", records[1])
+	}
+}
+
+func TestStatusRecordsWorktrees(t *testing.T) {
+	statuses := []types.RepoStatus{{
+		Alias: "api",
+		Worktrees: []types.WorktreeStatus{
+			{Path: "/src/api-feature", Branch: "feature", Workspace: types.Dirty, FilesChanged: 2},
+			{Path: "/src/api-review", IsDetached: true, Error: fmt.Errorf("status failed")},
+		},
+	}}
+
+	records := statusRecords(statuses)
+	worktrees := records[0].Worktrees
+	if len(worktrees) != 2 {
+		t.Fatalf("Expected 2 worktree records, got %+v", worktrees)
+	}
+	if worktrees[0].Branch != "feature" || worktrees[0].Workspace != "dirty" || worktrees[0].FilesChanged != 2 {
+		t.Errorf("Unexpected record for feature worktree: %+v", worktrees[0])
+	}
+	if !worktrees[1].Detached || worktrees[1].Error != "status failed" {
+		t.Errorf("Unexpected record for detached worktree: %+v", worktrees[1])
+	}
+}
@@ -8,7 +8,12 @@ import (
 	"strings"
 	"time"
 
+	"gman/internal/cache"
 	"gman/internal/di"
+	"gman/internal/display"
+	"gman/internal/errors"
+	"gman/internal/git"
+	"gman/internal/hooks"
 	"gman/internal/interactive"
 	"gman/pkg/types"
 
@@ -18,25 +23,37 @@ import (
 var (
 	showRecentOnly bool
 	recentLimit    int
+	switchCreate   bool
 )
 
 // switchCmd represents the switch command
 var switchCmd = &cobra.Command{
-	Use:   "switch [alias]",
+	Use:   "switch [alias | alias@branch]",
 	Short: "Switch to a repository directory with recent history",
 	Long: `Switch to the directory of the specified repository.
 If no alias is provided, an interactive menu will be displayed showing
-all repositories with recently accessed ones highlighted at the top.
+all repositories with your most used ones at the top.
 
-The interactive menu prioritizes recently accessed repositories to 
-improve navigation efficiency in your daily workflow.
+Candidates are ranked by frecency: every switch (and every cd into a
+repository when the shell-init cd-hook is active) counts as a visit, and
+frequently and recently visited repositories rank first. Bookmarks
+('gman bookmark') are listed too.
+
+With alias@branch gman switches to the worktree checked out on that branch.
+If there is none it offers to create one (a new branch is created when it
+does not exist yet) under the worktree_base_dir setting, or next to the
+repository when that is not set. Without a terminal to ask on, --create
+is required.
 
 Examples:
   gman switch my-repo       # Switch to 'my-repo'
   gman switch proj          # Fuzzy match repositories containing 'proj'
-  gman switch               # Interactive selection menu with recent repos first
-  gman switch --recent      # Show only recently accessed repositories
-  gman switch --recent --limit 5    # Show only last 5 accessed repositories`,
+  gman switch api@feature/login      # Worktree of 'api' on feature/login
+  gman switch api@fix --create      # Create the worktree without asking
+  gman switch -             # Jump back to the previous repository or worktree
+  gman switch               # Interactive selection menu with most used repos first
+  gman switch --recent      # Show only visited repositories, by frecency
+  gman switch --recent --limit 5    # Show only the top 5 visited repositories`,
 	Args: cobra.RangeArgs(0, 1),
 	RunE: runSwitch,
 	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -59,8 +76,9 @@ Examples:
 func init() {
 	rootCmd.AddCommand(switchCmd)
 	
-	switchCmd.Flags().BoolVar(&showRecentOnly, "recent", false, "Show only recently accessed repositories")
-	switchCmd.Flags().IntVar(&recentLimit, "limit", 10, "Limit number of recent repositories shown (used with --recent)")
+	switchCmd.Flags().BoolVar(&showRecentOnly, "recent", false, "Show only visited repositories, ranked by frecency")
+	switchCmd.Flags().IntVar(&recentLimit, "limit", 10, "Limit number of repositories shown (used with --recent)")
+	switchCmd.Flags().BoolVar(&switchCreate, "create", false, "Create a missing branch worktree without asking (used with alias@branch)")
 }
 
 func runSwitch(cmd *cobra.Command, args []string) error {
@@ -99,7 +117,8 @@ func runSwitch(cmd *cobra.Command, args []string) error {
 		fmt.Println("")
 		fmt.Println("🔄 Then reload your shell or run: source ~/.zshrc")
 		fmt.Println("")
-		fmt.Println("🚀 Alternative: Use 'gman tools init shell' for automated setup!")
+		fmt.Println("🚀 Alternative: Add 'eval \"$(gman shell-init bash)\"' (or zsh/fish) to your rc file,")
+		fmt.Println("   or use 'gman tools init shell' for automated setup!")
 		fmt.Println("📚 For detailed help: https://docs.anthropic.com/en/docs/claude-code/troubleshooting")
 		return fmt.Errorf("shell integration required")
 	}
@@ -111,36 +130,61 @@ func runSwitch(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("no repositories configured. Use 'gman add' to add repositories")
 	}
 
-	// Collect all available switch targets (repositories + worktrees)
-	targets, err := collectSwitchTargets(cfg.Repositories)
-	if err != nil {
-		return fmt.Errorf("failed to collect switch targets: %w", err)
+	// Collect all available switch targets (repositories + worktrees); a
+	// running daemon already knows them
+	var err error
+	targets, fromDaemon := daemonSwitchTargets(cfg.Repositories)
+	if !fromDaemon {
+		targets, err = collectSwitchTargets(cfg.Repositories)
+		if err != nil {
+			return fmt.Errorf("failed to collect switch targets: %w", err)
+		}
 	}
 
 	if len(targets) == 0 {
 		return fmt.Errorf("no repositories or worktrees available")
 	}
+	current := currentSwitchTarget(targets)
+	targets = append(targets, bookmarkSwitchTargets(cfg, targets)...)
+
+	frecency := loadFrecency(configMgr.GetConfigDir(), cfg.RecentUsage)
 
 	// Filter targets if --recent flag is used
 	if showRecentOnly {
-		targets = filterRecentTargets(targets, cfg.RecentUsage, recentLimit)
+		targets = filterRecentTargets(targets, frecency, recentLimit)
 		if len(targets) == 0 {
 			return fmt.Errorf("no recently accessed repositories found")
 		}
 	} else {
-		// Sort targets with recent repositories first
-		targets = sortTargetsByRecency(targets, cfg.RecentUsage)
+		// Sort targets with the most used repositories first
+		targets = sortTargetsByFrecency(targets, frecency)
 	}
 
 	var selectedTarget *types.SwitchTarget
 
-	if len(args) == 0 {
+	if len(args) == 1 && args[0] == "-" {
+		// Like 'cd -': go back to where the last switch started
+		selectedTarget, err = previousSwitchTarget(frecency, targets)
+		if err != nil {
+			return err
+		}
+	} else if len(args) == 0 {
+		if interactive.NonInteractive() {
+			return interactive.ErrUnavailable("interactive repository selection", "pass an alias, e.g. 'gman switch <alias>'")
+		}
+
 		// Interactive mode
-		selector := interactive.NewSwitchTargetSelector(targets)
+		selector := interactive.NewRankedSwitchTargetSelector(targets)
 		selectedTarget, err = selector.SelectTarget()
 		if err != nil {
 			return err
 		}
+	} else if repoInput, branch, ok := splitBranchTarget(args[0]); ok {
+		// Worktree of a branch, created on demand
+		selectedTarget, err = branchSwitchTarget(repoInput, branch, targets)
+		if err != nil {
+			return err
+		}
 	} else {
 		// Direct alias or fuzzy match
 		inputAlias := args[0]
@@ -150,19 +194,25 @@ func runSwitch(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Track recent usage (use repository alias for main repos, or parent repo for worktrees)
-	trackingAlias := selectedTarget.Alias
-	if selectedTarget.Type == "worktree" && selectedTarget.RepoAlias != "" {
-		trackingAlias = selectedTarget.RepoAlias
-	}
+	// Record the switch for gman recent; tracking never fails the switch
+	recordRecentSwitch(configMgr.GetConfigDir(), cfg, selectedTarget)
 
-	if err := configMgr.TrackRecentUsage(trackingAlias); err != nil {
-		// Don't fail the switch if tracking fails, just log it silently
-		// Could add debug logging here in the future
-	}
+	// Worktrees are ranked on their own, so record the selected target itself
+	frecency.Visit(selectedTarget.Alias, time.Now())
+	frecency.RecordSwitch(current, cache.SwitchRecord{
+		Alias: selectedTarget.Alias,
+		Path:  selectedTarget.Path,
+	})
+	_ = frecency.Save(cache.FrecencyPath(configMgr.GetConfigDir()))
 
 	// Output special format for shell wrapper to handle
 	fmt.Printf("GMAN_CD:%s", selectedTarget.Path)
+
+	// Hook output goes on lines of its own after the GMAN_CD line
+	if len(cfg.Hooks[hooks.PostSwitch]) > 0 {
+		fmt.Println()
+		runHooks(hooks.PostSwitch, hooks.Repository{Alias: selectedTarget.Alias, Path: selectedTarget.Path})
+	}
 	return nil
 }
 
@@ -178,7 +228,7 @@ func fuzzyMatchRepository(input string, repos map[string]string) (string, error)
 	}
 
 	if len(matches) == 0 {
-		return "", fmt.Errorf("no repositories found matching '%s'", input)
+		return "", errors.NewGmanError(errors.ErrTypeRepoNotFound, fmt.Sprintf("no repositories found matching '%s'", input))
 	}
 
 	if len(matches) == 1 {
@@ -212,7 +262,8 @@ func collectSwitchTargets(repositories map[string]string) ([]types.SwitchTarget,
 
 		for _, wt := range worktrees {
 			// Skip the main worktree (it's already included as the repository)
-			if wt.Path == path {
+			// and worktrees whose directory is gone
+			if wt.Path == path || wt.Prunable != "" {
 				continue
 			}
 
@@ -221,9 +272,7 @@ func collectSwitchTargets(repositories map[string]string) ([]types.SwitchTarget,
 			if wtBaseName == "." || wtBaseName == "" {
 				wtBaseName = "worktree"
 			}
-			
-			// Always prefix with repository alias to provide clear context
-			wtAlias := fmt.Sprintf("%s/%s", alias, wtBaseName)
+			wtAlias := worktreeTargetAlias(alias, wt.Path)
 
 			// Ensure worktree alias is unique (fallback safety)
 			originalAlias := wtAlias
@@ -275,7 +324,7 @@ func findSwitchTarget(input string, targets []types.SwitchTarget) (*types.Switch
 	}
 
 	if len(matches) == 0 {
-		return nil, fmt.Errorf("no targets found matching '%s'", input)
+		return nil, errors.NewGmanError(errors.ErrTypeRepoNotFound, fmt.Sprintf("no targets found matching '%s'", input))
 	}
 
 	if len(matches) == 1 {
@@ -359,34 +408,78 @@ func getShellIntegrationDiagnostics() string {
 	return strings.Join(diagnostics, "\n")
 }
 
-// filterRecentTargets returns only recently accessed repositories up to the specified limit
-func filterRecentTargets(targets []types.SwitchTarget, recentRepos []types.RecentEntry, limit int) []types.SwitchTarget {
-	if len(recentRepos) == 0 {
-		return []types.SwitchTarget{}
+// worktreeTargetAlias returns the switch target alias of a worktree,
+// always prefixed with the repository alias to provide clear context
+func worktreeTargetAlias(repoAlias, worktreePath string) string {
+	name := filepath.Base(worktreePath)
+	if name == "." || name == "" {
+		name = "worktree"
 	}
+	return fmt.Sprintf("%s/%s", repoAlias, name)
+}
 
-	var recentTargets []types.SwitchTarget
-	recentMap := make(map[string]types.RecentEntry)
-	
-	// Create a map for quick lookup
-	for _, recent := range recentRepos {
-		recentMap[recent.Alias] = recent
+// currentSwitchTarget returns the target containing the working directory,
+// or nil when gman is run outside of every repository and worktree
+func currentSwitchTarget(targets []types.SwitchTarget) *cache.SwitchRecord {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil
 	}
 
-	// Find targets that match recent repositories
+	paths := make(map[string]string, len(targets))
 	for _, target := range targets {
-		if recent, exists := recentMap[target.Alias]; exists {
-			// Add recent information to the target
-			targetCopy := target
-			targetCopy.LastAccessed = recent.AccessTime
-			recentTargets = append(recentTargets, targetCopy)
+		paths[target.Alias] = target.Path
+	}
+	alias, path := repoForPath(paths, cwd)
+	if alias == "" {
+		return nil
+	}
+	return &cache.SwitchRecord{Alias: alias, Path: path}
+}
+
+// previousSwitchTarget resolves the target 'gman switch -' returns to. The
+// path is matched first so a renamed alias still finds its worktree.
+func previousSwitchTarget(frecency *cache.FrecencyStore, targets []types.SwitchTarget) (*types.SwitchTarget, error) {
+	previous := frecency.Previous
+	if previous == nil {
+		return nil, fmt.Errorf("no previous repository to switch back to")
+	}
+
+	for i := range targets {
+		if targets[i].Path == previous.Path {
+			return &targets[i], nil
+		}
+	}
+	for i := range targets {
+		if targets[i].Alias == previous.Alias {
+			return &targets[i], nil
 		}
 	}
+	return nil, fmt.Errorf("previous target '%s' (%s) no longer exists", previous.Alias, previous.Path)
+}
 
-	// Sort by recency (most recent first)
-	sort.Slice(recentTargets, func(i, j int) bool {
-		return recentTargets[i].LastAccessed.After(recentTargets[j].LastAccessed)
-	})
+// loadFrecency reads the frecency store, seeded with the recent usage list
+// from the configuration. Errors yield an empty store so switching never fails.
+func loadFrecency(configDir string, recent []types.RecentEntry) *cache.FrecencyStore {
+	store, err := cache.LoadFrecency(cache.FrecencyPath(configDir))
+	if err != nil {
+		store = &cache.FrecencyStore{Entries: make(map[string]cache.FrecencyEntry)}
+	}
+	store.Seed(recent)
+	return store
+}
+
+// filterRecentTargets returns only visited targets, ranked by frecency, up to the specified limit
+func filterRecentTargets(targets []types.SwitchTarget, frecency *cache.FrecencyStore, limit int) []types.SwitchTarget {
+	var recentTargets []types.SwitchTarget
+	for _, target := range targets {
+		if entry, exists := frecency.Entries[target.Alias]; exists {
+			target.LastAccessed = entry.LastVisit
+			recentTargets = append(recentTargets, target)
+		}
+	}
+
+	recentTargets = sortTargetsByFrecency(recentTargets, frecency)
 
 	// Apply limit
 	if limit > 0 && len(recentTargets) > limit {
@@ -396,48 +489,159 @@ func filterRecentTargets(targets []types.SwitchTarget, recentRepos []types.Recen
 	return recentTargets
 }
 
-// sortTargetsByRecency sorts targets with recent repositories first, then alphabetically
-func sortTargetsByRecency(targets []types.SwitchTarget, recentRepos []types.RecentEntry) []types.SwitchTarget {
-	if len(recentRepos) == 0 {
-		// No recent repositories, just sort alphabetically
-		sort.Slice(targets, func(i, j int) bool {
-			return targets[i].Alias < targets[j].Alias
-		})
-		return targets
+// sortTargetsByFrecency sorts targets by descending frecency score, then alphabetically
+func sortTargetsByFrecency(targets []types.SwitchTarget, frecency *cache.FrecencyStore) []types.SwitchTarget {
+	now := time.Now()
+	scores := make(map[string]float64, len(targets))
+	for i := range targets {
+		scores[targets[i].Alias] = frecency.Score(targets[i].Alias, now)
+		if entry, exists := frecency.Entries[targets[i].Alias]; exists {
+			targets[i].LastAccessed = entry.LastVisit
+		}
+	}
+
+	sort.SliceStable(targets, func(i, j int) bool {
+		si, sj := scores[targets[i].Alias], scores[targets[j].Alias]
+		if si != sj {
+			return si > sj
+		}
+		return targets[i].Alias < targets[j].Alias
+	})
+
+	return targets
+}
+
+// splitBranchTarget splits an alias@branch argument
+func splitBranchTarget(arg string) (repo, branch string, ok bool) {
+	i := strings.LastIndex(arg, "@")
+	if i <= 0 || i == len(arg)-1 {
+		return "", "", false
 	}
+	return arg[:i], arg[i+1:], true
+}
 
-	// Create a map for quick lookup of recent access times
-	recentMap := make(map[string]time.Time)
-	for _, recent := range recentRepos {
-		recentMap[recent.Alias] = recent.AccessTime
+// branchSwitchTarget resolves alias@branch to the repository or worktree on
+// that branch, offering to create a worktree when the branch has none yet
+func branchSwitchTarget(repoInput, branch string, targets []types.SwitchTarget) (*types.SwitchTarget, error) {
+	var repositories []types.SwitchTarget
+	for _, target := range targets {
+		if target.Type == "repository" {
+			repositories = append(repositories, target)
+		}
+	}
+	repo, err := findSwitchTarget(repoInput, repositories)
+	if err != nil {
+		return nil, err
 	}
 
-	// Add recent access times to targets
 	for i := range targets {
-		if lastAccessed, exists := recentMap[targets[i].Alias]; exists {
-			targets[i].LastAccessed = lastAccessed
+		if targets[i].Type == "worktree" && targets[i].RepoAlias == repo.Alias && targets[i].Branch == branch {
+			return &targets[i], nil
 		}
 	}
 
-	// Sort: recent repositories first (by recency), then non-recent alphabetically
-	sort.Slice(targets, func(i, j int) bool {
-		iRecent := !targets[i].LastAccessed.IsZero()
-		jRecent := !targets[j].LastAccessed.IsZero()
+	gitMgr := di.GitManager()
+	if current, err := gitMgr.GetCurrentBranch(repo.Path); err == nil && current == branch {
+		return repo, nil
+	}
 
-		if iRecent && jRecent {
-			// Both are recent, sort by most recent first
-			return targets[i].LastAccessed.After(targets[j].LastAccessed)
-		} else if iRecent && !jRecent {
-			// i is recent, j is not - i comes first
-			return true
-		} else if !iRecent && jRecent {
-			// j is recent, i is not - j comes first
-			return false
-		} else {
-			// Neither is recent, sort alphabetically
-			return targets[i].Alias < targets[j].Alias
+	cfg := di.ConfigManager().GetConfig()
+	worktreePath, err := branchWorktreePath(cfg.Settings, repo.Alias, repo.Path, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	if !switchCreate {
+		// Scripts must ask for new branches and worktrees explicitly
+		if interactive.NonInteractive() {
+			return nil, interactive.ErrUnavailable(fmt.Sprintf("creating a worktree for branch '%s' in %s", branch, repo.Alias), "pass --create to create it")
 		}
-	})
+		fmt.Printf("No worktree for branch '%s' in %s. Create one at %s? [Y/n]: ", branch, repo.Alias, worktreePath)
+		if !askConfirmation(true) {
+			return nil, fmt.Errorf("no worktree for branch '%s' in %s", branch, repo.Alias)
+		}
+	}
 
-	return targets
+	if err := createBranchWorktree(repo.Path, worktreePath, branch); err != nil {
+		return nil, err
+	}
+	fmt.Printf("%s Created worktree for %s at %s\n", display.SuccessIcon(), branch, worktreePath)
+
+	// Collect the targets again so the new worktree gets its usual alias
+	refreshed, err := collectSwitchTargets(cfg.Repositories)
+	if err == nil {
+		for i := range refreshed {
+			if refreshed[i].Path == worktreePath {
+				return &refreshed[i], nil
+			}
+		}
+	}
+	return &types.SwitchTarget{
+		Alias:     fmt.Sprintf("%s/%s", repo.Alias, filepath.Base(worktreePath)),
+		Path:      worktreePath,
+		Type:      "worktree",
+		RepoAlias: repo.Alias,
+		Branch:    branch,
+	}, nil
+}
+
+// branchWorktreePath returns where the worktree for branch is created:
+// <baseDir>/<alias>-<branch>, or next to the repository without a base dir.
+// A worktree_name_template replaces the name in both places.
+func branchWorktreePath(settings types.Settings, alias, repoPath, branch string) (string, error) {
+	parent := filepath.Dir(repoPath)
+	template := git.DefaultWorktreeNameTemplate
+	if settings.WorktreeBaseDir != "" {
+		baseDir, err := expandWorktreeBaseDir(settings.WorktreeBaseDir)
+		if err != nil {
+			return "", err
+		}
+		parent = baseDir
+		template = git.DefaultBaseDirWorktreeTemplate
+	}
+	if settings.WorktreeNameTemplate != "" {
+		template = settings.WorktreeNameTemplate
+	}
+
+	name, err := git.WorktreeName(template, alias, repoPath, branch)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(parent, name), nil
+}
+
+// expandWorktreeBaseDir resolves ~ and environment variables in the
+// worktree_base_dir setting
+func expandWorktreeBaseDir(baseDir string) (string, error) {
+	if strings.HasPrefix(baseDir, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to expand worktree_base_dir: %w", err)
+		}
+		baseDir = filepath.Join(home, baseDir[2:])
+	}
+	baseDir, err := filepath.Abs(os.ExpandEnv(baseDir))
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve worktree_base_dir: %w", err)
+	}
+	return baseDir, nil
+}
+
+// createBranchWorktree adds a worktree for branch, setting up a tracking
+// branch first when the branch only exists on origin
+func createBranchWorktree(repoPath, worktreePath, branch string) error {
+	gitMgr := di.GitManager()
+
+	if _, err := gitMgr.RunCommand(repoPath, "rev-parse", "--verify", "--quiet", "refs/heads/"+branch); err != nil {
+		if _, err := gitMgr.RunCommand(repoPath, "rev-parse", "--verify", "--quiet", "refs/remotes/origin/"+branch); err == nil {
+			if _, err := gitMgr.RunCommand(repoPath, "branch", "--track", branch, "origin/"+branch); err != nil {
+				return fmt.Errorf("failed to create tracking branch: %w", err)
+			}
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(worktreePath), 0755); err != nil {
+		return fmt.Errorf("failed to create worktree directory: %w", err)
+	}
+	return gitMgr.AddWorktree(repoPath, worktreePath, branch)
 }
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	cmdutils "gman/internal/cmd"
+	"gman/pkg/types"
 	"gman/test"
 )
 
@@ -832,6 +833,67 @@ func TestShellIntegrationDiagnostics(t *testing.T) {
 	}
 }
 
+// TestSplitBranchTarget tests parsing of alias@branch arguments
+func TestSplitBranchTarget(t *testing.T) {
+	tests := []struct {
+		arg    string
+		repo   string
+		branch string
+		ok     bool
+	}{
+		{"api@main", "api", "main", true},
+		{"api@feature/login", "api", "feature/login", true},
+		{"me@host@fix", "me@host", "fix", true},
+		{"api", "", "", false},
+		{"@main", "", "", false},
+		{"api@", "", "", false},
+	}
+
+	for _, tt := range tests {
+		repo, branch, ok := splitBranchTarget(tt.arg)
+		if repo != tt.repo || branch != tt.branch || ok != tt.ok {
+			t.Errorf("splitBranchTarget(%q) = %q, %q, %v; want %q, %q, %v",
+				tt.arg, repo, branch, ok, tt.repo, tt.branch, tt.ok)
+		}
+	}
+}
+
+// TestBranchWorktreePath tests where branch worktrees are placed
+func TestBranchWorktreePath(t *testing.T) {
+	path, err := branchWorktreePath(types.Settings{}, "api", "/src/api-server", "feature/login")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join("/src", "api-server-feature-login"); path != want {
+		t.Errorf("Expected %s without a base dir, got %s", want, path)
+	}
+
+	base := t.TempDir()
+	path, err = branchWorktreePath(types.Settings{WorktreeBaseDir: base}, "api", "/src/api-server", "feature/login")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(base, "api-feature-login"); path != want {
+		t.Errorf("Expected %s with a base dir, got %s", want, path)
+	}
+
+	settings := types.Settings{WorktreeBaseDir: base, WorktreeNameTemplate: "{repo}/{branch}"}
+	path, err = branchWorktreePath(settings, "api", "/src/api-server", "feature/login")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(base, "api", "feature-login"); path != want {
+		t.Errorf("Expected %s with a name template, got %s", want, path)
+	}
+
+	for _, template := range []string{"{repo}-{name}", "../{branch}", "/tmp/{branch}"} {
+		settings.WorktreeNameTemplate = template
+		if _, err := branchWorktreePath(settings, "api", "/src/api-server", "main"); err == nil {
+			t.Errorf("Expected an error for template %q", template)
+		}
+	}
+}
+
 // Helper functions for switch command testing
 
 // initSwitchTestRepository creates a test repository suitable for switch operations
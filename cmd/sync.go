@@ -3,10 +3,18 @@ package cmd
 import (
 	"fmt"
 	"sort"
+	"strings"
 	"sync"
 
+	cmdutils "gman/internal/cmd"
 	"gman/internal/config"
 	"gman/internal/di"
+	"gman/internal/display"
+	"gman/internal/errors"
+	"gman/internal/hooks"
+	"gman/internal/index"
+	"gman/internal/interactive"
+	"gman/internal/notify"
 	"gman/internal/progress"
 	"gman/pkg/types"
 
@@ -34,6 +42,9 @@ Options:
   --progress     : Show detailed progress during sync operations
   --group        : Sync only repositories in the specified group
 
+With --output json or --output yaml the per-repository results (or the dry-run
+plan) are printed in that format instead of the summary.
+
 For more complex merge strategies, use native git commands in individual repositories.`,
 	RunE: runSync,
 }
@@ -69,14 +80,56 @@ func runSync(cmd *cobra.Command, args []string) error {
 		return displayDryRunPreview(reposToSync)
 	}
 
+	// Record the state before syncing for gman undo
+	recorder := newUndoRecorder("work sync")
+	for _, alias := range sortedAliases(reposToSync) {
+		recorder.Before(alias, reposToSync[alias])
+	}
+	defer saveUndoRecord(recorder)
+
 	// Execute the actual sync operations (always ff-only mode)
-	results, err := executeSyncOperations(reposToSync, cfg)
+	results, err := executeSyncOperations(reposToSync, cfg, getSyncMode())
 	if err != nil {
 		return err
 	}
 
+	// Keep existing search indexes current for the repositories that moved
+	refreshSearchIndexes(configMgr.GetConfigDir(), results)
+
 	// Display results and summary
-	return displaySyncResults(results)
+	err = displaySyncResults(results)
+	if err != nil && !cmdutils.StructuredOutput() {
+		// Offer the recovery of repositories blocked by local changes; the
+		// retried results replace the failed ones
+		retried, retryErr := offerAutostashRetry(results, cfg)
+		if len(retried) > 0 {
+			refreshSearchIndexes(configMgr.GetConfigDir(), retried)
+		}
+		err = syncFailureError(results)
+		if retryErr != nil {
+			err = retryErr
+		}
+	}
+
+	runHooks(hooks.PostSync, syncHookRepositories(results)...)
+	notifyProblems("sync", syncNotifyRepositories(results))
+	return err
+}
+
+// refreshSearchIndexes incrementally updates the search index of every
+// successfully synced repository that already has one (best effort)
+func refreshSearchIndexes(configDir string, results []syncResult) {
+	store := index.NewStore(index.DefaultDir(configDir))
+	synced := make(map[string]string)
+	for _, result := range results {
+		if result.error == nil && store.Exists(result.alias) {
+			synced[result.alias] = result.path
+		}
+	}
+	if len(synced) == 0 {
+		return
+	}
+	store.UpdateAll(di.GitManager(), synced, 0)
 }
 
 // Always use ff-only mode for safety
@@ -88,6 +141,8 @@ type syncResult struct {
 	alias string
 	path  string
 	error error
+	// plan is the recovery plan of error, nil when none applies
+	plan *errors.RecoveryPlan
 }
 
 // No filtering - sync all repositories for simplicity and consistency
@@ -122,6 +177,9 @@ func displayDryRunPreview(reposToSync map[string]string) error {
 	if groupName != "" {
 		groupInfo = fmt.Sprintf(" from group '%s'", groupName)
 	}
+	if cmdutils.StructuredOutput() {
+		return cmdutils.Render(repositoryRecords(reposToSync), nil)
+	}
 	fmt.Printf("DRY RUN: Would synchronize %d repositories%s (mode: ff-only):\n\n", len(reposToSync), groupInfo)
 	for alias, path := range reposToSync {
 		fmt.Printf("  %s → %s\n", alias, path)
@@ -130,20 +188,22 @@ func displayDryRunPreview(reposToSync map[string]string) error {
 }
 
 // executeSyncOperations performs the actual sync operations across repositories
-func executeSyncOperations(reposToSync map[string]string, cfg *types.Config) ([]syncResult, error) {
+func executeSyncOperations(reposToSync map[string]string, cfg *types.Config, mode string) ([]syncResult, error) {
 	// Setup progress tracking
+	// Structured output keeps stdout parseable: no banner or progress
+	structured := cmdutils.StructuredOutput()
 	var progressBar *progress.MultiBar
-	if showProgress {
+	if showProgress && !structured {
 		progressBar = progress.NewMultiBar()
 		for alias := range reposToSync {
 			progressBar.AddOperation(alias)
 		}
-	} else {
+	} else if !structured {
 		groupInfo := ""
 		if groupName != "" {
 			groupInfo = fmt.Sprintf(" from group '%s'", groupName)
 		}
-		fmt.Printf("Synchronizing %d repositories%s (mode: ff-only)...\n\n", len(reposToSync), groupInfo)
+		fmt.Printf("Synchronizing %d repositories%s (mode: %s)...\n\n", len(reposToSync), groupInfo, mode)
 	}
 
 	// Use a channel to collect results
@@ -169,18 +229,22 @@ func executeSyncOperations(reposToSync map[string]string, cfg *types.Config) ([]
 				progressBar.StartOperation(alias)
 			}
 
-			// Always use ff-only mode for safety
-			err := syncMgr.SyncRepository(path, "ff-only")
+			// Only fast-forwards, with or without autostash, for safety
+			err := syncMgr.SyncRepository(path, mode)
 
 			if progressBar != nil {
 				progressBar.CompleteOperation(alias, err)
 			}
 
-			resultChan <- syncResult{
+			result := syncResult{
 				alias: alias,
 				path:  path,
 				error: err,
 			}
+			if gErr, ok := errors.As(err); ok {
+				result.plan = errors.NewRecoveryPlan(gErr)
+			}
+			resultChan <- result
 		}(alias, path)
 	}
 
@@ -211,6 +275,22 @@ func executeSyncOperations(reposToSync map[string]string, cfg *types.Config) ([]
 
 // displaySyncResults displays the results and returns appropriate error if needed
 func displaySyncResults(results []syncResult) error {
+	if cmdutils.StructuredOutput() {
+		if err := cmdutils.Render(syncRecords(results), nil); err != nil {
+			return err
+		}
+		var errorCount int
+		for _, result := range results {
+			if result.error != nil {
+				errorCount++
+			}
+		}
+		if errorCount > 0 {
+			return fmt.Errorf("sync failed for %d repositories", errorCount)
+		}
+		return nil
+	}
+
 	var successCount, errorCount int
 	
 	// Count results
@@ -226,9 +306,10 @@ func displaySyncResults(results []syncResult) error {
 	if !showProgress {
 		for _, result := range results {
 			if result.error != nil {
-				fmt.Printf("❌ %s: %v\n", result.alias, result.error)
+				fmt.Printf("%s %s: %v\n", display.ErrorIcon(), result.alias, result.error)
+				printSyncRecovery(result, "   ")
 			} else {
-				fmt.Printf("✅ %s: synced successfully\n", result.alias)
+				fmt.Printf("%s %s: synced successfully\n", display.SuccessIcon(), result.alias)
 			}
 		}
 	}
@@ -241,13 +322,159 @@ func displaySyncResults(results []syncResult) error {
 		fmt.Println("\nFailed repositories:")
 		for _, result := range results {
 			if result.error != nil {
-				fmt.Printf("  ❌ %s: %v\n", result.alias, result.error)
+				fmt.Printf("  %s %s: %v\n", display.ErrorIcon(), result.alias, result.error)
+				printSyncRecovery(result, "     ")
 			}
 		}
-		return fmt.Errorf("sync failed for %d repositories", errorCount)
+		return syncFailureError(results)
 	} else if errorCount > 0 {
-		return fmt.Errorf("sync failed for %d repositories", errorCount)
+		return syncFailureError(results)
 	}
 
 	return nil
 }
+
+// printSyncRecovery lists the recovery actions of a failed sync
+func printSyncRecovery(result syncResult, indent string) {
+	if result.plan == nil {
+		return
+	}
+	for _, action := range result.plan.Actions {
+		fmt.Printf("%sRecovery: %s (%s)\n", indent, action.Description, action.Command())
+	}
+}
+
+// offerAutostashRetry offers to sync the repositories blocked by local
+// changes again with 'git pull --ff-only --autostash' and updates their
+// results. It returns the retried results.
+func offerAutostashRetry(results []syncResult, cfg *types.Config) ([]syncResult, error) {
+	dirty := make(map[string]string)
+	var aliases, unfinished []string
+	for _, result := range results {
+		switch errors.GetType(result.error) {
+		case errors.ErrTypeWorkspaceNotClean:
+			dirty[result.alias] = result.path
+			aliases = append(aliases, result.alias)
+		case errors.ErrTypeMergeConflict:
+			unfinished = append(unfinished, result.alias)
+		}
+	}
+
+	if len(unfinished) > 0 {
+		fmt.Printf("\n%s Unfinished merges block %s; run 'gman doctor --fix' to resolve them\n",
+			display.WarningIcon(), strings.Join(unfinished, ", "))
+	}
+	if len(dirty) == 0 {
+		return nil, nil
+	}
+
+	fmt.Printf("\n%d repositories have local changes in the way: %s\n", len(dirty), strings.Join(aliases, ", "))
+	if interactive.NonInteractive() {
+		fmt.Printf("Retry with 'git pull --ff-only --autostash' in each of them to keep the changes.\n")
+		return nil, nil
+	}
+	fmt.Printf("Autostash and retry these %d repositories? [y/N]: ", len(dirty))
+	if !askConfirmation(false) {
+		return nil, nil
+	}
+	fmt.Println()
+
+	retried, err := executeSyncOperations(dirty, cfg, "ff-only-autostash")
+	if err != nil {
+		return nil, err
+	}
+	byAlias := make(map[string]syncResult, len(retried))
+	for _, result := range retried {
+		byAlias[result.alias] = result
+		if result.error != nil {
+			fmt.Printf("%s %s: %v\n", display.ErrorIcon(), result.alias, result.error)
+		} else {
+			fmt.Printf("%s %s: synced successfully\n", display.SuccessIcon(), result.alias)
+		}
+	}
+	for i, result := range results {
+		if retry, ok := byAlias[result.alias]; ok {
+			results[i] = retry
+		}
+	}
+	return retried, nil
+}
+
+// syncFailureError summarizes failed syncs. When all failures share an
+// error type, the summary keeps it so the exit code tells what went wrong.
+func syncFailureError(results []syncResult) error {
+	var failed int
+	var errorType errors.ErrorType
+	mixed := false
+	for _, result := range results {
+		if result.error == nil {
+			continue
+		}
+		failed++
+		resultType := errors.GetType(result.error)
+		if failed == 1 {
+			errorType = resultType
+		} else if resultType != errorType {
+			mixed = true
+		}
+	}
+	if failed == 0 {
+		return nil
+	}
+
+	message := fmt.Sprintf("sync failed for %d repositories", failed)
+	if mixed || errorType == "" {
+		return fmt.Errorf("%s", message)
+	}
+	return errors.NewGmanError(errorType, message)
+}
+
+// syncRecord is the machine-readable form of a sync result
+type syncRecord struct {
+	Alias  string `json:"alias" yaml:"alias"`
+	Path   string `json:"path" yaml:"path"`
+	Status string `json:"status" yaml:"status"` // "synced" or "failed"
+	Error  string `json:"error,omitempty" yaml:"error,omitempty"`
+	// Code is the error type of a failure, see --error-format
+	Code string `json:"code,omitempty" yaml:"code,omitempty"`
+}
+
+// syncHookRepositories describes the sync results, after any autostash
+// retry, to post-sync hooks
+func syncHookRepositories(results []syncResult) []hooks.Repository {
+	repositories := make([]hooks.Repository, 0, len(results))
+	for _, record := range syncRecords(results) {
+		repositories = append(repositories, hooks.Repository{
+			Alias: record.Alias, Path: record.Path, Status: record.Status, Error: record.Error,
+		})
+	}
+	return repositories
+}
+
+// syncNotifyRepositories describes the sync results to the notifier
+func syncNotifyRepositories(results []syncResult) []notify.Repository {
+	repositories := make([]notify.Repository, 0, len(results))
+	for _, result := range results {
+		repo := notify.Repository{Alias: result.alias}
+		if result.error != nil {
+			repo.Error = result.error.Error()
+		}
+		repositories = append(repositories, repo)
+	}
+	return repositories
+}
+
+// syncRecords converts sync results to records, keeping their order
+func syncRecords(results []syncResult) []syncRecord {
+	records := make([]syncRecord, 0, len(results))
+	for _, result := range results {
+		record := syncRecord{Alias: result.alias, Path: result.path, Status: "synced"}
+		if result.error != nil {
+			record.Status = "failed"
+			record.Error = result.error.Error()
+			record.Code = string(errors.GetType(result.error))
+		}
+		records = append(records, record)
+	}
+	return records
+}
@@ -9,7 +9,9 @@ import (
 	"strings"
 
 	"gman/internal/di"
+	"gman/internal/display"
 	"gman/internal/external"
+	"gman/internal/interactive"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -146,7 +148,7 @@ func runTaskCreate(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("%s Created task '%s'\n", color.GreenString("✅"), taskName)
+	fmt.Printf("%s Created task '%s'\n", display.SuccessIcon(), taskName)
 	if description != "" {
 		fmt.Printf("   Description: %s\n", description)
 	}
@@ -168,7 +170,7 @@ func runTaskDelete(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("%s Deleted task '%s'\n", color.GreenString("✅"), taskName)
+	fmt.Printf("%s Deleted task '%s'\n", display.SuccessIcon(), taskName)
 	return nil
 }
 
@@ -197,7 +199,7 @@ func runTaskList(cmd *cobra.Command, args []string) error {
 	for _, name := range taskNames {
 		task := tasks[name]
 		fmt.Printf("%s %s (%d files)\n",
-			color.YellowString("📋"),
+			color.YellowString(display.Icon("📋", "TASK")),
 			color.GreenString(name),
 			len(task.Files))
 
@@ -252,6 +254,10 @@ func runTaskAdd(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("error reading from stdin: %w", err)
 		}
 	} else if taskInteractive {
+		if interactive.NonInteractive() {
+			return interactive.ErrUnavailable("interactive file selection", "pass file paths as arguments or use --stdin")
+		}
+
 		// Interactive file selection using fd
 		fdSearcher := external.NewFDSearcher()
 		
@@ -317,7 +323,7 @@ func runTaskAdd(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Printf("%s Added %d files to task '%s'\n",
-		color.GreenString("✅"), len(filePaths), taskName)
+		display.SuccessIcon(), len(filePaths), taskName)
 
 	// Show added files
 	for _, path := range filePaths {
@@ -339,6 +345,10 @@ func runTaskRemove(cmd *cobra.Command, args []string) error {
 	var filePaths []string
 
 	if taskInteractive {
+		if interactive.NonInteractive() {
+			return interactive.ErrUnavailable("interactive file removal", "pass file paths as arguments")
+		}
+
 		// Interactive file removal - show current task files
 		task, err := configMgr.GetTask(taskName)
 		if err != nil {
@@ -397,7 +407,7 @@ func runTaskRemove(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Printf("%s Removed %d files from task '%s'\n",
-		color.GreenString("✅"), len(filePaths), taskName)
+		display.SuccessIcon(), len(filePaths), taskName)
 
 	// Show removed files
 	for _, path := range filePaths {
@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"gman/internal/di"
+	"gman/internal/display"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// tmux open modes
+const (
+	tmuxModeWindow  = "window"
+	tmuxModeSession = "session"
+)
+
+var (
+	tmuxOpenSession bool
+	tmuxOpenWindow  bool
+)
+
+// tmuxCmd represents the tmux command
+var tmuxCmd = &cobra.Command{
+	Use:   "tmux",
+	Short: "Open repositories in tmux windows or sessions",
+	Long: `Use gman as the launcher for per-repository terminal sessions in tmux.
+
+Examples:
+  gman tmux open backend              # Window or session named 'backend'
+  gman tmux open backend --session    # Always use a dedicated session
+  gman tmux open backend/feature-x    # Worktrees work as in 'gman switch'`,
+}
+
+// tmuxOpenCmd represents the tmux open command
+var tmuxOpenCmd = &cobra.Command{
+	Use:   "open <alias>",
+	Short: "Open a repository in a tmux window or session named after its alias",
+	Long: `Open a repository or worktree in tmux, named after its alias. An existing
+window or session of that name is reused instead of creating a new one.
+
+The mode comes from 'tmux_mode' in the settings ("window" by default):
+  window    a new window in the current tmux session
+  session   a dedicated session, switched to (inside tmux) or attached
+
+Outside of tmux there is no current session, so window mode falls back to
+a session. --window and --session override the configured mode.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTmuxOpen,
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) != 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		cfg := di.ConfigManager().GetConfig()
+		var aliases []string
+		for alias := range cfg.Repositories {
+			aliases = append(aliases, alias)
+		}
+		return aliases, cobra.ShellCompDirectiveNoFileComp
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tmuxCmd)
+	tmuxCmd.AddCommand(tmuxOpenCmd)
+
+	tmuxOpenCmd.Flags().BoolVar(&tmuxOpenSession, "session", false, "Open in a dedicated tmux session")
+	tmuxOpenCmd.Flags().BoolVar(&tmuxOpenWindow, "window", false, "Open in a new window of the current tmux session")
+}
+
+func runTmuxOpen(cmd *cobra.Command, args []string) error {
+	if tmuxOpenSession && tmuxOpenWindow {
+		return fmt.Errorf("--session and --window are mutually exclusive")
+	}
+	if _, err := exec.LookPath("tmux"); err != nil {
+		return fmt.Errorf("tmux is not installed or not in PATH")
+	}
+
+	cfg := di.ConfigManager().GetConfig()
+	if len(cfg.Repositories) == 0 {
+		return fmt.Errorf("no repositories configured. Use 'gman repo add' to add repositories")
+	}
+
+	targets, err := collectSwitchTargets(cfg.Repositories)
+	if err != nil {
+		return fmt.Errorf("failed to collect switch targets: %w", err)
+	}
+	target, err := findSwitchTarget(args[0], targets)
+	if err != nil {
+		return err
+	}
+
+	mode, err := tmuxMode(cfg.Settings.TmuxMode)
+	if err != nil {
+		return err
+	}
+	insideTmux := os.Getenv("TMUX") != ""
+	if mode == tmuxModeWindow && !insideTmux {
+		mode = tmuxModeSession
+	}
+
+	name := tmuxName(target.Alias)
+	if mode == tmuxModeWindow {
+		return openTmuxWindow(name, target.Path)
+	}
+	return openTmuxSession(name, target.Path, insideTmux)
+}
+
+// tmuxMode resolves the open mode from the flags and the configured setting
+func tmuxMode(configured string) (string, error) {
+	switch {
+	case tmuxOpenSession:
+		return tmuxModeSession, nil
+	case tmuxOpenWindow:
+		return tmuxModeWindow, nil
+	}
+
+	switch configured {
+	case "", tmuxModeWindow:
+		return tmuxModeWindow, nil
+	case tmuxModeSession:
+		return tmuxModeSession, nil
+	default:
+		return "", fmt.Errorf("invalid tmux_mode '%s' in settings (use \"window\" or \"session\")", configured)
+	}
+}
+
+// tmuxName turns an alias into a valid tmux session or window name; tmux
+// reserves '.' and ':' for target syntax
+func tmuxName(alias string) string {
+	return strings.NewReplacer(".", "-", ":", "-").Replace(alias)
+}
+
+// openTmuxWindow selects the window named name in the current session, or
+// creates it in dir
+func openTmuxWindow(name, dir string) error {
+	output, err := exec.Command("tmux", "list-windows", "-F", "#{window_name}").Output()
+	if err != nil {
+		return fmt.Errorf("failed to list tmux windows: %w", err)
+	}
+	for _, window := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if window == name {
+			if err := runTmux("select-window", "-t", "="+name); err != nil {
+				return err
+			}
+			fmt.Printf("%s Switched to tmux window %s\n", display.SuccessIcon(), color.CyanString(name))
+			return nil
+		}
+	}
+
+	if err := runTmux("new-window", "-n", name, "-c", dir); err != nil {
+		return err
+	}
+	fmt.Printf("%s Opened tmux window %s in %s\n", display.SuccessIcon(), color.CyanString(name), dir)
+	return nil
+}
+
+// openTmuxSession creates the session named name in dir unless it exists,
+// then switches the client to it (inside tmux) or attaches to it
+func openTmuxSession(name, dir string, insideTmux bool) error {
+	if exec.Command("tmux", "has-session", "-t", "="+name).Run() != nil {
+		if err := runTmux("new-session", "-d", "-s", name, "-c", dir); err != nil {
+			return err
+		}
+	}
+
+	if insideTmux {
+		if err := runTmux("switch-client", "-t", "="+name); err != nil {
+			return err
+		}
+		fmt.Printf("%s Switched to tmux session %s\n", display.SuccessIcon(), color.CyanString(name))
+		return nil
+	}
+
+	attach := exec.Command("tmux", "attach-session", "-t", "="+name)
+	attach.Stdin = os.Stdin
+	attach.Stdout = os.Stdout
+	attach.Stderr = os.Stderr
+	return attach.Run()
+}
+
+// runTmux runs a tmux command, including its output in errors
+func runTmux(args ...string) error {
+	output, err := exec.Command("tmux", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("tmux %s failed: %s", args[0], strings.TrimSpace(string(output)))
+	}
+	return nil
+}
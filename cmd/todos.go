@@ -0,0 +1,245 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	cmdutils "gman/internal/cmd"
+	"gman/internal/di"
+	"gman/internal/external"
+	"gman/internal/pager"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	todosGroup    string
+	todosMarkers  []string
+	todosAssignee string
+	todosMarkdown bool
+)
+
+// todosCmd represents the todos command
+var todosCmd = &cobra.Command{
+	Use:   "todos",
+	Short: "List TODO, FIXME and HACK comments across repositories",
+	Long: `Scan the files of every repository (or the repositories of one group) for
+TODO, FIXME and HACK markers and list them by repository and file.
+
+An assignee in parentheses after the marker is extracted:
+  // TODO(alice): handle timeouts
+  # FIXME(bob) flaky on CI
+
+Files are searched with ripgrep, which skips files ignored by .gitignore,
+or with git grep when ripgrep is not installed. The search exclusions of the
+configuration apply.
+
+Examples:
+  gman todos
+  gman todos --group backend --assignee alice
+  gman todos --marker FIXME --marker XXX
+  gman todos --markdown > TODOS.md
+  gman todos --output json`,
+	Args: cobra.NoArgs,
+	RunE: runTodos,
+}
+
+func init() {
+	rootCmd.AddCommand(todosCmd)
+
+	todosCmd.Flags().StringVarP(&todosGroup, "group", "g", "", "Only scan the repositories of this group")
+	todosCmd.Flags().StringSliceVar(&todosMarkers, "marker", []string{"TODO", "FIXME", "HACK"}, "Markers to look for (repeatable)")
+	todosCmd.Flags().StringVar(&todosAssignee, "assignee", "", "Only list items assigned to this name")
+	todosCmd.Flags().BoolVar(&todosMarkdown, "markdown", false, "Print a Markdown checklist")
+
+	pager.Enable(todosCmd)
+}
+
+// todoItem is one marker comment
+type todoItem struct {
+	Alias    string `json:"repo" yaml:"repo"`
+	File     string `json:"path" yaml:"path"`
+	Line     int    `json:"line" yaml:"line"`
+	Marker   string `json:"marker" yaml:"marker"`
+	Assignee string `json:"assignee,omitempty" yaml:"assignee,omitempty"`
+	Text     string `json:"text" yaml:"text"`
+}
+
+// todoCommentEnd matches what closes a comment after its text
+var todoCommentEnd = regexp.MustCompile(`\s*(\*/|-->|#\}|%\})\s*$`)
+
+func runTodos(cmd *cobra.Command, args []string) error {
+	var markers []string
+	for _, marker := range todosMarkers {
+		if marker = strings.TrimSpace(marker); marker != "" {
+			markers = append(markers, regexp.QuoteMeta(marker))
+		}
+	}
+	if len(markers) == 0 {
+		return fmt.Errorf("pass at least one --marker")
+	}
+	if todosMarkdown && cmdutils.StructuredOutput() {
+		return fmt.Errorf("--markdown cannot be combined with --output")
+	}
+	todoPattern, err := todoRegexp(markers)
+	if err != nil {
+		return err
+	}
+
+	searcher, backend := external.NewWordScanner()
+	fmt.Fprintf(os.Stderr, "%s\n", color.BlueString("🔍 Scanning for %s with %s...", strings.Join(todosMarkers, ", "), backend))
+	results, err := searcher.SearchContent(strings.Join(markers, "|"), di.ConfigManager().GetConfig().Repositories, todosGroup)
+	if err != nil {
+		return fmt.Errorf("failed to search content: %w", err)
+	}
+
+	items := []todoItem{}
+	for _, result := range results {
+		item, ok := parseTodo(todoPattern, result.LineContent)
+		if !ok || (todosAssignee != "" && !strings.EqualFold(item.Assignee, todosAssignee)) {
+			continue
+		}
+		item.Alias, item.File, item.Line = result.RepoAlias, result.FilePath, result.LineNumber
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if a.Alias != b.Alias {
+			return a.Alias < b.Alias
+		}
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.Line < b.Line
+	})
+
+	if todosMarkdown {
+		renderTodosMarkdown(os.Stdout, items)
+		return nil
+	}
+	return cmdutils.Render(items, func() error {
+		printTodos(items)
+		return nil
+	})
+}
+
+// todoRegexp matches a marker as a word, an optional (assignee) and the
+// rest of the comment
+func todoRegexp(markers []string) (*regexp.Regexp, error) {
+	return regexp.Compile(`\b(` + strings.Join(markers, "|") + `)\b(?:\(([^)]*)\))?:?\s*(.*)`)
+}
+
+// parseTodo extracts the marker comment from a line
+func parseTodo(pattern *regexp.Regexp, line string) (todoItem, bool) {
+	match := pattern.FindStringSubmatch(line)
+	if match == nil {
+		return todoItem{}, false
+	}
+	text := todoCommentEnd.ReplaceAllString(strings.TrimSpace(match[3]), "")
+	return todoItem{
+		Marker:   match[1],
+		Assignee: strings.TrimPrefix(strings.TrimSpace(match[2]), "@"),
+		Text:     text,
+	}, true
+}
+
+// printTodos prints the items grouped by repository and file
+func printTodos(items []todoItem) {
+	if len(items) == 0 {
+		fmt.Println("No TODOs found.")
+		return
+	}
+
+	markerWidth := 0
+	for _, item := range items {
+		markerWidth = max(markerWidth, len(todoLabel(item)))
+	}
+	alias, file := "", ""
+	for _, item := range items {
+		if item.Alias != alias {
+			if alias != "" {
+				fmt.Println()
+			}
+			alias, file = item.Alias, ""
+			fmt.Println(color.New(color.Bold).Sprint(alias))
+		}
+		if item.File != file {
+			file = item.File
+			fmt.Printf("  %s\n", color.CyanString(file))
+		}
+		label := fmt.Sprintf("%-*s", markerWidth, todoLabel(item))
+		if item.Marker != "TODO" {
+			label = color.YellowString(label)
+		}
+		fmt.Printf("    %5d  %s  %s\n", item.Line, label, item.Text)
+	}
+
+	fmt.Printf("\n%s\n", todoSummary(items))
+}
+
+// todoLabel is the marker with its assignee, e.g. TODO(alice)
+func todoLabel(item todoItem) string {
+	if item.Assignee == "" {
+		return item.Marker
+	}
+	return fmt.Sprintf("%s(%s)", item.Marker, item.Assignee)
+}
+
+// todoSummary counts the items per marker, e.g. "12 items: 9 TODO, 3 FIXME"
+func todoSummary(items []todoItem) string {
+	counts := make(map[string]int)
+	var order []string
+	for _, item := range items {
+		if counts[item.Marker] == 0 {
+			order = append(order, item.Marker)
+		}
+		counts[item.Marker]++
+	}
+	sort.Slice(order, func(i, j int) bool {
+		if counts[order[i]] != counts[order[j]] {
+			return counts[order[i]] > counts[order[j]]
+		}
+		return order[i] < order[j]
+	})
+	parts := make([]string, 0, len(order))
+	for _, marker := range order {
+		parts = append(parts, fmt.Sprintf("%d %s", counts[marker], marker))
+	}
+	noun := "items"
+	if len(items) == 1 {
+		noun = "item"
+	}
+	return fmt.Sprintf("%d %s: %s", len(items), noun, strings.Join(parts, ", "))
+}
+
+// renderTodosMarkdown writes the items as a Markdown checklist
+func renderTodosMarkdown(w io.Writer, items []todoItem) {
+	fmt.Fprintf(w, "# TODOs\n\n")
+	if len(items) == 0 {
+		fmt.Fprintf(w, "No TODOs found.\n")
+		return
+	}
+	fmt.Fprintf(w, "%s.\n", todoSummary(items))
+
+	alias, file := "", ""
+	for _, item := range items {
+		if item.Alias != alias {
+			alias, file = item.Alias, ""
+			fmt.Fprintf(w, "\n## %s\n", alias)
+		}
+		if item.File != file {
+			file = item.File
+			fmt.Fprintf(w, "\n### `%s`\n\n", file)
+		}
+		assignee := ""
+		if item.Assignee != "" {
+			assignee = fmt.Sprintf(" (@%s)", item.Assignee)
+		}
+		fmt.Fprintf(w, "- [ ] **%s**%s %s (line %d)\n", item.Marker, assignee, item.Text, item.Line)
+	}
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestParseTodo(t *testing.T) {
+	pattern, err := todoRegexp([]string{"TODO", "FIXME", "HACK"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		line string
+		want todoItem
+		ok   bool
+	}{
+		{"	// TODO(alice): handle timeouts", todoItem{Marker: "TODO", Assignee: "alice", Text: "handle timeouts"}, true},
+		{"# FIXME(@bob) flaky on CI", todoItem{Marker: "FIXME", Assignee: "bob", Text: "flaky on CI"}, true},
+		{"/* HACK: until v2 ships */", todoItem{Marker: "HACK", Text: "until v2 ships"}, true},
+		{"<!-- TODO translate -->", todoItem{Marker: "TODO", Text: "translate"}, true},
+		{"x := 1 // TODO", todoItem{Marker: "TODO"}, true},
+		{"var TODOS = 3", todoItem{}, false},
+		{"autoFIXME()", todoItem{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseTodo(pattern, tt.line)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("parseTodo(%q) = %+v, %v; want %+v, %v", tt.line, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestRenderTodosMarkdown(t *testing.T) {
+	items := []todoItem{
+		{Alias: "api", File: "server.go", Line: 12, Marker: "TODO", Assignee: "alice", Text: "handle timeouts"},
+		{Alias: "api", File: "server.go", Line: 40, Marker: "FIXME", Text: "leaks"},
+		{Alias: "web", File: "app.ts", Line: 3, Marker: "TODO", Text: "i18n"},
+	}
+	var buf bytes.Buffer
+	renderTodosMarkdown(&buf, items)
+	out := buf.String()
+
+	for _, want := range []string{
+		"3 items: 2 TODO, 1 FIXME.",
+		"## api\n\n### `server.go`\n\n- [ ] **TODO** (@alice) handle timeouts (line 12)\n- [ ] **FIXME** leaks (line 40)\n",
+		"## web\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("markdown missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+
+	"gman/internal/di"
+	"gman/internal/display"
+	"gman/internal/interactive"
+	"gman/internal/undo"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	undoList bool
+	undoYes  bool
+)
+
+// undoCmd represents the undo command
+var undoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Restore repositories to their state before the last bulk operation",
+	Long: `Undo the last bulk operation: 'gman work sync', 'gman replace --commit',
+'gman patch apply' and 'gman tools find ref --switch/--delete' record the
+HEAD, branch and stash of every repository they touch before changing it.
+
+gman undo resets each repository to its recorded HEAD, switches back to the
+recorded branch, re-applies changes left in the stash by an autostash and
+recreates deleted branches. Repositories that changed since the operation,
+or that have uncommitted changes, are skipped so no work is lost.
+
+Examples:
+  gman undo           # Show what will be restored, then confirm
+  gman undo --yes     # Restore without confirmation
+  gman undo --list    # List recorded operations`,
+	Args: cobra.NoArgs,
+	RunE: runUndo,
+}
+
+func init() {
+	rootCmd.AddCommand(undoCmd)
+
+	undoCmd.Flags().BoolVar(&undoList, "list", false, "List recorded operations")
+	undoCmd.Flags().BoolVarP(&undoYes, "yes", "y", false, "Restore without confirmation")
+}
+
+func undoJournalPath() string {
+	return undo.JournalPath(di.ConfigManager().GetConfigDir())
+}
+
+// newUndoRecorder starts recording a bulk operation for gman undo
+func newUndoRecorder(operation string) *undo.Recorder {
+	return undo.NewRecorder(di.GitManager(), operation)
+}
+
+// saveUndoRecord journals the repositories the operation changed. Failing
+// to journal never fails the operation itself.
+func saveUndoRecord(recorder *undo.Recorder) {
+	entry, changed := recorder.Entry()
+	if !changed {
+		return
+	}
+	path := undoJournalPath()
+	journal, err := undo.Load(path)
+	if err != nil {
+		slog.Warn("failed to load undo journal", "error", err)
+		journal = &undo.Journal{}
+	}
+	journal.Add(entry)
+	if err := journal.Save(path); err != nil {
+		slog.Warn("failed to save undo journal", "error", err)
+	}
+}
+
+func runUndo(cmd *cobra.Command, args []string) error {
+	path := undoJournalPath()
+	journal, err := undo.Load(path)
+	if err != nil {
+		return err
+	}
+
+	if undoList {
+		printUndoJournal(journal)
+		return nil
+	}
+
+	last := journal.Last()
+	if last < 0 {
+		fmt.Println("Nothing to undo.")
+		return nil
+	}
+	entry := &journal.Entries[last]
+
+	gitMgr := di.GitManager()
+	fmt.Printf("Undo '%s' from %s:\n", entry.Operation, entry.Time.Format("2006-01-02 15:04"))
+	plans := make(map[string][]undo.Step)
+	for _, repo := range entry.Repositories {
+		steps, err := undo.Plan(gitMgr, repo)
+		if err != nil {
+			fmt.Printf("  %s %v, skipped\n", display.WarningIcon(), err)
+			continue
+		}
+		plans[repo.Alias] = steps
+		fmt.Printf("  %s:\n", repo.Alias)
+		for _, step := range steps {
+			fmt.Printf("    %s (%s)\n", step.Description, step.Command())
+		}
+	}
+	if len(plans) == 0 {
+		fmt.Println("No repository can be restored.")
+		return nil
+	}
+
+	if !undoYes {
+		if interactive.NonInteractive() {
+			return interactive.ErrUnavailable("confirming the undo", "pass --yes to restore")
+		}
+		fmt.Printf("Restore %d repositories? [y/N]: ", len(plans))
+		if !askConfirmation(false) {
+			fmt.Println("Undo cancelled.")
+			return nil
+		}
+	}
+
+	var restored, failed int
+	for _, repo := range entry.Repositories {
+		steps, ok := plans[repo.Alias]
+		if !ok {
+			continue
+		}
+		if err := applyUndoSteps(repo, steps); err != nil {
+			failed++
+			fmt.Printf("%s %s: %v\n", display.ErrorIcon(), repo.Alias, err)
+			continue
+		}
+		restored++
+		fmt.Printf("%s %s: restored\n", display.SuccessIcon(), repo.Alias)
+	}
+
+	// A partly failed undo is not repeated: the restored repositories
+	// no longer match the recorded state
+	if restored > 0 {
+		entry.Undone = true
+		if err := journal.Save(path); err != nil {
+			return err
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("undo failed in %d repositories", failed)
+	}
+	return nil
+}
+
+// applyUndoSteps runs the steps restoring one repository
+func applyUndoSteps(repo undo.Repository, steps []undo.Step) error {
+	gitMgr := di.GitManager()
+	for _, step := range steps {
+		if _, err := gitMgr.RunCommand(repo.Path, step.Args...); err != nil {
+			return fmt.Errorf("failed to %s: %w", step.Description, err)
+		}
+	}
+	return nil
+}
+
+// printUndoJournal lists recorded operations, newest first
+func printUndoJournal(journal *undo.Journal) {
+	if len(journal.Entries) == 0 {
+		fmt.Println("No operations recorded.")
+		return
+	}
+	for i := len(journal.Entries) - 1; i >= 0; i-- {
+		entry := journal.Entries[i]
+		state := ""
+		if entry.Undone {
+			state = " (undone)"
+		}
+		fmt.Printf("%s  %-30s %d repositories%s\n",
+			entry.Time.Format("2006-01-02 15:04"), entry.Operation, len(entry.Repositories), state)
+	}
+}
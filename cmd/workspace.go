@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"gman/internal/di"
+	"gman/internal/display"
+	"gman/pkg/types"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	workspaceGroup     string
+	workspaceOut       string
+	workspaceWorktrees bool
+)
+
+// workspaceCmd represents the workspace command
+var workspaceCmd = &cobra.Command{
+	Use:   "workspace",
+	Short: "Generate editor workspaces from repositories",
+	Long: `Generate editor workspaces that open several repositories at once.
+
+Examples:
+  gman workspace code --group product --out product.code-workspace`,
+}
+
+// workspaceCodeCmd generates a VSCode multi-root workspace
+var workspaceCodeCmd = &cobra.Command{
+	Use:   "code",
+	Short: "Generate a VSCode multi-root workspace",
+	Long: `Generate a VSCode multi-root workspace file with a folder for every
+repository (or every repository of a group), optionally with their worktrees.
+
+The workspace is regenerated whenever repositories are added to or removed
+from gman or its group, or moved. Run the command again to pick up new
+worktrees or to change the options; delete the file to stop regenerating it.
+Settings, extensions and other entries in an existing file are kept; only
+the folders are replaced.
+
+Examples:
+  gman workspace code                                      # gman.code-workspace with every repository
+  gman workspace code --group product --out product.code-workspace
+  gman workspace code -g product --worktrees               # Include the worktrees`,
+	Args: cobra.NoArgs,
+	RunE: runWorkspaceCode,
+}
+
+func init() {
+	rootCmd.AddCommand(workspaceCmd)
+	workspaceCmd.AddCommand(workspaceCodeCmd)
+
+	workspaceCodeCmd.Flags().StringVarP(&workspaceGroup, "group", "g", "", "Only include repositories of this group")
+	workspaceCodeCmd.Flags().StringVar(&workspaceOut, "out", "", "Workspace file to write (default: <group>.code-workspace, or gman.code-workspace)")
+	workspaceCodeCmd.Flags().BoolVar(&workspaceWorktrees, "worktrees", false, "Include the worktrees of each repository")
+}
+
+// codeWorkspaceFolder is a folder of a VSCode multi-root workspace
+type codeWorkspaceFolder struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+func runWorkspaceCode(cmd *cobra.Command, args []string) error {
+	out := workspaceOut
+	if out == "" {
+		out = "gman.code-workspace"
+		if workspaceGroup != "" {
+			out = workspaceGroup + ".code-workspace"
+		}
+	}
+	absOut, err := filepath.Abs(out)
+	if err != nil {
+		return fmt.Errorf("failed to resolve '%s': %w", out, err)
+	}
+
+	workspace := types.CodeWorkspace{Path: absOut, Group: workspaceGroup, Worktrees: workspaceWorktrees}
+	folders, err := generateCodeWorkspace(workspace)
+	if err != nil {
+		return err
+	}
+	if err := di.ConfigManager().TrackCodeWorkspace(workspace); err != nil {
+		return fmt.Errorf("failed to record workspace: %w", err)
+	}
+
+	fmt.Printf("%s Wrote %s with %d folders\n", display.SuccessIcon(), out, folders)
+	return nil
+}
+
+// generateCodeWorkspace writes the workspace file from the current
+// repositories and returns the number of folders
+func generateCodeWorkspace(workspace types.CodeWorkspace) (int, error) {
+	repositories, err := execRepositories(workspace.Group)
+	if err != nil {
+		return 0, err
+	}
+
+	folders := codeWorkspaceFolders(repositories, workspace.Worktrees)
+	if err := writeCodeWorkspace(workspace.Path, folders); err != nil {
+		return 0, err
+	}
+	return len(folders), nil
+}
+
+// codeWorkspaceFolders returns a folder per repository, in alias order,
+// each followed by its worktrees when requested
+func codeWorkspaceFolders(repositories map[string]string, worktrees bool) []codeWorkspaceFolder {
+	gitMgr := di.GitManager()
+
+	var folders []codeWorkspaceFolder
+	for _, alias := range sortedAliases(repositories) {
+		path := repositories[alias]
+		folders = append(folders, codeWorkspaceFolder{Name: alias, Path: path})
+		if !worktrees {
+			continue
+		}
+
+		list, err := gitMgr.ListWorktrees(path)
+		if err != nil {
+			slog.Warn("failed to list worktrees", "repository", alias, "error", err)
+			continue
+		}
+		for _, wt := range list {
+			// The main worktree is the repository itself
+			if wt.Path == path || wt.Prunable != "" {
+				continue
+			}
+			folders = append(folders, codeWorkspaceFolder{Name: worktreeTargetAlias(alias, wt.Path), Path: wt.Path})
+		}
+	}
+	return folders
+}
+
+// writeCodeWorkspace writes folders to the workspace file, keeping the other
+// entries of an existing file
+func writeCodeWorkspace(file string, folders []codeWorkspaceFolder) error {
+	document := make(map[string]json.RawMessage)
+	data, err := os.ReadFile(file)
+	if err == nil {
+		if err := json.Unmarshal(data, &document); err != nil {
+			return fmt.Errorf("failed to parse workspace '%s' (comments and trailing commas are not supported): %w", file, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read workspace '%s': %w", file, err)
+	}
+
+	if folders == nil {
+		folders = []codeWorkspaceFolder{}
+	}
+	if document["folders"], err = json.Marshal(folders); err != nil {
+		return fmt.Errorf("failed to encode workspace folders: %w", err)
+	}
+	if _, exists := document["settings"]; !exists {
+		document["settings"] = json.RawMessage("{}")
+	}
+
+	data, err = json.MarshalIndent(document, "", "\t")
+	if err != nil {
+		return fmt.Errorf("failed to encode workspace: %w", err)
+	}
+	if err := os.WriteFile(file, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write workspace '%s': %w", file, err)
+	}
+	return nil
+}
+
+// refreshCodeWorkspaces regenerates the tracked VSCode workspaces after
+// repositories or groups changed. Workspaces whose file was deleted are no
+// longer tracked. Failures are only logged.
+func refreshCodeWorkspaces() {
+	configMgr := di.ConfigManager()
+	workspaces := append([]types.CodeWorkspace(nil), configMgr.GetConfig().CodeWorkspaces...)
+	for _, workspace := range workspaces {
+		if _, err := os.Stat(workspace.Path); os.IsNotExist(err) {
+			if err := configMgr.UntrackCodeWorkspace(workspace.Path); err != nil {
+				slog.Warn("failed to untrack deleted workspace", "workspace", workspace.Path, "error", err)
+			}
+			continue
+		}
+		if _, err := generateCodeWorkspace(workspace); err != nil {
+			slog.Warn("failed to regenerate workspace", "workspace", workspace.Path, "error", err)
+		}
+	}
+}
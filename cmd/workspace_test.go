@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteCodeWorkspaceKeepsSettings(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "product.code-workspace")
+	existing := `{"folders": [{"path": "/old"}], "settings": {"editor.tabSize": 2}, "extensions": {"recommendations": ["golang.go"]}}`
+	if err := os.WriteFile(file, []byte(existing), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	folders := []codeWorkspaceFolder{{Name: "api", Path: "/src/api"}, {Name: "api/feature", Path: "/src/api-feature"}}
+	if err := writeCodeWorkspace(file, folders); err != nil {
+		t.Fatalf("writeCodeWorkspace() error = %v", err)
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var workspace struct {
+		Folders    []codeWorkspaceFolder
+		Settings   map[string]int
+		Extensions map[string][]string
+	}
+	if err := json.Unmarshal(data, &workspace); err != nil {
+		t.Fatalf("invalid workspace %s: %v", data, err)
+	}
+	if len(workspace.Folders) != 2 || workspace.Folders[1] != folders[1] {
+		t.Errorf("folders = %+v; want %+v", workspace.Folders, folders)
+	}
+	if workspace.Settings["editor.tabSize"] != 2 || len(workspace.Extensions["recommendations"]) != 1 {
+		t.Errorf("existing entries were not kept: %s", data)
+	}
+
+	// Comments cannot be kept, so the file is left alone
+	if err := os.WriteFile(file, []byte("// comment\n{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeCodeWorkspace(file, folders); err == nil {
+		t.Error("writeCodeWorkspace() overwrote a workspace with comments")
+	}
+}
@@ -0,0 +1,640 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gman/internal/cache"
+	"gman/internal/di"
+	"gman/internal/display"
+	"gman/internal/errors"
+	"gman/internal/git"
+	"gman/internal/interactive"
+	"gman/pkg/types"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	worktreeAddBranch      string
+	worktreeAddGroupBranch string
+	worktreeLockReason     string
+
+	worktreeCleanGroup  string
+	worktreeCleanDryRun bool
+	worktreeCleanYes    bool
+
+	worktreePruneAll    bool
+	worktreePruneDryRun bool
+	worktreePruneYes    bool
+	worktreePruneForce  bool
+)
+
+// worktreeCmd represents the worktree command group
+var worktreeCmd = &cobra.Command{
+	Use:     "worktree",
+	Aliases: []string{"wt"},
+	Short:   "Manage git worktrees of your repositories",
+	Long: `Manage the linked worktrees of your repositories.
+
+Worktrees appear as switch targets next to their repository; see
+'gman switch alias@branch' to create and enter one.`,
+}
+
+// worktreeAddCmd represents the worktree add command
+var worktreeAddCmd = &cobra.Command{
+	Use:   "add <alias> [path] -b <branch>",
+	Short: "Create a worktree for a branch",
+	Long: `Create a linked worktree of a repository for a branch. A missing branch is
+created from the current HEAD, or tracks origin when only origin has it.
+
+Without a path the worktree is placed like 'gman switch alias@branch' does:
+in worktree_base_dir when set, otherwise next to the repository, and named
+by worktree_name_template. Slashes in branch names become dashes, so
+feature/x does not create nested directories.
+
+  settings:
+    worktree_base_dir: ~/worktrees
+    worktree_name_template: "{repo}-{branch}"   # also {dir}: the repository directory
+
+Examples:
+  gman worktree add backend -b feature/login            # ~/worktrees/backend-feature-login
+  gman worktree add backend ../backend-hotfix -b hotfix`,
+	Args:              cobra.RangeArgs(1, 2),
+	RunE:              runWorktreeAdd,
+	ValidArgsFunction: removeCmd.ValidArgsFunction,
+}
+
+// worktreeAddGroupCmd represents the worktree add-group command
+var worktreeAddGroupCmd = &cobra.Command{
+	Use:   "add-group <group> --branch <branch>",
+	Short: "Create a worktree for the same branch in every repository of a group",
+	Long: `Create a linked worktree for one branch in every repository of a group, so
+coordinated work such as a release gets a parallel checkout everywhere.
+
+The branch is created where it is missing, or tracks origin when only origin
+has it. Worktrees are placed like 'gman worktree add' places them.
+Repositories that already have a worktree for the branch are skipped.
+
+Examples:
+  gman worktree add-group backend --branch release/2.3`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWorktreeAddGroup,
+}
+
+// worktreeLockCmd represents the worktree lock command
+var worktreeLockCmd = &cobra.Command{
+	Use:   "lock <path>",
+	Short: "Lock a worktree so it is not pruned, moved or removed",
+	Long: `Lock a linked worktree with 'git worktree lock'. A locked worktree is kept
+by 'git worktree prune' even while its directory is missing, for example
+because it lives on a removable drive, and 'gman worktree prune' refuses to
+remove it unless --force is given.
+
+Examples:
+  gman worktree lock ~/worktrees/backend-feature --reason "on USB drive"`,
+	Args:              cobra.ExactArgs(1),
+	RunE:              runWorktreeLock,
+	ValidArgsFunction: completeWorktreeDirs,
+}
+
+// worktreeUnlockCmd represents the worktree unlock command
+var worktreeUnlockCmd = &cobra.Command{
+	Use:               "unlock <path>",
+	Short:             "Unlock a locked worktree",
+	Args:              cobra.ExactArgs(1),
+	RunE:              runWorktreeUnlock,
+	ValidArgsFunction: completeWorktreeDirs,
+}
+
+// worktreeCleanCmd represents the worktree clean command
+var worktreeCleanCmd = &cobra.Command{
+	Use:   "clean",
+	Short: "Remove worktrees of merged branches and delete the branches",
+	Long: `Find linked worktrees whose branch is fully merged into the main branch
+(main, master or develop), remove the worktree and delete the branch.
+
+Branches without commits of their own, such as ones just created, are not
+considered merged. Worktrees with uncommitted changes and locked worktrees
+are kept.
+
+Examples:
+  gman worktree clean --dry-run          # Only show what would be removed
+  gman worktree clean --group backend    # Repositories of one group
+  gman worktree clean --yes              # Without confirmation`,
+	Args: cobra.NoArgs,
+	RunE: runWorktreeClean,
+}
+
+// worktreePruneCmd represents the worktree prune command
+var worktreePruneCmd = &cobra.Command{
+	Use:   "prune [alias]",
+	Short: "Prune stale worktrees and remove orphaned worktree directories",
+	Long: `Run 'git worktree prune' so worktrees whose directory was deleted stop
+showing up, then look for orphaned worktrees:
+
+- worktrees whose branch was deleted
+- directories next to the repository or in worktree_base_dir that are
+  worktrees of the repository whose admin files were already pruned
+
+Orphans are listed and removed after confirmation. Worktrees with
+uncommitted changes and locked worktrees are kept unless --force is given;
+so are directories whose admin files were pruned, as git can no longer
+check them for changes.
+
+Examples:
+  gman worktree prune backend          # One repository
+  gman worktree prune --all            # Every repository
+  gman worktree prune --all --dry-run  # Only show what would be pruned`,
+	Args:              cobra.MaximumNArgs(1),
+	RunE:              runWorktreePrune,
+	ValidArgsFunction: removeCmd.ValidArgsFunction,
+}
+
+// worktreeMoveCmd represents the worktree move command
+var worktreeMoveCmd = &cobra.Command{
+	Use:   "move <alias> <old-path> <new-path>",
+	Short: "Move a linked worktree to another directory",
+	Long: `Move a linked worktree of a repository with 'git worktree move'.
+
+Moving a worktree directory by hand breaks git's link to it, and with it
+the worktree's switch target. gman moves it through git, creates missing
+parent directories and carries over the switch history of the worktree, as
+well as a configured repository alias pointing at the old path.
+
+Examples:
+  gman worktree move backend ../backend-feature ~/worktrees/backend-feature`,
+	Args: cobra.ExactArgs(3),
+	RunE: runWorktreeMove,
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) == 0 {
+			return removeCmd.ValidArgsFunction(cmd, args, toComplete)
+		}
+		return nil, cobra.ShellCompDirectiveFilterDirs
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(worktreeCmd)
+	worktreeCmd.AddCommand(worktreeAddCmd)
+	worktreeCmd.AddCommand(worktreeAddGroupCmd)
+	worktreeCmd.AddCommand(worktreeLockCmd)
+	worktreeCmd.AddCommand(worktreeUnlockCmd)
+	worktreeCmd.AddCommand(worktreeCleanCmd)
+	worktreeCmd.AddCommand(worktreePruneCmd)
+	worktreeCmd.AddCommand(worktreeMoveCmd)
+
+	worktreeAddCmd.Flags().StringVarP(&worktreeAddBranch, "branch", "b", "", "Branch to check out in the worktree (created when missing)")
+	worktreeAddCmd.MarkFlagRequired("branch")
+	worktreeAddGroupCmd.Flags().StringVarP(&worktreeAddGroupBranch, "branch", "b", "", "Branch to check out in every worktree (created when missing)")
+	worktreeAddGroupCmd.MarkFlagRequired("branch")
+
+	worktreeLockCmd.Flags().StringVar(&worktreeLockReason, "reason", "", "Why the worktree is locked, shown by git")
+
+	worktreeCleanCmd.Flags().StringVarP(&worktreeCleanGroup, "group", "g", "", "Only clean the repositories of this group")
+	worktreeCleanCmd.Flags().BoolVar(&worktreeCleanDryRun, "dry-run", false, "Show what would be removed without changing anything")
+	worktreeCleanCmd.Flags().BoolVarP(&worktreeCleanYes, "yes", "y", false, "Remove without confirmation")
+
+	worktreePruneCmd.Flags().BoolVar(&worktreePruneAll, "all", false, "Prune the worktrees of every repository")
+	worktreePruneCmd.Flags().BoolVar(&worktreePruneDryRun, "dry-run", false, "Show what would be pruned and removed without changing anything")
+	worktreePruneCmd.Flags().BoolVarP(&worktreePruneYes, "yes", "y", false, "Remove orphaned worktrees without confirmation")
+	worktreePruneCmd.Flags().BoolVar(&worktreePruneForce, "force", false, "Also remove orphaned worktrees that may have uncommitted changes")
+}
+
+// orphanedWorktree is an orphan found in one repository
+type orphanedWorktree struct {
+	alias    string
+	repoPath string
+	git.OrphanWorktree
+}
+
+func runWorktreeAdd(cmd *cobra.Command, args []string) error {
+	repositories, err := worktreeRepositories(args[:1], false)
+	if err != nil {
+		return err
+	}
+	alias, repoPath := args[0], repositories[args[0]]
+
+	var worktreePath string
+	if len(args) == 2 {
+		worktreePath, err = filepath.Abs(args[1])
+	} else {
+		worktreePath, err = branchWorktreePath(di.ConfigManager().GetConfig().Settings, alias, repoPath, worktreeAddBranch)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := createBranchWorktree(repoPath, worktreePath, worktreeAddBranch); err != nil {
+		return err
+	}
+	display.PrintSuccess(fmt.Sprintf("Created worktree for %s at %s", worktreeAddBranch, worktreePath))
+	return nil
+}
+
+func runWorktreeAddGroup(cmd *cobra.Command, args []string) error {
+	repositories, err := di.ConfigManager().GetGroupRepositories(args[0])
+	if err != nil {
+		return err
+	}
+	if len(repositories) == 0 {
+		return fmt.Errorf("group '%s' has no repositories", args[0])
+	}
+
+	settings := di.ConfigManager().GetConfig().Settings
+	gitMgr := di.GitManager()
+	branch := worktreeAddGroupBranch
+	var created, failed int
+	for _, alias := range sortedAliases(repositories) {
+		repoPath := repositories[alias]
+		if existing := branchWorktree(gitMgr, repoPath, branch); existing != "" {
+			fmt.Printf("%s %s: %s is already checked out at %s\n", display.WarningIcon(), alias, branch, existing)
+			continue
+		}
+
+		worktreePath, err := branchWorktreePath(settings, alias, repoPath, branch)
+		if err == nil {
+			err = createBranchWorktree(repoPath, worktreePath, branch)
+		}
+		if err != nil {
+			failed++
+			fmt.Printf("%s %s: %v\n", display.ErrorIcon(), alias, err)
+			continue
+		}
+		created++
+		fmt.Printf("%s %s: %s\n", display.SuccessIcon(), alias, worktreePath)
+	}
+
+	fmt.Printf("\nCreated %d worktrees for %s.\n", created, branch)
+	return worktreeFailures(failed)
+}
+
+// branchWorktree returns the path of the worktree that has branch checked
+// out, or "" when there is none
+func branchWorktree(gitMgr *git.Manager, repoPath, branch string) string {
+	worktrees, err := gitMgr.ListWorktrees(repoPath)
+	if err != nil {
+		return ""
+	}
+	for _, wt := range worktrees {
+		if wt.Branch == branch && wt.Prunable == "" {
+			return wt.Path
+		}
+	}
+	return ""
+}
+
+func runWorktreeLock(cmd *cobra.Command, args []string) error {
+	alias, repoPath, wt, err := findLinkedWorktree(args[0])
+	if err != nil {
+		return err
+	}
+	if wt.IsLocked {
+		return fmt.Errorf("worktree '%s' is already locked", wt.Path)
+	}
+	if err := di.GitManager().LockWorktree(repoPath, wt.Path, worktreeLockReason); err != nil {
+		return err
+	}
+	display.PrintSuccess(fmt.Sprintf("Locked worktree %s of %s", wt.Path, alias))
+	return nil
+}
+
+func runWorktreeUnlock(cmd *cobra.Command, args []string) error {
+	alias, repoPath, wt, err := findLinkedWorktree(args[0])
+	if err != nil {
+		return err
+	}
+	if !wt.IsLocked {
+		return fmt.Errorf("worktree '%s' is not locked", wt.Path)
+	}
+	if err := di.GitManager().UnlockWorktree(repoPath, wt.Path); err != nil {
+		return err
+	}
+	display.PrintSuccess(fmt.Sprintf("Unlocked worktree %s of %s", wt.Path, alias))
+	return nil
+}
+
+// findLinkedWorktree finds the configured repository that has a linked
+// worktree at path
+func findLinkedWorktree(path string) (string, string, types.Worktree, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", "", types.Worktree{}, fmt.Errorf("failed to resolve %s: %w", path, err)
+	}
+
+	repositories := di.ConfigManager().GetConfig().Repositories
+	gitMgr := di.GitManager()
+	for _, alias := range sortedAliases(repositories) {
+		wt, found, err := gitMgr.FindWorktree(repositories[alias], absPath)
+		if err != nil || !found {
+			continue
+		}
+		if wt.Path == repositories[alias] {
+			return "", "", types.Worktree{}, fmt.Errorf("'%s' is the main worktree of %s; only linked worktrees can be locked", path, alias)
+		}
+		return alias, repositories[alias], wt, nil
+	}
+	return "", "", types.Worktree{}, errors.NotFoundError("worktree", path)
+}
+
+// completeWorktreeDirs completes worktree paths as directories
+func completeWorktreeDirs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return nil, cobra.ShellCompDirectiveFilterDirs
+}
+
+// mergedWorktree is a worktree of a merged branch found in one repository
+type mergedWorktree struct {
+	alias      string
+	repoPath   string
+	mainBranch string
+	types.Worktree
+}
+
+func runWorktreeClean(cmd *cobra.Command, args []string) error {
+	repositories, err := execRepositories(worktreeCleanGroup)
+	if err != nil {
+		return err
+	}
+
+	gitMgr := di.GitManager()
+	var merged []mergedWorktree
+	var failed int
+	for _, alias := range sortedAliases(repositories) {
+		path := repositories[alias]
+		worktrees, mainBranch, err := gitMgr.MergedWorktrees(path, "")
+		if err != nil {
+			failed++
+			fmt.Printf("%s %s: %v\n", display.ErrorIcon(), alias, err)
+			continue
+		}
+		for _, wt := range worktrees {
+			if wt.IsLocked {
+				fmt.Printf("%s Keeping %s: %s is merged into %s but the worktree is locked\n",
+					display.WarningIcon(), wt.Path, wt.Branch, mainBranch)
+				continue
+			}
+			if dirty, err := gitMgr.HasUncommittedChanges(wt.Path); err != nil || dirty {
+				fmt.Printf("%s Keeping %s: %s is merged into %s but the worktree has uncommitted changes\n",
+					display.WarningIcon(), wt.Path, wt.Branch, mainBranch)
+				continue
+			}
+			merged = append(merged, mergedWorktree{alias: alias, repoPath: path, mainBranch: mainBranch, Worktree: wt})
+		}
+	}
+
+	if len(merged) == 0 {
+		fmt.Println("No worktrees of merged branches found.")
+		return worktreeFailures(failed)
+	}
+
+	fmt.Printf("\nWorktrees of merged branches:\n")
+	for _, wt := range merged {
+		fmt.Printf("  %s: %s (%s, merged into %s)\n", wt.alias, wt.Path, wt.Branch, wt.mainBranch)
+	}
+	if worktreeCleanDryRun {
+		fmt.Printf("Dry run: %d worktrees and their branches would be removed.\n", len(merged))
+		return worktreeFailures(failed)
+	}
+
+	if !worktreeCleanYes {
+		if interactive.NonInteractive() {
+			return interactive.ErrUnavailable("confirming the removal", "pass --yes to remove or --dry-run to preview")
+		}
+		fmt.Printf("Remove %d worktrees and delete their branches? [y/N]: ", len(merged))
+		if !askConfirmation(false) {
+			fmt.Println("Removal cancelled.")
+			return worktreeFailures(failed)
+		}
+	}
+
+	for _, wt := range merged {
+		// Without force git still refuses worktrees changed since the check
+		if err := gitMgr.RemoveWorktree(wt.repoPath, wt.Path, false); err != nil {
+			failed++
+			fmt.Printf("%s %s: %v\n", display.ErrorIcon(), wt.alias, err)
+			continue
+		}
+		if err := gitMgr.DeleteBranch(wt.repoPath, wt.Branch, false); err != nil {
+			failed++
+			fmt.Printf("%s %s: removed %s, but %v\n", display.ErrorIcon(), wt.alias, wt.Path, err)
+			continue
+		}
+		fmt.Printf("%s %s: removed %s and deleted %s\n", display.SuccessIcon(), wt.alias, wt.Path, wt.Branch)
+	}
+	return worktreeFailures(failed)
+}
+
+func runWorktreePrune(cmd *cobra.Command, args []string) error {
+	repositories, err := worktreeRepositories(args, worktreePruneAll)
+	if err != nil {
+		return err
+	}
+
+	cfg := di.ConfigManager().GetConfig()
+	var baseDir string
+	if cfg.Settings.WorktreeBaseDir != "" {
+		if baseDir, err = expandWorktreeBaseDir(cfg.Settings.WorktreeBaseDir); err != nil {
+			return err
+		}
+	}
+
+	gitMgr := di.GitManager()
+	var orphans []orphanedWorktree
+	var failed int
+	for _, alias := range sortedAliases(repositories) {
+		path := repositories[alias]
+		pruned, err := gitMgr.PruneWorktrees(path, worktreePruneDryRun)
+		if err != nil {
+			failed++
+			fmt.Printf("%s %s: %v\n", display.ErrorIcon(), alias, err)
+			continue
+		}
+		for _, line := range pruned {
+			if worktreePruneDryRun {
+				fmt.Printf("%s: would prune: %s\n", alias, line)
+			} else {
+				fmt.Printf("%s %s: %s\n", display.SuccessIcon(), alias, line)
+			}
+		}
+
+		searchDirs := []string{filepath.Dir(path)}
+		if baseDir != "" {
+			searchDirs = append(searchDirs, baseDir)
+		}
+		found, err := gitMgr.FindOrphanWorktrees(path, searchDirs)
+		if err != nil {
+			failed++
+			fmt.Printf("%s %s: %v\n", display.ErrorIcon(), alias, err)
+			continue
+		}
+		for _, orphan := range found {
+			orphans = append(orphans, orphanedWorktree{alias: alias, repoPath: path, OrphanWorktree: orphan})
+		}
+	}
+
+	if len(orphans) == 0 {
+		fmt.Println("No orphaned worktrees found.")
+		return worktreeFailures(failed)
+	}
+
+	fmt.Printf("\nOrphaned worktrees:\n")
+	for _, orphan := range orphans {
+		fmt.Printf("  %s %s: %s (%s)\n", display.WarningIcon(), orphan.alias, orphan.Path, orphan.Reason)
+	}
+
+	// Git cannot tell whether unregistered directories hold uncommitted work
+	var removable []orphanedWorktree
+	for _, orphan := range orphans {
+		if orphan.Unregistered && !worktreePruneForce {
+			fmt.Printf("%s Keeping %s: git cannot check it for uncommitted changes, pass --force to remove it\n",
+				display.WarningIcon(), orphan.Path)
+			continue
+		}
+		removable = append(removable, orphan)
+	}
+	if len(removable) == 0 {
+		return worktreeFailures(failed)
+	}
+	if worktreePruneDryRun {
+		fmt.Printf("Dry run: %d orphaned worktrees would be removed.\n", len(removable))
+		return worktreeFailures(failed)
+	}
+
+	if !worktreePruneYes {
+		if interactive.NonInteractive() {
+			return interactive.ErrUnavailable("confirming the removal", "pass --yes to remove or --dry-run to preview")
+		}
+		fmt.Printf("Remove %d orphaned worktrees? [y/N]: ", len(removable))
+		if !askConfirmation(false) {
+			fmt.Println("Removal cancelled.")
+			return worktreeFailures(failed)
+		}
+	}
+
+	for _, orphan := range removable {
+		if err := removeOrphanedWorktree(gitMgr, orphan); err != nil {
+			failed++
+			fmt.Printf("%s %s: %v\n", display.ErrorIcon(), orphan.alias, err)
+			continue
+		}
+		fmt.Printf("%s %s: removed %s\n", display.SuccessIcon(), orphan.alias, orphan.Path)
+	}
+	return worktreeFailures(failed)
+}
+
+func runWorktreeMove(cmd *cobra.Command, args []string) error {
+	repositories, err := worktreeRepositories(args[:1], false)
+	if err != nil {
+		return err
+	}
+	alias, repoPath := args[0], repositories[args[0]]
+
+	oldPath, err := filepath.Abs(args[1])
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", args[1], err)
+	}
+	newPath, err := filepath.Abs(args[2])
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", args[2], err)
+	}
+
+	// Symlinks only resolve while the worktree is still in its old place
+	configMgr := di.ConfigManager()
+	cfg := configMgr.GetConfig()
+	var moved []string
+	for repoAlias, path := range cfg.Repositories {
+		if git.SamePath(path, oldPath) {
+			moved = append(moved, repoAlias)
+		}
+	}
+	sort.Strings(moved)
+
+	if err := di.GitManager().MoveWorktree(repoPath, oldPath, newPath); err != nil {
+		return err
+	}
+	fmt.Printf("%s Moved worktree %s to %s\n", display.SuccessIcon(), oldPath, newPath)
+
+	// The move itself succeeded; failing to update the history only warns
+	for _, repoAlias := range moved {
+		cfg.Repositories[repoAlias] = newPath
+	}
+	if len(moved) > 0 {
+		if err := configMgr.Save(); err != nil {
+			slog.Warn("failed to update repository path", "aliases", moved, "error", err)
+		} else {
+			fmt.Printf("%s Updated the path of %s\n", display.SuccessIcon(), strings.Join(moved, ", "))
+		}
+	}
+
+	from := cache.SwitchRecord{Alias: worktreeTargetAlias(alias, oldPath), Path: oldPath}
+	to := cache.SwitchRecord{Alias: worktreeTargetAlias(alias, newPath), Path: newPath}
+	recentPath := cache.RecentPath(configMgr.GetConfigDir())
+	if recent, err := cache.LoadRecent(recentPath); err != nil {
+		slog.Warn("failed to update recent switches", "error", err)
+	} else {
+		recent.Move(from, to)
+		if err := recent.Save(recentPath); err != nil {
+			slog.Warn("failed to update recent switches", "error", err)
+		}
+	}
+
+	frecencyPath := cache.FrecencyPath(configMgr.GetConfigDir())
+	frecency, err := cache.LoadFrecency(frecencyPath)
+	if err != nil {
+		slog.Warn("failed to update switch history", "error", err)
+		return nil
+	}
+	frecency.Move(from, to)
+	if err := frecency.Save(frecencyPath); err != nil {
+		slog.Warn("failed to update switch history", "error", err)
+	}
+	return nil
+}
+
+// removeOrphanedWorktree removes a worktree git still knows with 'git
+// worktree remove', and an unregistered worktree directory from disk
+func removeOrphanedWorktree(gitMgr *git.Manager, orphan orphanedWorktree) error {
+	if !orphan.Unregistered {
+		return gitMgr.RemoveWorktree(orphan.repoPath, orphan.Path, worktreePruneForce)
+	}
+	if err := os.RemoveAll(orphan.Path); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", orphan.Path, err)
+	}
+	return nil
+}
+
+// worktreeRepositories returns the repository named by args, or every
+// repository with all
+func worktreeRepositories(args []string, all bool) (map[string]string, error) {
+	cfg := di.ConfigManager().GetConfig()
+	switch {
+	case all && len(args) > 0:
+		return nil, fmt.Errorf("pass either a repository alias or --all, not both")
+	case all:
+		return cfg.Repositories, nil
+	case len(args) == 0:
+		return nil, fmt.Errorf("pass a repository alias or --all")
+	}
+
+	path, exists := cfg.Repositories[args[0]]
+	if !exists {
+		return nil, errors.NotFoundError("repository", args[0])
+	}
+	return map[string]string{args[0]: path}, nil
+}
+
+// worktreeFailures returns the error of a worktree command that failed in
+// some repositories
+func worktreeFailures(failed int) error {
+	if failed > 0 {
+		return fmt.Errorf("failed in %d repositories", failed)
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+// Package bench generates synthetic repositories and measures how fast gman
+// reads status, syncs and searches them, for 'gman bench' and the benchmarks
+// in the test package.
+package bench
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gman/internal/external"
+	"gman/internal/git"
+	"gman/pkg/types"
+)
+
+// filesPerRepository is the number of source files in a synthetic repository
+const filesPerRepository = 20
+
+// gitEnv makes fixture commits independent of the user's git configuration
+var gitEnv = []string{
+	"GIT_AUTHOR_NAME=Benchmark User",
+	"GIT_AUTHOR_EMAIL=benchmark@test.com",
+	"GIT_COMMITTER_NAME=Benchmark User",
+	"GIT_COMMITTER_EMAIL=benchmark@test.com",
+	"GIT_CONFIG_NOSYSTEM=1",
+	"LANG=C",
+	"LC_ALL=C",
+}
+
+// runGit runs a git command for fixture setup
+func runGit(dir string, args ...string) error {
+	cmd := exec.Command("git", append([]string{"-c", "commit.gpgsign=false", "-c", "init.defaultBranch=main"}, args...)...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), gitEnv...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git %s failed in %s: %w\nOutput: %s", strings.Join(args, " "), dir, err, string(output))
+	}
+	return nil
+}
+
+// CreateRepository creates a git repository at path with a README and one
+// commit. When remoteDir is not empty, a bare clone is created there as
+// origin and main tracks it, so the repository can be fetched and synced.
+func CreateRepository(path, alias, remoteDir string) error {
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return err
+	}
+	if err := runGit(path, "init", "--quiet"); err != nil {
+		return err
+	}
+
+	readme := fmt.Sprintf("# %s\nBenchmark repository\n", alias)
+	if err := os.WriteFile(filepath.Join(path, "README.md"), []byte(readme), 0644); err != nil {
+		return err
+	}
+	if err := runGit(path, "add", "."); err != nil {
+		return err
+	}
+	if err := runGit(path, "commit", "--quiet", "-m", "Initial commit"); err != nil {
+		return err
+	}
+
+	if remoteDir == "" {
+		return nil
+	}
+	remotePath := filepath.Join(remoteDir, alias+".git")
+	if err := os.MkdirAll(remotePath, 0755); err != nil {
+		return err
+	}
+	if err := runGit(remotePath, "init", "--quiet", "--bare"); err != nil {
+		return err
+	}
+	if err := runGit(path, "remote", "add", "origin", remotePath); err != nil {
+		return err
+	}
+	return runGit(path, "push", "--quiet", "-u", "origin", "HEAD")
+}
+
+// populate adds source files and a second commit to a repository
+func populate(path, alias string) error {
+	srcDir := filepath.Join(path, "src")
+	if err := os.MkdirAll(srcDir, 0755); err != nil {
+		return err
+	}
+	for i := 0; i < filesPerRepository; i++ {
+		content := fmt.Sprintf("package src\n\n// Handler%d serves %s requests\nfunc Handler%d() string {\n\treturn %q\n}\n", i, alias, i, alias)
+		if err := os.WriteFile(filepath.Join(srcDir, fmt.Sprintf("handler_%02d.go", i)), []byte(content), 0644); err != nil {
+			return err
+		}
+	}
+	if err := runGit(path, "add", "."); err != nil {
+		return err
+	}
+	if err := runGit(path, "commit", "--quiet", "-m", "Add handlers"); err != nil {
+		return err
+	}
+	return runGit(path, "push", "--quiet")
+}
+
+// Generate creates count synthetic repositories with origin remotes below
+// baseDir and returns them by alias
+func Generate(baseDir string, count int) (map[string]string, error) {
+	remoteDir := filepath.Join(baseDir, "remotes")
+	repositories := make(map[string]string, count)
+	for i := 0; i < count; i++ {
+		alias := fmt.Sprintf("bench-%03d", i)
+		repositories[alias] = filepath.Join(baseDir, "repos", alias)
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+	semaphore := make(chan struct{}, 8)
+	for alias, path := range repositories {
+		wg.Add(1)
+		go func(alias, path string) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			err := CreateRepository(path, alias, remoteDir)
+			if err == nil {
+				err = populate(path, alias)
+			}
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = fmt.Errorf("failed to create %s: %w", alias, err)
+				}
+				mu.Unlock()
+			}
+		}(alias, path)
+	}
+	wg.Wait()
+	return repositories, firstErr
+}
+
+// Result is the timing of one operation over all repositories
+type Result struct {
+	Operation      string        `json:"operation" yaml:"operation"`
+	Repositories   int           `json:"repositories" yaml:"repositories"`
+	Iterations     int           `json:"iterations" yaml:"iterations"`
+	Best           time.Duration `json:"best_ns" yaml:"best_ns"`
+	Median         time.Duration `json:"median_ns" yaml:"median_ns"`
+	ReposPerSecond float64       `json:"repos_per_second" yaml:"repos_per_second"`
+	Error          string        `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// operation is one measured step
+type operation struct {
+	name string
+	run  func() error
+}
+
+// Run measures every operation iterations times over repositories
+func Run(repositories map[string]string, iterations int) []Result {
+	gitMgr := git.NewManager()
+	operations := []operation{
+		{"status (local)", func() error {
+			return statusErrors(gitMgr.GetAllRepoStatusNoFetch(repositories))
+		}},
+		{"status (fetch)", func() error {
+			return statusErrors(gitMgr.GetAllRepoStatus(repositories))
+		}},
+		{"sync (ff-only)", func() error {
+			return gitMgr.SyncAllRepositories(repositories, "ff-only", 5)
+		}},
+		{"find file", func() error {
+			_, err := external.NewSmartSearcher(false).SearchFiles("handler_1", repositories, "")
+			return err
+		}},
+		{"find content", func() error {
+			searcher, _ := external.NewContentSearcher()
+			_, err := searcher.SearchContent("Handler7", repositories, "")
+			return err
+		}},
+	}
+
+	results := make([]Result, 0, len(operations))
+	for _, op := range operations {
+		results = append(results, measure(op, len(repositories), iterations))
+	}
+	return results
+}
+
+// measure runs op iterations times and keeps the best and median duration
+func measure(op operation, repoCount, iterations int) Result {
+	result := Result{Operation: op.name, Repositories: repoCount, Iterations: iterations}
+	durations := make([]time.Duration, 0, iterations)
+	for i := 0; i < iterations; i++ {
+		start := time.Now()
+		if err := op.run(); err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		durations = append(durations, time.Since(start))
+	}
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	result.Best = durations[0]
+	result.Median = durations[len(durations)/2]
+	if result.Median > 0 {
+		result.ReposPerSecond = float64(repoCount) / result.Median.Seconds()
+	}
+	return result
+}
+
+// statusErrors reports the first repository whose status failed
+func statusErrors(statuses []types.RepoStatus, err error) error {
+	if err != nil {
+		return err
+	}
+	for _, status := range statuses {
+		if status.Error != nil {
+			return fmt.Errorf("%s: %w", status.Alias, status.Error)
+		}
+		if status.SyncStatus.SyncError != nil {
+			return fmt.Errorf("%s: %w", status.Alias, status.SyncStatus.SyncError)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package bench
+
+import (
+	"errors"
+	"os/exec"
+	"testing"
+	"time"
+)
+
+func TestGenerate(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	repositories, err := Generate(t.TempDir(), 2)
+	if err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+	if len(repositories) != 2 {
+		t.Fatalf("Expected 2 repositories, got %d", len(repositories))
+	}
+
+	for _, result := range Run(repositories, 1) {
+		if result.Error != "" {
+			t.Errorf("%s failed: %s", result.Operation, result.Error)
+		}
+	}
+}
+
+func TestMeasure(t *testing.T) {
+	calls := 0
+	result := measure(operation{"sleep", func() error {
+		calls++
+		time.Sleep(time.Duration(calls) * time.Millisecond)
+		return nil
+	}}, 10, 3)
+
+	if calls != 3 || result.Best > result.Median || result.ReposPerSecond <= 0 {
+		t.Errorf("Unexpected result after %d calls: %+v", calls, result)
+	}
+
+	failed := measure(operation{"fail", func() error { return errors.New("boom") }}, 10, 3)
+	if failed.Error != "boom" {
+		t.Errorf("Expected the error to be reported, got %+v", failed)
+	}
+}
@@ -0,0 +1,176 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gman/pkg/types"
+)
+
+// FrecencyFile is the file name of the frecency store inside the config directory
+const FrecencyFile = "frecency.json"
+
+// maxFrecencyTotal bounds the sum of all visit counts. Once exceeded, every
+// count is aged so that old favourites slowly give way to new ones.
+const maxFrecencyTotal = 1000
+
+// FrecencyEntry records how often and how recently a switch target was visited
+type FrecencyEntry struct {
+	Alias     string    `json:"alias"`
+	Count     float64   `json:"count"`
+	LastVisit time.Time `json:"last_visit"`
+}
+
+// SwitchRecord identifies a switch target by alias and path
+type SwitchRecord struct {
+	Alias string `json:"alias"`
+	Path  string `json:"path"`
+}
+
+// FrecencyStore holds the visit history used to rank switch targets, and the
+// last two switch targets for 'gman switch -'
+type FrecencyStore struct {
+	Entries  map[string]FrecencyEntry `json:"entries"`
+	Last     *SwitchRecord            `json:"last,omitempty"`
+	Previous *SwitchRecord            `json:"previous,omitempty"`
+}
+
+// FrecencyPath returns the frecency store location inside the given config directory
+func FrecencyPath(configDir string) string {
+	return filepath.Join(configDir, FrecencyFile)
+}
+
+// LoadFrecency reads the frecency store from path. A missing file yields an
+// empty store.
+func LoadFrecency(path string) (*FrecencyStore, error) {
+	s := &FrecencyStore{Entries: make(map[string]FrecencyEntry)}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("invalid frecency store '%s': %w", path, err)
+	}
+	if s.Entries == nil {
+		s.Entries = make(map[string]FrecencyEntry)
+	}
+
+	return s, nil
+}
+
+// Save writes the frecency store to path atomically
+func (s *FrecencyStore) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creating cache directory: %w", err)
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("error marshaling frecency store: %w", err)
+	}
+
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+		return fmt.Errorf("error writing temp frecency file: %w", err)
+	}
+
+	if err := os.Rename(tempPath, path); err != nil {
+		os.Remove(tempPath) // Clean up on failure
+		return fmt.Errorf("error moving temp frecency file: %w", err)
+	}
+
+	return nil
+}
+
+// Visit records a switch or cd into the target with the given alias
+func (s *FrecencyStore) Visit(alias string, now time.Time) {
+	entry := s.Entries[alias]
+	entry.Alias = alias
+	entry.Count++
+	entry.LastVisit = now
+	s.Entries[alias] = entry
+
+	var total float64
+	for _, e := range s.Entries {
+		total += e.Count
+	}
+	if total <= maxFrecencyTotal {
+		return
+	}
+
+	for key, e := range s.Entries {
+		e.Count *= 0.9
+		if e.Count < 1 {
+			delete(s.Entries, key)
+			continue
+		}
+		s.Entries[key] = e
+	}
+}
+
+// Seed adds a single visit for recent entries the store does not know yet,
+// so history recorded before frecency tracking still counts
+func (s *FrecencyStore) Seed(recent []types.RecentEntry) {
+	for _, r := range recent {
+		if _, exists := s.Entries[r.Alias]; exists {
+			continue
+		}
+		s.Entries[r.Alias] = FrecencyEntry{Alias: r.Alias, Count: 1, LastVisit: r.AccessTime}
+	}
+}
+
+// Score combines visit count and recency; zero means never visited
+func (s *FrecencyStore) Score(alias string, now time.Time) float64 {
+	entry, exists := s.Entries[alias]
+	if !exists {
+		return 0
+	}
+
+	age := now.Sub(entry.LastVisit)
+	switch {
+	case age < time.Hour:
+		return entry.Count * 4
+	case age < 24*time.Hour:
+		return entry.Count * 2
+	case age < 7*24*time.Hour:
+		return entry.Count * 0.5
+	default:
+		return entry.Count * 0.25
+	}
+}
+
+// RecordSwitch remembers a switch to target. from is where the switch started
+// (nil when outside of any target); it becomes the previous target, falling
+// back to the last switch target when unknown.
+func (s *FrecencyStore) RecordSwitch(from *SwitchRecord, target SwitchRecord) {
+	switch {
+	case from != nil && from.Path != target.Path:
+		s.Previous = from
+	case from == nil && s.Last != nil && s.Last.Path != target.Path:
+		s.Previous = s.Last
+	}
+	s.Last = &target
+}
+
+// Move carries the history of a switch target over to its new alias and
+// path, e.g. after its worktree was moved
+func (s *FrecencyStore) Move(from, to SwitchRecord) {
+	if entry, exists := s.Entries[from.Alias]; exists && from.Alias != to.Alias {
+		delete(s.Entries, from.Alias)
+		entry.Alias = to.Alias
+		s.Entries[to.Alias] = entry
+	}
+	for _, record := range []*SwitchRecord{s.Last, s.Previous} {
+		if record != nil && record.Path == from.Path {
+			*record = to
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package cache
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gman/pkg/types"
+)
+
+func TestFrecencyScoreAndPersistence(t *testing.T) {
+	now := time.Now()
+	store := &FrecencyStore{Entries: make(map[string]FrecencyEntry)}
+
+	// Frequent but long ago versus a single visit right now
+	for i := 0; i < 20; i++ {
+		store.Visit("old-favourite", now.Add(-30*24*time.Hour))
+	}
+	store.Visit("fresh", now)
+	store.Seed([]types.RecentEntry{
+		{Alias: "fresh", AccessTime: now.Add(-time.Hour)},
+		{Alias: "seeded", AccessTime: now.Add(-2 * time.Hour)},
+	})
+
+	if got := store.Entries["fresh"].Count; got != 1 {
+		t.Errorf("Seed changed an existing entry: count = %v", got)
+	}
+	if store.Score("fresh", now) <= store.Score("seeded", now) {
+		t.Error("expected a visit within the hour to outrank an older one")
+	}
+	if store.Score("old-favourite", now) <= store.Score("fresh", now) {
+		t.Error("expected twenty old visits to outrank a single fresh one")
+	}
+	if store.Score("unknown", now) != 0 {
+		t.Error("expected zero score for an unvisited alias")
+	}
+
+	path := filepath.Join(t.TempDir(), FrecencyFile)
+	if err := store.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := LoadFrecency(path)
+	if err != nil {
+		t.Fatalf("LoadFrecency failed: %v", err)
+	}
+	if len(loaded.Entries) != 3 || loaded.Entries["old-favourite"].Count != 20 {
+		t.Errorf("unexpected entries after reload: %+v", loaded.Entries)
+	}
+
+	missing, err := LoadFrecency(filepath.Join(t.TempDir(), "absent.json"))
+	if err != nil || len(missing.Entries) != 0 {
+		t.Errorf("expected an empty store for a missing file, got %+v (err %v)", missing, err)
+	}
+}
+
+func TestFrecencyAging(t *testing.T) {
+	now := time.Now()
+	store := &FrecencyStore{Entries: map[string]FrecencyEntry{
+		"busy": {Alias: "busy", Count: maxFrecencyTotal, LastVisit: now},
+		"rare": {Alias: "rare", Count: 1, LastVisit: now},
+	}}
+
+	store.Visit("busy", now)
+
+	if _, exists := store.Entries["rare"]; exists {
+		t.Error("expected entries aged below one visit to be dropped")
+	}
+	if got := store.Entries["busy"].Count; got >= maxFrecencyTotal {
+		t.Errorf("expected counts to be aged, got %v", got)
+	}
+}
+
+func TestRecordSwitch(t *testing.T) {
+	store := &FrecencyStore{Entries: make(map[string]FrecencyEntry)}
+	a := SwitchRecord{Alias: "a", Path: "/src/a"}
+	b := SwitchRecord{Alias: "b", Path: "/src/b"}
+	wt := SwitchRecord{Alias: "a/feature", Path: "/src/a-feature"}
+
+	// Switching from outside of any target falls back to the last target
+	store.RecordSwitch(nil, a)
+	if store.Previous != nil {
+		t.Errorf("expected no previous target after the first switch, got %+v", store.Previous)
+	}
+	store.RecordSwitch(nil, b)
+	if store.Previous == nil || *store.Previous != a {
+		t.Errorf("Previous = %+v, want %+v", store.Previous, a)
+	}
+
+	// The starting location wins over the last switch target
+	store.RecordSwitch(&wt, a)
+	if *store.Previous != wt || *store.Last != a {
+		t.Errorf("Previous = %+v, Last = %+v", store.Previous, store.Last)
+	}
+
+	// Switching to where we already are keeps the previous target
+	store.RecordSwitch(&a, a)
+	if *store.Previous != wt {
+		t.Errorf("Previous = %+v, want %+v", store.Previous, wt)
+	}
+}
+
+func TestFrecencyMove(t *testing.T) {
+	now := time.Now()
+	store := &FrecencyStore{Entries: make(map[string]FrecencyEntry)}
+	old := SwitchRecord{Alias: "a/a-feature", Path: "/src/a-feature"}
+	moved := SwitchRecord{Alias: "a/feature", Path: "/work/feature"}
+	store.Visit(old.Alias, now)
+	store.Visit(old.Alias, now)
+	store.RecordSwitch(nil, SwitchRecord{Alias: "a", Path: "/src/a"})
+	store.RecordSwitch(nil, old)
+
+	store.Move(old, moved)
+	if _, exists := store.Entries[old.Alias]; exists {
+		t.Errorf("expected the old alias to be gone: %+v", store.Entries)
+	}
+	if entry := store.Entries[moved.Alias]; entry.Count != 2 || entry.Alias != moved.Alias {
+		t.Errorf("Entries[%q] = %+v, want the moved history", moved.Alias, entry)
+	}
+	if *store.Last != moved || store.Previous.Alias != "a" {
+		t.Errorf("Last = %+v, Previous = %+v", store.Last, store.Previous)
+	}
+}
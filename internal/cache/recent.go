@@ -0,0 +1,171 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gman/pkg/types"
+)
+
+// RecentFile is the file name of the recent switch history inside the
+// config directory
+const RecentFile = "recent.json"
+
+// maxRecentSwitches bounds the history; the oldest switches are dropped
+const maxRecentSwitches = 1000
+
+// RecentSwitch records one switch
+type RecentSwitch struct {
+	Alias    string    `json:"alias"`              // Repository alias
+	Worktree string    `json:"worktree,omitempty"` // Switch target of a worktree or bookmark; empty for the repository itself
+	Path     string    `json:"path"`
+	Source   string    `json:"source,omitempty"` // Directory the switch started from
+	Time     time.Time `json:"time"`
+}
+
+// Target is the switch target alias: the worktree, or else the repository
+func (r RecentSwitch) Target() string {
+	if r.Worktree != "" {
+		return r.Worktree
+	}
+	return r.Alias
+}
+
+// RecentTarget is a switch target with its most recent switch and how
+// often it was switched to
+type RecentTarget struct {
+	RecentSwitch
+	Switches int
+}
+
+// RecentStore is the history of switches, oldest first
+type RecentStore struct {
+	Switches []RecentSwitch `json:"switches"`
+}
+
+// RecentPath returns the recent switch history location inside the given
+// config directory
+func RecentPath(configDir string) string {
+	return filepath.Join(configDir, RecentFile)
+}
+
+// LoadRecent reads the switch history from path. A missing file yields an
+// empty history.
+func LoadRecent(path string) (*RecentStore, error) {
+	s := &RecentStore{}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("invalid recent switch history '%s': %w", path, err)
+	}
+	return s, nil
+}
+
+// Save writes the switch history to path atomically
+func (s *RecentStore) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creating cache directory: %w", err)
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("error marshaling recent switch history: %w", err)
+	}
+
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+		return fmt.Errorf("error writing temp recent switch history: %w", err)
+	}
+
+	if err := os.Rename(tempPath, path); err != nil {
+		os.Remove(tempPath) // Clean up on failure
+		return fmt.Errorf("error moving temp recent switch history: %w", err)
+	}
+
+	return nil
+}
+
+// Record appends a switch to the history
+func (s *RecentStore) Record(entry RecentSwitch) {
+	s.Switches = append(s.Switches, entry)
+	if excess := len(s.Switches) - maxRecentSwitches; excess > 0 {
+		s.Switches = s.Switches[excess:]
+	}
+}
+
+// Seed fills an empty history with the recent usage list from the
+// configuration, so switches recorded before the history existed still show.
+// paths maps repository aliases to their paths.
+func (s *RecentStore) Seed(recent []types.RecentEntry, paths map[string]string) {
+	if len(s.Switches) > 0 {
+		return
+	}
+	// The recent usage list is newest first
+	for i := len(recent) - 1; i >= 0; i-- {
+		if path, exists := paths[recent[i].Alias]; exists {
+			s.Switches = append(s.Switches, RecentSwitch{Alias: recent[i].Alias, Path: path, Time: recent[i].AccessTime})
+		}
+	}
+}
+
+// Recent returns the switch targets switched to since the given time (all
+// when zero), most recent first, at most limit of them unless limit is zero
+func (s *RecentStore) Recent(since time.Time, limit int) []RecentTarget {
+	var targets []RecentTarget
+	seen := make(map[string]int)
+	for i := len(s.Switches) - 1; i >= 0; i-- {
+		entry := s.Switches[i]
+		if !since.IsZero() && entry.Time.Before(since) {
+			continue
+		}
+		if index, exists := seen[entry.Path]; exists {
+			targets[index].Switches++
+			continue
+		}
+		seen[entry.Path] = len(targets)
+		targets = append(targets, RecentTarget{RecentSwitch: entry, Switches: 1})
+	}
+	if limit > 0 && len(targets) > limit {
+		targets = targets[:limit]
+	}
+	return targets
+}
+
+// Prune drops the switches for which keep returns false, e.g. those of
+// deleted repositories, and returns how many were dropped
+func (s *RecentStore) Prune(keep func(RecentSwitch) bool) int {
+	kept := s.Switches[:0]
+	for _, entry := range s.Switches {
+		if keep(entry) {
+			kept = append(kept, entry)
+		}
+	}
+	pruned := len(s.Switches) - len(kept)
+	s.Switches = kept
+	return pruned
+}
+
+// Move carries the history of a switch target over to its new alias and
+// path, e.g. after its worktree was moved
+func (s *RecentStore) Move(from, to SwitchRecord) {
+	for i := range s.Switches {
+		entry := &s.Switches[i]
+		if entry.Path != from.Path {
+			continue
+		}
+		entry.Path = to.Path
+		if entry.Worktree != "" {
+			entry.Worktree = to.Alias
+		}
+	}
+}
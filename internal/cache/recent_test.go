@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gman/pkg/types"
+)
+
+func TestRecentStore(t *testing.T) {
+	now := time.Now()
+	store := &RecentStore{}
+	store.Seed([]types.RecentEntry{
+		{Alias: "b", AccessTime: now.Add(-time.Hour)},
+		{Alias: "gone", AccessTime: now.Add(-2 * time.Hour)},
+		{Alias: "a", AccessTime: now.Add(-10 * 24 * time.Hour)},
+	}, map[string]string{"a": "/src/a", "b": "/src/b"})
+	store.Record(RecentSwitch{Alias: "a", Worktree: "a/feature", Path: "/src/a-feature", Source: "/src/b", Time: now.Add(-time.Minute)})
+	store.Record(RecentSwitch{Alias: "b", Path: "/src/b", Source: "/src/a-feature", Time: now})
+
+	recent := store.Recent(time.Time{}, 0)
+	if len(recent) != 3 || recent[0].Target() != "b" || recent[0].Switches != 2 || recent[1].Target() != "a/feature" {
+		t.Fatalf("Recent() = %+v; want b (2 switches), a/feature, a", recent)
+	}
+	if since := store.Recent(now.Add(-7*24*time.Hour), 0); len(since) != 2 {
+		t.Errorf("Recent(7 days) = %+v; want 2 targets", since)
+	}
+	if limited := store.Recent(time.Time{}, 1); len(limited) != 1 || limited[0].Alias != "b" {
+		t.Errorf("Recent(limit 1) = %+v", limited)
+	}
+
+	// Seeding only fills an empty history
+	store.Seed([]types.RecentEntry{{Alias: "b", AccessTime: now}}, map[string]string{"b": "/src/b"})
+	if len(store.Switches) != 4 {
+		t.Errorf("Seed changed a non-empty history: %+v", store.Switches)
+	}
+
+	store.Move(SwitchRecord{Alias: "a/feature", Path: "/src/a-feature"}, SwitchRecord{Alias: "a/feat", Path: "/work/feat"})
+	if moved := store.Recent(time.Time{}, 0)[1]; moved.Path != "/work/feat" || moved.Target() != "a/feat" {
+		t.Errorf("moved target = %+v", moved)
+	}
+
+	if pruned := store.Prune(func(entry RecentSwitch) bool { return entry.Alias != "a" }); pruned != 2 {
+		t.Errorf("Prune() = %d; want 2", pruned)
+	}
+
+	path := filepath.Join(t.TempDir(), RecentFile)
+	if err := store.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := LoadRecent(path)
+	if err != nil || len(loaded.Switches) != 2 {
+		t.Errorf("LoadRecent() = %+v, %v; want 2 switches", loaded, err)
+	}
+}
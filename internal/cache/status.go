@@ -0,0 +1,174 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gman/pkg/types"
+)
+
+// StatusCacheFile is the file name of the status cache inside the config directory
+const StatusCacheFile = "status_cache.json"
+
+// StatusEntry is the cached, serializable subset of a repository status
+type StatusEntry struct {
+	Alias     string    `json:"alias"`
+	Path      string    `json:"path"`
+	Branch    string    `json:"branch"`
+	Dirty     bool      `json:"dirty"`
+	Stashed   bool      `json:"stashed"`
+	Ahead     int       `json:"ahead"`
+	Behind    int       `json:"behind"`
+	Error     string    `json:"error,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// StatusCache holds the last known status of every repository
+type StatusCache struct {
+	UpdatedAt time.Time              `json:"updated_at"`
+	Entries   map[string]StatusEntry `json:"entries"`
+}
+
+// StatusCachePath returns the status cache location inside the given config directory
+func StatusCachePath(configDir string) string {
+	return filepath.Join(configDir, StatusCacheFile)
+}
+
+// NewStatusCache builds a cache from freshly computed repository statuses
+func NewStatusCache(statuses []types.RepoStatus) *StatusCache {
+	now := time.Now()
+	c := &StatusCache{
+		UpdatedAt: now,
+		Entries:   make(map[string]StatusEntry, len(statuses)),
+	}
+
+	for _, status := range statuses {
+		entry := StatusEntry{
+			Alias:     status.Alias,
+			Path:      status.Path,
+			Branch:    status.Branch,
+			Dirty:     status.Workspace == types.Dirty,
+			Stashed:   status.Workspace == types.Stashed,
+			Ahead:     status.SyncStatus.Ahead,
+			Behind:    status.SyncStatus.Behind,
+			UpdatedAt: now,
+		}
+		if status.Error != nil {
+			entry.Error = status.Error.Error()
+		} else if status.SyncStatus.SyncError != nil {
+			entry.Error = status.SyncStatus.SyncError.Error()
+		}
+		c.Entries[status.Alias] = entry
+	}
+
+	return c
+}
+
+// LoadStatusCache reads the status cache from path
+func LoadStatusCache(path string) (*StatusCache, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var c StatusCache
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("invalid status cache '%s': %w", path, err)
+	}
+	if c.Entries == nil {
+		c.Entries = make(map[string]StatusEntry)
+	}
+
+	return &c, nil
+}
+
+// Save writes the status cache to path atomically
+func (c *StatusCache) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creating cache directory: %w", err)
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("error marshaling status cache: %w", err)
+	}
+
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+		return fmt.Errorf("error writing temp cache file: %w", err)
+	}
+
+	if err := os.Rename(tempPath, path); err != nil {
+		os.Remove(tempPath) // Clean up on failure
+		return fmt.Errorf("error moving temp cache file: %w", err)
+	}
+
+	return nil
+}
+
+// IsStale reports whether the cache is older than maxAge
+func (c *StatusCache) IsStale(maxAge time.Duration) bool {
+	return time.Since(c.UpdatedAt) > maxAge
+}
+
+// Restrict drops entries for repositories that are no longer configured
+func (c *StatusCache) Restrict(repositories map[string]string) {
+	for alias, entry := range c.Entries {
+		path, exists := repositories[alias]
+		if !exists || path != entry.Path {
+			delete(c.Entries, alias)
+		}
+	}
+}
+
+// PromptSummary counts repositories by state for prompt rendering
+type PromptSummary struct {
+	Dirty  int
+	Behind int
+	Ahead  int
+	Errors int
+}
+
+// Summarize counts dirty, behind, ahead and failing repositories
+func (c *StatusCache) Summarize() PromptSummary {
+	var s PromptSummary
+	for _, entry := range c.Entries {
+		if entry.Error != "" {
+			s.Errors++
+			continue
+		}
+		if entry.Dirty {
+			s.Dirty++
+		}
+		if entry.Behind > 0 {
+			s.Behind++
+		}
+		if entry.Ahead > 0 {
+			s.Ahead++
+		}
+	}
+	return s
+}
+
+// String renders the summary as a compact single line, e.g. "3✚ 2⇣ 1⇡ 1✗".
+// Zero counts are omitted so a fully clean fleet renders as an empty string.
+func (s PromptSummary) String() string {
+	var parts []string
+	if s.Dirty > 0 {
+		parts = append(parts, fmt.Sprintf("%d✚", s.Dirty))
+	}
+	if s.Behind > 0 {
+		parts = append(parts, fmt.Sprintf("%d⇣", s.Behind))
+	}
+	if s.Ahead > 0 {
+		parts = append(parts, fmt.Sprintf("%d⇡", s.Ahead))
+	}
+	if s.Errors > 0 {
+		parts = append(parts, fmt.Sprintf("%d✗", s.Errors))
+	}
+	return strings.Join(parts, " ")
+}
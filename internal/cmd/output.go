@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats accepted by the global --output flag
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputYAML  = "yaml"
+	OutputCSV   = "csv"
+	OutputTSV   = "tsv"
+)
+
+// outputFormats lists the valid formats in the order they are documented
+var outputFormats = []string{OutputTable, OutputJSON, OutputYAML, OutputCSV, OutputTSV}
+
+// outputFormat is the format selected with --output
+var outputFormat = OutputTable
+
+// SetOutputFormat selects the format used by Render
+func SetOutputFormat(format string) error {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		format = OutputTable
+	}
+	for _, valid := range outputFormats {
+		if format == valid {
+			outputFormat = format
+			return nil
+		}
+	}
+	return FormatValidationError("output format", format, "must be one of "+strings.Join(outputFormats, ", "))
+}
+
+// OutputFormat returns the format selected with --output
+func OutputFormat() string {
+	return outputFormat
+}
+
+// StructuredOutput reports whether machine-readable output was requested.
+// Commands use it to suppress banners and progress output.
+func StructuredOutput() bool {
+	return outputFormat != OutputTable
+}
+
+// Render writes data to stdout in the selected format. The table format is
+// drawn by table, so every command keeps its own human-readable layout.
+func Render(data any, table func() error) error {
+	return RenderTo(os.Stdout, data, table)
+}
+
+// RenderTo is Render with an explicit writer for structured formats
+func RenderTo(w io.Writer, data any, table func() error) error {
+	switch outputFormat {
+	case OutputJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(data); err != nil {
+			return fmt.Errorf("failed to encode JSON output: %w", err)
+		}
+		return nil
+	case OutputYAML:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(data); err != nil {
+			return fmt.Errorf("failed to encode YAML output: %w", err)
+		}
+		return encoder.Close()
+	case OutputCSV:
+		return writeDelimited(w, data, ',')
+	case OutputTSV:
+		return writeDelimited(w, data, '\t')
+	default:
+		return table()
+	}
+}
+
+// formatFuncs are the helpers available to --format templates
+var formatFuncs = template.FuncMap{
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+// RenderFormat executes the Go template format once per item, writing one
+// line each, e.g. --format '{{.Alias}} {{.Branch}}'
+func RenderFormat[T any](w io.Writer, format string, items []T) error {
+	if OutputFormat() != OutputTable {
+		return fmt.Errorf("--format cannot be combined with --output %s", OutputFormat())
+	}
+
+	tmpl, err := template.New("format").Funcs(formatFuncs).Parse(format)
+	if err != nil {
+		return fmt.Errorf("invalid format template: %w", err)
+	}
+
+	for _, item := range items {
+		if err := tmpl.Execute(w, item); err != nil {
+			return fmt.Errorf("failed to execute format template: %w", err)
+		}
+		fmt.Fprintln(w)
+	}
+	return nil
+}
+
+// writeDelimited writes a slice of structs as CSV (or TSV with a tab
+// separator). The header row uses the json field names; nested values are
+// not supported and fields tagged csv:"-" are left out, lists are joined
+// with ";" and times use RFC 3339.
+func writeDelimited(w io.Writer, data any, separator rune) error {
+	rows := reflect.ValueOf(data)
+	if rows.Kind() != reflect.Slice || rows.Type().Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%s output is not supported by this command", OutputFormat())
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = separator
+
+	rowType := rows.Type().Elem()
+	var header []string
+	var fields []int
+	for i := 0; i < rowType.NumField(); i++ {
+		field := rowType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || field.Tag.Get("csv") == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		header = append(header, name)
+		fields = append(fields, i)
+	}
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write %s output: %w", OutputFormat(), err)
+	}
+
+	for i := 0; i < rows.Len(); i++ {
+		record := make([]string, len(fields))
+		for j, field := range fields {
+			record[j] = delimitedValue(rows.Index(i).Field(field).Interface())
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write %s output: %w", OutputFormat(), err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// delimitedValue formats a single CSV/TSV cell
+func delimitedValue(value any) string {
+	// Optional fields are pointers; unset ones are empty cells
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return ""
+		}
+		value = rv.Elem().Interface()
+	}
+
+	switch v := value.(type) {
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.Format(time.RFC3339)
+	case []string:
+		return strings.Join(v, ";")
+	default:
+		return fmt.Sprint(v)
+	}
+}
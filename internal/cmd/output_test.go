@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+type outputRecord struct {
+	Alias string `json:"alias" yaml:"alias"`
+	Path  string `json:"path" yaml:"path"`
+}
+
+func TestSetOutputFormat(t *testing.T) {
+	defer SetOutputFormat(OutputTable)
+
+	for _, format := range []string{"table", "json", "YAML", ""} {
+		if err := SetOutputFormat(format); err != nil {
+			t.Errorf("SetOutputFormat(%q) returned error: %v", format, err)
+		}
+	}
+	if OutputFormat() != OutputTable {
+		t.Errorf("Expected empty format to select table, got %s", OutputFormat())
+	}
+
+	if err := SetOutputFormat("xml"); err == nil {
+		t.Error("Expected error for unknown format")
+	}
+	if OutputFormat() != OutputTable {
+		t.Errorf("Expected invalid format to keep table, got %s", OutputFormat())
+	}
+}
+
+func TestRenderTo(t *testing.T) {
+	defer SetOutputFormat(OutputTable)
+
+	records := []outputRecord{{Alias: "api", Path: "/src/api"}}
+	tableCalled := false
+	table := func() error {
+		tableCalled = true
+		return nil
+	}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{OutputJSON, "[\n  {\n    \"alias\": \"api\",\n    \"path\": \"/src/api\"\n  }\n]\n"},
+		{OutputYAML, "- alias: api\n  path: /src/api\n"},
+	}
+
+	for _, tt := range tests {
+		SetOutputFormat(tt.format)
+		var buf bytes.Buffer
+		if err := RenderTo(&buf, records, table); err != nil {
+			t.Fatalf("RenderTo(%s) returned error: %v", tt.format, err)
+		}
+		if buf.String() != tt.want {
+			t.Errorf("RenderTo(%s) = %q, want %q", tt.format, buf.String(), tt.want)
+		}
+		if !StructuredOutput() {
+			t.Errorf("Expected StructuredOutput() for %s", tt.format)
+		}
+	}
+	if tableCalled {
+		t.Error("Table renderer should not run for structured formats")
+	}
+
+	SetOutputFormat(OutputTable)
+	var buf bytes.Buffer
+	if err := RenderTo(&buf, records, table); err != nil {
+		t.Fatalf("RenderTo(table) returned error: %v", err)
+	}
+	if !tableCalled || strings.TrimSpace(buf.String()) != "" {
+		t.Error("Expected the table renderer to draw table output")
+	}
+}
+
+func TestRenderFormat(t *testing.T) {
+	records := []outputRecord{{Alias: "api", Path: "/src/api"}, {Alias: "web", Path: "/src/web"}}
+
+	var buf bytes.Buffer
+	if err := RenderFormat(&buf, "{{.Alias}}={{upper .Path}}", records); err != nil {
+		t.Fatalf("RenderFormat returned error: %v", err)
+	}
+	if want := "api=/SRC/API\nweb=/SRC/WEB\n"; buf.String() != want {
+		t.Errorf("RenderFormat = %q, want %q", buf.String(), want)
+	}
+
+	if err := RenderFormat(&buf, "{{.Alias", records); err == nil {
+		t.Error("Expected error for an invalid template")
+	}
+	if err := RenderFormat(&buf, "{{.Missing}}", records); err == nil {
+		t.Error("Expected error for an unknown field")
+	}
+
+	SetOutputFormat(OutputJSON)
+	defer SetOutputFormat(OutputTable)
+	if err := RenderFormat(&buf, "{{.Alias}}", records); err == nil {
+		t.Error("Expected error when combined with --output json")
+	}
+}
+
+func TestRenderDelimited(t *testing.T) {
+	defer SetOutputFormat(OutputTable)
+
+	type groupRow struct {
+		Name         string    `json:"name"`
+		Repositories []string  `json:"repositories"`
+		CreatedAt    time.Time `json:"created_at,omitzero"`
+	}
+	rows := []groupRow{
+		{Name: "core, infra", Repositories: []string{"api", "web"}, CreatedAt: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)},
+		{Name: "empty"},
+	}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{OutputCSV, "name,repositories,created_at\n\"core, infra\",api;web,2024-05-01T12:00:00Z\nempty,,\n"},
+		{OutputTSV, "name\trepositories\tcreated_at\ncore, infra\tapi;web\t2024-05-01T12:00:00Z\nempty\t\t\n"},
+	}
+
+	for _, tt := range tests {
+		SetOutputFormat(tt.format)
+		var buf bytes.Buffer
+		if err := RenderTo(&buf, rows, nil); err != nil {
+			t.Fatalf("RenderTo(%s) returned error: %v", tt.format, err)
+		}
+		if buf.String() != tt.want {
+			t.Errorf("RenderTo(%s) = %q, want %q", tt.format, buf.String(), tt.want)
+		}
+	}
+
+	if err := RenderTo(&bytes.Buffer{}, map[string]string{"a": "b"}, nil); err == nil {
+		t.Error("Expected error for data that is not a slice of structs")
+	}
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// Pagination is the page of a long listing selected with --limit and --page
+type Pagination struct {
+	Limit int // Items per page; zero shows everything
+	Page  int // 1-based page number
+}
+
+// AddPaginationFlags registers --limit and --page on cmd
+func AddPaginationFlags(cmd *cobra.Command, p *Pagination) {
+	cmd.Flags().IntVar(&p.Limit, "limit", 0, "Show at most this many repositories (0 for all)")
+	cmd.Flags().IntVar(&p.Page, "page", 1, "Page of --limit repositories to show, starting at 1")
+}
+
+// Validate checks the flag values
+func (p Pagination) Validate() error {
+	if p.Limit < 0 {
+		return FormatValidationError("limit", strconv.Itoa(p.Limit), "must not be negative")
+	}
+	if p.Page < 1 {
+		return FormatValidationError("page", strconv.Itoa(p.Page), "pages start at 1")
+	}
+	return nil
+}
+
+// Bounds returns the index range of the page within total items. Pages past
+// the end are empty.
+func (p Pagination) Bounds(total int) (start, end int) {
+	if p.Limit <= 0 {
+		return 0, total
+	}
+	start = min((p.Page-1)*p.Limit, total)
+	end = min(start+p.Limit, total)
+	return start, end
+}
+
+// Footer describes a partial page, e.g. "Showing 51-100 of 512 (page 2 of
+// 11, next: --page 3)". It is empty when every item is shown.
+func (p Pagination) Footer(total int) string {
+	start, end := p.Bounds(total)
+	if start == 0 && end == total {
+		return ""
+	}
+	pages := (total + p.Limit - 1) / p.Limit
+	if start == end {
+		return fmt.Sprintf("Page %d is empty: %d items fill %d pages", p.Page, total, pages)
+	}
+	footer := fmt.Sprintf("Showing %d-%d of %d (page %d of %d", start+1, end, total, p.Page, pages)
+	if p.Page < pages {
+		footer += fmt.Sprintf(", next: --page %d", p.Page+1)
+	}
+	return footer + ")"
+}
+
+// Paginate returns the items on the selected page
+func Paginate[T any](items []T, p Pagination) []T {
+	start, end := p.Bounds(len(items))
+	return items[start:end]
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPaginate(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+
+	tests := []struct {
+		name       string
+		pagination Pagination
+		want       []int
+		footer     string
+	}{
+		{"no limit", Pagination{Limit: 0, Page: 1}, []int{1, 2, 3, 4, 5}, ""},
+		{"first page", Pagination{Limit: 2, Page: 1}, []int{1, 2}, "Showing 1-2 of 5 (page 1 of 3, next: --page 2)"},
+		{"last page", Pagination{Limit: 2, Page: 3}, []int{5}, "Showing 5-5 of 5 (page 3 of 3)"},
+		{"past the end", Pagination{Limit: 2, Page: 4}, []int{}, "Page 4 is empty: 5 items fill 3 pages"},
+		{"limit above total", Pagination{Limit: 10, Page: 1}, []int{1, 2, 3, 4, 5}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Paginate(items, tt.pagination); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Paginate() = %v, want %v", got, tt.want)
+			}
+			if got := tt.pagination.Footer(len(items)); got != tt.footer {
+				t.Errorf("Footer() = %q, want %q", got, tt.footer)
+			}
+		})
+	}
+}
+
+func TestPaginationValidate(t *testing.T) {
+	if err := (Pagination{Limit: -1, Page: 1}).Validate(); err == nil {
+		t.Error("Expected an error for a negative limit")
+	}
+	if err := (Pagination{Limit: 10, Page: 0}).Validate(); err == nil {
+		t.Error("Expected an error for page 0")
+	}
+	if err := (Pagination{Limit: 10, Page: 2}).Validate(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
@@ -31,10 +31,22 @@ func DefaultValidationConfig() *ValidationConfig {
 // This consolidates the repeated validation patterns across command groups
 func CreatePersistentPreRunE(config *ValidationConfig) func(*cobra.Command, []string) error {
 	return func(cmd *cobra.Command, args []string) error {
-		// Call parent's PersistentPreRunE first to ensure config is loaded
-		if cmd.Parent() != nil && cmd.Parent().PersistentPreRunE != nil {
-			if err := cmd.Parent().PersistentPreRunE(cmd, args); err != nil {
-				return err
+		// Call the next PersistentPreRunE up the tree first to ensure config is loaded.
+		// cmd is the executing leaf, so locate the command owning this hook (the
+		// nearest one with a PersistentPreRunE, as cobra picks) and start above it;
+		// starting at cmd.Parent() would re-enter this very hook forever.
+		owner := cmd
+		for owner != nil && owner.PersistentPreRunE == nil {
+			owner = owner.Parent()
+		}
+		if owner != nil {
+			for parent := owner.Parent(); parent != nil; parent = parent.Parent() {
+				if parent.PersistentPreRunE != nil {
+					if err := parent.PersistentPreRunE(cmd, args); err != nil {
+						return err
+					}
+					break
+				}
 			}
 		}
 
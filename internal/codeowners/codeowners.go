@@ -0,0 +1,154 @@
+// Package codeowners parses CODEOWNERS files and answers who owns a path.
+package codeowners
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Locations are where a repository's CODEOWNERS file is looked up, in the
+// order GitHub uses
+var Locations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// Pattern is a CODEOWNERS path pattern, which follows .gitignore rules: a
+// pattern without a slash matches at any depth, a leading slash anchors it
+// to the repository root and a matched directory covers everything below it
+type Pattern struct {
+	Text string
+	re   *regexp.Regexp
+}
+
+// Rule is one line of a CODEOWNERS file. A rule without owners makes the
+// paths it matches unowned.
+type Rule struct {
+	Pattern Pattern
+	Owners  []string
+	Line    int
+}
+
+// File is a parsed CODEOWNERS file
+type File struct {
+	Path  string // Relative to the repository root
+	Rules []Rule
+}
+
+// NewPattern compiles a path pattern
+func NewPattern(text string) (Pattern, error) {
+	pattern := strings.TrimSpace(text)
+	if pattern == "" {
+		return Pattern{}, fmt.Errorf("empty pattern")
+	}
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(strings.Trim(pattern, "/"), "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+
+	var expr strings.Builder
+	expr.WriteString("^")
+	if !anchored {
+		expr.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			expr.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case c == '*':
+			expr.WriteString("[^/]*")
+		case c == '?':
+			expr.WriteString("[^/]")
+		case c == '\\' && i+1 < len(pattern):
+			i++
+			expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	switch {
+	case dirOnly:
+		expr.WriteString("/.*")
+	case strings.HasSuffix(pattern, "/*"):
+		// docs/* owns the files directly in docs, not those in subdirectories
+	default:
+		expr.WriteString("(?:/.*)?")
+	}
+	expr.WriteString("$")
+
+	re, err := regexp.Compile(expr.String())
+	if err != nil {
+		return Pattern{}, fmt.Errorf("invalid pattern '%s': %w", text, err)
+	}
+	return Pattern{Text: text, re: re}, nil
+}
+
+// Match reports whether a slash-separated path relative to the repository
+// root matches the pattern
+func (p Pattern) Match(path string) bool {
+	return p.re != nil && p.re.MatchString(path)
+}
+
+// Parse reads CODEOWNERS rules. Comments, blank lines and GitLab section
+// headers are skipped; a line whose pattern does not compile is an error.
+func Parse(r io.Reader) ([]Rule, error) {
+	var rules []Rule
+	scanner := bufio.NewScanner(r)
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[") {
+			continue
+		}
+		if comment := strings.Index(line, " #"); comment >= 0 {
+			line = line[:comment]
+		}
+		fields := strings.Fields(line)
+		pattern, err := NewPattern(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", number, err)
+		}
+		rules = append(rules, Rule{Pattern: pattern, Owners: fields[1:], Line: number})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// Find reads the CODEOWNERS file of the repository at repoPath; nil when the
+// repository has none
+func Find(repoPath string) (*File, error) {
+	for _, location := range Locations {
+		file, err := os.Open(filepath.Join(repoPath, filepath.FromSlash(location)))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", location, err)
+		}
+		rules, err := Parse(file)
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", location, err)
+		}
+		return &File{Path: location, Rules: rules}, nil
+	}
+	return nil, nil
+}
+
+// Owner returns the rule deciding who owns path: the last one matching it,
+// as in GitHub. ok is false when no rule matches.
+func (f *File) Owner(path string) (rule Rule, ok bool) {
+	for i := len(f.Rules) - 1; i >= 0; i-- {
+		if f.Rules[i].Pattern.Match(path) {
+			return f.Rules[i], true
+		}
+	}
+	return Rule{}, false
+}
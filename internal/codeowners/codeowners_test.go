@@ -0,0 +1,70 @@
+package codeowners
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPatternMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"*", "cmd/root.go", true},
+		{"*.go", "cmd/root.go", true},
+		{"*.go", "README.md", false},
+		{"docs", "docs/guide/intro.md", true},
+		{"docs", "src/docs/api.md", true},
+		{"/docs", "src/docs/api.md", false},
+		{"docs/", "docs/a.md", true},
+		{"docs/*", "docs/a.md", true},
+		{"docs/*", "docs/guide/intro.md", false},
+		{"apps/web", "apps/web/index.ts", true},
+		{"apps/web", "x/apps/web/index.ts", false},
+		{"**/logs", "deploy/prod/logs/app.log", true},
+		{"src/**/test", "src/a/b/test/x_test.go", true},
+		{"/build/", "build", false},
+	}
+	for _, tt := range tests {
+		pattern, err := NewPattern(tt.pattern)
+		if err != nil {
+			t.Fatalf("NewPattern(%q) error = %v", tt.pattern, err)
+		}
+		if got := pattern.Match(tt.path); got != tt.want {
+			t.Errorf("%q.Match(%q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestOwnerLastMatchWins(t *testing.T) {
+	rules, err := Parse(strings.NewReader(`# Default owners
+*                 @acme/platform
+
+[Frontend]
+/web/             @acme/frontend @alice  # inline comment
+/web/vendor/
+*.proto           @acme/api
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := &File{Rules: rules}
+
+	tests := []struct {
+		path   string
+		owners string
+		line   int
+	}{
+		{"main.go", "@acme/platform", 2},
+		{"web/app.ts", "@acme/frontend @alice", 5},
+		{"web/vendor/lib.js", "", 6},
+		{"web/api/user.proto", "@acme/api", 7},
+	}
+	for _, tt := range tests {
+		rule, ok := file.Owner(tt.path)
+		if !ok || strings.Join(rule.Owners, " ") != tt.owners || rule.Line != tt.line {
+			t.Errorf("Owner(%q) = %v (line %d), %v; want %q from line %d", tt.path, rule.Owners, rule.Line, ok, tt.owners, tt.line)
+		}
+	}
+}
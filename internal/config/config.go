@@ -1,13 +1,18 @@
 package config
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
+	"gman/internal/errors"
+	"gman/internal/hooks"
 	"gman/pkg/types"
 
 	"github.com/gofrs/flock"
@@ -20,6 +25,8 @@ type Manager struct {
 	config     *types.Config
 	configPath string
 	fileLock   *flock.Flock
+	loadedData []byte          // file contents as last loaded or saved, to detect external changes
+	onConflict ConflictHandler // decides conflicting external changes, reload when nil
 }
 
 // NewManager creates a new configuration manager
@@ -62,15 +69,27 @@ func (m *Manager) Load() error {
 		return fmt.Errorf("error reading config file: %w", err)
 	}
 
+	config, err := m.parseConfig(configPath, data)
+	if err != nil {
+		return err
+	}
+
+	m.config = config
+	m.loadedData = data
+	return nil
+}
+
+// parseConfig decodes and validates configuration file contents
+func (m *Manager) parseConfig(configPath string, data []byte) (*types.Config, error) {
 	// Unmarshal YAML directly with strict mode to catch errors
 	config := &types.Config{}
 	if err := yaml.Unmarshal(data, config); err != nil {
-		return fmt.Errorf("invalid YAML in config file '%s': %w", configPath, err)
+		return nil, fmt.Errorf("invalid YAML in config file '%s': %w", configPath, err)
 	}
 
 	// Validate configuration structure and values
 	if err := m.validateConfig(config); err != nil {
-		return fmt.Errorf("configuration validation failed: %w", err)
+		return nil, fmt.Errorf("configuration validation failed: %w", err)
 	}
 
 	// Set defaults if not present
@@ -80,9 +99,7 @@ func (m *Manager) Load() error {
 	if config.Settings.DefaultSyncMode == "" {
 		config.Settings.DefaultSyncMode = "ff-only"
 	}
-
-	m.config = config
-	return nil
+	return config, nil
 }
 
 // GetConfig returns the current configuration
@@ -130,10 +147,35 @@ func (m *Manager) RemoveRepository(alias string) error {
 	}
 
 	if _, exists := m.config.Repositories[alias]; !exists {
-		return fmt.Errorf("repository '%s' not found", alias)
+		return errors.NotFoundError("repository", alias)
 	}
 
 	delete(m.config.Repositories, alias)
+	delete(m.config.RepositoryOptions, alias)
+	for name, bookmark := range m.config.Bookmarks {
+		if bookmark.Repository == alias {
+			delete(m.config.Bookmarks, name)
+		}
+	}
+	return m.Save()
+}
+
+// SetProtected marks a repository as protected or removes the mark
+func (m *Manager) SetProtected(alias string, protected bool) error {
+	if _, exists := m.config.Repositories[alias]; !exists {
+		return errors.NotFoundError("repository", alias)
+	}
+
+	options := m.config.RepositoryOptions[alias]
+	options.Protected = protected
+	if options.IsZero() {
+		delete(m.config.RepositoryOptions, alias)
+	} else {
+		if m.config.RepositoryOptions == nil {
+			m.config.RepositoryOptions = make(map[string]types.RepoOptions)
+		}
+		m.config.RepositoryOptions[alias] = options
+	}
 	return m.Save()
 }
 
@@ -141,6 +183,40 @@ func (m *Manager) RemoveRepository(alias string) error {
 func (m *Manager) Save() error {
 	configPath := m.getConfigPath()
 
+	// Ensure config directory exists
+	configDir := filepath.Dir(configPath)
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return fmt.Errorf("error creating config directory: %w", err)
+	}
+
+	// Marshal config to YAML
+	data, err := yaml.Marshal(m.config)
+	if err != nil {
+		return fmt.Errorf("error marshaling config: %w", err)
+	}
+
+	// Changes another gman process saved since this one loaded the file are
+	// merged before taking the lock, as a conflict may prompt and other
+	// processes must not wait for the answer. Should the file change again
+	// meanwhile, it is merged again.
+	for {
+		if data, err = m.mergeIfChanged(configPath, data); err != nil {
+			return err
+		}
+		written, err := m.writeIfUnchanged(configPath, data)
+		if err != nil {
+			return err
+		}
+		if written {
+			m.loadedData = data
+			return nil
+		}
+	}
+}
+
+// writeIfUnchanged writes data under the exclusive lock unless the file
+// changed since this process last read it, and reports whether it wrote
+func (m *Manager) writeIfUnchanged(configPath string, data []byte) (bool, error) {
 	// Initialize file lock if not already done
 	if m.fileLock == nil {
 		lockPath := configPath + ".lock"
@@ -153,38 +229,20 @@ func (m *Manager) Save() error {
 
 	locked, err := m.fileLock.TryLockContext(ctx, time.Millisecond*100)
 	if err != nil {
-		return fmt.Errorf("error acquiring write lock: %w", err)
+		return false, fmt.Errorf("error acquiring write lock: %w", err)
 	}
 	if !locked {
-		return fmt.Errorf("timeout acquiring write lock on config file")
+		return false, fmt.Errorf("timeout acquiring write lock on config file")
 	}
 	defer m.fileLock.Unlock()
 
-	// Ensure config directory exists
-	configDir := filepath.Dir(configPath)
-	if err := os.MkdirAll(configDir, 0755); err != nil {
-		return fmt.Errorf("error creating config directory: %w", err)
-	}
-
-	// Marshal config to YAML
-	data, err := yaml.Marshal(m.config)
-	if err != nil {
-		return fmt.Errorf("error marshaling config: %w", err)
-	}
-
-	// Write to file atomically
-	tempPath := configPath + ".tmp"
-	if err := os.WriteFile(tempPath, data, 0644); err != nil {
-		return fmt.Errorf("error writing temp config file: %w", err)
-	}
-
-	// Atomic move to final location
-	if err := os.Rename(tempPath, configPath); err != nil {
-		os.Remove(tempPath) // Clean up on failure
-		return fmt.Errorf("error moving temp config file: %w", err)
+	if m.loadedData != nil {
+		current, err := os.ReadFile(configPath)
+		if err == nil && !bytes.Equal(current, m.loadedData) {
+			return false, nil
+		}
 	}
-
-	return nil
+	return true, writeFileAtomic(configPath, data)
 }
 
 // setDefaults sets default configuration values
@@ -232,6 +290,17 @@ func (m *Manager) getConfigPath() string {
 	return filepath.Join(home, ".config", "gman", "config.yml")
 }
 
+// GetConfigPath returns the location of the configuration file
+func (m *Manager) GetConfigPath() string {
+	return m.getConfigPath()
+}
+
+// GetConfigDir returns the directory holding the configuration file and
+// gman's other state files (caches, logs)
+func (m *Manager) GetConfigDir() string {
+	return filepath.Dir(m.getConfigPath())
+}
+
 // expandPath expands ~ and environment variables in path
 func expandPath(path string) (string, error) {
 	if path[:2] == "~/" {
@@ -255,41 +324,72 @@ func isGitRepository(path string) bool {
 	return info.IsDir()
 }
 
-// TrackRecentUsage adds or updates a repository in the recent usage list
-func (m *Manager) TrackRecentUsage(alias string) error {
+// GetRecentUsage returns the recent usage list
+func (m *Manager) GetRecentUsage() []types.RecentEntry {
 	if m.config.RecentUsage == nil {
-		m.config.RecentUsage = []types.RecentEntry{}
+		return []types.RecentEntry{}
 	}
+	return m.config.RecentUsage
+}
 
-	// Remove existing entry if present
-	for i, entry := range m.config.RecentUsage {
-		if entry.Alias == alias {
-			m.config.RecentUsage = append(m.config.RecentUsage[:i], m.config.RecentUsage[i+1:]...)
-			break
-		}
+// AddBookmark adds a named bookmark of a path inside a repository
+func (m *Manager) AddBookmark(name string, bookmark types.Bookmark) error {
+	if err := m.validateAlias(name); err != nil {
+		return fmt.Errorf("invalid bookmark name '%s': %w", name, err)
+	}
+	if _, exists := m.config.Bookmarks[name]; exists {
+		return fmt.Errorf("bookmark '%s' already exists", name)
+	}
+	if _, exists := m.config.Repositories[bookmark.Repository]; !exists {
+		return errors.NotFoundError("repository", bookmark.Repository)
 	}
 
-	// Add to the beginning of the list
-	newEntry := types.RecentEntry{
-		Alias:      alias,
-		AccessTime: time.Now(),
+	if m.config.Bookmarks == nil {
+		m.config.Bookmarks = make(map[string]types.Bookmark)
 	}
-	m.config.RecentUsage = append([]types.RecentEntry{newEntry}, m.config.RecentUsage...)
+	m.config.Bookmarks[name] = bookmark
+	return m.Save()
+}
 
-	// Keep only the last 10 entries
-	if len(m.config.RecentUsage) > 10 {
-		m.config.RecentUsage = m.config.RecentUsage[:10]
+// RemoveBookmark removes a bookmark
+func (m *Manager) RemoveBookmark(name string) error {
+	if _, exists := m.config.Bookmarks[name]; !exists {
+		return errors.NotFoundError("bookmark", name)
 	}
 
+	delete(m.config.Bookmarks, name)
 	return m.Save()
 }
 
-// GetRecentUsage returns the recent usage list
-func (m *Manager) GetRecentUsage() []types.RecentEntry {
-	if m.config.RecentUsage == nil {
-		return []types.RecentEntry{}
+// TrackCodeWorkspace records a generated VSCode workspace so it is
+// regenerated when its repositories change, replacing an earlier record of
+// the same file
+func (m *Manager) TrackCodeWorkspace(workspace types.CodeWorkspace) error {
+	if workspace.Group != "" {
+		if _, exists := m.config.Groups[workspace.Group]; !exists {
+			return fmt.Errorf("group '%s' not found", workspace.Group)
+		}
 	}
-	return m.config.RecentUsage
+
+	for i, existing := range m.config.CodeWorkspaces {
+		if existing.Path == workspace.Path {
+			m.config.CodeWorkspaces[i] = workspace
+			return m.Save()
+		}
+	}
+	m.config.CodeWorkspaces = append(m.config.CodeWorkspaces, workspace)
+	return m.Save()
+}
+
+// UntrackCodeWorkspace stops regenerating the VSCode workspace at path
+func (m *Manager) UntrackCodeWorkspace(path string) error {
+	for i, existing := range m.config.CodeWorkspaces {
+		if existing.Path == path {
+			m.config.CodeWorkspaces = append(m.config.CodeWorkspaces[:i], m.config.CodeWorkspaces[i+1:]...)
+			return m.Save()
+		}
+	}
+	return fmt.Errorf("workspace '%s' is not tracked", path)
 }
 
 // CreateGroup creates a new repository group
@@ -306,7 +406,7 @@ func (m *Manager) CreateGroup(name, description string, repositories []string) e
 	// Validate repositories exist
 	for _, repo := range repositories {
 		if _, exists := m.config.Repositories[repo]; !exists {
-			return fmt.Errorf("repository '%s' not found", repo)
+			return errors.NotFoundError("repository", repo)
 		}
 	}
 
@@ -333,6 +433,13 @@ func (m *Manager) DeleteGroup(name string) error {
 	}
 
 	delete(m.config.Groups, name)
+	workspaces := m.config.CodeWorkspaces[:0]
+	for _, workspace := range m.config.CodeWorkspaces {
+		if workspace.Group != name {
+			workspaces = append(workspaces, workspace)
+		}
+	}
+	m.config.CodeWorkspaces = workspaces
 	return m.Save()
 }
 
@@ -379,7 +486,7 @@ func (m *Manager) AddToGroup(groupName string, repositories []string) error {
 	// Validate repositories exist
 	for _, repo := range repositories {
 		if _, exists := m.config.Repositories[repo]; !exists {
-			return fmt.Errorf("repository '%s' not found", repo)
+			return errors.NotFoundError("repository", repo)
 		}
 	}
 
@@ -452,11 +559,31 @@ func (m *Manager) validateConfig(config *types.Config) error {
 			// Check if path exists (warning, not error)
 			if _, err := os.Stat(expandedPath); os.IsNotExist(err) {
 				// Log warning but don't fail validation
-				fmt.Printf("Warning: Repository path '%s' for alias '%s' does not exist\n", expandedPath, alias)
+				slog.Warn("repository path does not exist", "alias", alias, "path", expandedPath)
+			}
+		}
+	}
+
+	// Validate repository options
+	for alias, options := range config.RepositoryOptions {
+		if _, exists := config.Repositories[alias]; !exists {
+			return fmt.Errorf("repository_options references non-existent repository '%s'", alias)
+		}
+		// A removed dependency only changes the release order (warning, not error)
+		for _, dependency := range options.DependsOn {
+			if _, exists := config.Repositories[dependency]; !exists {
+				slog.Warn("depends_on references non-existent repository", "alias", alias, "dependency", dependency)
 			}
 		}
 	}
 
+	// Hooks of unknown events never run, e.g. because of a typo
+	for event := range config.Hooks {
+		if !slices.Contains(hooks.Events, event) {
+			slog.Warn("hooks configured for unknown event", "event", event, "events", strings.Join(hooks.Events, ", "))
+		}
+	}
+
 	// Validate settings
 	if config.Settings.ParallelJobs < 0 {
 		return fmt.Errorf("parallel_jobs must be >= 0, got %d", config.Settings.ParallelJobs)
@@ -465,6 +592,41 @@ func (m *Manager) validateConfig(config *types.Config) error {
 		return fmt.Errorf("parallel_jobs too high (max 50), got %d", config.Settings.ParallelJobs)
 	}
 
+	// Validate git command timeouts
+	if _, _, err := config.Settings.CommandTimeouts(); err != nil {
+		return err
+	}
+
+	// Validate notifications; group overrides of unknown groups never apply
+	if _, err := config.Notifications.Interval(); err != nil {
+		return err
+	}
+	for group := range config.Notifications.Groups {
+		if _, exists := config.Groups[group]; !exists {
+			slog.Warn("notifications configured for non-existent group", "group", group)
+		}
+	}
+
+	// Validate bookmarks
+	for name, bookmark := range config.Bookmarks {
+		if err := m.validateAlias(name); err != nil {
+			return fmt.Errorf("invalid bookmark name '%s': %w", name, err)
+		}
+		if _, exists := config.Repositories[bookmark.Repository]; !exists {
+			return fmt.Errorf("bookmark '%s' references non-existent repository '%s'", name, bookmark.Repository)
+		}
+	}
+
+	// Validate generated workspaces
+	for _, workspace := range config.CodeWorkspaces {
+		if workspace.Group == "" {
+			continue
+		}
+		if _, exists := config.Groups[workspace.Group]; !exists {
+			return fmt.Errorf("workspace '%s' references non-existent group '%s'", workspace.Path, workspace.Group)
+		}
+	}
+
 	// Validate sync mode
 	validSyncModes := map[string]bool{
 		"ff-only":   true,
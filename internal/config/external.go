@@ -0,0 +1,220 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+
+	"gman/internal/errors"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConflictResolution is the choice made when the configuration file was
+// changed by another process and both changed the same settings
+type ConflictResolution int
+
+const (
+	// ConflictReload keeps the file on disk and drops this process's changes
+	ConflictReload ConflictResolution = iota
+	// ConflictOverwrite replaces the file with this process's configuration
+	ConflictOverwrite
+)
+
+// ConflictHandler decides a conflicting external change of the
+// configuration file at path; keys are the conflicting settings
+type ConflictHandler func(path string, keys []string) ConflictResolution
+
+// SetConflictHandler sets how Save resolves conflicting external changes.
+// Without a handler the file on disk wins.
+func (m *Manager) SetConflictHandler(handler ConflictHandler) {
+	m.onConflict = handler
+}
+
+// mergeIfChanged merges the changes another process saved since this one
+// last read the file into data, and returns the data to write
+func (m *Manager) mergeIfChanged(configPath string, data []byte) ([]byte, error) {
+	if m.loadedData == nil {
+		return data, nil
+	}
+	current, err := os.ReadFile(configPath)
+	if err != nil || bytes.Equal(current, m.loadedData) {
+		return data, nil
+	}
+
+	merged, err := m.mergeExternalChange(configPath, current, data)
+	if err != nil {
+		return nil, err
+	}
+	// The merged data includes the file as it is now
+	m.loadedData = current
+	return merged, nil
+}
+
+// mergeExternalChange merges the changes another process saved (theirs)
+// with the changes of this process (ours) and returns the data to write.
+// Both sides are compared with the file as it was loaded.
+func (m *Manager) mergeExternalChange(configPath string, theirs, ours []byte) ([]byte, error) {
+	merged, conflicts, err := mergeYAML(m.loadedData, theirs, ours)
+	if err != nil {
+		return nil, fmt.Errorf("error merging external config change: %w", err)
+	}
+
+	if len(conflicts) > 0 {
+		resolution := ConflictReload
+		if m.onConflict != nil {
+			resolution = m.onConflict(configPath, conflicts)
+		}
+		if resolution == ConflictOverwrite {
+			slog.Warn("overwriting external config change", "path", configPath, "keys", conflicts)
+			return ours, nil
+		}
+
+		config, err := m.parseConfig(configPath, theirs)
+		if err != nil {
+			return nil, err
+		}
+		// Keep the pointer handed out by GetConfig valid
+		*m.config = *config
+		m.loadedData = theirs
+		return nil, errors.NewGmanError(errors.ErrTypeConfigConflict,
+			fmt.Sprintf("%s was changed by another gman process; reloaded it without this command's changes", configPath)).
+			WithSuggestion("Run the command again to apply its changes to the reloaded configuration")
+	}
+
+	config, err := m.parseConfig(configPath, merged)
+	if err != nil {
+		return nil, err
+	}
+	*m.config = *config
+	slog.Debug("merged external config change", "path", configPath)
+	return yaml.Marshal(m.config)
+}
+
+// mergeYAML merges two YAML documents derived from base key by key. A key
+// changed on one side only takes that side's value; nested mappings are
+// merged recursively and other values, lists included, conflict when both
+// sides changed them differently.
+func mergeYAML(base, theirs, ours []byte) ([]byte, []string, error) {
+	var baseMap, theirMap, ourMap map[string]any
+	for _, doc := range []struct {
+		data   []byte
+		target *map[string]any
+	}{{base, &baseMap}, {theirs, &theirMap}, {ours, &ourMap}} {
+		if err := yaml.Unmarshal(doc.data, doc.target); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var conflicts []string
+	merged := mergeMaps("", baseMap, theirMap, ourMap, &conflicts)
+	data, err := yaml.Marshal(merged)
+	return data, conflicts, err
+}
+
+func mergeMaps(prefix string, base, theirs, ours map[string]any, conflicts *[]string) map[string]any {
+	keys := make(map[string]bool)
+	for _, m := range []map[string]any{base, theirs, ours} {
+		for key := range m {
+			keys[key] = true
+		}
+	}
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	merged := make(map[string]any)
+	for _, key := range sorted {
+		b, inBase := base[key]
+		t, inTheirs := theirs[key]
+		o, inOurs := ours[key]
+		same := func(v1 any, ok1 bool, v2 any, ok2 bool) bool {
+			return ok1 == ok2 && reflect.DeepEqual(v1, v2)
+		}
+
+		var value any
+		var present bool
+		switch {
+		case same(o, inOurs, b, inBase):
+			value, present = t, inTheirs
+		case same(t, inTheirs, b, inBase), same(o, inOurs, t, inTheirs):
+			value, present = o, inOurs
+		default:
+			bm, bok := asMap(b, inBase)
+			tm, tok := asMap(t, inTheirs)
+			om, ook := asMap(o, inOurs)
+			if bok && tok && ook && inTheirs && inOurs {
+				value, present = mergeMaps(prefix+key+".", bm, tm, om, conflicts), true
+			} else {
+				*conflicts = append(*conflicts, prefix+key)
+				value, present = o, inOurs
+			}
+		}
+		if present {
+			merged[key] = value
+		}
+	}
+	return merged
+}
+
+// asMap returns v as a mapping; a missing value is an empty mapping
+func asMap(v any, present bool) (map[string]any, bool) {
+	if !present {
+		return map[string]any{}, true
+	}
+	m, ok := v.(map[string]any)
+	return m, ok
+}
+
+// writeFileAtomic replaces path with data so that readers, and a crash at
+// any point, see either the old or the new file but never a partial one
+func writeFileAtomic(path string, data []byte) error {
+	// Replace the target of a symlinked config, not the link
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	temp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("error creating temp config file: %w", err)
+	}
+	tempPath := temp.Name()
+	defer os.Remove(tempPath) // No-op after the rename
+
+	if _, err := temp.Write(data); err != nil {
+		temp.Close()
+		return fmt.Errorf("error writing temp config file: %w", err)
+	}
+	if err := temp.Sync(); err != nil {
+		temp.Close()
+		return fmt.Errorf("error syncing temp config file: %w", err)
+	}
+	if err := temp.Close(); err != nil {
+		return fmt.Errorf("error closing temp config file: %w", err)
+	}
+	if err := os.Chmod(tempPath, mode); err != nil {
+		return fmt.Errorf("error setting config file permissions: %w", err)
+	}
+
+	// Atomic move to final location
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("error moving temp config file: %w", err)
+	}
+
+	// Make the rename itself durable; not every platform supports it
+	if dir, err := os.Open(filepath.Dir(path)); err == nil {
+		dir.Sync()
+		dir.Close()
+	}
+	return nil
+}
@@ -0,0 +1,358 @@
+// Package daemon implements the optional background process that keeps
+// repository status and switch targets warm, and the client the CLI uses to
+// query it over a unix socket. Every caller falls back to running git
+// directly when no daemon answers.
+package daemon
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"gman/pkg/types"
+)
+
+// SocketFile is the file name of the daemon socket inside the config directory
+const SocketFile = "daemon.sock"
+
+// Methods understood by the daemon
+const (
+	MethodPing    = "ping"
+	MethodStatus  = "status"
+	MethodTargets = "targets"
+	MethodRefresh = "refresh"
+	MethodStop    = "stop"
+)
+
+// dialTimeout bounds connecting to the daemon; a missing daemon must not
+// slow down the direct fallback
+const dialTimeout = 200 * time.Millisecond
+
+// callTimeout bounds a whole request, refreshes included
+const callTimeout = 2 * time.Minute
+
+// SocketPath returns the daemon socket location inside the given config directory
+func SocketPath(configDir string) string {
+	return filepath.Join(configDir, SocketFile)
+}
+
+// State is the data the daemon keeps warm
+type State struct {
+	UpdatedAt time.Time            `json:"updated_at"`
+	Fetched   time.Time            `json:"fetched_at,omitzero"`
+	Statuses  []Status             `json:"statuses"`
+	Targets   []types.SwitchTarget `json:"targets"`
+}
+
+// Info describes a running daemon
+type Info struct {
+	PID          int       `json:"pid"`
+	Started      time.Time `json:"started"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	Fetched      time.Time `json:"fetched_at,omitzero"`
+	Repositories int       `json:"repositories"`
+	LastError    string    `json:"last_error,omitempty"`
+}
+
+// Status is the serializable form of types.RepoStatus
+type Status struct {
+	Alias        string    `json:"alias"`
+	Path         string    `json:"path"`
+	Branch       string    `json:"branch"`
+	Workspace    int       `json:"workspace"`
+	Ahead        int       `json:"ahead"`
+	Behind       int       `json:"behind"`
+	SyncError    string    `json:"sync_error,omitempty"`
+	LastCommit   string    `json:"last_commit,omitempty"`
+	FilesChanged int       `json:"files_changed"`
+	CommitTime   time.Time `json:"commit_time,omitzero"`
+	RemoteURL    string    `json:"remote_url,omitempty"`
+	RemoteBranch string    `json:"remote_branch,omitempty"`
+	Error        string    `json:"error,omitempty"`
+}
+
+// NewStatus converts a repository status for the wire
+func NewStatus(status types.RepoStatus) Status {
+	s := Status{
+		Alias:        status.Alias,
+		Path:         status.Path,
+		Branch:       status.Branch,
+		Workspace:    int(status.Workspace),
+		Ahead:        status.SyncStatus.Ahead,
+		Behind:       status.SyncStatus.Behind,
+		LastCommit:   status.LastCommit,
+		FilesChanged: status.FilesChanged,
+		CommitTime:   status.CommitTime,
+		RemoteURL:    status.RemoteURL,
+		RemoteBranch: status.RemoteBranch,
+	}
+	if status.SyncStatus.SyncError != nil {
+		s.SyncError = status.SyncStatus.SyncError.Error()
+	}
+	if status.Error != nil {
+		s.Error = status.Error.Error()
+	}
+	return s
+}
+
+// RepoStatus converts the status back; errors keep only their message
+func (s Status) RepoStatus() types.RepoStatus {
+	status := types.RepoStatus{
+		Alias:        s.Alias,
+		Path:         s.Path,
+		Branch:       s.Branch,
+		Workspace:    types.WorkspaceStatus(s.Workspace),
+		SyncStatus:   types.SyncStatus{Ahead: s.Ahead, Behind: s.Behind},
+		LastCommit:   s.LastCommit,
+		FilesChanged: s.FilesChanged,
+		CommitTime:   s.CommitTime,
+		RemoteURL:    s.RemoteURL,
+		RemoteBranch: s.RemoteBranch,
+	}
+	if s.SyncError != "" {
+		status.SyncStatus.SyncError = errors.New(s.SyncError)
+	}
+	if s.Error != "" {
+		status.Error = errors.New(s.Error)
+	}
+	return status
+}
+
+// request is one line sent by the client
+type request struct {
+	Method string `json:"method"`
+}
+
+// response is one line sent back by the daemon
+type response struct {
+	Error  string          `json:"error,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+}
+
+// RefreshFunc rebuilds the state; fetch asks for a remote fetch as well
+type RefreshFunc func(fetch bool) (*State, error)
+
+// Server answers CLI requests from a periodically refreshed state
+type Server struct {
+	socketPath    string
+	refresh       RefreshFunc
+	interval      time.Duration
+	fetchInterval time.Duration
+
+	mu        sync.RWMutex
+	state     *State
+	lastError error
+	started   time.Time
+
+	refreshMu sync.Mutex
+	stop      chan struct{}
+	stopOnce  sync.Once
+}
+
+// NewServer creates a daemon refreshing local status every interval and
+// fetching remotes every fetchInterval
+func NewServer(socketPath string, refresh RefreshFunc, interval, fetchInterval time.Duration) *Server {
+	return &Server{
+		socketPath:    socketPath,
+		refresh:       refresh,
+		interval:      interval,
+		fetchInterval: fetchInterval,
+		state:         &State{},
+		stop:          make(chan struct{}),
+	}
+}
+
+// Run listens on the socket until Stop is called or a stop request arrives.
+// It fails when another daemon already serves the socket.
+func (s *Server) Run() error {
+	if Running(s.socketPath) {
+		return fmt.Errorf("gman daemon is already running on %s", s.socketPath)
+	}
+	// A socket left behind by a crashed daemon blocks Listen
+	os.Remove(s.socketPath)
+
+	if err := os.MkdirAll(filepath.Dir(s.socketPath), 0755); err != nil {
+		return fmt.Errorf("failed to create socket directory: %w", err)
+	}
+	listener, err := net.Listen("unix", s.socketPath)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.socketPath, err)
+	}
+	defer os.Remove(s.socketPath)
+	os.Chmod(s.socketPath, 0600)
+
+	s.started = time.Now()
+	s.doRefresh(true)
+
+	go s.refreshLoop()
+	go func() {
+		<-s.stop
+		listener.Close()
+	}()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			select {
+			case <-s.stop:
+				return nil
+			default:
+				return fmt.Errorf("failed to accept connection: %w", err)
+			}
+		}
+		go s.handle(conn)
+	}
+}
+
+// Stop shuts the daemon down
+func (s *Server) Stop() {
+	s.stopOnce.Do(func() { close(s.stop) })
+}
+
+// refreshLoop refreshes the state until the daemon stops
+func (s *Server) refreshLoop() {
+	local := time.NewTicker(s.interval)
+	defer local.Stop()
+	remote := time.NewTicker(s.fetchInterval)
+	defer remote.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-local.C:
+			s.doRefresh(false)
+		case <-remote.C:
+			s.doRefresh(true)
+		}
+	}
+}
+
+// doRefresh rebuilds the state, keeping the previous one on failure
+func (s *Server) doRefresh(fetch bool) {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	state, err := s.refresh(fetch)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastError = err
+	if err != nil {
+		slog.Warn("daemon refresh failed", "error", err)
+		return
+	}
+	if fetch {
+		state.Fetched = state.UpdatedAt
+	} else {
+		state.Fetched = s.state.Fetched
+	}
+	s.state = state
+}
+
+// handle answers the requests of one connection
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(callTimeout))
+
+	var req request
+	if err := json.NewDecoder(bufio.NewReader(conn)).Decode(&req); err != nil {
+		return
+	}
+
+	result, err := s.dispatch(req.Method)
+	var resp response
+	if err != nil {
+		resp.Error = err.Error()
+	} else if resp.Result, err = json.Marshal(result); err != nil {
+		resp.Error = err.Error()
+	}
+	json.NewEncoder(conn).Encode(resp)
+
+	if req.Method == MethodStop {
+		s.Stop()
+	}
+}
+
+// dispatch runs one method
+func (s *Server) dispatch(method string) (any, error) {
+	switch method {
+	case MethodPing, MethodStop:
+		return s.info(), nil
+	case MethodStatus, MethodTargets:
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		return s.state, nil
+	case MethodRefresh:
+		s.doRefresh(true)
+		return s.info(), nil
+	default:
+		return nil, fmt.Errorf("unknown method '%s'", method)
+	}
+}
+
+// info describes the daemon and its state
+func (s *Server) info() Info {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	info := Info{
+		PID:          os.Getpid(),
+		Started:      s.started,
+		UpdatedAt:    s.state.UpdatedAt,
+		Fetched:      s.state.Fetched,
+		Repositories: len(s.state.Statuses),
+	}
+	if s.lastError != nil {
+		info.LastError = s.lastError.Error()
+	}
+	return info
+}
+
+// Call sends one request to the daemon and decodes its result
+func Call(socketPath, method string, result any) error {
+	conn, err := net.DialTimeout("unix", socketPath, dialTimeout)
+	if err != nil {
+		return fmt.Errorf("gman daemon is not running: %w", err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(callTimeout))
+
+	if err := json.NewEncoder(conn).Encode(request{Method: method}); err != nil {
+		return fmt.Errorf("failed to send request to daemon: %w", err)
+	}
+	var resp response
+	if err := json.NewDecoder(bufio.NewReader(conn)).Decode(&resp); err != nil {
+		return fmt.Errorf("failed to read daemon response: %w", err)
+	}
+	if resp.Error != "" {
+		return fmt.Errorf("daemon error: %s", resp.Error)
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(resp.Result, result)
+}
+
+// Running reports whether a daemon answers on the socket
+func Running(socketPath string) bool {
+	return Call(socketPath, MethodPing, nil) == nil
+}
+
+// FetchState returns the daemon's state when a daemon is running and has
+// completed a refresh
+func FetchState(socketPath string) (*State, bool) {
+	if _, err := os.Stat(socketPath); err != nil {
+		return nil, false
+	}
+	var state State
+	if err := Call(socketPath, MethodStatus, &state); err != nil || state.UpdatedAt.IsZero() {
+		return nil, false
+	}
+	return &state, true
+}
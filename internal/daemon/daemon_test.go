@@ -0,0 +1,104 @@
+package daemon
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gman/pkg/types"
+)
+
+func TestStatusRoundTrip(t *testing.T) {
+	status := types.RepoStatus{
+		Alias:      "api",
+		Path:       "/src/api",
+		Branch:     "main",
+		Workspace:  types.Dirty,
+		SyncStatus: types.SyncStatus{Ahead: 1, Behind: 2, SyncError: errors.New("fetch failed")},
+		LastCommit: "abc123 Fix",
+	}
+
+	got := NewStatus(status).RepoStatus()
+	if got.Alias != "api" || got.Workspace != types.Dirty || got.SyncStatus.Behind != 2 || got.LastCommit != "abc123 Fix" {
+		t.Errorf("Unexpected status after round trip: %+v", got)
+	}
+	if got.SyncStatus.SyncError == nil || got.SyncStatus.SyncError.Error() != "fetch failed" {
+		t.Errorf("Expected the sync error message to survive, got %v", got.SyncStatus.SyncError)
+	}
+	if got.Error != nil {
+		t.Errorf("Expected no error, got %v", got.Error)
+	}
+}
+
+func TestServer(t *testing.T) {
+	// Unix socket paths are limited to ~100 bytes; t.TempDir() can be longer
+	dir, err := os.MkdirTemp("", "gmand")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	socketPath := filepath.Join(dir, SocketFile)
+
+	if _, ok := FetchState(socketPath); ok {
+		t.Fatal("Expected no state without a daemon")
+	}
+
+	fetches := 0
+	refresh := func(fetch bool) (*State, error) {
+		if fetch {
+			fetches++
+		}
+		return &State{
+			UpdatedAt: time.Now(),
+			Statuses:  []Status{{Alias: "api", Path: "/src/api", Branch: "main"}},
+			Targets:   []types.SwitchTarget{{Alias: "api", Path: "/src/api", Type: "repository"}},
+		}, nil
+	}
+	server := NewServer(socketPath, refresh, time.Hour, time.Hour)
+	done := make(chan error, 1)
+	go func() { done <- server.Run() }()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !Running(socketPath) {
+		if time.Now().After(deadline) {
+			t.Fatal("Daemon did not start")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	state, ok := FetchState(socketPath)
+	if !ok || len(state.Statuses) != 1 || state.Statuses[0].Branch != "main" || len(state.Targets) != 1 {
+		t.Fatalf("Unexpected state: %+v", state)
+	}
+	if state.Fetched.IsZero() {
+		t.Error("Expected the first refresh to fetch")
+	}
+
+	var info Info
+	if err := Call(socketPath, MethodRefresh, &info); err != nil || info.Repositories != 1 {
+		t.Errorf("Refresh returned %+v, %v", info, err)
+	}
+	if fetches != 2 {
+		t.Errorf("Expected 2 fetching refreshes, got %d", fetches)
+	}
+	if err := Call(socketPath, "bogus", nil); err == nil {
+		t.Error("Expected an error for an unknown method")
+	}
+
+	if err := Call(socketPath, MethodStop, nil); err != nil {
+		t.Fatalf("Stop returned error: %v", err)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Run returned error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Daemon did not stop")
+	}
+	if _, err := os.Stat(socketPath); !os.IsNotExist(err) {
+		t.Error("Expected the socket to be removed")
+	}
+}
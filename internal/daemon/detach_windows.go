@@ -0,0 +1,8 @@
+//go:build windows
+
+package daemon
+
+import "os/exec"
+
+// Detach is a no-op on Windows; the daemon keeps the console's lifetime
+func Detach(cmd *exec.Cmd) {}
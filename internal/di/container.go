@@ -55,8 +55,10 @@ func (c *Container) Initialize() error {
 	// Initialize git manager
 	c.gitManager = git.NewManager()
 
-	// Initialize git facade with interfaces
-	c.gitFacade = git.NewGitManager()
+	// Initialize git facade with interfaces; it shares the git manager so
+	// timeouts, the command log and protection apply to every caller
+	m := c.gitManager
+	c.gitFacade = git.NewGitManagerWithComponents(m, m, m, m, m, m, m)
 
 	c.initialized = true
 	c.initialized_at = time.Now().Unix()
@@ -0,0 +1,93 @@
+package display
+
+import (
+	"gman/internal/progress"
+	"gman/pkg/types"
+
+	"github.com/fatih/color"
+)
+
+// asciiMode replaces emoji and box-drawing characters with plain text labels
+var asciiMode bool
+
+// ConfigureAccessibility applies the accessible rendering options.
+// noColor disables ANSI colors (NO_COLOR is already honored by the color
+// package); ascii switches state indicators to text labels and turns off
+// progress animations so output stays readable for screen readers, dumb
+// terminals and log files.
+func ConfigureAccessibility(noColor, ascii bool) {
+	if noColor {
+		color.NoColor = true
+	}
+	asciiMode = ascii
+	progress.SetAnimations(!ascii)
+}
+
+// ASCIIMode reports whether plain text rendering is active
+func ASCIIMode() bool {
+	return asciiMode
+}
+
+// WorkspaceLabel renders a workspace state for the active rendering mode
+func WorkspaceLabel(w types.WorkspaceStatus) string {
+	if asciiMode {
+		return w.Label()
+	}
+	return w.String()
+}
+
+// SyncLabel renders a sync state for the active rendering mode
+func SyncLabel(s types.SyncStatus) string {
+	if asciiMode {
+		return s.Label()
+	}
+	return s.String()
+}
+
+// ruleChar returns the character used for table separators
+func ruleChar() string {
+	if asciiMode {
+		return "-"
+	}
+	return "─"
+}
+
+// worktreeMarker returns the prefix of a worktree row below its repository
+func worktreeMarker() string {
+	if asciiMode {
+		return "+-"
+	}
+	return "└─"
+}
+
+// arrow returns the character used to point from a name to a target
+func arrow() string {
+	if asciiMode {
+		return "->"
+	}
+	return "→"
+}
+
+// Icon returns emoji, or the bracketed text label in plain text mode.
+// Commands use it instead of hard-coding emoji in their output.
+func Icon(emoji, label string) string {
+	if asciiMode {
+		return "[" + label + "]"
+	}
+	return emoji
+}
+
+// SuccessIcon returns the colored prefix of a successful result
+func SuccessIcon() string {
+	return color.GreenString(Icon("✅", "OK"))
+}
+
+// WarningIcon returns the colored prefix of a warning
+func WarningIcon() string {
+	return color.YellowString(Icon("⚠️", "WARN"))
+}
+
+// ErrorIcon returns the colored prefix of a failed result
+func ErrorIcon() string {
+	return color.RedString(Icon("❌", "ERROR"))
+}
@@ -68,11 +68,11 @@ func (d *StatusDisplayer) Display(statuses []types.RepoStatus) {
 		if len(status.Branch) > maxBranch {
 			maxBranch = len(status.Branch)
 		}
-		workspaceStr := stripAnsiCodes(status.Workspace.String())
+		workspaceStr := stripAnsiCodes(WorkspaceLabel(status.Workspace))
 		if len(workspaceStr) > maxWorkspace {
 			maxWorkspace = len(workspaceStr)
 		}
-		syncStr := stripAnsiCodes(status.SyncStatus.String())
+		syncStr := stripAnsiCodes(SyncLabel(status.SyncStatus))
 		if len(syncStr) > maxSync {
 			maxSync = len(syncStr)
 		}
@@ -103,6 +103,11 @@ func (d *StatusDisplayer) Display(statuses []types.RepoStatus) {
 				maxBranches = len(branchStr)
 			}
 		}
+		for _, wt := range status.Worktrees {
+			if len(worktreeBranch(wt)) > maxBranch {
+				maxBranch = len(worktreeBranch(wt))
+			}
+		}
 	}
 
 	// Add padding
@@ -145,21 +150,21 @@ func (d *StatusDisplayer) Display(statuses []types.RepoStatus) {
 
 	// Print separator
 	fmt.Printf("%s %s %s %s",
-		strings.Repeat("─", maxAlias),
-		strings.Repeat("─", maxBranch),
-		strings.Repeat("─", maxWorkspace),
-		strings.Repeat("─", maxSync))
+		strings.Repeat(ruleChar(), maxAlias),
+		strings.Repeat(ruleChar(), maxBranch),
+		strings.Repeat(ruleChar(), maxWorkspace),
+		strings.Repeat(ruleChar(), maxSync))
 	if d.showSuperExtended {
 		fmt.Printf(" %s %s %s %s %s", 
-			strings.Repeat("─", maxFiles), 
-			strings.Repeat("─", maxTime),
-			strings.Repeat("─", maxRemote),
-			strings.Repeat("─", maxStash),
-			strings.Repeat("─", maxBranches))
+			strings.Repeat(ruleChar(), maxFiles), 
+			strings.Repeat(ruleChar(), maxTime),
+			strings.Repeat(ruleChar(), maxRemote),
+			strings.Repeat(ruleChar(), maxStash),
+			strings.Repeat(ruleChar(), maxBranches))
 	} else if d.showExtended {
-		fmt.Printf(" %s %s", strings.Repeat("─", maxFiles), strings.Repeat("─", maxTime))
+		fmt.Printf(" %s %s", strings.Repeat(ruleChar(), maxFiles), strings.Repeat(ruleChar(), maxTime))
 	} else if d.showLastCommit {
-		fmt.Printf(" %s", strings.Repeat("─", maxCommit))
+		fmt.Printf(" %s", strings.Repeat(ruleChar(), maxCommit))
 	}
 	fmt.Println()
 
@@ -185,8 +190,8 @@ func (d *StatusDisplayer) Display(statuses []types.RepoStatus) {
 		fmt.Printf("%-*s %-*s %-*s %-*s",
 			maxAlias, d.formatAlias(status.Alias, status.IsCurrent),
 			maxBranch, d.formatBranch(status.Branch),
-			maxWorkspace, status.Workspace.String(),
-			maxSync, status.SyncStatus.String())
+			maxWorkspace, WorkspaceLabel(status.Workspace),
+			maxSync, SyncLabel(status.SyncStatus))
 
 		if d.showSuperExtended {
 			filesDisplay := ""
@@ -218,11 +223,32 @@ func (d *StatusDisplayer) Display(statuses []types.RepoStatus) {
 			fmt.Printf(" %-*s", maxCommit, d.formatCommit(status.LastCommit))
 		}
 		fmt.Println()
+
+		// Linked worktrees as sub-rows: branch, workspace and path
+		for _, wt := range status.Worktrees {
+			workspace := WorkspaceLabel(wt.Workspace)
+			if wt.Error != nil {
+				workspace = color.RedString("ERROR")
+			}
+			fmt.Printf("%-*s %-*s %-*s %s\n",
+				maxAlias, "    "+worktreeMarker(),
+				maxBranch, d.formatBranch(worktreeBranch(wt)),
+				maxWorkspace, workspace,
+				color.HiBlackString("%s", wt.Path))
+		}
 	}
 
 	fmt.Println() // Add empty line at the end
 }
 
+// worktreeBranch returns the branch shown for a linked worktree
+func worktreeBranch(wt types.WorktreeStatus) string {
+	if wt.IsDetached || wt.Branch == "" {
+		return "(detached)"
+	}
+	return wt.Branch
+}
+
 // formatAlias formats the alias with current indicator
 func (d *StatusDisplayer) formatAlias(alias string, isCurrent bool) string {
 	if isCurrent {
@@ -263,33 +289,33 @@ func PrintRepositoryList(repositories map[string]string) {
 
 	// Print header
 	fmt.Printf("%-*s   %s\n", maxAliasLen, color.CyanString("Alias"), color.CyanString("Path"))
-	fmt.Printf("%s   %s\n", strings.Repeat("─", maxAliasLen), strings.Repeat("─", 40))
+	fmt.Printf("%s   %s\n", strings.Repeat(ruleChar(), maxAliasLen), strings.Repeat(ruleChar(), 40))
 
 	// Print repositories
 	for alias, path := range repositories {
-		fmt.Printf("%-*s → %s\n", maxAliasLen, color.YellowString(alias), path)
+		fmt.Printf("%-*s %s %s\n", maxAliasLen, color.YellowString(alias), arrow(), path)
 	}
 	fmt.Println()
 }
 
 // PrintSuccess prints a success message
 func PrintSuccess(message string) {
-	fmt.Printf("%s %s\n", color.GreenString("✅"), message)
+	fmt.Printf("%s %s\n", SuccessIcon(), message)
 }
 
 // PrintError prints an error message
 func PrintError(message string) {
-	fmt.Printf("%s %s\n", color.RedString("❌"), message)
+	fmt.Printf("%s %s\n", ErrorIcon(), message)
 }
 
 // PrintWarning prints a warning message
 func PrintWarning(message string) {
-	fmt.Printf("%s %s\n", color.YellowString("⚠️"), message)
+	fmt.Printf("%s %s\n", WarningIcon(), message)
 }
 
 // PrintInfo prints an info message
 func PrintInfo(message string) {
-	fmt.Printf("%s %s\n", color.BlueString("ℹ️"), message)
+	fmt.Printf("%s %s\n", color.BlueString(Icon("ℹ️", "INFO")), message)
 }
 
 // truncateString truncates a string to maxLen with ellipsis
@@ -364,7 +390,7 @@ func (d *StatusDisplayer) formatRemote(remoteURL, remoteBranch string) string {
 		if len(parts) >= 2 {
 			shortName := parts[len(parts)-2] + "/" + strings.TrimSuffix(parts[len(parts)-1], ".git")
 			if remoteBranch != "" {
-				return color.CyanString("%s→%s", shortName, remoteBranch)
+				return color.CyanString("%s%s%s", shortName, arrow(), remoteBranch)
 			}
 			return color.CyanString(shortName)
 		}
@@ -375,7 +401,7 @@ func (d *StatusDisplayer) formatRemote(remoteURL, remoteBranch string) string {
 	if len(parts) > 0 {
 		shortName := parts[len(parts)-1]
 		if remoteBranch != "" {
-			return color.CyanString("%s→%s", shortName, remoteBranch)
+			return color.CyanString("%s%s%s", shortName, arrow(), remoteBranch)
 		}
 		return color.CyanString(shortName)
 	}
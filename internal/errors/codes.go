@@ -0,0 +1,109 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Exit codes are part of gman's interface: wrappers and CI scripts branch on
+// them, so existing values must never change
+var exitCodes = map[ErrorType]int{
+	ErrTypeInvalidInput: 2,
+
+	ErrTypeRepoNotFound:      3,
+	ErrTypeNotGitRepo:        4,
+	ErrTypeRepoAlreadyExists: 5,
+
+	ErrTypeWorkspaceNotClean: 10,
+	ErrTypeMergeConflict:     11,
+	ErrTypeBranchNotFound:    12,
+	ErrTypeWorktreeExists:    13,
+
+	ErrTypeNetworkTimeout:    20,
+	ErrTypeRemoteUnreachable: 21,
+	ErrTypeConnectFailed:     22,
+
+	ErrTypeConfigInvalid:    30,
+	ErrTypeConfigNotFound:   31,
+	ErrTypePermissionDenied: 32,
+	ErrTypeRepoProtected:    33,
+	ErrTypeConfigConflict:   34,
+
+	ErrTypeToolNotAvailable: 40,
+
+	ErrTypeOperationCancelled: 130,
+}
+
+// GenericExitCode is the exit code of errors without a more specific code
+const GenericExitCode = 1
+
+// ExitCodeFor returns the stable exit code of an error type
+func ExitCodeFor(errorType ErrorType) int {
+	if code, ok := exitCodes[errorType]; ok {
+		return code
+	}
+	return GenericExitCode
+}
+
+// Error output formats selected with --error-format
+const (
+	ErrorFormatText = "text"
+	ErrorFormatJSON = "json"
+)
+
+// errorFormat is the format failed commands report their error in
+var errorFormat = ErrorFormatText
+
+// SetErrorFormat selects how the global handler reports errors
+func SetErrorFormat(format string) error {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		format = ErrorFormatText
+	}
+	if format != ErrorFormatText && format != ErrorFormatJSON {
+		return fmt.Errorf("unknown error format '%s': use text or json", format)
+	}
+	errorFormat = format
+	return nil
+}
+
+// ErrorFormat returns the selected error format
+func ErrorFormat() string {
+	return errorFormat
+}
+
+// ErrorReport is the machine-readable form of a failed command's error
+type ErrorReport struct {
+	// Code is the ErrorType, or "ERROR" for errors without a type
+	Code        string   `json:"code"`
+	ExitCode    int      `json:"exit_code"`
+	Message     string   `json:"message"`
+	Cause       string   `json:"cause,omitempty"`
+	Suggestions []string `json:"suggestions,omitempty"`
+}
+
+// NewErrorReport describes err for --error-format json
+func NewErrorReport(err error) ErrorReport {
+	gErr, ok := As(err)
+	if !ok {
+		return ErrorReport{Code: "ERROR", ExitCode: GenericExitCode, Message: err.Error()}
+	}
+
+	report := ErrorReport{
+		Code:        string(gErr.Type),
+		ExitCode:    ExitCodeFor(gErr.Type),
+		Message:     err.Error(),
+		Suggestions: gErr.Suggestions,
+	}
+	if gErr.Cause != nil {
+		report.Cause = gErr.Cause.Error()
+	}
+	return report
+}
+
+// JSON returns the report as a single line of JSON
+func (r ErrorReport) JSON() string {
+	data, _ := json.Marshal(r)
+	return string(data)
+}
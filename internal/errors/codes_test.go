@@ -0,0 +1,66 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestExitCodes(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{NewRepoNotFoundError("/repo"), 3},
+		{fmt.Errorf("failed to fetch: %w", NewNetworkTimeoutError("git fetch", "30s")), 20},
+		{NewWorkspaceNotCleanError("/repo"), 10},
+		{NewInternalError("status", "boom"), GenericExitCode},
+		{fmt.Errorf("plain error"), GenericExitCode},
+	}
+
+	for _, tt := range tests {
+		if got := ExitCode(tt.err); got != tt.want {
+			t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestExitCodesAreUnique(t *testing.T) {
+	seen := make(map[int]ErrorType)
+	for errorType, code := range exitCodes {
+		if other, ok := seen[code]; ok {
+			t.Errorf("%s and %s share exit code %d", errorType, other, code)
+		}
+		seen[code] = errorType
+	}
+}
+
+func TestNewErrorReport(t *testing.T) {
+	err := fmt.Errorf("sync failed: %w", NewNetworkTimeoutError("git fetch", "30s"))
+	var report ErrorReport
+	if jsonErr := json.Unmarshal([]byte(NewErrorReport(err).JSON()), &report); jsonErr != nil {
+		t.Fatalf("Report is not valid JSON: %v", jsonErr)
+	}
+	if report.Code != string(ErrTypeNetworkTimeout) || report.ExitCode != 20 {
+		t.Errorf("Unexpected report %+v", report)
+	}
+	if report.Message != err.Error() {
+		t.Errorf("Expected message %q, got %q", err.Error(), report.Message)
+	}
+
+	plain := NewErrorReport(fmt.Errorf("plain error"))
+	if plain.Code != "ERROR" || plain.ExitCode != GenericExitCode {
+		t.Errorf("Unexpected report for untyped error %+v", plain)
+	}
+}
+
+func TestSetErrorFormat(t *testing.T) {
+	defer SetErrorFormat(ErrorFormatText)
+
+	if err := SetErrorFormat("JSON"); err != nil || ErrorFormat() != ErrorFormatJSON {
+		t.Errorf("Expected json format, got %q (%v)", ErrorFormat(), err)
+	}
+	if err := SetErrorFormat("xml"); err == nil {
+		t.Error("Expected error for unknown format")
+	}
+}
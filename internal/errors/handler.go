@@ -33,6 +33,11 @@ func (h *ErrorHandler) HandleError(err error) {
 		return
 	}
 
+	if errorFormat == ErrorFormatJSON {
+		fmt.Fprintln(os.Stderr, NewErrorReport(err).JSON())
+		return
+	}
+
 	// Check if it's a GmanError
 	if gErr, ok := As(err); ok {
 		h.handleGmanError(gErr)
@@ -69,21 +74,17 @@ func (h *ErrorHandler) handleStandardError(err error) {
 	}
 }
 
-// ExitCode returns the appropriate exit code for an error (simplified)
+// ExitCode returns the stable exit code of an error's type, see ExitCodeFor
 func (h *ErrorHandler) ExitCode(err error) int {
 	if err == nil {
 		return 0
 	}
 
 	if gErr, ok := As(err); ok {
-		// Simplified - critical errors return 2, others return 1
-		if IsCritical(gErr) {
-			return 2
-		}
-		return 1
+		return ExitCodeFor(gErr.Type)
 	}
 
-	return 1 // Standard errors return 1
+	return GenericExitCode // Standard errors return 1
 }
 
 // WrapCobraCommand wraps a cobra command to provide enhanced error handling
@@ -140,6 +141,11 @@ func Exit(err error) {
 	}
 }
 
+// ExitCode returns the exit code of an error using the global handler
+func ExitCode(err error) int {
+	return globalHandler.ExitCode(err)
+}
+
 // Fatal processes an error and exits (convenience function)
 func Fatal(err error) {
 	Exit(err)
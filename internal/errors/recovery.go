@@ -0,0 +1,97 @@
+package errors
+
+import "strings"
+
+// SafeLevel rates how much a recovery action can change a repository
+type SafeLevel int
+
+const (
+	// SafeLevelSafe actions only talk to the remote or read state
+	SafeLevelSafe SafeLevel = iota
+	// SafeLevelCaution actions change the working tree but keep all work
+	SafeLevelCaution
+	// SafeLevelDangerous actions may discard work
+	SafeLevelDangerous
+)
+
+func (l SafeLevel) String() string {
+	switch l {
+	case SafeLevelSafe:
+		return "safe"
+	case SafeLevelCaution:
+		return "caution"
+	default:
+		return "dangerous"
+	}
+}
+
+// RecoveryAction is one git command that may resolve an error
+type RecoveryAction struct {
+	Description string
+	// Args are the git arguments, run inside the affected repository
+	Args      []string
+	SafeLevel SafeLevel
+	// AutoExec marks safe actions that may run without asking
+	AutoExec bool
+}
+
+// Command returns the action as a git command line for display
+func (a RecoveryAction) Command() string {
+	return "git " + strings.Join(a.Args, " ")
+}
+
+// CanAutoExec reports whether the action may run without confirmation
+func (a RecoveryAction) CanAutoExec() bool {
+	return a.AutoExec && a.SafeLevel == SafeLevelSafe
+}
+
+// RecoveryPlan lists the actions that may resolve an error. Suggestions of
+// the error remain the manual alternatives.
+type RecoveryPlan struct {
+	Error   *GmanError
+	Actions []RecoveryAction
+}
+
+// retryFetch is the recovery of transient remote failures
+var retryFetch = RecoveryAction{
+	Description: "Retry the fetch",
+	Args:        []string{"fetch", "--quiet"},
+	SafeLevel:   SafeLevelSafe,
+	AutoExec:    true,
+}
+
+// NewRecoveryPlan returns the recovery plan of err, or nil when no action
+// can be run for its type
+func NewRecoveryPlan(err *GmanError) *RecoveryPlan {
+	if err == nil {
+		return nil
+	}
+
+	var actions []RecoveryAction
+	switch err.Type {
+	case ErrTypeNetworkTimeout, ErrTypeConnectFailed, ErrTypeRemoteUnreachable:
+		actions = []RecoveryAction{retryFetch}
+	case ErrTypeBranchNotFound:
+		actions = []RecoveryAction{{
+			Description: "Fetch to update remote branches",
+			Args:        []string{"fetch", "--quiet", "--prune"},
+			SafeLevel:   SafeLevelSafe,
+			AutoExec:    true,
+		}}
+	case ErrTypeWorkspaceNotClean:
+		actions = []RecoveryAction{{
+			Description: "Stash local changes, including untracked files",
+			Args:        []string{"stash", "push", "--include-untracked", "-m", "gman-recovery"},
+			SafeLevel:   SafeLevelCaution,
+		}}
+	case ErrTypeMergeConflict:
+		actions = []RecoveryAction{{
+			Description: "Abort the merge and restore the pre-merge state",
+			Args:        []string{"merge", "--abort"},
+			SafeLevel:   SafeLevelDangerous,
+		}}
+	default:
+		return nil
+	}
+	return &RecoveryPlan{Error: err, Actions: actions}
+}
@@ -0,0 +1,51 @@
+package errors
+
+import "testing"
+
+func TestNewRecoveryPlan(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       *GmanError
+		wantLevel SafeLevel
+		wantAuto  bool
+	}{
+		{"network timeout", NewNetworkTimeoutError("git fetch", "30s"), SafeLevelSafe, true},
+		{"dirty workspace", NewWorkspaceNotCleanError("/repo"), SafeLevelCaution, false},
+		{"merge conflict", NewMergeConflictError("/repo"), SafeLevelDangerous, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := NewRecoveryPlan(tt.err)
+			if plan == nil || len(plan.Actions) == 0 {
+				t.Fatal("Expected a recovery plan with actions")
+			}
+			action := plan.Actions[0]
+			if action.SafeLevel != tt.wantLevel {
+				t.Errorf("Expected safe level %s, got %s", tt.wantLevel, action.SafeLevel)
+			}
+			if action.CanAutoExec() != tt.wantAuto {
+				t.Errorf("Expected CanAutoExec %v, got %v", tt.wantAuto, action.CanAutoExec())
+			}
+		})
+	}
+}
+
+func TestNewRecoveryPlanWithoutActions(t *testing.T) {
+	if plan := NewRecoveryPlan(NewConfigInvalidError("bad yaml")); plan != nil {
+		t.Errorf("Expected no plan for config errors, got %+v", plan)
+	}
+	if plan := NewRecoveryPlan(nil); plan != nil {
+		t.Errorf("Expected no plan for nil, got %+v", plan)
+	}
+}
+
+func TestCanAutoExecRequiresSafeLevel(t *testing.T) {
+	action := RecoveryAction{Args: []string{"merge", "--abort"}, SafeLevel: SafeLevelDangerous, AutoExec: true}
+	if action.CanAutoExec() {
+		t.Error("Expected dangerous actions never to run automatically")
+	}
+	if action.Command() != "git merge --abort" {
+		t.Errorf("Unexpected command %q", action.Command())
+	}
+}
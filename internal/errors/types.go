@@ -25,6 +25,8 @@ const (
 	ErrTypeConfigInvalid   ErrorType = "CONFIG_INVALID"
 	ErrTypeConfigNotFound  ErrorType = "CONFIG_NOT_FOUND"
 	ErrTypePermissionDenied ErrorType = "PERMISSION_DENIED"
+	ErrTypeRepoProtected    ErrorType = "REPO_PROTECTED"
+	ErrTypeConfigConflict   ErrorType = "CONFIG_CONFLICT"
 	
 	// External tool errors
 	ErrTypeToolNotAvailable ErrorType = "TOOL_NOT_AVAILABLE"
@@ -0,0 +1,136 @@
+package external
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ContentSearcher interface defines the contract for content searching
+type ContentSearcher interface {
+	SearchContent(pattern string, repositories map[string]string, groupFilter string) ([]ContentResult, error)
+	FormatForFZF(results []ContentResult) string
+	ParseFZFSelection(selection string, results []ContentResult) (*ContentResult, error)
+}
+
+// defaultMaxMatchesPerFile limits the matches reported per file, so a
+// generated or minified file cannot flood interactive search results
+const defaultMaxMatchesPerFile = 50
+
+// NewContentSearcher returns the best available content searcher: ripgrep
+// when installed, otherwise git grep. The second return value names the
+// backend for user-facing messages.
+func NewContentSearcher() (ContentSearcher, string) {
+	if RipGrep.IsAvailable() {
+		return NewRGSearcher(), "ripgrep"
+	}
+	return NewGitGrepSearcher(), "git grep"
+}
+
+// NewWordScanner returns a content searcher like NewContentSearcher that
+// matches the pattern as whole words and reports every match of a file, for
+// scans like 'gman todos' that must not drop any match
+func NewWordScanner() (ContentSearcher, string) {
+	if RipGrep.IsAvailable() {
+		searcher := NewRGSearcher()
+		searcher.maxCount, searcher.words = 0, true
+		return searcher, "ripgrep"
+	}
+	searcher := NewGitGrepSearcher()
+	searcher.maxCount, searcher.words = 0, true
+	return searcher, "git grep"
+}
+
+// ContentGroup holds the content matches of a single repository
+type ContentGroup struct {
+	RepoAlias string
+	Results   []ContentResult
+}
+
+// GroupContentByRepository groups results per repository, sorted by alias,
+// with matches ordered by file path and line number
+func GroupContentByRepository(results []ContentResult) []ContentGroup {
+	byRepo := make(map[string][]ContentResult)
+	for _, result := range results {
+		byRepo[result.RepoAlias] = append(byRepo[result.RepoAlias], result)
+	}
+
+	groups := make([]ContentGroup, 0, len(byRepo))
+	for alias, repoResults := range byRepo {
+		sort.Slice(repoResults, func(i, j int) bool {
+			if repoResults[i].FilePath != repoResults[j].FilePath {
+				return repoResults[i].FilePath < repoResults[j].FilePath
+			}
+			return repoResults[i].LineNumber < repoResults[j].LineNumber
+		})
+		groups = append(groups, ContentGroup{RepoAlias: alias, Results: repoResults})
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].RepoAlias < groups[j].RepoAlias
+	})
+	return groups
+}
+
+// ReadLineRange returns lines first..last (1-based, inclusive) of a file
+// keyed by line number, used to show context around content matches
+func ReadLineRange(path string, first, last int) (map[int]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	lines := make(map[int]string)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		if n < first {
+			continue
+		}
+		if n > last {
+			break
+		}
+		lines[n] = scanner.Text()
+	}
+
+	return lines, scanner.Err()
+}
+
+// formatContentForFZF formats content results as "absolute_path:line_number:display_text"
+// so fzf key bindings can extract the path and line number easily
+func formatContentForFZF(results []ContentResult) string {
+	var lines []string
+	for _, result := range results {
+		line := fmt.Sprintf("%s:%d:%s", result.FullPath, result.LineNumber, result.DisplayText)
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// parseContentFZFSelection maps an fzf selection back to its content result
+func parseContentFZFSelection(selection string, results []ContentResult) (*ContentResult, error) {
+	// Format is: "absolute_path:line_number:display_text"
+	parts := strings.SplitN(selection, ":", 3)
+	if len(parts) < 3 {
+		// Fallback: try to match the entire selection as display text
+		for _, result := range results {
+			if result.DisplayText == selection {
+				return &result, nil
+			}
+		}
+		return nil, fmt.Errorf("selection not found in results")
+	}
+
+	// Extract display text (everything after the second colon)
+	displayText := parts[2]
+
+	for _, result := range results {
+		if result.DisplayText == displayText {
+			return &result, nil
+		}
+	}
+	return nil, fmt.Errorf("selection not found in results")
+}
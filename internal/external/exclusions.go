@@ -0,0 +1,120 @@
+package external
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gman/internal/di"
+)
+
+// SearchFilter holds the exclude globs and file size cutoff applied to one repository
+type SearchFilter struct {
+	Excludes    []string
+	MaxFileSize int64 // bytes, 0 for no limit
+}
+
+// maxFileSizeWarning reports an unparsable max_file_size only once per run
+var maxFileSizeWarning sync.Once
+
+// SearchFilterFor builds the search filter of a repository from the configuration.
+// An unparsable max_file_size is reported once and ignored.
+func SearchFilterFor(alias string) SearchFilter {
+	settings := di.ConfigManager().GetConfig().Search
+	filter := SearchFilter{Excludes: settings.ExcludesFor(alias)}
+
+	if settings.MaxFileSize != "" {
+		size, err := ParseSize(settings.MaxFileSize)
+		if err != nil {
+			maxFileSizeWarning.Do(func() {
+				slog.Warn("ignoring search.max_file_size", "error", err)
+			})
+		} else {
+			filter.MaxFileSize = size
+		}
+	}
+	return filter
+}
+
+// ParseSize parses sizes like "512", "512K", "1M", "1.5G" (binary units) into bytes
+func ParseSize(value string) (int64, error) {
+	s := strings.ToUpper(strings.TrimSpace(value))
+	s = strings.TrimSuffix(strings.TrimSuffix(s, "B"), "I")
+
+	multiplier := float64(1)
+	if s != "" {
+		switch s[len(s)-1] {
+		case 'K':
+			multiplier = 1 << 10
+		case 'M':
+			multiplier = 1 << 20
+		case 'G':
+			multiplier = 1 << 30
+		}
+		if multiplier > 1 {
+			s = s[:len(s)-1]
+		}
+	}
+
+	number, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || number < 0 {
+		return 0, fmt.Errorf("invalid size '%s' (use e.g. 512K, 1M or 2G)", value)
+	}
+	return int64(number * multiplier), nil
+}
+
+// IsExcluded reports whether a repository-relative path matches any exclude glob.
+// A glob without a slash matches any path component ("node_modules" excludes
+// every node_modules directory); a glob with a slash matches the path or one of
+// its leading directories ("web/build" excludes everything under web/build).
+func (f SearchFilter) IsExcluded(relPath string) bool {
+	relPath = filepath.ToSlash(relPath)
+	components := strings.Split(relPath, "/")
+
+	for _, glob := range f.Excludes {
+		glob = strings.TrimSuffix(strings.TrimSuffix(filepath.ToSlash(glob), "/**"), "/")
+		if glob == "" {
+			continue
+		}
+
+		if !strings.Contains(glob, "/") {
+			for _, component := range components {
+				if matched, _ := filepath.Match(glob, component); matched {
+					return true
+				}
+			}
+			continue
+		}
+
+		for i := range components {
+			prefix := strings.Join(components[:i+1], "/")
+			if matched, _ := filepath.Match(glob, prefix); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// TooLarge reports whether a file exceeds the size cutoff
+func (f SearchFilter) TooLarge(size int64) bool {
+	return f.MaxFileSize > 0 && size > f.MaxFileSize
+}
+
+// skipFile reports whether a search result should be dropped, checking the
+// file size on disk only when a cutoff is configured
+func (f SearchFilter) skipFile(relPath, fullPath string) bool {
+	if f.IsExcluded(relPath) {
+		return true
+	}
+	if f.MaxFileSize > 0 {
+		if info, err := os.Stat(fullPath); err == nil && f.TooLarge(info.Size()) {
+			return true
+		}
+	}
+	return false
+}
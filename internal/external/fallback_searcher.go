@@ -3,6 +3,7 @@ package external
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
@@ -56,7 +57,7 @@ func (fs *FallbackSearcher) SearchFiles(pattern string, repositories map[string]
 			repoResults, err := fs.searchInRepository(ctx, alias, path, pattern)
 			if err != nil {
 				// Log error but continue with other repositories
-				fmt.Printf("Warning: Failed to search %s: %v\n", alias, err)
+				slog.Warn("failed to search", "repo", alias, "error", err)
 				return
 			}
 
@@ -81,6 +82,8 @@ func (fs *FallbackSearcher) searchInRepository(ctx context.Context, alias, repoP
 	
 	// Convert pattern to lowercase for case-insensitive matching
 	lowerPattern := strings.ToLower(pattern)
+
+	filter := SearchFilterFor(alias)
 	
 	err := filepath.Walk(repoPath, func(path string, info os.FileInfo, err error) error {
 		// Check for context cancellation
@@ -103,6 +106,14 @@ func (fs *FallbackSearcher) searchInRepository(ctx context.Context, alias, repoP
 			if info.Name() == ".git" {
 				return filepath.SkipDir
 			}
+			if relDir, err := filepath.Rel(repoPath, path); err == nil && relDir != "." && filter.IsExcluded(relDir) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		// Skip files over the size cutoff
+		if filter.TooLarge(info.Size()) {
 			return nil
 		}
 
@@ -120,6 +131,9 @@ func (fs *FallbackSearcher) searchInRepository(ctx context.Context, alias, repoP
 				// If we can't get relative path, use the full path
 				relPath = path
 			}
+			if filter.IsExcluded(relPath) {
+				return nil
+			}
 
 			// Create display text: "alias:path"
 			displayText := fmt.Sprintf("%s:%s", alias, relPath)
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"log/slog"
 	"os/exec"
 	"path/filepath"
 	"strings"
@@ -63,7 +64,7 @@ func (fs *FDSearcher) SearchFiles(pattern string, repositories map[string]string
 			repoResults, err := fs.searchInRepository(ctx, alias, path, pattern)
 			if err != nil {
 				// Log error but continue with other repositories
-				fmt.Printf("Warning: Failed to search %s: %v\n", alias, err)
+				slog.Warn("failed to search", "repo", alias, "error", err)
 				return
 			}
 
@@ -93,6 +94,15 @@ func (fs *FDSearcher) searchInRepository(ctx context.Context, alias, repoPath, p
 		"--color", "never",   // no color output
 	}
 
+	// Apply configured exclusions and size cutoff
+	filter := SearchFilterFor(alias)
+	for _, glob := range filter.Excludes {
+		args = append(args, "--exclude", glob)
+	}
+	if filter.MaxFileSize > 0 {
+		args = append(args, "--size", fmt.Sprintf("-%db", filter.MaxFileSize))
+	}
+
 	// Add pattern if provided
 	if pattern != "" {
 		args = append(args, pattern)
@@ -0,0 +1,244 @@
+package external
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gman/internal/di"
+	"gman/internal/repository"
+)
+
+// GitGrepSearcher performs content searches using `git grep` (fallback for rg).
+// It only searches tracked files, which also keeps build artifacts out of results.
+type GitGrepSearcher struct {
+	timeout  time.Duration
+	ref      string // search this branch, tag or commit instead of the working tree
+	maxCount int    // matches reported per file; 0 reports all
+	words    bool   // match the pattern as whole words only
+}
+
+// NewGitGrepSearcher creates a new git grep based content searcher
+func NewGitGrepSearcher() *GitGrepSearcher {
+	return &GitGrepSearcher{
+		timeout:  30 * time.Second, // Slower than rg on large trees
+		maxCount: defaultMaxMatchesPerFile,
+	}
+}
+
+// NewGitGrepSearcherAtRef creates a git grep searcher over the tree of ref,
+// so content can be searched without checking the ref out
+func NewGitGrepSearcherAtRef(ref string) *GitGrepSearcher {
+	searcher := NewGitGrepSearcher()
+	searcher.ref = ref
+	return searcher
+}
+
+// SearchContent searches for content across multiple repositories using git grep
+func (gs *GitGrepSearcher) SearchContent(pattern string, repositories map[string]string, groupFilter string) ([]ContentResult, error) {
+	if pattern == "" {
+		return nil, fmt.Errorf("search pattern is required for content search")
+	}
+
+	// Use consolidated repository filtering
+	filter := repository.NewFilter(di.ConfigManager())
+	reposToSearch, err := filter.FilterByGroup(repositories, groupFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), gs.timeout)
+	defer cancel()
+
+	var results []ContentResult
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	// Search each repository concurrently
+	for alias, path := range reposToSearch {
+		wg.Add(1)
+		go func(alias, path string) {
+			defer wg.Done()
+
+			repoResults, err := gs.searchInRepository(ctx, alias, path, pattern)
+			if err != nil {
+				// Log error but continue with other repositories
+				slog.Warn("failed to search content", "repo", alias, "error", err)
+				return
+			}
+
+			mu.Lock()
+			results = append(results, repoResults...)
+			mu.Unlock()
+		}(alias, path)
+	}
+
+	wg.Wait()
+
+	if ctx.Err() == context.DeadlineExceeded {
+		return results, fmt.Errorf("content search timed out after %v (using git grep)", gs.timeout)
+	}
+
+	return results, nil
+}
+
+// gitGrepRegexFlag selects Perl-compatible regexes (-P), whose syntax
+// matches ripgrep's (\d, lazy quantifiers, (?i)), when git was built with
+// PCRE. Otherwise extended regexes (-E) are the closest git offers.
+var gitGrepRegexFlag = sync.OnceValue(func() string {
+	dir, err := os.MkdirTemp("", "gman-grep-")
+	if err != nil {
+		return "-E"
+	}
+	defer os.RemoveAll(dir)
+
+	// Searching an empty directory finds nothing (exit 1) when -P works;
+	// git without PCRE fails with exit 128
+	cmd := exec.Command("git", "grep", "--no-index", "-q", "-P", "-e", `\d`)
+	cmd.Dir = dir
+	var exitErr *exec.ExitError
+	if err := cmd.Run(); err == nil || (errors.As(err, &exitErr) && exitErr.ExitCode() == 1) {
+		return "-P"
+	}
+	return "-E"
+})
+
+// searchInRepository runs git grep in a single repository
+func (gs *GitGrepSearcher) searchInRepository(ctx context.Context, alias, repoPath, pattern string) ([]ContentResult, error) {
+	args := []string{
+		"grep",
+		"--line-number", // show line numbers
+		"--column",      // show column numbers
+		"-I",            // skip binary files
+		"--no-color",    // no color output
+		"--full-name",   // paths relative to the repository root
+		gitGrepRegexFlag(),
+	}
+	if gs.maxCount > 0 {
+		args = append(args, "--max-count", strconv.Itoa(gs.maxCount))
+	}
+	if gs.words {
+		args = append(args, "--word-regexp")
+	}
+	args = append(args, "-e", pattern)
+	if gs.ref != "" {
+		// Repositories without the ref have nothing to search
+		if !di.GitManager().RefExists(repoPath, gs.ref) {
+			return nil, nil
+		}
+		args = append(args, gs.ref)
+	}
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = repoPath
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stdout pipe: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start git grep: %w", err)
+	}
+
+	// git grep has no size cutoff, so exclusions are applied to its output
+	filter := SearchFilterFor(alias)
+	skipped := make(map[string]bool)
+
+	var results []ContentResult
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if gs.ref != "" {
+			// With a ref, lines are prefixed with "<ref>:"
+			line = strings.TrimPrefix(line, gs.ref+":")
+		}
+		result, err := gs.parseGrepLine(alias, repoPath, line)
+		if err != nil {
+			// Skip malformed lines
+			continue
+		}
+
+		skip, seen := skipped[result.FilePath]
+		if !seen {
+			// Files at a ref are not on disk, so only exclusions apply there
+			if gs.ref != "" {
+				skip = filter.IsExcluded(result.FilePath)
+			} else {
+				skip = filter.skipFile(result.FilePath, result.FullPath)
+			}
+			skipped[result.FilePath] = skip
+		}
+		if skip {
+			continue
+		}
+		results = append(results, *result)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading git grep output: %w", err)
+	}
+
+	if err := cmd.Wait(); err != nil {
+		// git grep exits with 1 when nothing matched, which is not an error
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return results, nil
+		}
+		return nil, fmt.Errorf("git grep failed: %w", err)
+	}
+
+	return results, nil
+}
+
+// parseGrepLine parses a single "file:line:column:content" line of git grep output
+func (gs *GitGrepSearcher) parseGrepLine(alias, repoPath, line string) (*ContentResult, error) {
+	parts := strings.SplitN(line, ":", 4)
+	if len(parts) < 4 {
+		return nil, fmt.Errorf("invalid git grep output format: %s", line)
+	}
+
+	lineNum, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid line number: %s", parts[1])
+	}
+
+	column, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid column number: %s", parts[2])
+	}
+
+	relPath := parts[0]
+	content := parts[3]
+
+	return &ContentResult{
+		RepoAlias:   alias,
+		FilePath:    relPath,
+		FullPath:    filepath.Join(repoPath, relPath),
+		LineNumber:  lineNum,
+		LineContent: content,
+		MatchColumn: column,
+		DisplayText: fmt.Sprintf("%s:%s:%d: %s", alias, relPath, lineNum, strings.TrimSpace(content)),
+	}, nil
+}
+
+// FormatForFZF formats content results for fzf input
+// Format: "absolute_path:line_number:display_text"
+func (gs *GitGrepSearcher) FormatForFZF(results []ContentResult) string {
+	return formatContentForFZF(results)
+}
+
+// ParseFZFSelection parses fzf selection and returns the corresponding content result
+func (gs *GitGrepSearcher) ParseFZFSelection(selection string, results []ContentResult) (*ContentResult, error) {
+	return parseContentFZFSelection(selection, results)
+}
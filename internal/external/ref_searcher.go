@@ -0,0 +1,75 @@
+package external
+
+import (
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gman/internal/di"
+	"gman/internal/repository"
+)
+
+// SearchFilesAtRef searches the file paths of a branch, tag or commit via
+// git ls-tree, so files can be found without checking the ref out.
+// Repositories that do not have the ref are skipped.
+func SearchFilesAtRef(pattern, ref string, repositories map[string]string, groupFilter string) ([]FileResult, error) {
+	if ref == "" {
+		return nil, fmt.Errorf("a ref is required")
+	}
+
+	// Use consolidated repository filtering
+	filter := repository.NewFilter(di.ConfigManager())
+	reposToSearch, err := filter.FilterByGroup(repositories, groupFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	gitMgr := di.GitManager()
+	lowerPattern := strings.ToLower(pattern)
+	var results []FileResult
+	found := false
+
+	aliases := make([]string, 0, len(reposToSearch))
+	for alias := range reposToSearch {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+
+	for _, alias := range aliases {
+		repoPath := reposToSearch[alias]
+		if !gitMgr.RefExists(repoPath, ref) {
+			continue
+		}
+		found = true
+
+		files, err := gitMgr.ListFilesAtRef(repoPath, ref)
+		if err != nil {
+			// Log error but continue with other repositories
+			slog.Warn("failed to search", "repo", alias, "error", err)
+			continue
+		}
+
+		searchFilter := SearchFilterFor(alias)
+		for _, relPath := range files {
+			if pattern != "" && !strings.Contains(strings.ToLower(relPath), lowerPattern) {
+				continue
+			}
+			if searchFilter.IsExcluded(relPath) {
+				continue
+			}
+			results = append(results, FileResult{
+				RepoAlias:    alias,
+				RelativePath: relPath,
+				FullPath:     filepath.Join(repoPath, relPath),
+				DisplayText:  fmt.Sprintf("%s@%s:%s", alias, ref, relPath),
+			})
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("ref '%s' not found in any searched repository", ref)
+	}
+	return results, nil
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"log/slog"
 	"os/exec"
 	"path/filepath"
 	"strconv"
@@ -28,13 +29,16 @@ type ContentResult struct {
 
 // RGSearcher performs content searches using the rg (ripgrep) tool
 type RGSearcher struct {
-	timeout time.Duration
+	timeout  time.Duration
+	maxCount int  // matches reported per file; 0 reports all
+	words    bool // match the pattern as whole words only
 }
 
 // NewRGSearcher creates a new rg-based content searcher
 func NewRGSearcher() *RGSearcher {
 	return &RGSearcher{
-		timeout: 15 * time.Second, // Content search might take longer
+		timeout:  15 * time.Second, // Content search might take longer
+		maxCount: defaultMaxMatchesPerFile,
 	}
 }
 
@@ -71,7 +75,7 @@ func (rs *RGSearcher) SearchContent(pattern string, repositories map[string]stri
 			repoResults, err := rs.searchInRepository(ctx, alias, path, pattern)
 			if err != nil {
 				// Log error but continue with other repositories
-				fmt.Printf("Warning: Failed to search content in %s: %v\n", alias, err)
+				slog.Warn("failed to search content", "repo", alias, "error", err)
 				return
 			}
 
@@ -103,11 +107,25 @@ func (rs *RGSearcher) searchInRepository(ctx context.Context, alias, repoPath, p
 		"--follow",           // follow symlinks
 		"--text",         // treat all files as text (skip binary detection)
 		"-g", "!.git/**",     // exclude .git directory
-		"--max-count", "50",  // limit matches per file to prevent overwhelming results
-		pattern,              // search pattern
-		repoPath,             // search path
+	}
+	if rs.maxCount > 0 {
+		args = append(args, "--max-count", strconv.Itoa(rs.maxCount))
+	}
+	if rs.words {
+		args = append(args, "--word-regexp")
 	}
 
+	// Apply configured exclusions and size cutoff
+	filter := SearchFilterFor(alias)
+	for _, glob := range filter.Excludes {
+		args = append(args, "-g", "!"+glob)
+	}
+	if filter.MaxFileSize > 0 {
+		args = append(args, "--max-filesize", strconv.FormatInt(filter.MaxFileSize, 10))
+	}
+
+	args = append(args, pattern, repoPath)
+
 	cmd := exec.CommandContext(ctx, "rg", args...)
 	
 	stdout, err := cmd.StdoutPipe()
@@ -209,38 +227,10 @@ func (rs *RGSearcher) parseRGLine(alias, repoPath, line string) (*ContentResult,
 // FormatForFZF formats content results for fzf input
 // Format: "absolute_path:line_number:display_text"
 func (rs *RGSearcher) FormatForFZF(results []ContentResult) string {
-	var lines []string
-	for _, result := range results {
-		// Format: absolute_path:line_number:display_text
-		// This allows fzf key bindings to extract the path and line number easily
-		line := fmt.Sprintf("%s:%d:%s", result.FullPath, result.LineNumber, result.DisplayText)
-		lines = append(lines, line)
-	}
-	return strings.Join(lines, "\n")
+	return formatContentForFZF(results)
 }
 
 // ParseFZFSelection parses fzf selection and returns the corresponding content result
 func (rs *RGSearcher) ParseFZFSelection(selection string, results []ContentResult) (*ContentResult, error) {
-	// New format is: "absolute_path:line_number:display_text"
-	// We need to extract the display_text part and match it
-	parts := strings.SplitN(selection, ":", 3)
-	if len(parts) < 3 {
-		// Fallback: try to match the entire selection as display text
-		for _, result := range results {
-			if result.DisplayText == selection {
-				return &result, nil
-			}
-		}
-		return nil, fmt.Errorf("selection not found in results")
-	}
-
-	// Extract display text (everything after the second colon)
-	displayText := parts[2]
-	
-	for _, result := range results {
-		if result.DisplayText == displayText {
-			return &result, nil
-		}
-	}
-	return nil, fmt.Errorf("selection not found in results")
-}
\ No newline at end of file
+	return parseContentFZFSelection(selection, results)
+}
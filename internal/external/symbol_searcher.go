@@ -0,0 +1,288 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gman/internal/di"
+	"gman/internal/repository"
+)
+
+// Symbol search backends
+const (
+	SymbolBackendCtags = "ctags"
+	SymbolBackendGopls = "gopls"
+)
+
+// CTags is universal-ctags, used for symbol search
+var CTags = &Tool{
+	Name:        "universal-ctags",
+	Command:     "ctags",
+	Description: "Source code indexer used for symbol (definition) search",
+	Website:     "https://github.com/universal-ctags/ctags",
+	Required:    false,
+	InstallCommands: map[string]string{
+		"darwin":  "brew install universal-ctags",
+		"linux":   "apt install universal-ctags || yum install ctags || pacman -S ctags",
+		"windows": "winget install UniversalCtags.Ctags",
+	},
+	CheckCmd: []string{"ctags", "--version"},
+}
+
+// Gopls is the Go language server, optionally used for symbol search in Go modules
+var Gopls = &Tool{
+	Name:        "gopls",
+	Command:     "gopls",
+	Description: "Go language server, used for precise symbol search in Go modules",
+	Website:     "https://pkg.go.dev/golang.org/x/tools/gopls",
+	Required:    false,
+	InstallCommands: map[string]string{
+		"darwin":  "go install golang.org/x/tools/gopls@latest",
+		"linux":   "go install golang.org/x/tools/gopls@latest",
+		"windows": "go install golang.org/x/tools/gopls@latest",
+	},
+	CheckCmd: []string{"gopls", "version"},
+}
+
+// SymbolSearcher locates symbol definitions across repositories. It implements
+// ContentSearcher so results flow through the same fzf and print pipeline.
+type SymbolSearcher struct {
+	Backend string // SymbolBackendCtags or SymbolBackendGopls
+	Partial bool   // substring instead of exact name matching
+	Kind    string // only symbols of this kind (function, type, ...)
+	timeout time.Duration
+}
+
+// NewSymbolSearcher creates a symbol searcher for the given backend
+// (ctags when empty) after checking the backend is installed
+func NewSymbolSearcher(backend string) (*SymbolSearcher, error) {
+	if backend == "" {
+		backend = SymbolBackendCtags
+	}
+
+	switch backend {
+	case SymbolBackendCtags:
+		version, err := CTags.GetVersion()
+		if err != nil {
+			return nil, fmt.Errorf("ctags is required for symbol search:\n%s", CTags.GetInstallInstructions())
+		}
+		if !strings.Contains(version, "Universal Ctags") {
+			return nil, fmt.Errorf("universal-ctags is required for symbol search (found a ctags without JSON output):\n%s", CTags.GetInstallInstructions())
+		}
+	case SymbolBackendGopls:
+		if !Gopls.IsAvailable() {
+			return nil, fmt.Errorf("gopls is required for the gopls symbol backend:\n%s", Gopls.GetInstallInstructions())
+		}
+	default:
+		return nil, fmt.Errorf("unknown symbol backend '%s' (use ctags or gopls)", backend)
+	}
+
+	return &SymbolSearcher{
+		Backend: backend,
+		timeout: 60 * time.Second, // Indexing large repositories takes a while
+	}, nil
+}
+
+// SearchContent finds definitions of the named symbol across repositories
+func (ss *SymbolSearcher) SearchContent(name string, repositories map[string]string, groupFilter string) ([]ContentResult, error) {
+	if name == "" {
+		return nil, fmt.Errorf("symbol name is required for symbol search")
+	}
+
+	// Use consolidated repository filtering
+	filter := repository.NewFilter(di.ConfigManager())
+	reposToSearch, err := filter.FilterByGroup(repositories, groupFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), ss.timeout)
+	defer cancel()
+
+	var results []ContentResult
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for alias, path := range reposToSearch {
+		wg.Add(1)
+		go func(alias, path string) {
+			defer wg.Done()
+
+			var repoResults []ContentResult
+			var err error
+			if ss.Backend == SymbolBackendGopls {
+				repoResults, err = ss.searchWithGopls(ctx, alias, path, name)
+			} else {
+				repoResults, err = ss.searchWithCtags(ctx, alias, path, name)
+			}
+			if err != nil {
+				// Log error but continue with other repositories
+				slog.Warn("failed to search symbols", "repo", alias, "error", err)
+				return
+			}
+
+			mu.Lock()
+			results = append(results, repoResults...)
+			mu.Unlock()
+		}(alias, path)
+	}
+
+	wg.Wait()
+
+	if ctx.Err() == context.DeadlineExceeded {
+		return results, fmt.Errorf("symbol search timed out after %v (using %s)", ss.timeout, ss.Backend)
+	}
+
+	return results, nil
+}
+
+// ctagsTag is one entry of universal-ctags JSON output
+type ctagsTag struct {
+	Type    string `json:"_type"`
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	Pattern string `json:"pattern"`
+	Line    int    `json:"line"`
+	Kind    string `json:"kind"`
+}
+
+// searchWithCtags indexes the tracked files of a repository with ctags and
+// keeps the tags matching name
+func (ss *SymbolSearcher) searchWithCtags(ctx context.Context, alias, repoPath, name string) ([]ContentResult, error) {
+	files, err := exec.CommandContext(ctx, "git", "-C", repoPath, "ls-files").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, "ctags", "-f", "-", "--output-format=json", "--fields=+nK", "-L", "-")
+	cmd.Dir = repoPath
+	cmd.Stdin = bytes.NewReader(files)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("ctags failed: %w", err)
+	}
+
+	return ss.parseCtagsOutput(alias, repoPath, name, output), nil
+}
+
+// parseCtagsOutput converts ctags JSON lines into results for matching symbols
+func (ss *SymbolSearcher) parseCtagsOutput(alias, repoPath, name string, output []byte) []ContentResult {
+	var results []ContentResult
+	for _, line := range bytes.Split(output, []byte("\n")) {
+		var tag ctagsTag
+		if err := json.Unmarshal(line, &tag); err != nil || tag.Type != "tag" {
+			continue
+		}
+		if !ss.matches(tag.Name, tag.Kind, name) {
+			continue
+		}
+		results = append(results, newSymbolResult(alias, repoPath, tag.Path, tag.Line, tag.Name, tag.Kind, ctagsPatternText(tag.Pattern)))
+	}
+	return results
+}
+
+// ctagsPatternText extracts the source line from a ctags search pattern like /^func Foo() {$/
+func ctagsPatternText(pattern string) string {
+	pattern = strings.TrimPrefix(pattern, "/^")
+	pattern = strings.TrimSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "$")
+	return strings.ReplaceAll(pattern, `\/`, "/")
+}
+
+// goplsSymbolLine matches "path:line:col-col Name Kind" lines of gopls workspace_symbol
+var goplsSymbolLine = regexp.MustCompile(`^(.+?):(\d+):\d+(?:-\d+)?\s+(\S+)\s+(\S+)$`)
+
+// searchWithGopls queries gopls for workspace symbols; repositories without
+// a go.mod are skipped
+func (ss *SymbolSearcher) searchWithGopls(ctx context.Context, alias, repoPath, name string) ([]ContentResult, error) {
+	if _, err := os.Stat(filepath.Join(repoPath, "go.mod")); err != nil {
+		return nil, nil
+	}
+
+	cmd := exec.CommandContext(ctx, "gopls", "workspace_symbol", name)
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("gopls failed: %w", err)
+	}
+
+	return ss.parseGoplsOutput(alias, repoPath, name, output), nil
+}
+
+// parseGoplsOutput converts gopls workspace_symbol output into results for matching symbols
+func (ss *SymbolSearcher) parseGoplsOutput(alias, repoPath, name string, output []byte) []ContentResult {
+	var results []ContentResult
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := goplsSymbolLine.FindStringSubmatch(strings.TrimSpace(line))
+		if fields == nil {
+			continue
+		}
+		lineNum, _ := strconv.Atoi(fields[2])
+		symbol := fields[3]
+		// gopls qualifies methods and fields as Type.Name
+		if i := strings.LastIndex(symbol, "."); i >= 0 {
+			symbol = symbol[i+1:]
+		}
+		kind := strings.ToLower(fields[4])
+		if !ss.matches(symbol, kind, name) {
+			continue
+		}
+
+		relPath := fields[1]
+		if filepath.IsAbs(relPath) {
+			if rel, err := filepath.Rel(repoPath, relPath); err == nil {
+				relPath = rel
+			}
+		}
+		results = append(results, newSymbolResult(alias, repoPath, relPath, lineNum, fields[3], kind, ""))
+	}
+	return results
+}
+
+// matches applies the name and kind filters to a symbol
+func (ss *SymbolSearcher) matches(symbol, kind, name string) bool {
+	if ss.Kind != "" && !strings.EqualFold(kind, ss.Kind) {
+		return false
+	}
+	if ss.Partial {
+		return strings.Contains(strings.ToLower(symbol), strings.ToLower(name))
+	}
+	return symbol == name
+}
+
+// newSymbolResult builds a content result for a symbol definition
+func newSymbolResult(alias, repoPath, relPath string, line int, symbol, kind, text string) ContentResult {
+	if text == "" {
+		text = symbol
+	}
+	return ContentResult{
+		RepoAlias:   alias,
+		FilePath:    relPath,
+		FullPath:    filepath.Join(repoPath, relPath),
+		LineNumber:  line,
+		LineContent: text,
+		DisplayText: fmt.Sprintf("%s:%s:%d: [%s] %s", alias, relPath, line, kind, strings.TrimSpace(text)),
+	}
+}
+
+// FormatForFZF formats symbol results for fzf input
+// Format: "absolute_path:line_number:display_text"
+func (ss *SymbolSearcher) FormatForFZF(results []ContentResult) string {
+	return formatContentForFZF(results)
+}
+
+// ParseFZFSelection parses fzf selection and returns the corresponding symbol result
+func (ss *SymbolSearcher) ParseFZFSelection(selection string, results []ContentResult) (*ContentResult, error) {
+	return parseContentFZFSelection(selection, results)
+}
@@ -62,6 +62,20 @@ var (
 		},
 		CheckCmd: []string{"fzf", "--version"},
 	}
+
+	GitHubCLI = &Tool{
+		Name:        "gh",
+		Command:     "gh",
+		Description: "GitHub CLI, used to create remote repositories",
+		Website:     "https://cli.github.com",
+		Required:    false,
+		InstallCommands: map[string]string{
+			"darwin":  "brew install gh",
+			"linux":   "apt install gh || yum install gh || pacman -S github-cli",
+			"windows": "winget install GitHub.cli",
+		},
+		CheckCmd: []string{"gh", "--version"},
+	}
 )
 
 // IsAvailable checks if the tool is available on the system
@@ -158,7 +172,7 @@ func (t *Tool) GetDiagnosticInfo() DiagnosticInfo {
 		case "fd":
 			info.Alternative = "Standard file listing will be used (slower)"
 		case "ripgrep":
-			info.Alternative = "Content search will be unavailable"
+			info.Alternative = "git grep will be used for content search (slower, tracked files only)"
 		case "fzf":
 			info.Alternative = "Basic numbered selection will be used"
 		}
@@ -296,7 +310,7 @@ func generateDiagnosticSuggestions(diag *SystemDiagnostics) []string {
 	} else if missingFd {
 		suggestions = append(suggestions, "⚡ Installing fd will make file search much faster")
 	} else if missingRg {
-		suggestions = append(suggestions, "🔍 Installing ripgrep will enable powerful content search capabilities")
+		suggestions = append(suggestions, "🔍 Installing ripgrep will make content search much faster")
 	}
 	
 	return suggestions
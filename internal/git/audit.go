@@ -0,0 +1,204 @@
+package git
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"gman/internal/errors"
+)
+
+// AuditLogFile is the file name of the audit trail inside the config directory
+const AuditLogFile = "audit.log"
+
+// Results of audited git commands
+const (
+	AuditOK      = "ok"
+	AuditFailed  = "failed"
+	AuditRefused = "refused" // Blocked by repository protection
+)
+
+// AuditRecord describes one state-changing git command gman ran or refused
+type AuditRecord struct {
+	Time      time.Time `json:"time"`
+	Repo      string    `json:"repo"`
+	Args      []string  `json:"args"`
+	Initiator string    `json:"initiator"` // The gman command that ran it, e.g. "gman work sync"
+	User      string    `json:"user,omitempty"`
+	Result    string    `json:"result"`
+	ExitCode  int       `json:"exit_code"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// AuditLog appends audit records as JSON lines to a file. Unlike the
+// command log it is never rotated or truncated by gman.
+type AuditLog struct {
+	path      string
+	initiator string
+	user      string
+	mu        sync.Mutex
+}
+
+// NewAuditLog creates an audit log writing to path for the gman command initiator
+func NewAuditLog(path, initiator string) *AuditLog {
+	name := os.Getenv("USER")
+	if current, err := user.Current(); err == nil {
+		name = current.Username
+	}
+	return &AuditLog{path: path, initiator: initiator, user: name}
+}
+
+// Path returns the audit file location
+func (l *AuditLog) Path() string {
+	return l.path
+}
+
+// Record appends a record to the audit file. Like the command log, a write
+// failure must not break the git operation and is ignored.
+func (l *AuditLog) Record(record AuditRecord) {
+	record.Initiator = l.initiator
+	record.User = l.user
+	data, err := json.Marshal(record)
+	if err != nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return
+	}
+	file, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	file.Write(append(data, '\n'))
+}
+
+// ReadAuditLog returns all records in path, oldest first
+func ReadAuditLog(path string) ([]AuditRecord, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer file.Close()
+
+	var records []AuditRecord
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var record AuditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			continue // Skip partially written lines
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	return records, nil
+}
+
+// SetAuditLog enables auditing of the state-changing git commands run by
+// this manager. Passing nil disables auditing.
+func (g *Manager) SetAuditLog(log *AuditLog) {
+	g.auditLog = log
+}
+
+// auditCommand records a state-changing git invocation when auditing is on
+func (g *Manager) auditCommand(path string, args []string, start time.Time, err error) {
+	if g.auditLog == nil || !isMutation(args) {
+		return
+	}
+
+	record := AuditRecord{Time: start, Repo: path, Args: args, Result: AuditOK}
+	if err != nil {
+		record.Error = err.Error()
+		record.Result = AuditFailed
+		record.ExitCode = -1
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			record.ExitCode = exitErr.ExitCode()
+		}
+		if errors.IsType(err, errors.ErrTypeRepoProtected) {
+			record.Result = AuditRefused
+		}
+	}
+	g.auditLog.Record(record)
+}
+
+// readOnlyCommands never change a repository. fetch only moves
+// remote-tracking refs and runs on every status refresh, so it is not
+// audited either.
+var readOnlyCommands = []string{
+	"status", "rev-parse", "log", "diff", "show", "rev-list",
+	"ls-files", "ls-tree", "for-each-ref", "fetch", "cat-file", "count-objects",
+}
+
+// isMutation reports whether git args can change a repository
+func isMutation(args []string) bool {
+	if len(args) == 0 || slices.Contains(readOnlyCommands, args[0]) {
+		return false
+	}
+
+	var flags, positional []string
+	for _, arg := range args[1:] {
+		if strings.HasPrefix(arg, "-") {
+			flags = append(flags, arg)
+		} else {
+			positional = append(positional, arg)
+		}
+	}
+	hasFlag := func(names ...string) bool {
+		return slices.ContainsFunc(flags, func(flag string) bool {
+			name, _, _ := strings.Cut(flag, "=")
+			return slices.Contains(names, name)
+		})
+	}
+	first := ""
+	if len(positional) > 0 {
+		first = positional[0]
+	}
+
+	switch args[0] {
+	case "branch":
+		if hasFlag("-d", "-D", "--delete", "-m", "-M", "--move", "-c", "-C", "--copy",
+			"-u", "--set-upstream-to", "--unset-upstream", "--edit-description") {
+			return true
+		}
+		listing := hasFlag("-a", "--all", "-r", "--remotes", "-l", "--list", "--show-current",
+			"--merged", "--no-merged", "--contains", "--no-contains", "--points-at")
+		return !listing && len(positional) > 0
+	case "stash":
+		return first != "list" && first != "show"
+	case "worktree":
+		return first != "list"
+	case "tag":
+		if hasFlag("-d", "--delete", "-a", "--annotate", "-s", "--sign", "-f", "--force") {
+			return true
+		}
+		listing := hasFlag("-l", "--list", "--contains", "--no-contains", "--merged", "--no-merged", "--points-at")
+		return !listing && len(positional) > 0
+	case "remote":
+		return first != "" && first != "get-url" && first != "show"
+	case "config":
+		if hasFlag("--get", "--get-all", "--get-regexp", "-l", "--list") {
+			return false
+		}
+		return len(positional) > 1 || hasFlag("--unset", "--unset-all", "--add", "--replace-all")
+	}
+	return true
+}
@@ -0,0 +1,71 @@
+package git
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestIsMutation(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{[]string{"status", "--porcelain"}, false},
+		{[]string{"fetch", "--all"}, false},
+		{[]string{"commit", "-m", "msg"}, true},
+		{[]string{"pull", "--ff-only"}, true},
+		{[]string{"branch", "-a"}, false},
+		{[]string{"branch", "--merged", "main"}, false},
+		{[]string{"branch", "feature"}, true},
+		{[]string{"branch", "-d", "feature"}, true},
+		{[]string{"stash", "list", "--oneline"}, false},
+		{[]string{"stash"}, true},
+		{[]string{"stash", "pop"}, true},
+		{[]string{"worktree", "list", "--porcelain"}, false},
+		{[]string{"tag"}, false},
+		{[]string{"tag", "-l", "v*"}, false},
+		{[]string{"tag", "-a", "v1.0.0", "-m", "Release v1.0.0"}, true},
+		{[]string{"worktree", "add", "/tmp/wt", "main"}, true},
+		{[]string{"remote", "get-url", "origin"}, false},
+		{[]string{"remote", "add", "upstream", "url"}, true},
+		{[]string{"config", "user.name"}, false},
+		{[]string{"config", "--get", "user.name"}, false},
+		{[]string{"config", "user.name", "Tester"}, true},
+	}
+
+	for _, tt := range tests {
+		if got := isMutation(tt.args); got != tt.want {
+			t.Errorf("isMutation(%v) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestAuditLogRecordsMutations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), AuditLogFile)
+	g := NewManager()
+	g.SetAuditLog(NewAuditLog(path, "gman work sync"))
+
+	g.auditCommand("/src/api", []string{"status"}, time.Now(), nil)
+	g.auditCommand("/src/api", []string{"pull", "--ff-only"}, time.Now(), nil)
+
+	// A refused force push is audited although it never ran
+	g.SetProtection(Protection{Protected: []string{"/src/api"}})
+	if err := g.checkProtection("/src/api", []string{"push", "--force"}); err == nil {
+		t.Fatal("Expected force push to be refused")
+	}
+
+	records, err := ReadAuditLog(path)
+	if err != nil {
+		t.Fatalf("ReadAuditLog failed: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 audit records, got %d: %+v", len(records), records)
+	}
+	if records[0].Result != AuditOK || records[0].Initiator != "gman work sync" {
+		t.Errorf("Unexpected pull record: %+v", records[0])
+	}
+	if records[1].Result != AuditRefused {
+		t.Errorf("Expected refused force push, got %+v", records[1])
+	}
+}
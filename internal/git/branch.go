@@ -2,8 +2,11 @@ package git
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
 	"strings"
+
+	"gman/internal/errors"
 )
 
 // BranchManagerImpl implements BranchManager interface
@@ -117,7 +120,7 @@ func (b *BranchManagerImpl) SwitchBranch(path, branchName string) error {
 	}
 
 	if hasChanges {
-		return fmt.Errorf("cannot switch branches with uncommitted changes. Please commit or stash your changes first")
+		return errors.NewGmanError(errors.ErrTypeWorkspaceNotClean, "cannot switch branches with uncommitted changes. Please commit or stash your changes first")
 	}
 
 	// Switch to the branch
@@ -182,7 +185,7 @@ func (b *BranchManagerImpl) CleanMergedBranches(path, mainBranch string) ([]stri
 		_, err := b.runGitCommand(path, "branch", "-d", branch)
 		if err != nil {
 			// Log error but continue with other branches
-			fmt.Printf("Warning: Failed to delete branch '%s': %v\n", branch, err)
+			slog.Warn("failed to delete branch", "branch", branch, "error", err)
 			continue
 		}
 		deletedBranches = append(deletedBranches, branch)
@@ -218,7 +221,7 @@ func (b *BranchManagerImpl) verifyBranchExists(repoPath, branch string) error {
 		}
 	}
 
-	return fmt.Errorf("branch '%s' does not exist", branch)
+	return errors.NewGmanError(errors.ErrTypeBranchNotFound, fmt.Sprintf("branch '%s' does not exist", branch))
 }
 
 func (b *BranchManagerImpl) detectMainBranch(path string) (string, error) {
@@ -0,0 +1,37 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// CloneShallow clones the latest commit of url into dst, which must not
+// exist yet. Credential prompts are disabled so a clone never waits for input.
+func (g *Manager) CloneShallow(url, dst string) error {
+	parent := filepath.Dir(dst)
+	if err := g.validatePath(parent); err != nil {
+		return fmt.Errorf("invalid clone destination: %w", err)
+	}
+	if err := g.ValidateArguments(url, dst); err != nil {
+		return err
+	}
+
+	args := []string{"clone", "--quiet", "--depth", "1", "--", url, dst}
+	timeout := g.commandTimeout("clone")
+	cmd, ctx, cancel := gitCommand(parent, timeout, args)
+	defer cancel()
+
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+	start := time.Now()
+	output, err := cmd.CombinedOutput()
+	if err != nil && ctx.Err() == nil && len(output) > 0 {
+		err = fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+	err = timeoutError(ctx, "clone", timeout, err)
+	g.recordCommand(parent, args, start, err)
+	return err
+}
@@ -0,0 +1,46 @@
+package git
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestManager_CloneShallow(t *testing.T) {
+	for _, name := range []string{"GIT_AUTHOR_NAME", "GIT_COMMITTER_NAME"} {
+		t.Setenv(name, "test")
+	}
+	for _, name := range []string{"GIT_AUTHOR_EMAIL", "GIT_COMMITTER_EMAIL"} {
+		t.Setenv(name, "test@example.com")
+	}
+	dir := t.TempDir()
+	run := func(args ...string) string {
+		t.Helper()
+		output, err := exec.Command("git", args...).CombinedOutput()
+		if err != nil {
+			t.Skipf("git %v failed: %v\n%s", args, err, output)
+		}
+		return strings.TrimSpace(string(output))
+	}
+
+	source := filepath.Join(dir, "source")
+	run("init", "-b", "main", source)
+	run("-C", source, "commit", "-q", "--allow-empty", "-m", "first")
+	run("-C", source, "commit", "-q", "--allow-empty", "-m", "second")
+
+	manager := NewManager()
+	clone := filepath.Join(dir, "clone")
+	if err := manager.CloneShallow("file://"+source, clone); err != nil {
+		t.Fatalf("CloneShallow() error = %v", err)
+	}
+	if count := run("-C", clone, "rev-list", "--count", "HEAD"); count != "1" {
+		t.Errorf("clone has %s commits; want only the latest", count)
+	}
+
+	// The failure carries git's message
+	err := manager.CloneShallow(filepath.Join(dir, "missing"), filepath.Join(dir, "other"))
+	if err == nil || !strings.Contains(err.Error(), "missing") {
+		t.Errorf("CloneShallow() of a missing repository = %v; want git's error", err)
+	}
+}
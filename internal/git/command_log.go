@@ -0,0 +1,162 @@
+package git
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CommandLogFile is the file name of the git command log inside the config directory
+const CommandLogFile = "git_commands.log"
+
+// maxCommandLogSize is the size at which the command log is rotated to <file>.1
+const maxCommandLogSize = 5 * 1024 * 1024
+
+// CommandRecord describes a single git invocation made by gman
+type CommandRecord struct {
+	Time       time.Time `json:"time"`
+	Repo       string    `json:"repo"`
+	Args       []string  `json:"args"`
+	DurationMs int64     `json:"duration_ms"`
+	ExitCode   int       `json:"exit_code"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// CommandLine returns a shell command that replays the invocation manually
+func (r CommandRecord) CommandLine() string {
+	quoted := make([]string, 0, len(r.Args))
+	for _, arg := range r.Args {
+		quoted = append(quoted, shellQuote(arg))
+	}
+	return fmt.Sprintf("git -C %s %s", shellQuote(r.Repo), strings.Join(quoted, " "))
+}
+
+// CommandLog appends git command records as JSON lines to a file
+type CommandLog struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewCommandLog creates a command log writing to path
+func NewCommandLog(path string) *CommandLog {
+	return &CommandLog{path: path}
+}
+
+// Path returns the log file location
+func (l *CommandLog) Path() string {
+	return l.path
+}
+
+// Record appends a record to the log. Logging must never break a git
+// operation, so write failures are ignored.
+func (l *CommandLog) Record(record CommandRecord) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return
+	}
+
+	if info, err := os.Stat(l.path); err == nil && info.Size() > maxCommandLogSize {
+		os.Rename(l.path, l.path+".1")
+	}
+
+	file, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	file.Write(append(data, '\n'))
+}
+
+// ReadCommandLog returns up to limit of the most recent records in path
+// (all records when limit <= 0), oldest first
+func ReadCommandLog(path string, limit int) ([]CommandRecord, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open command log: %w", err)
+	}
+	defer file.Close()
+
+	var records []CommandRecord
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var record CommandRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			continue // Skip partially written or corrupt lines
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read command log: %w", err)
+	}
+
+	if limit > 0 && len(records) > limit {
+		records = records[len(records)-limit:]
+	}
+	return records, nil
+}
+
+// SetCommandLog enables recording of every git command run by this manager.
+// Passing nil disables recording.
+func (g *Manager) SetCommandLog(log *CommandLog) {
+	g.commandLog = log
+}
+
+// recordCommand logs a finished git invocation at debug level, and to the
+// command log when command logging is enabled
+func (g *Manager) recordCommand(path string, args []string, start time.Time, err error) {
+	slog.Debug("git", "dir", path, "args", strings.Join(args, " "),
+		"duration_ms", time.Since(start).Milliseconds(), "failed", err != nil)
+	g.auditCommand(path, args, start, err)
+
+	if g.commandLog == nil {
+		return
+	}
+
+	record := CommandRecord{
+		Time:       start,
+		Repo:       path,
+		Args:       args,
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		record.Error = err.Error()
+		record.ExitCode = -1
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			record.ExitCode = exitErr.ExitCode()
+		}
+	}
+
+	g.commandLog.Record(record)
+}
+
+// shellQuote quotes s for POSIX shells when it contains anything unusual
+func shellQuote(s string) string {
+	if s == "" {
+		return "''"
+	}
+	if strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./=:@%+,", r))
+	}) == -1 {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
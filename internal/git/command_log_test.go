@@ -0,0 +1,54 @@
+package git
+
+import (
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestManager_CommandLog(t *testing.T) {
+	repoPath := t.TempDir()
+	if err := exec.Command("git", "init", repoPath).Run(); err != nil {
+		t.Skipf("git not available: %v", err)
+	}
+
+	logPath := filepath.Join(t.TempDir(), CommandLogFile)
+	manager := NewManager()
+	manager.SetCommandLog(NewCommandLog(logPath))
+
+	if _, err := manager.RunCommand(repoPath, "status", "--porcelain"); err != nil {
+		t.Fatalf("status failed: %v", err)
+	}
+	// Rejected by validation, so it is never executed nor recorded
+	manager.RunCommand(repoPath, "gc")
+	// Fails inside git and is recorded with its exit code
+	manager.RunCommand(repoPath, "rev-parse", "--verify", "no-such-ref")
+
+	records, err := ReadCommandLog(logPath, 0)
+	if err != nil {
+		t.Fatalf("ReadCommandLog() error = %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d: %+v", len(records), records)
+	}
+
+	if records[0].Repo != repoPath || records[0].ExitCode != 0 {
+		t.Errorf("unexpected first record: %+v", records[0])
+	}
+	if records[1].ExitCode == 0 || records[1].Error == "" {
+		t.Errorf("expected failed second record, got: %+v", records[1])
+	}
+
+	limited, err := ReadCommandLog(logPath, 1)
+	if err != nil || len(limited) != 1 || limited[0].Args[0] != "rev-parse" {
+		t.Errorf("ReadCommandLog() with limit returned %+v, %v", limited, err)
+	}
+}
+
+func TestCommandRecord_CommandLine(t *testing.T) {
+	record := CommandRecord{Repo: "/tmp/my repo", Args: []string{"log", "--format=%h %s", "-n", "5"}}
+	want := `git -C '/tmp/my repo' log '--format=%h %s' -n 5`
+	if got := record.CommandLine(); got != want {
+		t.Errorf("CommandLine() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,169 @@
+package git
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"gman/pkg/types"
+)
+
+const (
+	// DefaultFetchTimeout bounds a single fetch unless git_timeout,
+	// git_timeouts or --fetch-timeout say otherwise
+	DefaultFetchTimeout = 30 * time.Second
+
+	// fetchWorkers is the size of the fetch pool, which runs beside the
+	// local status reads instead of sharing their semaphore
+	fetchWorkers = 8
+
+	// fetchPerHost caps concurrent fetches against one remote host
+	fetchPerHost = 3
+)
+
+// SetFetchTimeout sets how long a single fetch may take before it is killed.
+// Zero or a negative duration uses the configured fetch timeout.
+func (g *Manager) SetFetchTimeout(timeout time.Duration) {
+	g.fetchTimeout = timeout
+}
+
+// getFetchTimeout returns the --fetch-timeout limit, or the configured
+// timeout of fetch commands
+func (g *Manager) getFetchTimeout() time.Duration {
+	if g.fetchTimeout <= 0 {
+		return g.commandTimeout("fetch")
+	}
+	return g.fetchTimeout
+}
+
+// fetch runs 'git fetch --quiet' in path, killing it after the fetch timeout.
+// Credential prompts are disabled so a fetch never waits for input.
+func (g *Manager) fetch(path string) error {
+	if err := g.validatePath(path); err != nil {
+		return fmt.Errorf("invalid repository path: %w", err)
+	}
+
+	timeout := g.getFetchTimeout()
+	args := []string{"fetch", "--quiet"}
+	cmd, ctx, cancel := gitCommand(path, timeout, args)
+	defer cancel()
+
+	cmd.Env = append(os.Environ(), "LANG=C", "LC_ALL=C", "GIT_TERMINAL_PROMPT=0")
+
+	start := time.Now()
+	output, err := cmd.CombinedOutput()
+	if err != nil && ctx.Err() == nil && len(output) > 0 {
+		err = fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+	err = timeoutError(ctx, "fetch", timeout, err)
+	g.recordCommand(path, args, start, err)
+	// Remote refs moved; reads memoized before the fetch are stale
+	g.reads.reset()
+	return err
+}
+
+// startFetches fetches all repositories in the fetch pool and returns one
+// channel per alias that receives the result once that fetch finishes.
+// Paths that are not git repositories report nil; their status shows the error.
+func (g *Manager) startFetches(repositories map[string]string) map[string]chan error {
+	results := make(map[string]chan error, len(repositories))
+	for alias := range repositories {
+		results[alias] = make(chan error, 1)
+	}
+
+	workers := make(chan struct{}, fetchWorkers)
+	hosts := newHostLimiter(fetchPerHost)
+
+	for alias, path := range repositories {
+		go func(result chan<- error, path string) {
+			if !g.isGitRepository(path) {
+				result <- nil
+				return
+			}
+
+			// Take the host slot first so a worker is never held waiting on a busy host
+			host := ""
+			if remoteURL, err := g.GetRemoteURL(path); err == nil {
+				host = remoteHost(remoteURL)
+			}
+			release := hosts.acquire(host)
+			defer release()
+
+			workers <- struct{}{}
+			defer func() { <-workers }()
+
+			result <- g.fetch(path)
+		}(results[alias], path)
+	}
+
+	return results
+}
+
+// applyFetchResult refreshes the sync state of a status read before the
+// fetch finished, recording fetchErr as the sync error
+func (g *Manager) applyFetchResult(status *types.RepoStatus, fetchErr error) {
+	if syncStatus, err := g.getSyncStatusInternal(status.Path, false); err == nil {
+		status.SyncStatus = syncStatus
+	}
+	if fetchErr != nil {
+		status.SyncStatus.SyncError = fmt.Errorf("failed to fetch from remote: %w", fetchErr)
+	}
+	if status.Extended {
+		if fetchTime, err := g.GetLastFetchTime(status.Path); err == nil {
+			status.LastFetchTime = fetchTime
+		}
+	}
+}
+
+// hostLimiter hands out a bounded number of slots per remote host
+type hostLimiter struct {
+	mu      sync.Mutex
+	perHost int
+	slots   map[string]chan struct{}
+}
+
+func newHostLimiter(perHost int) *hostLimiter {
+	return &hostLimiter{perHost: perHost, slots: make(map[string]chan struct{})}
+}
+
+// acquire blocks until host has a free slot and returns its release function
+func (l *hostLimiter) acquire(host string) func() {
+	l.mu.Lock()
+	slot, ok := l.slots[host]
+	if !ok {
+		slot = make(chan struct{}, l.perHost)
+		l.slots[host] = slot
+	}
+	l.mu.Unlock()
+
+	slot <- struct{}{}
+	return func() { <-slot }
+}
+
+// remoteHost returns the host name of a remote URL (https, ssh or scp-like),
+// or "" for local paths and file:// remotes
+func remoteHost(remote string) string {
+	remote = strings.TrimSpace(remote)
+	if strings.Contains(remote, "://") {
+		parsed, err := url.Parse(remote)
+		if err != nil {
+			return ""
+		}
+		return strings.ToLower(parsed.Hostname())
+	}
+
+	// scp-like syntax: [user@]host:owner/repo.git; a slash before the colon
+	// means a local path such as ./repos/a:b
+	colon := strings.Index(remote, ":")
+	if colon <= 0 || strings.Contains(remote[:colon], "/") {
+		return ""
+	}
+	host := remote[:colon]
+	if at := strings.LastIndex(host, "@"); at >= 0 {
+		host = host[at+1:]
+	}
+	return strings.ToLower(host)
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -16,7 +17,16 @@ import (
 
 // Manager handles git operations
 type Manager struct {
-	currentDir string
+	currentDir     string
+	commandLog     *CommandLog              // optional record of executed git commands
+	auditLog       *AuditLog                // optional audit trail of state-changing commands
+	fetchTimeout   time.Duration            // limit of status fetches, overrides the fetch timeout when set
+	defaultTimeout time.Duration            // limit of git commands, DefaultCommandTimeout when zero
+	timeouts       map[string]time.Duration // per subcommand limits
+	reads          *readCache               // memoized reads of one status pass, see withReadCache
+	extendedStatus bool                     // also read stash, branch counts and last fetch time
+	worktreeStatus bool                     // also read the status of linked worktrees
+	protection     Protection               // where destructive commands are refused
 }
 
 // NewManager creates a new git manager
@@ -39,6 +49,9 @@ func (g *Manager) GetRepoStatusNoFetch(alias, path string) types.RepoStatus {
 
 // getRepoStatusInternal gets the status of a single repository
 func (g *Manager) getRepoStatusInternal(alias, path string, withFetch bool) types.RepoStatus {
+	// The helpers below share reads such as the current branch; run each once
+	g = g.withReadCache()
+
 	status := types.RepoStatus{
 		Alias: alias,
 		Path:  path,
@@ -83,8 +96,10 @@ func (g *Manager) getRepoStatusInternal(alias, path string, withFetch bool) type
 	syncStatus, err := g.getSyncStatusInternal(path, withFetch)
 	if err != nil {
 		// Sync errors might be network-related, so they're often recoverable
-		if strings.Contains(err.Error(), "timeout") || strings.Contains(err.Error(), "connection") {
-			status.Error = errors.NewNetworkTimeoutError("sync status check", "30s").
+		if timeoutErr, ok := errors.As(err); ok && timeoutErr.Type == errors.ErrTypeNetworkTimeout {
+			status.Error = timeoutErr.WithContext("repository", path)
+		} else if strings.Contains(err.Error(), "timeout") || strings.Contains(err.Error(), "connection") {
+			status.Error = errors.NewNetworkTimeoutError("sync status check", g.commandTimeout("fetch").String()).
 				WithCause(err).
 				WithContext("repository", path)
 		} else {
@@ -134,24 +149,43 @@ func (g *Manager) getRepoStatusInternal(alias, path string, withFetch bool) type
 		status.RemoteBranch = remoteBranch
 	}
 
+	if g.extendedStatus {
+		g.readExtendedStatus(&status)
+	}
+
+	if g.worktreeStatus {
+		g.readWorktreeStatus(&status)
+	}
+
+	return status
+}
+
+// SetExtendedStatus makes status reads include stash counts, branch counts
+// and the last fetch time, which are skipped by default to keep status fast
+func (g *Manager) SetExtendedStatus(enabled bool) {
+	g.extendedStatus = enabled
+}
+
+// readExtendedStatus fills the optional status fields (non-blocking)
+func (g *Manager) readExtendedStatus(status *types.RepoStatus) {
+	status.Extended = true
+
 	// Stash count
-	if stashCount, err := g.GetStashCount(path); err == nil {
+	if stashCount, err := g.GetStashCount(status.Path); err == nil {
 		status.StashCount = stashCount
 	}
 
 	// Branch counts
-	if local, remote, total, err := g.GetBranchCounts(path); err == nil {
+	if local, remote, total, err := g.GetBranchCounts(status.Path); err == nil {
 		status.LocalBranches = local
 		status.RemoteBranches = remote
 		status.TotalBranches = total
 	}
 
 	// Last fetch time
-	if fetchTime, err := g.GetLastFetchTime(path); err == nil {
+	if fetchTime, err := g.GetLastFetchTime(status.Path); err == nil {
 		status.LastFetchTime = fetchTime
 	}
-
-	return status
 }
 
 // GetAllRepoStatus gets status for multiple repositories concurrently
@@ -185,18 +219,29 @@ func (g *Manager) getAllRepoStatusInternal(repositories map[string]string, withF
 	
 	semaphore := make(chan struct{}, maxConcurrency)
 
+	// Fetches run in their own pool so slow remotes don't hold up local reads
+	var fetches map[string]chan error
+	if withFetch {
+		fetches = g.startFetches(repositories)
+	}
+
 	for alias, path := range repositories {
 		wg.Add(1)
 		go func(alias, path string) {
 			defer wg.Done()
-			semaphore <- struct{}{}        // Acquire semaphore
-			defer func() { <-semaphore }() // Release semaphore
 
-			var status types.RepoStatus
+			semaphore <- struct{}{} // Acquire semaphore
+			status := g.GetRepoStatusNoFetch(alias, path)
+			<-semaphore // Release semaphore
+
+			// Wait for this repository's fetch without holding a local slot
 			if withFetch {
-				status = g.GetRepoStatus(alias, path)
-			} else {
-				status = g.GetRepoStatusNoFetch(alias, path)
+				fetchErr := <-fetches[alias]
+				if status.Error == nil {
+					semaphore <- struct{}{}
+					g.applyFetchResult(&status, fetchErr)
+					<-semaphore
+				}
 			}
 			statusChan <- status
 		}(alias, path)
@@ -219,8 +264,27 @@ func (g *Manager) getAllRepoStatusInternal(repositories map[string]string, withF
 
 // SyncRepository synchronizes a repository with remote
 func (g *Manager) SyncRepository(path, mode string) error {
-	cmd := g.buildSyncCommand(mode)
-	return g.runGitCommand(path, cmd...)
+	args := g.buildSyncCommand(mode)
+	timeout := g.commandTimeout(args[0])
+	cmd, ctx, cancel := gitCommand(path, timeout, args)
+	defer cancel()
+
+	// git's messages tell a dirty workspace from a conflict; keep them in English
+	cmd.Env = append(os.Environ(), "LANG=C", "LC_ALL=C", "GIT_TERMINAL_PROMPT=0")
+
+	start := time.Now()
+	output, err := cmd.CombinedOutput()
+	err = timeoutError(ctx, args[0], timeout, err)
+	g.recordCommand(path, args, start, err)
+	if err != nil {
+		return syncError(path, string(output), err)
+	}
+	if strings.Contains(string(output), autostashConflictMessage) {
+		// The pull succeeded but the stashed changes did not apply cleanly
+		return errors.NewMergeConflictError(path).
+			WithSuggestion("Your changes are kept in the stash; run 'git stash drop' once the conflicts are resolved")
+	}
+	return nil
 }
 
 // SyncAllRepositories synchronizes multiple repositories concurrently
@@ -228,7 +292,7 @@ func (g *Manager) SyncAllRepositories(repositories map[string]string, mode strin
 	semaphore := make(chan struct{}, maxConcurrency)
 	var wg sync.WaitGroup
 	var mu sync.Mutex
-	var errors []error
+	var failures SyncFailures
 
 	for alias, path := range repositories {
 		wg.Add(1)
@@ -240,7 +304,7 @@ func (g *Manager) SyncAllRepositories(repositories map[string]string, mode strin
 			err := g.SyncRepository(path, mode)
 			if err != nil {
 				mu.Lock()
-				errors = append(errors, fmt.Errorf("%s: %w", alias, err))
+				failures = append(failures, newSyncFailure(alias, path, err))
 				mu.Unlock()
 			}
 		}(alias, path)
@@ -248,8 +312,11 @@ func (g *Manager) SyncAllRepositories(repositories map[string]string, mode strin
 
 	wg.Wait()
 
-	if len(errors) > 0 {
-		return fmt.Errorf("sync failed for some repositories: %v", errors)
+	if len(failures) > 0 {
+		sort.Slice(failures, func(i, j int) bool {
+			return failures[i].Alias < failures[j].Alias
+		})
+		return failures
 	}
 
 	return nil
@@ -267,14 +334,33 @@ func (g *Manager) RunCommand(path string, args ...string) (string, error) {
 		return "", fmt.Errorf("invalid git arguments: %w", err)
 	}
 
-	cmd := exec.Command("git", args...)
-	cmd.Dir = path
+	return g.runValidated(path, args)
+}
+
+// runValidated runs a git command whose path and arguments were already
+// validated, applying protection, the read cache and the timeout
+func (g *Manager) runValidated(path string, args []string) (string, error) {
+	if err := g.checkProtection(path, args); err != nil {
+		return "", err
+	}
+
+	if cached, ok := g.reads.lookup(path, args); ok {
+		return cached.output, cached.err
+	}
+
+	timeout := g.commandTimeout(args[0])
+	cmd, ctx, cancel := gitCommand(path, timeout, args)
+	defer cancel()
 	
 	// Force English locale to ensure consistent Git output parsing
 	// This prevents issues with localized Git messages
 	cmd.Env = append(os.Environ(), "LANG=C", "LC_ALL=C")
 	
+	start := time.Now()
 	output, err := cmd.CombinedOutput()
+	err = timeoutError(ctx, args[0], timeout, err)
+	g.recordCommand(path, args, start, err)
+	g.reads.store(path, args, strings.TrimSpace(string(output)), err)
 	return strings.TrimSpace(string(output)), err
 }
 
@@ -317,24 +403,34 @@ func (g *Manager) validateGitArgs(args []string) error {
 
 	// Whitelist of allowed git commands for security
 	allowedCommands := map[string]bool{
-		"status":    true,
-		"rev-parse": true,
-		"log":       true,
-		"fetch":     true,
-		"pull":      true,
-		"push":      true,
-		"checkout":  true,
-		"branch":    true,
-		"commit":    true,
-		"add":       true,
-		"diff":      true,
-		"show":      true,
-		"stash":     true,
-		"rev-list":  true,
-		"worktree":  true,
-		"merge":     true,
-		"reset":     true,
-		"config":    true, // For test environments only
+		"status":        true,
+		"rev-parse":     true,
+		"log":           true,
+		"fetch":         true,
+		"pull":          true,
+		"push":          true,
+		"checkout":      true,
+		"branch":        true,
+		"commit":        true,
+		"add":           true,
+		"diff":          true,
+		"show":          true,
+		"stash":         true,
+		"rev-list":      true,
+		"worktree":      true,
+		"merge":         true,
+		"reset":         true,
+		"remote":        true,
+		"ls-files":      true,
+		"ls-tree":       true,
+		"cat-file":      true,
+		"count-objects": true,
+		"for-each-ref":  true,
+		"tag":           true,
+		"init":          true,
+		"am":            true,
+		"apply":         true,
+		"config":        true, // For test environments only
 	}
 
 	if !allowedCommands[args[0]] {
@@ -369,6 +465,18 @@ func (g *Manager) validateArgument(arg string) error {
 	return nil
 }
 
+// ValidateArguments checks arguments the way RunCommand does, so that
+// commands touching several repositories can reject user input up front
+// instead of failing halfway through
+func (g *Manager) ValidateArguments(args ...string) error {
+	for _, arg := range args {
+		if err := g.validateArgument(arg); err != nil {
+			return fmt.Errorf("invalid argument '%s': %w", arg, err)
+		}
+	}
+	return nil
+}
+
 // isGitRepository checks if the given path is a git repository
 func (g *Manager) isGitRepository(path string) bool {
 	gitDir := filepath.Join(path, ".git")
@@ -410,8 +518,8 @@ func (g *Manager) getCurrentBranch(path string) (string, error) {
 
 // getWorkspaceStatus gets the workspace status
 func (g *Manager) getWorkspaceStatus(path string) (types.WorkspaceStatus, error) {
-	// Check for stashes
-	stashOutput, err := g.RunCommand(path, "stash", "list")
+	// Check for stashes (same query as StashList, so a status pass reads it once)
+	stashOutput, err := g.RunCommand(path, "stash", "list", "--oneline")
 	if err == nil && stashOutput != "" {
 		return types.Stashed, nil
 	}
@@ -440,8 +548,7 @@ func (g *Manager) getSyncStatusInternal(path string, withFetch bool) (types.Sync
 
 	// Attempt to fetch latest from remote if requested
 	if withFetch {
-		_, err := g.RunCommand(path, "fetch", "--quiet")
-		if err != nil {
+		if err := g.fetch(path); err != nil {
 			// If fetch fails, mark the sync error but continue with local state
 			syncStatus.SyncError = fmt.Errorf("failed to fetch from remote: %w", err)
 		}
@@ -501,6 +608,8 @@ func (g *Manager) buildSyncCommand(mode string) []string {
 		return []string{"pull", "--ff-only"}
 	case "autostash":
 		return []string{"pull", "--autostash"}
+	case "ff-only-autostash":
+		return []string{"pull", "--ff-only", "--autostash"}
 	default:
 		return []string{"pull", "--ff-only"}
 	}
@@ -508,9 +617,16 @@ func (g *Manager) buildSyncCommand(mode string) []string {
 
 // runGitCommand runs a git command in the specified directory
 func (g *Manager) runGitCommand(path string, args ...string) error {
-	cmd := exec.Command("git", args...)
-	cmd.Dir = path
-	return cmd.Run()
+	if err := g.checkProtection(path, args); err != nil {
+		return err
+	}
+	timeout := g.commandTimeout(args[0])
+	cmd, ctx, cancel := gitCommand(path, timeout, args)
+	defer cancel()
+	start := time.Now()
+	err := timeoutError(ctx, args[0], timeout, cmd.Run())
+	g.recordCommand(path, args, start, err)
+	return err
 }
 
 // getFilesChangedCount gets the number of changed files in the workspace
@@ -639,7 +755,7 @@ func (g *Manager) SwitchBranch(path, branchName string) error {
 	}
 
 	if !branchExists {
-		return fmt.Errorf("branch '%s' does not exist", branchName)
+		return errors.NewGmanError(errors.ErrTypeBranchNotFound, fmt.Sprintf("branch '%s' does not exist", branchName))
 	}
 
 	// Switch to the branch
@@ -780,6 +896,16 @@ func (g *Manager) CommitChanges(path, message string, addAll bool) error {
 	return g.runGitCommand(path, "commit", "-m", message)
 }
 
+// CommitPaths commits the working tree content of the given tracked paths
+// only; changes staged for other paths stay in the index
+func (g *Manager) CommitPaths(path, message string, paths []string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("no paths to commit")
+	}
+	args := append([]string{"commit", "--quiet", "-m", message, "--"}, paths...)
+	return g.runGitCommand(path, args...)
+}
+
 // PushChanges pushes local commits to remote
 func (g *Manager) PushChanges(path string, force, setUpstream bool) error {
 	args := []string{"push"}
@@ -940,6 +1066,12 @@ func (g *Manager) GetLastFetchTime(path string) (time.Time, error) {
 	return info.ModTime(), nil
 }
 
+// MergeInProgress reports whether a merge was started but not concluded
+func (g *Manager) MergeInProgress(path string) bool {
+	_, err := g.RunCommand(path, "rev-parse", "-q", "--verify", "MERGE_HEAD")
+	return err == nil
+}
+
 // DiffFileBetweenBranches compares a specific file between two branches
 func (g *Manager) DiffFileBetweenBranches(repoPath, branch1, branch2, filePath string) (string, error) {
 	// Verify that both branches exist
@@ -1016,8 +1148,18 @@ func (g *Manager) verifyBranchExists(repoPath, branch string) error {
 	return err
 }
 
-// AddWorktree creates a new Git worktree for the specified branch
+// AddWorktree creates a new Git worktree for the specified branch. Without
+// a worktree path it is placed next to the repository, named after the
+// repository directory and the branch.
 func (g *Manager) AddWorktree(repoPath, worktreePath, branch string) error {
+	if worktreePath == "" {
+		name, err := WorktreeName(DefaultWorktreeNameTemplate, "", repoPath, branch)
+		if err != nil {
+			return err
+		}
+		worktreePath = filepath.Join(filepath.Dir(repoPath), name)
+	}
+
 	// Check if the worktree path already exists
 	if _, err := os.Stat(worktreePath); err == nil {
 		return fmt.Errorf("path '%s' already exists", worktreePath)
@@ -1059,11 +1201,25 @@ func (g *Manager) ListWorktrees(repoPath string) ([]types.Worktree, error) {
 	return g.parseWorktreeList(output)
 }
 
-// RemoveWorktree removes a Git worktree
+// RemoveWorktree removes a Git worktree. Like git, it refuses to remove a
+// locked worktree unless forced.
 func (g *Manager) RemoveWorktree(repoPath, worktreePath string, force bool) error {
+	locked, reason := g.worktreeLock(repoPath, worktreePath)
+	if locked && !force {
+		message := fmt.Sprintf("worktree '%s' is locked", worktreePath)
+		if reason != "" {
+			message += ": " + reason
+		}
+		return fmt.Errorf("%s; unlock it or pass --force to remove it", message)
+	}
+
 	args := []string{"worktree", "remove"}
 	if force {
 		args = append(args, "--force")
+		if locked {
+			// git needs --force twice for a locked worktree
+			args = append(args, "--force")
+		}
 	}
 	args = append(args, worktreePath)
 
@@ -1119,6 +1275,11 @@ func (g *Manager) parseWorktreeList(output string) ([]types.Worktree, error) {
 			current.IsBare = true
 		case "detached":
 			current.IsDetached = true
+		case "prunable":
+			current.Prunable = value
+		case "locked":
+			current.IsLocked = true
+			current.LockReason = value
 		}
 	}
 
@@ -656,3 +656,55 @@ func min(a, b int) int {
 	}
 	return b
 }
+
+func TestManager_CommitPaths(t *testing.T) {
+	for _, name := range []string{"GIT_AUTHOR_NAME", "GIT_COMMITTER_NAME"} {
+		t.Setenv(name, "test")
+	}
+	for _, name := range []string{"GIT_AUTHOR_EMAIL", "GIT_COMMITTER_EMAIL"} {
+		t.Setenv(name, "test@example.com")
+	}
+	repoPath := t.TempDir()
+	run := func(args ...string) string {
+		t.Helper()
+		output, err := exec.Command("git", append([]string{"-C", repoPath}, args...)...).CombinedOutput()
+		if err != nil {
+			t.Skipf("git %v failed: %v\n%s", args, err, output)
+		}
+		return strings.TrimSpace(string(output))
+	}
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(repoPath, name)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(repoPath, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	run("init", "-q", "-b", "main")
+	write("app/(auth)/page.txt", "old\n")
+	write("notes.txt", "draft\n")
+	run("add", ".")
+	run("commit", "-q", "-m", "initial")
+
+	// An unrelated change is already staged when the paths are committed
+	write("notes.txt", "staged\n")
+	run("add", "notes.txt")
+	write("app/(auth)/page.txt", "new\n")
+
+	manager := NewManager()
+	if err := manager.CommitPaths(repoPath, "Replace old (R&D)", []string{"app/(auth)/page.txt"}); err != nil {
+		t.Fatalf("CommitPaths() error = %v", err)
+	}
+	if files := run("show", "--name-only", "--format=", "HEAD"); files != "app/(auth)/page.txt" {
+		t.Errorf("committed files = %q; want only the given path", files)
+	}
+	if subject := run("log", "-1", "--format=%s"); subject != "Replace old (R&D)" {
+		t.Errorf("commit message = %q", subject)
+	}
+	if staged := run("diff", "--cached", "--name-only"); staged != "notes.txt" {
+		t.Errorf("staged files = %q; want notes.txt left staged", staged)
+	}
+}
@@ -0,0 +1,136 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gman/pkg/types"
+)
+
+// StaleBranchAge is how long a local branch goes without commits before it
+// counts as stale in the health score
+const StaleBranchAge = 30 * 24 * time.Hour
+
+// healthInput is what the health score is computed from
+type healthInput struct {
+	Unreadable    error     // status could not be read at all
+	SyncError     error     // the last fetch failed
+	NoUpstream    string    // branch without an origin counterpart
+	LastFetch     time.Time // zero when never fetched
+	DirtySince    time.Time // zero when clean
+	StaleBranches int
+}
+
+// Health computes the health score of a repository from its status and a
+// few extra reads: stale branches, the last fetch, how long the workspace has
+// been dirty and whether the branch has an upstream
+func (g *Manager) Health(status types.RepoStatus) types.Health {
+	now := time.Now()
+	input := healthInput{Unreadable: status.Error, SyncError: status.SyncStatus.SyncError}
+	if status.Error != nil {
+		return scoreHealth(input, now)
+	}
+
+	if status.Branch != "HEAD" && !g.RefExists(status.Path, "origin/"+status.Branch) {
+		input.NoUpstream = status.Branch
+	}
+	if fetchTime, err := g.GetLastFetchTime(status.Path); err == nil {
+		input.LastFetch = fetchTime
+	}
+	input.DirtySince = g.dirtySince(status.Path)
+	if branches, err := g.LocalBranchActivity(status.Path); err == nil {
+		for _, branch := range branches {
+			if branch.Name != status.Branch && now.Sub(branch.LastCommit) > StaleBranchAge {
+				input.StaleBranches++
+			}
+		}
+	}
+	return scoreHealth(input, now)
+}
+
+// dirtySince approximates how long the workspace has had uncommitted changes
+// by the oldest modification time of a changed file; zero when clean
+func (g *Manager) dirtySince(path string) time.Time {
+	output, err := g.RunCommand(path, "status", "--porcelain")
+	if err != nil || output == "" {
+		return time.Time{}
+	}
+	var oldest time.Time
+	for _, line := range strings.Split(output, "\n") {
+		if len(line) < 4 {
+			continue
+		}
+		name := line[3:]
+		// Renames list "old -> new"
+		if _, renamed, ok := strings.Cut(name, " -> "); ok {
+			name = renamed
+		}
+		// Deleted and oddly quoted paths have no usable time
+		info, err := os.Lstat(filepath.Join(path, strings.Trim(name, `"`)))
+		if err != nil {
+			continue
+		}
+		if oldest.IsZero() || info.ModTime().Before(oldest) {
+			oldest = info.ModTime()
+		}
+	}
+	if oldest.IsZero() {
+		return time.Now()
+	}
+	return oldest
+}
+
+// scoreHealth deducts a penalty per problem from 100
+func scoreHealth(input healthInput, now time.Time) types.Health {
+	health := types.Health{Score: 100}
+	deduct := func(name string, penalty int, detail string) {
+		health.Factors = append(health.Factors, types.HealthFactor{Name: name, Penalty: penalty, Detail: detail})
+		health.Score -= penalty
+	}
+
+	if input.Unreadable != nil {
+		deduct("unreadable", 100, input.Unreadable.Error())
+		health.Score = max(health.Score, 0)
+		return health
+	}
+	if input.SyncError != nil {
+		deduct("failing sync", 30, input.SyncError.Error())
+	}
+	if input.NoUpstream != "" {
+		deduct("missing upstream", 15, fmt.Sprintf("%s has no origin/%s", input.NoUpstream, input.NoUpstream))
+	}
+
+	day := 24 * time.Hour
+	switch fetchAge := now.Sub(input.LastFetch); {
+	case input.LastFetch.IsZero():
+		deduct("last fetch", 15, "never fetched")
+	case fetchAge > 30*day:
+		deduct("last fetch", 15, fmt.Sprintf("last fetched %d days ago", int(fetchAge/day)))
+	case fetchAge > 7*day:
+		deduct("last fetch", 10, fmt.Sprintf("last fetched %d days ago", int(fetchAge/day)))
+	}
+
+	if !input.DirtySince.IsZero() {
+		dirtyAge := now.Sub(input.DirtySince)
+		detail := fmt.Sprintf("uncommitted changes for %d days", int(dirtyAge/day))
+		switch {
+		case dirtyAge > 30*day:
+			deduct("dirty workspace", 20, detail)
+		case dirtyAge > 7*day:
+			deduct("dirty workspace", 10, detail)
+		case dirtyAge > day:
+			deduct("dirty workspace", 5, detail)
+		}
+	}
+
+	if input.StaleBranches > 0 {
+		deduct("stale branches", min(3*input.StaleBranches, 15),
+			fmt.Sprintf("%d branches without commits for %d days", input.StaleBranches, int(StaleBranchAge/day)))
+	}
+
+	health.Score = max(health.Score, 0)
+	return health
+}
@@ -0,0 +1,36 @@
+package git
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestScoreHealth(t *testing.T) {
+	now := time.Date(2024, 5, 10, 9, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	healthy := scoreHealth(healthInput{LastFetch: now.Add(-time.Hour)}, now)
+	if healthy.Score != 100 || len(healthy.Factors) != 0 || healthy.Grade() != "healthy" {
+		t.Errorf("expected a perfect score, got %+v", healthy)
+	}
+
+	health := scoreHealth(healthInput{
+		SyncError:     errors.New("could not resolve host"),
+		NoUpstream:    "feature",
+		LastFetch:     now.Add(-10 * day),
+		DirtySince:    now.Add(-40 * day),
+		StaleBranches: 7,
+	}, now)
+	// 30 + 15 + 10 + 20 + 15 (capped)
+	if health.Score != 10 || len(health.Factors) != 5 || health.Grade() != "poor" {
+		t.Errorf("expected score 10 from 5 factors, got %+v", health)
+	}
+
+	if never := scoreHealth(healthInput{}, now); never.Score != 85 {
+		t.Errorf("expected a never fetched repository to score 85, got %d", never.Score)
+	}
+	if unreadable := scoreHealth(healthInput{Unreadable: errors.New("not a git repository")}, now); unreadable.Score != 0 {
+		t.Errorf("expected an unreadable repository to score 0, got %d", unreadable.Score)
+	}
+}
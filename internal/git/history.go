@@ -0,0 +1,137 @@
+package git
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CommitSummary is a one-line description of a commit
+type CommitSummary struct {
+	Hash    string
+	Author  string
+	Subject string
+	Time    time.Time
+}
+
+// BranchActivity records when a local branch last received a commit
+type BranchActivity struct {
+	Name       string
+	LastCommit time.Time
+}
+
+// commitSummaryFormat separates fields with tabs; %x09 avoids a literal tab
+// in the argument
+const commitSummaryFormat = "--format=%h%x09%ct%x09%an%x09%s"
+
+// RecentCommits returns up to limit commits of HEAD made after since, newest first
+func (g *Manager) RecentCommits(path string, since time.Time, limit int) ([]CommitSummary, error) {
+	output, err := g.RunCommand(path, "log",
+		"--since="+since.Format(time.RFC3339),
+		"-n", strconv.Itoa(limit),
+		commitSummaryFormat)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recent commits: %w", err)
+	}
+	return parseCommitSummaries(output), nil
+}
+
+// CommitsSince returns the commits of HEAD made since a revision such as a
+// tag, or after a time when ref is empty, newest first. Merge commits are
+// left out.
+func (g *Manager) CommitsSince(path, ref string, after time.Time) ([]CommitSummary, error) {
+	args := []string{"log", "--no-merges", commitSummaryFormat}
+	if ref != "" {
+		args = append(args, ref+"..HEAD")
+	} else {
+		args = append(args, "--since="+after.Format(time.RFC3339))
+	}
+	output, err := g.RunCommand(path, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read commits: %w", err)
+	}
+	return parseCommitSummaries(output), nil
+}
+
+// BehindSince returns how many commits of the current branch's origin
+// counterpart HEAD lacks, and the commit time of the oldest of them: the
+// time since which the branch has been behind. Branches without a remote
+// counterpart are not behind.
+func (g *Manager) BehindSince(path string) (int, time.Time, error) {
+	branch, err := g.getCurrentBranch(path)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	remoteRef := "origin/" + branch
+	if !g.RefExists(path, remoteRef) {
+		return 0, time.Time{}, nil
+	}
+
+	output, err := g.RunCommand(path, "log", "--format=%ct", "HEAD.."+remoteRef)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("failed to read upstream commits: %w", err)
+	}
+	if output == "" {
+		return 0, time.Time{}, nil
+	}
+	lines := strings.Split(output, "\n")
+	var oldest int64
+	for _, line := range lines {
+		seconds, err := strconv.ParseInt(strings.TrimSpace(line), 10, 64)
+		if err != nil {
+			return 0, time.Time{}, fmt.Errorf("failed to parse commit time: %w", err)
+		}
+		if oldest == 0 || seconds < oldest {
+			oldest = seconds
+		}
+	}
+	return len(lines), time.Unix(oldest, 0), nil
+}
+
+// LocalBranchActivity returns the last commit time of every local branch
+func (g *Manager) LocalBranchActivity(path string) ([]BranchActivity, error) {
+	refs, err := g.ListRefs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var branches []BranchActivity
+	for _, ref := range refs {
+		if ref.Kind != RefLocalBranch {
+			continue
+		}
+		output, err := g.RunCommand(path, "log", "-1", "--format=%ct", "refs/heads/"+ref.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read last commit of %s: %w", ref.Name, err)
+		}
+		seconds, err := strconv.ParseInt(strings.TrimSpace(output), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse commit time of %s: %w", ref.Name, err)
+		}
+		branches = append(branches, BranchActivity{Name: ref.Name, LastCommit: time.Unix(seconds, 0)})
+	}
+	return branches, nil
+}
+
+// parseCommitSummaries parses log output in commitSummaryFormat
+func parseCommitSummaries(output string) []CommitSummary {
+	var commits []CommitSummary
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.SplitN(line, "\t", 4)
+		if len(fields) != 4 {
+			continue
+		}
+		seconds, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		commits = append(commits, CommitSummary{
+			Hash:    fields[0],
+			Time:    time.Unix(seconds, 0),
+			Author:  fields[2],
+			Subject: fields[3],
+		})
+	}
+	return commits
+}
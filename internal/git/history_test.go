@@ -0,0 +1,25 @@
+package git
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCommitSummaries(t *testing.T) {
+	output := "abc1234\t1700000000\tAlice\tFix login\tredirect\n" +
+		"def5678\t1699990000\tBob\tAdd tests\n" +
+		"malformed line\n"
+
+	commits := parseCommitSummaries(output)
+	if len(commits) != 2 {
+		t.Fatalf("Expected 2 commits, got %d: %+v", len(commits), commits)
+	}
+
+	want := CommitSummary{Hash: "abc1234", Author: "Alice", Subject: "Fix login\tredirect", Time: time.Unix(1700000000, 0)}
+	if commits[0] != want {
+		t.Errorf("First commit = %+v, want %+v", commits[0], want)
+	}
+	if commits[1].Hash != "def5678" || commits[1].Subject != "Add tests" {
+		t.Errorf("Unexpected second commit: %+v", commits[1])
+	}
+}
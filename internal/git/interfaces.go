@@ -56,6 +56,8 @@ type WorktreeManager interface {
 	AddWorktree(repoPath, worktreePath, branch string) error
 	ListWorktrees(repoPath string) ([]types.Worktree, error)
 	RemoveWorktree(repoPath, worktreePath string, force bool) error
+	LockWorktree(repoPath, worktreePath, reason string) error
+	UnlockWorktree(repoPath, worktreePath string) error
 }
 
 // DiffProvider handles file comparison operations
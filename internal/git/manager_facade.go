@@ -149,6 +149,14 @@ func (g *GitManager) RemoveWorktree(repoPath, worktreePath string, force bool) e
 	return g.worktree.RemoveWorktree(repoPath, worktreePath, force)
 }
 
+func (g *GitManager) LockWorktree(repoPath, worktreePath, reason string) error {
+	return g.worktree.LockWorktree(repoPath, worktreePath, reason)
+}
+
+func (g *GitManager) UnlockWorktree(repoPath, worktreePath string) error {
+	return g.worktree.UnlockWorktree(repoPath, worktreePath)
+}
+
 // DiffProvider methods
 func (g *GitManager) DiffFileBetweenBranches(repoPath, branch1, branch2, filePath string) (string, error) {
 	return g.diff.DiffFileBetweenBranches(repoPath, branch1, branch2, filePath)
@@ -0,0 +1,192 @@
+package git
+
+import (
+	"bufio"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ObjectStats describes the size of a repository's history
+type ObjectStats struct {
+	Commits      int             // Commits reachable from any ref
+	Objects      int             // Loose and packed objects
+	LooseObjects int             // Objects not yet packed
+	Packs        int             // Pack files
+	PackSize     int64           // Bytes of the pack files
+	LooseSize    int64           // Bytes of the loose objects
+	Directories  []DirectorySize // Biggest top-level directories, largest first
+}
+
+// DirectorySize is how much a top-level directory contributes to the
+// history: the uncompressed size of every file version ever committed below
+// it. Files in the repository root are counted as ".".
+type DirectorySize struct {
+	Path  string `json:"path" yaml:"path"`
+	Size  int64  `json:"bytes" yaml:"bytes"`
+	Blobs int    `json:"blobs" yaml:"blobs"`
+}
+
+// ObjectStats counts the commits and objects of a repository and returns
+// its topDirs biggest directories over the whole history
+func (g *Manager) ObjectStats(path string, topDirs int) (ObjectStats, error) {
+	var stats ObjectStats
+
+	output, err := g.RunCommand(path, "count-objects", "-v")
+	if err != nil {
+		return stats, fmt.Errorf("failed to count objects: %w", err)
+	}
+	parseCountObjects(output, &stats)
+
+	output, err = g.RunCommand(path, "rev-list", "--count", "--all")
+	if err != nil {
+		return stats, fmt.Errorf("failed to count commits: %w", err)
+	}
+	if stats.Commits, err = strconv.Atoi(output); err != nil {
+		return stats, fmt.Errorf("failed to count commits: unexpected output %q", output)
+	}
+	if stats.Commits == 0 || topDirs <= 0 {
+		return stats, nil
+	}
+
+	totals, err := g.directorySizes(path)
+	if err != nil {
+		return stats, err
+	}
+	stats.Directories = totals.biggest(topDirs)
+	return stats, nil
+}
+
+// directorySizes pipes 'git rev-list --objects --all' into 'git cat-file
+// --batch-check' and sums the sizes of the reachable blobs as they stream
+// by, so that large histories are never held in memory
+func (g *Manager) directorySizes(path string) (directoryTotals, error) {
+	listArgs := []string{"rev-list", "--objects", "--all"}
+	checkArgs := []string{"cat-file", "--batch-check=%(objecttype) %(objectsize) %(rest)"}
+	timeout := g.commandTimeout("rev-list")
+	list, listCtx, cancelList := gitCommand(path, timeout, listArgs)
+	defer cancelList()
+	check, checkCtx, cancelCheck := gitCommand(path, timeout, checkArgs)
+	defer cancelCheck()
+
+	objects, err := list.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list objects: %w", err)
+	}
+	check.Stdin = objects
+	sizes, err := check.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object sizes: %w", err)
+	}
+
+	start := time.Now()
+	if err := list.Start(); err != nil {
+		return nil, fmt.Errorf("failed to list objects: %w", err)
+	}
+	if err := check.Start(); err != nil {
+		cancelList()
+		list.Wait()
+		return nil, fmt.Errorf("failed to read object sizes: %w", err)
+	}
+
+	totals := make(directoryTotals)
+	scanner := bufio.NewScanner(sizes)
+	for scanner.Scan() {
+		totals.add(scanner.Text())
+	}
+	scanErr := scanner.Err()
+	if scanErr != nil {
+		// Stop both commands instead of waiting for output nobody reads
+		cancelCheck()
+		cancelList()
+	}
+
+	checkErr := timeoutError(checkCtx, "cat-file", timeout, check.Wait())
+	listErr := timeoutError(listCtx, "rev-list", timeout, list.Wait())
+	g.recordCommand(path, listArgs, start, listErr)
+	g.recordCommand(path, checkArgs, start, checkErr)
+	switch {
+	case listErr != nil:
+		return nil, fmt.Errorf("failed to list objects: %w", listErr)
+	case checkErr != nil:
+		return nil, fmt.Errorf("failed to read object sizes: %w", checkErr)
+	case scanErr != nil:
+		return nil, fmt.Errorf("failed to read object sizes: %w", scanErr)
+	}
+	return totals, nil
+}
+
+// parseCountObjects reads the output of git count-objects -v, which reports
+// sizes in KiB
+func parseCountObjects(output string, stats *ObjectStats) {
+	for _, line := range strings.Split(output, "\n") {
+		key, value, ok := strings.Cut(line, ": ")
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			continue
+		}
+		switch key {
+		case "count":
+			stats.LooseObjects = int(n)
+		case "size":
+			stats.LooseSize = n * 1024
+		case "in-pack":
+			stats.Objects = int(n)
+		case "packs":
+			stats.Packs = int(n)
+		case "size-pack":
+			stats.PackSize = n * 1024
+		}
+	}
+	stats.Objects += stats.LooseObjects
+}
+
+// directoryTotals sums blob sizes per top-level directory
+type directoryTotals map[string]*DirectorySize
+
+// add counts a "<type> <size> <path>" line of git cat-file --batch-check
+// output; commits and trees are not counted
+func (totals directoryTotals) add(line string) {
+	fields := strings.SplitN(line, " ", 3)
+	if len(fields) != 3 || fields[0] != "blob" {
+		return
+	}
+	size, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return
+	}
+	dir, _, nested := strings.Cut(fields[2], "/")
+	if !nested {
+		dir = "."
+	}
+	total := totals[dir]
+	if total == nil {
+		total = &DirectorySize{Path: dir}
+		totals[dir] = total
+	}
+	total.Size += size
+	total.Blobs++
+}
+
+// biggest returns the limit biggest directories, largest first
+func (totals directoryTotals) biggest(limit int) []DirectorySize {
+	dirs := make([]DirectorySize, 0, len(totals))
+	for _, total := range totals {
+		dirs = append(dirs, *total)
+	}
+	sort.Slice(dirs, func(i, j int) bool {
+		if dirs[i].Size != dirs[j].Size {
+			return dirs[i].Size > dirs[j].Size
+		}
+		return dirs[i].Path < dirs[j].Path
+	})
+	if len(dirs) > limit {
+		dirs = dirs[:limit]
+	}
+	return dirs
+}
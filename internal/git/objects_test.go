@@ -0,0 +1,38 @@
+package git
+
+import "testing"
+
+func TestParseCountObjects(t *testing.T) {
+	output := "count: 12\nsize: 48\nin-pack: 3000\npacks: 2\nsize-pack: 1536\nprune-packable: 0\ngarbage: 0\nsize-garbage: 0"
+	var stats ObjectStats
+	parseCountObjects(output, &stats)
+
+	want := ObjectStats{Objects: 3012, LooseObjects: 12, Packs: 2, PackSize: 1536 * 1024, LooseSize: 48 * 1024}
+	if stats.Objects != want.Objects || stats.LooseObjects != want.LooseObjects || stats.Packs != want.Packs ||
+		stats.PackSize != want.PackSize || stats.LooseSize != want.LooseSize {
+		t.Errorf("parseCountObjects() = %+v; want %+v", stats, want)
+	}
+}
+
+func TestDirectoryTotals(t *testing.T) {
+	lines := []string{"commit 200 ", "tree 90 ", "blob 100 README.md", "blob 700 assets/logo.png",
+		"blob 50 src/main.go", "blob 30 src/util/strings.go", "tree 40 src", "blob 5 docs/a b.md"}
+	totals := make(directoryTotals)
+	for _, line := range lines {
+		totals.add(line)
+	}
+
+	dirs := totals.biggest(2)
+	if len(dirs) != 2 {
+		t.Fatalf("biggest() = %+v; want 2 directories", dirs)
+	}
+	if dirs[0] != (DirectorySize{Path: "assets", Size: 700, Blobs: 1}) {
+		t.Errorf("dirs[0] = %+v; want assets with 700 bytes", dirs[0])
+	}
+	if dirs[1] != (DirectorySize{Path: ".", Size: 100, Blobs: 1}) {
+		t.Errorf("dirs[1] = %+v; want the root with 100 bytes", dirs[1])
+	}
+	if src := totals["src"]; src == nil || src.Size != 80 || src.Blobs != 2 {
+		t.Errorf("src = %+v; want 80 bytes in 2 blobs", src)
+	}
+}
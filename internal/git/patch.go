@@ -0,0 +1,188 @@
+package git
+
+import (
+	"bufio"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PatchResult is the outcome of applying patches to a repository
+type PatchResult struct {
+	Applied   int      // Patches applied without conflicts
+	Conflicts []string // Files left with conflict markers by a three-way merge
+}
+
+// PatchesAreMailboxes reports whether the patch files are mailboxes written
+// by git format-patch, which carry commit messages, rather than plain diffs.
+// Mixing both kinds is an error.
+func PatchesAreMailboxes(patches []string) (bool, error) {
+	if len(patches) == 0 {
+		return false, fmt.Errorf("no patches given")
+	}
+	var mailboxes int
+	for _, patch := range patches {
+		file, err := os.Open(patch)
+		if err != nil {
+			return false, fmt.Errorf("failed to read patch: %w", err)
+		}
+		scanner := bufio.NewScanner(file)
+		if scanner.Scan() && strings.HasPrefix(scanner.Text(), "From ") {
+			mailboxes++
+		}
+		file.Close()
+	}
+	if mailboxes != 0 && mailboxes != len(patches) {
+		return false, fmt.Errorf("patches mix git format-patch mailboxes and plain diffs")
+	}
+	return mailboxes > 0, nil
+}
+
+// ApplyPatches applies patch files in order. mailbox tells whether they are
+// mailboxes, as reported by PatchesAreMailboxes. Mailboxes are committed with
+// git am; plain diffs only change the working tree. With threeWay, a patch
+// that does not apply cleanly falls back to a three-way merge: the
+// conflicted files are returned and, for mailboxes, git am stays in progress
+// for the user to resolve. Any other failure leaves the repository as it was.
+func (g *Manager) ApplyPatches(path string, patches []string, mailbox, threeWay bool) (PatchResult, error) {
+	if output, err := g.RunCommand(path, "rev-parse", "--git-path", "rebase-apply"); err == nil {
+		if !filepath.IsAbs(output) {
+			output = filepath.Join(path, output)
+		}
+		if _, err := os.Stat(output); err == nil {
+			return PatchResult{}, fmt.Errorf("a git am or rebase is already in progress")
+		}
+	}
+	if mailbox {
+		return g.applyMailboxes(path, patches, threeWay)
+	}
+	return g.applyDiffs(path, patches, threeWay)
+}
+
+// CheckPatches reports how patches would apply to HEAD without touching the
+// repository, by applying them in a temporary worktree. Uncommitted changes
+// of the repository are not taken into account.
+func (g *Manager) CheckPatches(path string, patches []string, mailbox, threeWay bool) (PatchResult, error) {
+	tempDir, err := os.MkdirTemp("", "gman-patch-")
+	if err != nil {
+		return PatchResult{}, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	worktree := filepath.Join(tempDir, "check")
+	if _, err := g.RunCommand(path, "worktree", "add", "--detach", worktree, "HEAD"); err != nil {
+		return PatchResult{}, fmt.Errorf("failed to create temporary worktree: %w", err)
+	}
+	defer func() {
+		if err := g.removeTemporaryWorktree(path, worktree); err != nil {
+			slog.Warn("failed to remove temporary worktree", "repository", path, "worktree", worktree, "error", err)
+		}
+	}()
+
+	return g.ApplyPatches(worktree, patches, mailbox, threeWay)
+}
+
+// removeTemporaryWorktree removes a worktree gman created for itself. Unlike
+// the user's worktrees it is removed even in protected repositories and in
+// safe mode, which would otherwise leave its administrative files behind.
+func (g *Manager) removeTemporaryWorktree(path, worktree string) error {
+	args := []string{"worktree", "remove", "--force", worktree}
+	timeout := g.commandTimeout(args[0])
+	cmd, ctx, cancel := gitCommand(path, timeout, args)
+	defer cancel()
+	start := time.Now()
+	output, err := cmd.CombinedOutput()
+	err = timeoutError(ctx, args[0], timeout, err)
+	g.recordCommand(path, args, start, err)
+	if err != nil {
+		return patchError(strings.TrimSpace(string(output)), err)
+	}
+	return nil
+}
+
+// applyMailboxes commits mailbox patches with git am
+func (g *Manager) applyMailboxes(path string, patches []string, threeWay bool) (PatchResult, error) {
+	head, err := g.RunCommand(path, "rev-parse", "HEAD")
+	if err != nil {
+		return PatchResult{}, fmt.Errorf("failed to read HEAD: %w", err)
+	}
+
+	args := []string{"am", "--quiet"}
+	if threeWay {
+		args = append(args, "--3way")
+	}
+	output, amErr := g.RunCommand(path, append(args, patches...)...)
+
+	var result PatchResult
+	if count, err := g.RunCommand(path, "rev-list", "--count", head+"..HEAD"); err == nil {
+		result.Applied, _ = strconv.Atoi(count)
+	}
+	if amErr == nil {
+		return result, nil
+	}
+	if threeWay {
+		if result.Conflicts = g.unmergedFiles(path); len(result.Conflicts) > 0 {
+			return result, nil
+		}
+	}
+	g.RunCommand(path, "am", "--abort")
+	return PatchResult{}, patchError(output, amErr)
+}
+
+// applyDiffs applies plain diffs one by one. Without threeWay a failing
+// patch reverts the ones applied before it.
+func (g *Manager) applyDiffs(path string, patches []string, threeWay bool) (PatchResult, error) {
+	var result PatchResult
+	for _, patch := range patches {
+		args := []string{"apply"}
+		if threeWay {
+			args = append(args, "--3way")
+		}
+		output, err := g.RunCommand(path, append(args, patch)...)
+		if err == nil {
+			result.Applied++
+			continue
+		}
+		if threeWay {
+			if result.Conflicts = g.unmergedFiles(path); len(result.Conflicts) > 0 {
+				return result, nil
+			}
+		}
+		for i := result.Applied - 1; i >= 0; i-- {
+			g.RunCommand(path, "apply", "--reverse", patches[i])
+		}
+		return PatchResult{}, fmt.Errorf("%s: %w", filepath.Base(patch), patchError(output, err))
+	}
+	return result, nil
+}
+
+// unmergedFiles lists the files with unresolved conflicts
+func (g *Manager) unmergedFiles(path string) []string {
+	output, err := g.RunCommand(path, "diff", "--name-only", "--diff-filter=U")
+	if err != nil || output == "" {
+		return nil
+	}
+	return strings.Split(output, "\n")
+}
+
+// patchError turns the output of a failed git am or git apply into an
+// error, keeping git's error lines and dropping its advice
+func patchError(output string, err error) error {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		if message, ok := strings.CutPrefix(line, "error: "); ok {
+			lines = append(lines, message)
+		}
+	}
+	if len(lines) == 0 {
+		if output == "" {
+			return err
+		}
+		lines = strings.SplitN(output, "\n", 2)[:1]
+	}
+	return fmt.Errorf("%s", strings.Join(lines, "; "))
+}
@@ -0,0 +1,105 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestManager_ApplyPatches(t *testing.T) {
+	for _, name := range []string{"GIT_AUTHOR_NAME", "GIT_COMMITTER_NAME"} {
+		t.Setenv(name, "test")
+	}
+	for _, name := range []string{"GIT_AUTHOR_EMAIL", "GIT_COMMITTER_EMAIL"} {
+		t.Setenv(name, "test@example.com")
+	}
+	dir := t.TempDir()
+	run := func(args ...string) string {
+		t.Helper()
+		output, err := exec.Command("git", args...).CombinedOutput()
+		if err != nil {
+			t.Skipf("git %v failed: %v\n%s", args, err, output)
+		}
+		return strings.TrimSpace(string(output))
+	}
+	write := func(path, content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Two commits on top of the base become the patch series
+	source := filepath.Join(dir, "source")
+	run("init", "-b", "main", source)
+	write(filepath.Join(source, "config.yml"), "a: 1\nb: 2\nc: 3\n")
+	run("-C", source, "add", ".")
+	run("-C", source, "commit", "-m", "base")
+	for _, content := range []string{"a: 1\nb: 20\nc: 3\n", "a: 1\nb: 20\nc: 30\n"} {
+		write(filepath.Join(source, "config.yml"), content)
+		run("-C", source, "commit", "-am", "change")
+	}
+	run("-C", source, "format-patch", "-q", "-2", "-o", filepath.Join(dir, "series"))
+	patches, _ := filepath.Glob(filepath.Join(dir, "series", "*.patch"))
+
+	clean := filepath.Join(dir, "clean")
+	diverged := filepath.Join(dir, "diverged")
+	for _, path := range []string{clean, diverged} {
+		run("clone", "-q", source, path)
+		run("-C", path, "reset", "-q", "--hard", "HEAD~2")
+	}
+	write(filepath.Join(diverged, "config.yml"), "a: 1\nb: 5\nc: 3\n")
+	run("-C", diverged, "commit", "-am", "diverge")
+
+	manager := NewManager()
+	result, err := manager.ApplyPatches(clean, patches, true, false)
+	if err != nil || result.Applied != 2 {
+		t.Fatalf("ApplyPatches() = %+v, %v; want 2 patches applied", result, err)
+	}
+
+	// A failed series leaves the repository as it was
+	head := run("-C", diverged, "rev-parse", "HEAD")
+	if _, err := manager.ApplyPatches(diverged, patches, true, false); err == nil {
+		t.Fatal("ApplyPatches() error = nil; want the patch to fail")
+	}
+	if after := run("-C", diverged, "rev-parse", "HEAD"); after != head {
+		t.Errorf("HEAD moved from %s to %s", head, after)
+	}
+	if _, err := os.Stat(filepath.Join(diverged, ".git", "rebase-apply")); err == nil {
+		t.Error("git am is still in progress after a failed series")
+	}
+
+	// The dry run reports the conflict without touching the repository, and
+	// cleans up its worktree even in safe mode
+	manager.SetProtection(Protection{SafeMode: true})
+	result, err = manager.CheckPatches(diverged, patches, true, true)
+	if err != nil || len(result.Conflicts) != 1 || result.Conflicts[0] != "config.yml" {
+		t.Errorf("CheckPatches() = %+v, %v; want a conflict in config.yml", result, err)
+	}
+	if status := run("-C", diverged, "status", "--porcelain"); status != "" {
+		t.Errorf("CheckPatches() changed the repository: %s", status)
+	}
+	if worktrees := run("-C", diverged, "worktree", "list", "--porcelain"); strings.Count(worktrees, "worktree ") != 1 {
+		t.Errorf("CheckPatches() left a worktree behind:\n%s", worktrees)
+	}
+}
+
+func TestPatchesAreMailboxes(t *testing.T) {
+	dir := t.TempDir()
+	mailbox := filepath.Join(dir, "0001-fix.patch")
+	diff := filepath.Join(dir, "fix.diff")
+	os.WriteFile(mailbox, []byte("From 1234 Mon Sep 17 00:00:00 2001\nSubject: [PATCH] Fix\n"), 0644)
+	os.WriteFile(diff, []byte("diff --git a/f b/f\n"), 0644)
+
+	if ok, err := PatchesAreMailboxes([]string{mailbox}); !ok || err != nil {
+		t.Errorf("PatchesAreMailboxes(mailbox) = %v, %v; want true", ok, err)
+	}
+	if ok, err := PatchesAreMailboxes([]string{diff}); ok || err != nil {
+		t.Errorf("PatchesAreMailboxes(diff) = %v, %v; want false", ok, err)
+	}
+	if _, err := PatchesAreMailboxes([]string{mailbox, diff}); err == nil {
+		t.Error("Expected an error for mixed patches")
+	}
+}
@@ -0,0 +1,112 @@
+package git
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"gman/internal/errors"
+)
+
+// Protection decides where destructive git commands are refused
+type Protection struct {
+	// Protected holds the paths of repositories marked protected: true
+	Protected []string
+	// SafeMode refuses destructive commands in every repository
+	SafeMode bool
+	// Override allows destructive commands anyway (--allow-destructive)
+	Override bool
+}
+
+// SetProtection makes the manager refuse destructive git commands in
+// protected repositories, or everywhere in safe mode
+func (g *Manager) SetProtection(protection Protection) {
+	g.protection = protection
+}
+
+// checkProtection returns an error when args are destructive and path is
+// protected by the configured Protection
+func (g *Manager) checkProtection(path string, args []string) error {
+	if g.protection.Override {
+		return nil
+	}
+	operation := destructiveOperation(args)
+	if operation == "" {
+		return nil
+	}
+
+	cleaned := filepath.Clean(path)
+	protected := slices.ContainsFunc(g.protection.Protected, func(p string) bool {
+		return filepath.Clean(p) == cleaned
+	})
+	var err error
+	switch {
+	case protected:
+		err = errors.NewGmanError(errors.ErrTypeRepoProtected,
+			fmt.Sprintf("refusing %s in protected repository %s", operation, path)).
+			WithSuggestions(
+				"Re-run with --allow-destructive if you really mean it",
+				"Remove the protection with 'gman repo unprotect <alias>'",
+			)
+	case g.protection.SafeMode:
+		err = errors.NewGmanError(errors.ErrTypeRepoProtected,
+			fmt.Sprintf("refusing %s in %s: safe mode is on", operation, path)).
+			WithSuggestion("Re-run with --allow-destructive, or without --safe and settings.safe_mode")
+	default:
+		return nil
+	}
+	// Refused attempts belong in the audit trail too
+	g.auditCommand(path, args, time.Now(), err)
+	return err
+}
+
+// destructiveOperation describes args when they can discard commits or
+// changes, and returns "" otherwise
+func destructiveOperation(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	has := func(flags ...string) bool {
+		for _, arg := range args[1:] {
+			for _, flag := range flags {
+				if arg == flag || (strings.HasPrefix(flag, "--") && strings.HasPrefix(arg, flag+"=")) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	switch args[0] {
+	case "push":
+		forcedRefspec := slices.ContainsFunc(args[1:], func(arg string) bool {
+			return strings.HasPrefix(arg, "+")
+		})
+		if has("--force", "-f", "--force-with-lease") || forcedRefspec {
+			return "force push"
+		}
+	case "branch":
+		if has("-D") || (has("-d", "--delete") && has("--force", "-f")) {
+			return "forced branch deletion"
+		}
+	case "stash":
+		if len(args) > 1 && args[1] == "clear" {
+			return "stash clear"
+		}
+	case "worktree":
+		if len(args) > 1 && args[1] == "remove" && has("--force", "-f") {
+			return "forced worktree removal"
+		}
+	case "reset":
+		if has("--hard", "--merge", "--keep") {
+			return "reset"
+		}
+	case "merge":
+		if has("--abort") {
+			return "merge abort"
+		}
+	}
+	return ""
+}
@@ -0,0 +1,62 @@
+package git
+
+import (
+	"testing"
+
+	"gman/internal/errors"
+)
+
+func TestDestructiveOperation(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"push", "--force"}, "force push"},
+		{[]string{"push", "--force-with-lease=main"}, "force push"},
+		{[]string{"push", "origin", "+main"}, "force push"},
+		{[]string{"push", "--set-upstream", "origin", "main"}, ""},
+		{[]string{"branch", "-D", "spike"}, "forced branch deletion"},
+		{[]string{"branch", "--delete", "--force", "spike"}, "forced branch deletion"},
+		{[]string{"branch", "-d", "spike"}, ""},
+		{[]string{"stash", "clear"}, "stash clear"},
+		{[]string{"stash", "list"}, ""},
+		{[]string{"worktree", "remove", "--force", "/tmp/wt"}, "forced worktree removal"},
+		{[]string{"worktree", "remove", "/tmp/wt"}, ""},
+		{[]string{"reset", "--hard", "HEAD~1"}, "reset"},
+		{[]string{"reset", "README.md"}, ""},
+		{[]string{"merge", "--abort"}, "merge abort"},
+		{[]string{"status", "--porcelain"}, ""},
+	}
+
+	for _, tt := range tests {
+		if got := destructiveOperation(tt.args); got != tt.want {
+			t.Errorf("destructiveOperation(%v) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestCheckProtection(t *testing.T) {
+	g := NewManager()
+	forcePush := []string{"push", "--force"}
+
+	g.SetProtection(Protection{Protected: []string{"/src/api/"}})
+	if err := g.checkProtection("/src/api", forcePush); !errors.IsType(err, errors.ErrTypeRepoProtected) {
+		t.Errorf("Expected protected repository to refuse force push, got %v", err)
+	}
+	if err := g.checkProtection("/src/web", forcePush); err != nil {
+		t.Errorf("Expected unprotected repository to allow force push, got %v", err)
+	}
+	if err := g.checkProtection("/src/api", []string{"push"}); err != nil {
+		t.Errorf("Expected plain push to be allowed, got %v", err)
+	}
+
+	g.SetProtection(Protection{SafeMode: true})
+	if err := g.checkProtection("/src/web", forcePush); err == nil {
+		t.Error("Expected safe mode to refuse force push everywhere")
+	}
+
+	g.SetProtection(Protection{Protected: []string{"/src/api"}, SafeMode: true, Override: true})
+	if err := g.checkProtection("/src/api", forcePush); err != nil {
+		t.Errorf("Expected override to allow force push, got %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+package git
+
+import (
+	"strings"
+	"sync"
+)
+
+// readCache memoizes git command output during one status pass, where
+// several helpers ask for the same data (current branch, porcelain status,
+// stash list). It must not outlive the pass: the repository may change.
+type readCache struct {
+	mu      sync.Mutex
+	outputs map[string]cachedOutput
+}
+
+// cachedOutput is the result of one git invocation
+type cachedOutput struct {
+	output string
+	err    error
+}
+
+// withReadCache returns a copy of the manager whose git reads are memoized,
+// for use during a single status pass of one repository
+func (g *Manager) withReadCache() *Manager {
+	scoped := *g
+	scoped.reads = &readCache{outputs: make(map[string]cachedOutput)}
+	return &scoped
+}
+
+// cacheKey identifies an invocation by repository and arguments
+func cacheKey(path string, args []string) string {
+	return path + "\x00" + strings.Join(args, "\x00")
+}
+
+// lookup returns the memoized result of an invocation
+func (c *readCache) lookup(path string, args []string) (cachedOutput, bool) {
+	if c == nil {
+		return cachedOutput{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	result, ok := c.outputs[cacheKey(path, args)]
+	return result, ok
+}
+
+// store memoizes the result of an invocation
+func (c *readCache) store(path string, args []string, output string, err error) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.outputs[cacheKey(path, args)] = cachedOutput{output: output, err: err}
+}
+
+// reset forgets all results, e.g. after a fetch moved remote refs
+func (c *readCache) reset() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.outputs = make(map[string]cachedOutput)
+}
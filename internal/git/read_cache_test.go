@@ -0,0 +1,49 @@
+package git
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestManager_StatusPassReadsOnce(t *testing.T) {
+	repoPath := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-b", "main", repoPath},
+		{"-C", repoPath, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--allow-empty", "-m", "initial"},
+	} {
+		if err := exec.Command("git", args...).Run(); err != nil {
+			t.Skipf("git not available: %v", err)
+		}
+	}
+
+	logPath := filepath.Join(t.TempDir(), CommandLogFile)
+	manager := NewManager()
+	manager.SetCommandLog(NewCommandLog(logPath))
+
+	status := manager.GetRepoStatusNoFetch("repo", repoPath)
+	if status.Error != nil {
+		t.Fatalf("status failed: %v", status.Error)
+	}
+
+	records, err := ReadCommandLog(logPath, 0)
+	if err != nil {
+		t.Fatalf("ReadCommandLog() error = %v", err)
+	}
+	seen := make(map[string]bool)
+	for _, record := range records {
+		key := strings.Join(record.Args, " ")
+		if seen[key] {
+			t.Errorf("git %s ran more than once in one status pass", key)
+		}
+		seen[key] = true
+	}
+
+	// The cache is scoped to the pass: the next one reads again
+	manager.GetRepoStatusNoFetch("repo", repoPath)
+	again, _ := ReadCommandLog(logPath, 0)
+	if len(again) != 2*len(records) {
+		t.Errorf("Expected the second pass to run %d commands, got %d", len(records), len(again)-len(records))
+	}
+}
@@ -0,0 +1,93 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Ref kinds reported by ListRefs
+const (
+	RefLocalBranch  = "branch"
+	RefRemoteBranch = "remote"
+	RefTag          = "tag"
+)
+
+// RefInfo describes a branch or tag of a repository
+type RefInfo struct {
+	Name   string // short name, e.g. "release/2.3" or "v1.0.0"
+	Kind   string // RefLocalBranch, RefRemoteBranch or RefTag
+	Remote string // remote name for remote branches
+}
+
+// ListRefs returns the local branches, remote branches and tags of a repository
+func (g *Manager) ListRefs(path string) ([]RefInfo, error) {
+	// Default output is "<object> <type>\t<refname>"; custom --format atoms
+	// use parentheses, which argument validation rejects
+	output, err := g.RunCommand(path, "for-each-ref", "refs/heads", "refs/remotes", "refs/tags")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list refs: %w", err)
+	}
+
+	var refs []RefInfo
+	for _, line := range strings.Split(output, "\n") {
+		_, refname, found := strings.Cut(line, "\t")
+		if !found {
+			continue
+		}
+		if ref, ok := parseRefName(strings.TrimSpace(refname)); ok {
+			refs = append(refs, ref)
+		}
+	}
+	return refs, nil
+}
+
+// parseRefName converts a full ref name into a RefInfo
+func parseRefName(refname string) (RefInfo, bool) {
+	switch {
+	case strings.HasPrefix(refname, "refs/heads/"):
+		return RefInfo{Name: strings.TrimPrefix(refname, "refs/heads/"), Kind: RefLocalBranch}, true
+	case strings.HasPrefix(refname, "refs/tags/"):
+		return RefInfo{Name: strings.TrimPrefix(refname, "refs/tags/"), Kind: RefTag}, true
+	case strings.HasPrefix(refname, "refs/remotes/"):
+		remote, name, found := strings.Cut(strings.TrimPrefix(refname, "refs/remotes/"), "/")
+		if !found || name == "HEAD" {
+			return RefInfo{}, false
+		}
+		return RefInfo{Name: name, Kind: RefRemoteBranch, Remote: remote}, true
+	}
+	return RefInfo{}, false
+}
+
+// DeleteBranch deletes a local branch. Unmerged branches are only deleted with force.
+func (g *Manager) DeleteBranch(path, branch string, force bool) error {
+	flag := "-d"
+	if force {
+		flag = "-D"
+	}
+	if _, err := g.RunCommand(path, "branch", flag, branch); err != nil {
+		return fmt.Errorf("failed to delete branch '%s': %w", branch, err)
+	}
+	return nil
+}
+
+// RefExists reports whether ref resolves to a commit in the repository
+func (g *Manager) RefExists(path, ref string) bool {
+	_, err := g.RunCommand(path, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	return err == nil
+}
+
+// ListFilesAtRef lists the file paths in the tree of ref without checking it out
+func (g *Manager) ListFilesAtRef(path, ref string) ([]string, error) {
+	output, err := g.RunCommand(path, "ls-tree", "-r", "--name-only", "--full-tree", ref)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files at '%s': %w", ref, err)
+	}
+
+	var files []string
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,27 @@
+package git
+
+import "testing"
+
+func TestParseRefName(t *testing.T) {
+	tests := []struct {
+		refname string
+		want    RefInfo
+		ok      bool
+	}{
+		{"refs/heads/main", RefInfo{Name: "main", Kind: RefLocalBranch}, true},
+		{"refs/heads/release/2.3", RefInfo{Name: "release/2.3", Kind: RefLocalBranch}, true},
+		{"refs/remotes/origin/release/2.3", RefInfo{Name: "release/2.3", Kind: RefRemoteBranch, Remote: "origin"}, true},
+		{"refs/remotes/origin/HEAD", RefInfo{}, false},
+		{"refs/tags/v1.0.0", RefInfo{Name: "v1.0.0", Kind: RefTag}, true},
+		{"refs/stash", RefInfo{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.refname, func(t *testing.T) {
+			got, ok := parseRefName(tt.refname)
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("parseRefName(%q) = %+v, %v; want %+v, %v", tt.refname, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+
+	"gman/internal/errors"
+)
+
+// Messages of git pull that mean local changes are in the way
+var dirtyWorkspaceMessages = []string{
+	"would be overwritten by merge",
+	"Please commit your changes or stash them",
+	"You have unstaged changes",
+	"untracked working tree files would be overwritten",
+}
+
+// Messages of git pull that mean a merge is in progress or conflicted
+var mergeConflictMessages = []string{
+	"You have not concluded your merge",
+	"unmerged files",
+	"CONFLICT",
+}
+
+// autostashConflictMessage is printed by a successful pull --autostash
+// whose stashed changes conflict with the pulled commits
+const autostashConflictMessage = "Applying autostash resulted in conflicts"
+
+// syncError adds git's output to a failed pull and types the failures gman
+// can recover from
+func syncError(path, output string, err error) error {
+	if _, ok := errors.As(err); ok {
+		// Timeouts are typed already
+		return err
+	}
+
+	for _, message := range dirtyWorkspaceMessages {
+		if strings.Contains(output, message) {
+			return errors.NewWorkspaceNotCleanError(path).WithCause(err)
+		}
+	}
+	for _, message := range mergeConflictMessages {
+		if strings.Contains(output, message) {
+			return errors.NewMergeConflictError(path).WithCause(err)
+		}
+	}
+
+	// Without a type, git's own message is the only explanation
+	if output = strings.TrimSpace(output); output != "" {
+		err = fmt.Errorf("%w: %s", err, output)
+	}
+	return err
+}
+
+// SyncFailure is a repository that failed to sync
+type SyncFailure struct {
+	Alias string
+	Path  string
+	Err   error
+	// Plan is nil when no recovery action applies
+	Plan *errors.RecoveryPlan
+}
+
+func newSyncFailure(alias, path string, err error) SyncFailure {
+	failure := SyncFailure{Alias: alias, Path: path, Err: err}
+	if gErr, ok := errors.As(err); ok {
+		failure.Plan = errors.NewRecoveryPlan(gErr)
+	}
+	return failure
+}
+
+// SyncFailures is the error of SyncAllRepositories, one entry per failed
+// repository sorted by alias
+type SyncFailures []SyncFailure
+
+func (f SyncFailures) Error() string {
+	aliases := make([]string, 0, len(f))
+	for _, failure := range f {
+		aliases = append(aliases, failure.Alias)
+	}
+	return fmt.Sprintf("sync failed for %d repositories: %s", len(f), strings.Join(aliases, ", "))
+}
+
+// Unwrap exposes the failures to errors.As and errors.Is
+func (f SyncFailures) Unwrap() []error {
+	errs := make([]error, 0, len(f))
+	for _, failure := range f {
+		errs = append(errs, failure.Err)
+	}
+	return errs
+}
+
+// OfType returns the failures whose error is of errorType
+func (f SyncFailures) OfType(errorType errors.ErrorType) SyncFailures {
+	var matching SyncFailures
+	for _, failure := range f {
+		if errors.IsType(failure.Err, errorType) {
+			matching = append(matching, failure)
+		}
+	}
+	return matching
+}
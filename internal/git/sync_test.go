@@ -0,0 +1,66 @@
+package git
+
+import (
+	stderrors "errors"
+	"strings"
+	"testing"
+
+	"gman/internal/errors"
+)
+
+func TestSyncError(t *testing.T) {
+	exitErr := stderrors.New("exit status 1")
+	tests := []struct {
+		name     string
+		output   string
+		wantType errors.ErrorType
+	}{
+		{
+			name:     "local changes",
+			output:   "error: Your local changes to the following files would be overwritten by merge:\n\tREADME.md\nPlease commit your changes or stash them before you merge.",
+			wantType: errors.ErrTypeWorkspaceNotClean,
+		},
+		{
+			name:     "unfinished merge",
+			output:   "error: You have not concluded your merge (MERGE_HEAD exists).",
+			wantType: errors.ErrTypeMergeConflict,
+		},
+		{
+			name:     "diverged",
+			output:   "fatal: Not possible to fast-forward, aborting.",
+			wantType: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := syncError("/repo", tt.output, exitErr)
+			if got := errors.GetType(err); got != tt.wantType {
+				t.Errorf("Expected type %q, got %q", tt.wantType, got)
+			}
+			if tt.wantType == "" && !strings.Contains(err.Error(), tt.output) {
+				t.Errorf("Expected git output in error, got %q", err.Error())
+			}
+		})
+	}
+}
+
+func TestSyncFailures(t *testing.T) {
+	failures := SyncFailures{
+		newSyncFailure("api", "/src/api", errors.NewWorkspaceNotCleanError("/src/api")),
+		newSyncFailure("web", "/src/web", stderrors.New("exit status 1")),
+	}
+
+	if !strings.Contains(failures.Error(), "2 repositories: api, web") {
+		t.Errorf("Unexpected message %q", failures.Error())
+	}
+	if failures[0].Plan == nil || failures[1].Plan != nil {
+		t.Error("Expected a recovery plan for the dirty workspace only")
+	}
+	if !errors.IsType(failures, errors.ErrTypeWorkspaceNotClean) {
+		t.Error("Expected errors.As to find the typed failure")
+	}
+	if dirty := failures.OfType(errors.ErrTypeWorkspaceNotClean); len(dirty) != 1 || dirty[0].Alias != "api" {
+		t.Errorf("Unexpected dirty failures %+v", dirty)
+	}
+}
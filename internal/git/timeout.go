@@ -0,0 +1,68 @@
+package git
+
+import (
+	"context"
+	"os/exec"
+	"time"
+
+	"gman/internal/errors"
+)
+
+// DefaultCommandTimeout bounds git commands without a configured limit
+const DefaultCommandTimeout = 2 * time.Minute
+
+// DefaultTransferTimeout bounds pulls, pushes and clones, which transfer
+// whole histories over slow links and must not be cut off by the default
+const DefaultTransferTimeout = 10 * time.Minute
+
+// defaultCommandTimeouts are the built-in per subcommand limits, used while
+// no default timeout is configured; fetches back status commands and should
+// fail fast
+var defaultCommandTimeouts = map[string]time.Duration{
+	"fetch": DefaultFetchTimeout,
+	"pull":  DefaultTransferTimeout,
+	"push":  DefaultTransferTimeout,
+	"clone": DefaultTransferTimeout,
+}
+
+// SetTimeouts configures how long git commands may run before they are
+// killed. defaultTimeout applies to every subcommand without an entry in
+// overrides; zero keeps the built-in limits and DefaultCommandTimeout.
+func (g *Manager) SetTimeouts(defaultTimeout time.Duration, overrides map[string]time.Duration) {
+	g.defaultTimeout = defaultTimeout
+	g.timeouts = overrides
+}
+
+// commandTimeout returns the time limit of a git subcommand
+func (g *Manager) commandTimeout(subcommand string) time.Duration {
+	if timeout, ok := g.timeouts[subcommand]; ok && timeout > 0 {
+		return timeout
+	}
+	if g.defaultTimeout > 0 {
+		return g.defaultTimeout
+	}
+	if timeout, ok := defaultCommandTimeouts[subcommand]; ok {
+		return timeout
+	}
+	return DefaultCommandTimeout
+}
+
+// gitCommand builds a git command in path that is killed after timeout.
+// The returned cancel function must be called once the command finished.
+func gitCommand(path string, timeout time.Duration, args []string) (*exec.Cmd, context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = path
+	// Don't wait forever for ssh helpers that keep the output pipes open
+	cmd.WaitDelay = time.Second
+	return cmd, ctx, cancel
+}
+
+// timeoutError turns the error of a command killed by its deadline into a
+// network timeout, so callers can suggest retrying with a longer limit
+func timeoutError(ctx context.Context, subcommand string, timeout time.Duration, err error) error {
+	if ctx.Err() != context.DeadlineExceeded {
+		return err
+	}
+	return errors.NewNetworkTimeoutError("git "+subcommand, timeout.String()).WithCause(err)
+}
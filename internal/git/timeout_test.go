@@ -0,0 +1,64 @@
+package git
+
+import (
+	"os/exec"
+	"testing"
+	"time"
+
+	"gman/internal/errors"
+)
+
+func TestManager_CommandTimeout(t *testing.T) {
+	manager := NewManager()
+	if got := manager.commandTimeout("status"); got != DefaultCommandTimeout {
+		t.Errorf("Expected default timeout %s, got %s", DefaultCommandTimeout, got)
+	}
+	if got := manager.commandTimeout("fetch"); got != DefaultFetchTimeout {
+		t.Errorf("Expected built-in fetch timeout %s, got %s", DefaultFetchTimeout, got)
+	}
+	if got := manager.commandTimeout("push"); got != DefaultTransferTimeout {
+		t.Errorf("Expected built-in push timeout %s, got %s", DefaultTransferTimeout, got)
+	}
+
+	manager.SetTimeouts(time.Minute, map[string]time.Duration{"pull": 5 * time.Minute})
+	if got := manager.commandTimeout("status"); got != time.Minute {
+		t.Errorf("Expected configured default 1m, got %s", got)
+	}
+	if got := manager.commandTimeout("pull"); got != 5*time.Minute {
+		t.Errorf("Expected pull override 5m, got %s", got)
+	}
+	// A configured default replaces the built-in limits too
+	if got := manager.commandTimeout("fetch"); got != time.Minute {
+		t.Errorf("Expected fetch to use the configured default 1m, got %s", got)
+	}
+	if got := manager.commandTimeout("clone"); got != time.Minute {
+		t.Errorf("Expected clone to use the configured default 1m, got %s", got)
+	}
+
+	manager.SetFetchTimeout(10 * time.Second)
+	if got := manager.getFetchTimeout(); got != 10*time.Second {
+		t.Errorf("Expected --fetch-timeout to win, got %s", got)
+	}
+}
+
+func TestManager_RunCommandTimeout(t *testing.T) {
+	repoPath := t.TempDir()
+	if err := exec.Command("git", "init", repoPath).Run(); err != nil {
+		t.Skipf("git not available: %v", err)
+	}
+
+	manager := NewManager()
+	manager.SetTimeouts(0, map[string]time.Duration{"status": time.Nanosecond})
+
+	_, err := manager.RunCommand(repoPath, "status", "--porcelain")
+	if !errors.IsType(err, errors.ErrTypeNetworkTimeout) {
+		t.Fatalf("Expected a network timeout error, got %v", err)
+	}
+	if !errors.IsRecoverable(err) {
+		t.Errorf("Expected the timeout to be recoverable")
+	}
+
+	if _, err := manager.RunCommand(repoPath, "rev-parse", "--git-dir"); err != nil {
+		t.Errorf("Expected other commands to keep the default timeout, got %v", err)
+	}
+}
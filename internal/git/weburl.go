@@ -0,0 +1,97 @@
+package git
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// RemoteToWebURL converts a git remote URL (https, ssh or scp-like) into the
+// repository's web page URL, e.g. git@github.com:owner/repo.git becomes
+// https://github.com/owner/repo
+func RemoteToWebURL(remote string) (string, error) {
+	remote = strings.TrimSpace(remote)
+	if remote == "" {
+		return "", fmt.Errorf("remote URL is empty")
+	}
+
+	var host, repoPath string
+
+	if strings.Contains(remote, "://") {
+		parsed, err := url.Parse(remote)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse remote URL '%s': %w", remote, err)
+		}
+		switch parsed.Scheme {
+		case "http", "https", "ssh", "git", "git+ssh", "ssh+git":
+		default:
+			return "", fmt.Errorf("unsupported remote URL scheme '%s'", parsed.Scheme)
+		}
+		// Drop credentials and ssh ports; the web UI lives on the default port
+		host = parsed.Hostname()
+		if parsed.Scheme == "http" || parsed.Scheme == "https" {
+			host = parsed.Host
+		}
+		repoPath = parsed.Path
+	} else {
+		// scp-like syntax: [user@]host:owner/repo.git
+		colon := strings.Index(remote, ":")
+		if colon <= 0 {
+			return "", fmt.Errorf("unrecognized remote URL '%s'", remote)
+		}
+		host = remote[:colon]
+		if at := strings.LastIndex(host, "@"); at >= 0 {
+			host = host[at+1:]
+		}
+		repoPath = remote[colon+1:]
+	}
+
+	repoPath = strings.Trim(repoPath, "/")
+	repoPath = strings.TrimSuffix(repoPath, ".git")
+	if host == "" || repoPath == "" {
+		return "", fmt.Errorf("unrecognized remote URL '%s'", remote)
+	}
+
+	return fmt.Sprintf("https://%s/%s", host, repoPath), nil
+}
+
+// WebURLForRef builds a web URL for a branch, or for a file on that branch
+// when filePath is set. A positive line deep-links to that line. GitLab and
+// Bitbucket URL layouts are detected from the host; everything else uses the
+// GitHub layout, which most other forges (Gitea, Forgejo) also accept.
+func WebURLForRef(webURL, ref, filePath string, line int) string {
+	webURL = strings.TrimSuffix(webURL, "/")
+	if ref == "" {
+		return webURL
+	}
+
+	filePath = strings.TrimPrefix(filePath, "/")
+	host := strings.ToLower(webURL)
+
+	switch {
+	case strings.Contains(host, "gitlab"):
+		if filePath == "" {
+			return fmt.Sprintf("%s/-/tree/%s", webURL, ref)
+		}
+		result := fmt.Sprintf("%s/-/blob/%s/%s", webURL, ref, filePath)
+		if line > 0 {
+			result += fmt.Sprintf("#L%d", line)
+		}
+		return result
+	case strings.Contains(host, "bitbucket"):
+		result := fmt.Sprintf("%s/src/%s/%s", webURL, ref, filePath)
+		if filePath != "" && line > 0 {
+			result += fmt.Sprintf("#lines-%d", line)
+		}
+		return result
+	default:
+		if filePath == "" {
+			return fmt.Sprintf("%s/tree/%s", webURL, ref)
+		}
+		result := fmt.Sprintf("%s/blob/%s/%s", webURL, ref, filePath)
+		if line > 0 {
+			result += fmt.Sprintf("#L%d", line)
+		}
+		return result
+	}
+}
@@ -0,0 +1,357 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+
+	"gman/pkg/types"
+)
+
+// Default worktree naming templates, used when worktree_name_template is
+// not set: next to the repository after its directory, or in
+// worktree_base_dir after its alias
+const (
+	DefaultWorktreeNameTemplate    = "{dir}-{branch}"
+	DefaultBaseDirWorktreeTemplate = "{repo}-{branch}"
+)
+
+// SanitizeBranchName turns a branch name into a single path component, so
+// that feature/x does not create nested directories
+func SanitizeBranchName(branch string) string {
+	return strings.NewReplacer("/", "-", "\\", "-").Replace(branch)
+}
+
+// WorktreeName expands a worktree naming template: {repo} is the repository
+// alias, {dir} the name of the repository directory and {branch} the
+// sanitized branch name
+func WorktreeName(template, alias, repoPath, branch string) (string, error) {
+	name := strings.NewReplacer(
+		"{repo}", alias,
+		"{dir}", filepath.Base(repoPath),
+		"{branch}", SanitizeBranchName(branch),
+	).Replace(template)
+	if strings.ContainsAny(name, "{}") {
+		return "", fmt.Errorf("invalid worktree name template '%s': use {repo}, {dir} and {branch}", template)
+	}
+	if name == "" || filepath.IsAbs(name) || slices.Contains(strings.Split(filepath.ToSlash(name), "/"), "..") {
+		return "", fmt.Errorf("invalid worktree name template '%s': it must expand to a relative path", template)
+	}
+	return name, nil
+}
+
+// PruneWorktrees runs 'git worktree prune', which drops the admin files of
+// worktrees whose directory is gone, and returns git's description of each
+// pruned worktree. With dryRun nothing is removed.
+func (g *Manager) PruneWorktrees(repoPath string, dryRun bool) ([]string, error) {
+	args := []string{"worktree", "prune", "--verbose"}
+	if dryRun {
+		args = append(args, "--dry-run")
+	}
+	output, err := g.RunCommand(repoPath, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prune worktrees: %w", err)
+	}
+	if output == "" {
+		return nil, nil
+	}
+	return strings.Split(output, "\n"), nil
+}
+
+// OrphanWorktree is a worktree directory that is no longer useful: its
+// branch was deleted, or git no longer knows about it
+type OrphanWorktree struct {
+	Path   string
+	Branch string
+	Reason string
+	// Unregistered directories are unknown to git and are removed from
+	// disk directly instead of with 'git worktree remove'
+	Unregistered bool
+}
+
+// FindOrphanWorktrees returns the worktrees of the repository whose branch
+// was deleted, and the directories directly inside searchDirs that are
+// worktrees of the repository whose admin files no longer exist
+func (g *Manager) FindOrphanWorktrees(repoPath string, searchDirs []string) ([]OrphanWorktree, error) {
+	worktrees, err := g.ListWorktrees(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var orphans []OrphanWorktree
+	for _, wt := range worktrees {
+		if wt.Path == repoPath || wt.IsBare || wt.IsDetached || wt.Prunable != "" || wt.Branch == "" {
+			continue
+		}
+		if g.verifyBranchExists(repoPath, "refs/heads/"+wt.Branch) != nil {
+			orphans = append(orphans, OrphanWorktree{
+				Path:   wt.Path,
+				Branch: wt.Branch,
+				Reason: fmt.Sprintf("branch %s was deleted", wt.Branch),
+			})
+		}
+	}
+
+	commonDir, err := g.RunCommand(repoPath, "rev-parse", "--git-common-dir")
+	if err != nil {
+		return nil, fmt.Errorf("failed to find git directory: %w", err)
+	}
+	if !filepath.IsAbs(commonDir) {
+		commonDir = filepath.Join(repoPath, commonDir)
+	}
+	adminDir := filepath.Join(filepath.Clean(commonDir), "worktrees") + string(filepath.Separator)
+
+	seen := make(map[string]bool)
+	for _, dir := range searchDirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			path := filepath.Join(dir, entry.Name())
+			if !entry.IsDir() || seen[path] {
+				continue
+			}
+			seen[path] = true
+
+			gitDir, ok := worktreeGitDir(path)
+			if !ok || !strings.HasPrefix(gitDir, adminDir) {
+				continue
+			}
+			if _, err := os.Stat(gitDir); os.IsNotExist(err) {
+				orphans = append(orphans, OrphanWorktree{
+					Path:         path,
+					Reason:       "its worktree admin files were pruned",
+					Unregistered: true,
+				})
+			}
+		}
+	}
+	return orphans, nil
+}
+
+// worktreeGitDir returns the admin directory a linked worktree's .git file
+// points to
+func worktreeGitDir(path string) (string, bool) {
+	data, err := os.ReadFile(filepath.Join(path, ".git"))
+	if err != nil {
+		// Missing, or a directory: not a linked worktree
+		return "", false
+	}
+	gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir: ")
+	if !ok {
+		return "", false
+	}
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(path, gitDir)
+	}
+	return filepath.Clean(gitDir), true
+}
+
+// SetWorktreeStatus makes status reads include the branch and workspace
+// state of each linked worktree, which costs extra git calls per worktree
+func (g *Manager) SetWorktreeStatus(enabled bool) {
+	g.worktreeStatus = enabled
+}
+
+// readWorktreeStatus fills the status of the repository's linked worktrees
+// (non-blocking: a worktree that cannot be read carries its error)
+func (g *Manager) readWorktreeStatus(status *types.RepoStatus) {
+	worktrees, err := g.ListWorktrees(status.Path)
+	if err != nil || len(worktrees) == 0 {
+		return
+	}
+
+	// The first entry is the main worktree, already described by status
+	for _, wt := range worktrees[1:] {
+		if wt.IsBare || wt.Prunable != "" {
+			continue
+		}
+		worktree := types.WorktreeStatus{
+			Path:       wt.Path,
+			Branch:     wt.Branch,
+			IsDetached: wt.IsDetached,
+		}
+		// Stashes are shared by all worktrees, so only local changes count
+		filesChanged, err := g.getFilesChangedCount(wt.Path)
+		if err != nil {
+			worktree.Error = err
+		} else if filesChanged > 0 {
+			worktree.Workspace = types.Dirty
+			worktree.FilesChanged = filesChanged
+		}
+		status.Worktrees = append(status.Worktrees, worktree)
+	}
+}
+
+// MoveWorktree moves a linked worktree of the repository to newPath with
+// 'git worktree move'. The main worktree cannot be moved.
+func (g *Manager) MoveWorktree(repoPath, worktreePath, newPath string) error {
+	worktrees, err := g.ListWorktrees(repoPath)
+	if err != nil {
+		return err
+	}
+
+	index := slices.IndexFunc(worktrees, func(wt types.Worktree) bool {
+		return SamePath(wt.Path, worktreePath)
+	})
+	switch {
+	case index < 0:
+		return fmt.Errorf("'%s' is not a worktree of %s", worktreePath, repoPath)
+	case index == 0:
+		return fmt.Errorf("'%s' is the main worktree; only linked worktrees can be moved", worktreePath)
+	case worktrees[index].Prunable != "":
+		return fmt.Errorf("worktree '%s' cannot be moved: %s", worktreePath, worktrees[index].Prunable)
+	}
+	if _, err := os.Stat(newPath); err == nil {
+		return fmt.Errorf("path '%s' already exists", newPath)
+	}
+	if err := os.MkdirAll(filepath.Dir(newPath), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(newPath), err)
+	}
+
+	if _, err := g.RunCommand(repoPath, "worktree", "move", worktrees[index].Path, newPath); err != nil {
+		return fmt.Errorf("failed to move worktree: %w", err)
+	}
+	return nil
+}
+
+// RepairWorktrees runs 'git worktree repair' after the repository or its
+// worktrees were moved by hand. It reconnects the linked worktrees to the
+// repository; paths are the new locations of moved linked worktrees.
+func (g *Manager) RepairWorktrees(repoPath string, paths ...string) error {
+	if _, err := g.RunCommand(repoPath, append([]string{"worktree", "repair"}, paths...)...); err != nil {
+		return fmt.Errorf("failed to repair worktrees: %w", err)
+	}
+	return nil
+}
+
+// SamePath reports whether two paths name the same location, following
+// symlinks such as macOS's /tmp
+func SamePath(a, b string) bool {
+	if filepath.Clean(a) == filepath.Clean(b) {
+		return true
+	}
+	resolvedA, errA := filepath.EvalSymlinks(a)
+	resolvedB, errB := filepath.EvalSymlinks(b)
+	return errA == nil && errB == nil && resolvedA == resolvedB
+}
+
+// LockWorktree locks a linked worktree with 'git worktree lock' so that it
+// is neither pruned nor moved or removed, e.g. while it lives on a
+// removable drive. The reason is optional free text: only control
+// characters are refused, not the characters other arguments may not use.
+func (g *Manager) LockWorktree(repoPath, worktreePath, reason string) error {
+	if err := g.validatePath(repoPath); err != nil {
+		return fmt.Errorf("invalid repository path: %w", err)
+	}
+	args := []string{"worktree", "lock", worktreePath}
+	if err := g.validateGitArgs(args); err != nil {
+		return fmt.Errorf("invalid git arguments: %w", err)
+	}
+	if reason != "" {
+		if strings.IndexFunc(reason, unicode.IsControl) >= 0 {
+			return fmt.Errorf("lock reason must not contain control characters")
+		}
+		args = []string{"worktree", "lock", "--reason", reason, worktreePath}
+	}
+
+	if _, err := g.runValidated(repoPath, args); err != nil {
+		return fmt.Errorf("failed to lock worktree: %w", err)
+	}
+	return nil
+}
+
+// UnlockWorktree unlocks a locked worktree
+func (g *Manager) UnlockWorktree(repoPath, worktreePath string) error {
+	if _, err := g.RunCommand(repoPath, "worktree", "unlock", worktreePath); err != nil {
+		return fmt.Errorf("failed to unlock worktree: %w", err)
+	}
+	return nil
+}
+
+// FindWorktree returns the worktree of the repository at path
+func (g *Manager) FindWorktree(repoPath, path string) (types.Worktree, bool, error) {
+	worktrees, err := g.ListWorktrees(repoPath)
+	if err != nil {
+		return types.Worktree{}, false, err
+	}
+	for _, wt := range worktrees {
+		if SamePath(wt.Path, path) {
+			return wt, true, nil
+		}
+	}
+	return types.Worktree{}, false, nil
+}
+
+// worktreeLock reports whether the worktree at path is locked, and why
+func (g *Manager) worktreeLock(repoPath, path string) (bool, string) {
+	wt, found, err := g.FindWorktree(repoPath, path)
+	if err != nil || !found {
+		return false, ""
+	}
+	return wt.IsLocked, wt.LockReason
+}
+
+// MergedWorktrees returns the linked worktrees whose branch is fully merged
+// into mainBranch (detected like CleanMergedBranches when empty), together
+// with the main branch compared against. Branches without commits of their
+// own, such as ones just created, are not considered merged.
+func (g *Manager) MergedWorktrees(repoPath, mainBranch string) ([]types.Worktree, string, error) {
+	if mainBranch == "" {
+		var err error
+		if mainBranch, err = g.detectMainBranch(repoPath); err != nil {
+			return nil, "", fmt.Errorf("failed to detect main branch: %w", err)
+		}
+	}
+
+	output, err := g.RunCommand(repoPath, "branch", "--merged", mainBranch)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get merged branches: %w", err)
+	}
+	var merged []string
+	for _, line := range strings.Split(output, "\n") {
+		// "* " marks the current branch, "+ " branches checked out in worktrees
+		if len(line) > 2 {
+			merged = append(merged, strings.TrimSpace(line[2:]))
+		}
+	}
+
+	worktrees, err := g.ListWorktrees(repoPath)
+	if err != nil {
+		return nil, "", err
+	}
+	var result []types.Worktree
+	for i, wt := range worktrees {
+		if i == 0 || wt.IsBare || wt.IsDetached || wt.Prunable != "" || wt.Branch == "" || wt.Branch == mainBranch {
+			continue
+		}
+		if slices.Contains(merged, wt.Branch) && g.branchHasOwnCommits(repoPath, wt.Branch, mainBranch) {
+			result = append(result, wt)
+		}
+	}
+	return result, mainBranch, nil
+}
+
+// branchHasOwnCommits reports whether branch moved since it was created,
+// from its reflog. Without a reflog, a branch pointing at the tip of
+// mainBranch is taken to have none.
+func (g *Manager) branchHasOwnCommits(repoPath, branch, mainBranch string) bool {
+	ref := "refs/heads/" + branch
+	if _, err := g.RunCommand(repoPath, "rev-parse", "--verify", "--quiet", ref+"@{1}"); err == nil {
+		return true
+	}
+	if _, err := g.RunCommand(repoPath, "rev-parse", "--verify", "--quiet", ref+"@{0}"); err == nil {
+		// The reflog only has the creation of the branch
+		return false
+	}
+	tip, err := g.RunCommand(repoPath, "rev-parse", ref)
+	if err != nil {
+		return false
+	}
+	mainTip, err := g.RunCommand(repoPath, "rev-parse", "refs/heads/"+mainBranch)
+	return err == nil && tip != mainTip
+}
@@ -0,0 +1,245 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"gman/pkg/types"
+)
+
+func TestManager_PruneAndFindOrphanWorktrees(t *testing.T) {
+	dir := t.TempDir()
+	repoPath := filepath.Join(dir, "repo")
+	run := func(args ...string) {
+		t.Helper()
+		if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Skipf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+	run("init", "-b", "main", repoPath)
+	run("-C", repoPath, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--allow-empty", "-m", "initial")
+	for _, name := range []string{"gone", "deleted", "stale"} {
+		run("-C", repoPath, "worktree", "add", "-b", name, filepath.Join(dir, "repo-"+name))
+	}
+
+	// Directory deleted, branch deleted, admin files pruned
+	os.RemoveAll(filepath.Join(dir, "repo-gone"))
+	run("-C", repoPath, "update-ref", "-d", "refs/heads/deleted")
+	os.RemoveAll(filepath.Join(repoPath, ".git", "worktrees", "repo-stale"))
+
+	manager := NewManager()
+	pruned, err := manager.PruneWorktrees(repoPath, false)
+	if err != nil {
+		t.Fatalf("PruneWorktrees() error = %v", err)
+	}
+	if len(pruned) != 1 {
+		t.Errorf("Expected one pruned worktree, got %v", pruned)
+	}
+
+	orphans, err := manager.FindOrphanWorktrees(repoPath, []string{dir})
+	if err != nil {
+		t.Fatalf("FindOrphanWorktrees() error = %v", err)
+	}
+	if len(orphans) != 2 {
+		t.Fatalf("Expected 2 orphans, got %+v", orphans)
+	}
+	for _, orphan := range orphans {
+		switch filepath.Base(orphan.Path) {
+		case "repo-deleted":
+			if orphan.Unregistered || orphan.Branch != "deleted" {
+				t.Errorf("Unexpected orphan for deleted branch: %+v", orphan)
+			}
+		case "repo-stale":
+			if !orphan.Unregistered {
+				t.Errorf("Expected stale worktree to be unregistered: %+v", orphan)
+			}
+		default:
+			t.Errorf("Unexpected orphan %+v", orphan)
+		}
+	}
+}
+
+func TestManager_WorktreeStatus(t *testing.T) {
+	dir := t.TempDir()
+	repoPath := filepath.Join(dir, "repo")
+	run := func(args ...string) {
+		t.Helper()
+		if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Skipf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+	run("init", "-b", "main", repoPath)
+	run("-C", repoPath, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--allow-empty", "-m", "initial")
+	run("-C", repoPath, "worktree", "add", "-b", "feature", filepath.Join(dir, "repo-feature"))
+	run("-C", repoPath, "worktree", "add", "--detach", filepath.Join(dir, "repo-detached"))
+	os.WriteFile(filepath.Join(dir, "repo-feature", "new.txt"), []byte("new\n"), 0644)
+
+	manager := NewManager()
+	if status := manager.GetRepoStatusNoFetch("repo", repoPath); len(status.Worktrees) != 0 {
+		t.Errorf("Expected no worktrees without SetWorktreeStatus, got %+v", status.Worktrees)
+	}
+
+	manager.SetWorktreeStatus(true)
+	status := manager.GetRepoStatusNoFetch("repo", repoPath)
+	if len(status.Worktrees) != 2 {
+		t.Fatalf("Expected 2 worktrees, got %+v", status.Worktrees)
+	}
+	for _, wt := range status.Worktrees {
+		switch filepath.Base(wt.Path) {
+		case "repo-feature":
+			if wt.Branch != "feature" || wt.Workspace != types.Dirty || wt.FilesChanged != 1 {
+				t.Errorf("Unexpected status for feature worktree: %+v", wt)
+			}
+		case "repo-detached":
+			if !wt.IsDetached || wt.Workspace != types.Clean {
+				t.Errorf("Unexpected status for detached worktree: %+v", wt)
+			}
+		default:
+			t.Errorf("Unexpected worktree %+v", wt)
+		}
+	}
+}
+
+func TestManager_MoveWorktree(t *testing.T) {
+	dir := t.TempDir()
+	repoPath := filepath.Join(dir, "repo")
+	run := func(args ...string) {
+		t.Helper()
+		if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Skipf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+	run("init", "-b", "main", repoPath)
+	run("-C", repoPath, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--allow-empty", "-m", "initial")
+	oldPath := filepath.Join(dir, "repo-feature")
+	run("-C", repoPath, "worktree", "add", "-b", "feature", oldPath)
+
+	manager := NewManager()
+	if err := manager.MoveWorktree(repoPath, repoPath, filepath.Join(dir, "elsewhere")); err == nil {
+		t.Error("Expected moving the main worktree to fail")
+	}
+	if err := manager.MoveWorktree(repoPath, filepath.Join(dir, "missing"), filepath.Join(dir, "elsewhere")); err == nil {
+		t.Error("Expected moving an unknown worktree to fail")
+	}
+	if err := manager.MoveWorktree(repoPath, oldPath, repoPath); err == nil {
+		t.Error("Expected moving onto an existing path to fail")
+	}
+
+	newPath := filepath.Join(dir, "feature")
+	if err := manager.MoveWorktree(repoPath, oldPath, newPath); err != nil {
+		t.Fatalf("MoveWorktree() error = %v", err)
+	}
+	worktrees, err := manager.ListWorktrees(repoPath)
+	if err != nil {
+		t.Fatalf("ListWorktrees() error = %v", err)
+	}
+	if len(worktrees) != 2 || !SamePath(worktrees[1].Path, newPath) {
+		t.Errorf("Expected the worktree at %s, got %+v", newPath, worktrees)
+	}
+}
+
+func TestManager_AddWorktreeDefaultPath(t *testing.T) {
+	dir := t.TempDir()
+	repoPath := filepath.Join(dir, "repo")
+	if output, err := exec.Command("git", "init", "-b", "main", repoPath).CombinedOutput(); err != nil {
+		t.Skipf("git init failed: %v\n%s", err, output)
+	}
+	if output, err := exec.Command("git", "-C", repoPath, "-c", "user.name=test", "-c", "user.email=test@example.com",
+		"commit", "--allow-empty", "-m", "initial").CombinedOutput(); err != nil {
+		t.Skipf("git commit failed: %v\n%s", err, output)
+	}
+
+	manager := NewManager()
+	if err := manager.AddWorktree(repoPath, "", "feature/login"); err != nil {
+		t.Fatalf("AddWorktree() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "repo-feature-login", ".git")); err != nil {
+		t.Errorf("Expected the worktree next to the repository: %v", err)
+	}
+}
+
+func TestManager_LockWorktree(t *testing.T) {
+	dir := t.TempDir()
+	repoPath := filepath.Join(dir, "repo")
+	run := func(args ...string) {
+		t.Helper()
+		if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Skipf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+	run("init", "-b", "main", repoPath)
+	run("-C", repoPath, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--allow-empty", "-m", "initial")
+	wtPath := filepath.Join(dir, "repo-usb")
+	run("-C", repoPath, "worktree", "add", "-b", "usb", wtPath)
+
+	manager := NewManager()
+	if err := manager.LockWorktree(repoPath, wtPath, "bad\nreason"); err == nil {
+		t.Error("Expected a reason with a newline to be refused")
+	}
+	// Reasons are free text, unlike other git arguments
+	reason := "on USB drive (ext4); don't prune & keep $HOME"
+	if err := manager.LockWorktree(repoPath, wtPath, reason); err != nil {
+		t.Fatalf("LockWorktree() error = %v", err)
+	}
+	wt, found, err := manager.FindWorktree(repoPath, wtPath)
+	if err != nil || !found {
+		t.Fatalf("FindWorktree() = %v, %v", found, err)
+	}
+	if !wt.IsLocked || wt.LockReason != reason {
+		t.Errorf("Expected a locked worktree with its reason, got %+v", wt)
+	}
+
+	if err := manager.RemoveWorktree(repoPath, wtPath, false); err == nil {
+		t.Error("Expected removing a locked worktree without force to fail")
+	}
+	if err := manager.UnlockWorktree(repoPath, wtPath); err != nil {
+		t.Fatalf("UnlockWorktree() error = %v", err)
+	}
+	if wt, _, _ := manager.FindWorktree(repoPath, wtPath); wt.IsLocked {
+		t.Errorf("Expected the worktree to be unlocked, got %+v", wt)
+	}
+
+	// Forcing removes a locked worktree, as git does with --force twice
+	if err := manager.LockWorktree(repoPath, wtPath, ""); err != nil {
+		t.Fatalf("LockWorktree() error = %v", err)
+	}
+	if err := manager.RemoveWorktree(repoPath, wtPath, true); err != nil {
+		t.Errorf("RemoveWorktree(force) error = %v", err)
+	}
+}
+
+func TestManager_MergedWorktrees(t *testing.T) {
+	dir := t.TempDir()
+	repoPath := filepath.Join(dir, "repo")
+	run := func(args ...string) {
+		t.Helper()
+		if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Skipf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+	commit := func(path, message string) {
+		run("-C", path, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--allow-empty", "-m", message)
+	}
+	run("init", "-b", "main", repoPath)
+	commit(repoPath, "initial")
+	run("-C", repoPath, "worktree", "add", "-b", "done", filepath.Join(dir, "repo-done"))
+	run("-C", repoPath, "worktree", "add", "-b", "wip", filepath.Join(dir, "repo-wip"))
+	run("-C", repoPath, "worktree", "add", "-b", "fresh", filepath.Join(dir, "repo-fresh"), "main")
+	commit(filepath.Join(dir, "repo-done"), "finished")
+	commit(filepath.Join(dir, "repo-wip"), "in progress")
+	run("-C", repoPath, "merge", "--ff-only", "done")
+
+	manager := NewManager()
+	worktrees, mainBranch, err := manager.MergedWorktrees(repoPath, "")
+	if err != nil {
+		t.Fatalf("MergedWorktrees() error = %v", err)
+	}
+	if mainBranch != "main" {
+		t.Errorf("Expected main branch 'main', got %q", mainBranch)
+	}
+	if len(worktrees) != 1 || worktrees[0].Branch != "done" {
+		t.Errorf("Expected only the worktree of 'done', not the fresh one, got %+v", worktrees)
+	}
+}
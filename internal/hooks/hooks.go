@@ -0,0 +1,95 @@
+// Package hooks runs the user scripts configured for gman events.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Events gman fires hooks for
+const (
+	PostSwitch  = "post-switch"  // After 'gman switch' selected a target
+	PostSync    = "post-sync"    // After 'gman work sync' finished
+	RepoAdded   = "repo-added"   // After repositories were added
+	RepoRemoved = "repo-removed" // After a repository was removed
+	StatusError = "status-error" // When 'gman work status' failed to read repositories
+)
+
+// Events lists every event, in the order of the documentation
+var Events = []string{PostSwitch, PostSync, RepoAdded, RepoRemoved, StatusError}
+
+// Timeout limits how long each hook may run
+const Timeout = 30 * time.Second
+
+// Repository is a repository an event is about
+type Repository struct {
+	Alias  string `json:"alias"`
+	Path   string `json:"path"`
+	Status string `json:"status,omitempty"` // e.g. "synced" or "failed"
+	Error  string `json:"error,omitempty"`
+}
+
+// Payload describes an event; hooks read it as JSON on stdin
+type Payload struct {
+	Event        string       `json:"event"`
+	Time         time.Time    `json:"time"`
+	Repositories []Repository `json:"repositories"`
+}
+
+// Run runs the hook commands through the shell, one after the other. Each
+// gets the payload as JSON on stdin and in the environment GMAN_EVENT and,
+// for a single repository, GMAN_REPO and GMAN_REPO_PATH; it runs in that
+// repository. Hook output goes to stderr, as gman's stdout may be parsed.
+// A failing hook does not stop the others; their errors are joined.
+func Run(commands []string, payload Payload) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode hook payload: %w", err)
+	}
+
+	env := append(os.Environ(), "GMAN_EVENT="+payload.Event)
+	dir := ""
+	if len(payload.Repositories) == 1 {
+		repo := payload.Repositories[0]
+		env = append(env, "GMAN_REPO="+repo.Alias, "GMAN_REPO_PATH="+repo.Path)
+		if info, err := os.Stat(repo.Path); err == nil && info.IsDir() {
+			dir = repo.Path
+		}
+	}
+
+	var errs []error
+	for _, command := range commands {
+		if strings.TrimSpace(command) == "" {
+			continue
+		}
+		if err := run(command, dir, env, data); err != nil {
+			errs = append(errs, fmt.Errorf("%s hook '%s' failed: %w", payload.Event, command, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// run runs one hook command
+func run(command, dir string, env []string, payload []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = dir
+	cmd.Env = env
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	err := cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("timed out after %s", Timeout)
+	}
+	return err
+}
@@ -0,0 +1,54 @@
+package hooks
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRun(t *testing.T) {
+	repoPath := t.TempDir()
+	out := filepath.Join(t.TempDir(), "out")
+	payload := Payload{
+		Event:        PostSync,
+		Time:         time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		Repositories: []Repository{{Alias: "api", Path: repoPath, Status: "synced"}},
+	}
+
+	commands := []string{
+		`cat > "` + out + `.json"`,
+		`echo "$GMAN_EVENT $GMAN_REPO $GMAN_REPO_PATH $(pwd)" > "` + out + `.env"`,
+		"exit 3",
+		"  ",
+	}
+	err := Run(commands, payload)
+	if err == nil || !strings.Contains(err.Error(), "post-sync hook 'exit 3' failed") {
+		t.Errorf("Run() error = %v; want the failing hook reported", err)
+	}
+
+	data, err := os.ReadFile(out + ".json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Payload
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("hook stdin is not JSON: %v: %s", err, data)
+	}
+	if got.Event != PostSync || len(got.Repositories) != 1 || got.Repositories[0].Alias != "api" {
+		t.Errorf("payload = %+v", got)
+	}
+
+	env, err := os.ReadFile(out + ".env")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resolved, _ := filepath.EvalSymlinks(repoPath)
+	fields := strings.Fields(string(env))
+	if len(fields) != 4 || fields[0] != PostSync || fields[1] != "api" || fields[2] != repoPath ||
+		(fields[3] != repoPath && fields[3] != resolved) {
+		t.Errorf("hook environment = %q", env)
+	}
+}
@@ -0,0 +1,386 @@
+package index
+
+import (
+	"encoding/gob"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gman/internal/external"
+	"gman/internal/git"
+)
+
+// DirName is the directory inside the config directory holding index files
+const DirName = "index"
+
+// maxIndexedCommits caps the commit history indexed per repository
+const maxIndexedCommits = 5000
+
+// logFormat separates commit fields with the ASCII unit separator
+const logFormat = "--format=%H%x1f%an%x1f%aI%x1f%s"
+
+// CommitEntry is the indexed metadata of a single commit
+type CommitEntry struct {
+	Hash    string
+	Author  string
+	Date    time.Time
+	Subject string
+}
+
+// ShortHash returns the abbreviated commit hash
+func (c CommitEntry) ShortHash() string {
+	if len(c.Hash) > 7 {
+		return c.Hash[:7]
+	}
+	return c.Hash
+}
+
+// RepoIndex holds the indexed file paths and commit metadata of one repository
+type RepoIndex struct {
+	Alias     string
+	Path      string
+	Head      string    // HEAD commit the index was built from
+	Tips      []string  // commits of all refs the history was indexed from
+	GitIndex  time.Time // mtime of the git index when file paths were listed
+	Files     []string  // tracked file paths relative to the repository root
+	Commits   []CommitEntry
+	UpdatedAt time.Time
+}
+
+// UpdateStats describes what an incremental update changed
+type UpdateStats struct {
+	FilesRelisted bool
+	NewCommits    int
+	Rebuilt       bool // history was rewritten, commits re-indexed from scratch
+	Unchanged     bool
+}
+
+// Store persists repository indexes as gob files, one per alias
+type Store struct {
+	dir string
+}
+
+// NewStore creates a store rooted at dir
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// DefaultDir returns the index directory inside the given config directory
+func DefaultDir(configDir string) string {
+	return filepath.Join(configDir, DirName)
+}
+
+func (s *Store) path(alias string) string {
+	return filepath.Join(s.dir, alias+".gob")
+}
+
+// Exists reports whether an index exists for alias
+func (s *Store) Exists(alias string) bool {
+	_, err := os.Stat(s.path(alias))
+	return err == nil
+}
+
+// Load reads the index of alias
+func (s *Store) Load(alias string) (*RepoIndex, error) {
+	file, err := os.Open(s.path(alias))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var idx RepoIndex
+	if err := gob.NewDecoder(file).Decode(&idx); err != nil {
+		return nil, fmt.Errorf("corrupt index for '%s': %w", alias, err)
+	}
+	return &idx, nil
+}
+
+// Save writes an index atomically
+func (s *Store) Save(idx *RepoIndex) error {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("error creating index directory: %w", err)
+	}
+
+	finalPath := s.path(idx.Alias)
+	tempPath := finalPath + ".tmp"
+	file, err := os.Create(tempPath)
+	if err != nil {
+		return fmt.Errorf("error creating temp index file: %w", err)
+	}
+
+	if err := gob.NewEncoder(file).Encode(idx); err != nil {
+		file.Close()
+		os.Remove(tempPath)
+		return fmt.Errorf("error encoding index: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(tempPath)
+		return fmt.Errorf("error writing temp index file: %w", err)
+	}
+
+	if err := os.Rename(tempPath, finalPath); err != nil {
+		os.Remove(tempPath) // Clean up on failure
+		return fmt.Errorf("error moving temp index file: %w", err)
+	}
+	return nil
+}
+
+// Remove deletes the index of alias
+func (s *Store) Remove(alias string) error {
+	if err := os.Remove(s.path(alias)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Clear deletes all indexes
+func (s *Store) Clear() error {
+	return os.RemoveAll(s.dir)
+}
+
+// Update brings the index of a repository up to date, re-listing files only
+// when HEAD or the git index changed. Commits of all refs are indexed, like
+// the --all search without an index; only new commits are appended when the
+// previously indexed ref tips are still reachable from the current ones.
+func (s *Store) Update(gitMgr *git.Manager, alias, path string) (*RepoIndex, UpdateStats, error) {
+	var stats UpdateStats
+
+	idx, err := s.Load(alias)
+	if err != nil || idx.Path != path {
+		idx = &RepoIndex{Alias: alias, Path: path}
+	}
+
+	head, err := gitMgr.RunCommand(path, "rev-parse", "HEAD")
+	if err != nil {
+		return nil, stats, fmt.Errorf("failed to resolve HEAD for '%s': %w", alias, err)
+	}
+
+	tips, err := refTips(gitMgr, path)
+	if err != nil {
+		return nil, stats, fmt.Errorf("failed to list refs for '%s': %w", alias, err)
+	}
+
+	var gitIndexTime time.Time
+	if info, err := os.Stat(gitIndexPath(gitMgr, path)); err == nil {
+		gitIndexTime = info.ModTime()
+	}
+
+	tipsChanged := !slices.Equal(idx.Tips, tips)
+	if idx.Head == head && !tipsChanged && idx.GitIndex.Equal(gitIndexTime) {
+		stats.Unchanged = true
+		return idx, stats, nil
+	}
+
+	// File paths: cheap to re-list, and a diff would not be cheaper
+	if idx.Head != head || !idx.GitIndex.Equal(gitIndexTime) {
+		output, err := gitMgr.RunCommand(path, "ls-files")
+		if err != nil {
+			return nil, stats, fmt.Errorf("failed to list files for '%s': %w", alias, err)
+		}
+		idx.Files = splitLines(output)
+		idx.GitIndex = gitIndexTime
+		stats.FilesRelisted = true
+	}
+	idx.Head = head
+
+	// Commits: append incrementally when history only moved forward
+	if tipsChanged {
+		incremental := false
+		if len(idx.Tips) > 0 {
+			// Empty output means every old tip is reachable from the new ones
+			args := append(append([]string{"rev-list", "-n", "1"}, idx.Tips...), "--not")
+			behind, err := gitMgr.RunCommand(path, append(args, tips...)...)
+			incremental = err == nil && behind == ""
+		}
+
+		var newCommits []CommitEntry
+		if incremental {
+			newCommits, err = readCommits(gitMgr, path, append(append(tips, "--not"), idx.Tips...)...)
+			if err != nil {
+				return nil, stats, err
+			}
+			idx.Commits = append(newCommits, idx.Commits...)
+		} else {
+			newCommits, err = readCommits(gitMgr, path, tips...)
+			if err != nil {
+				return nil, stats, err
+			}
+			idx.Commits = newCommits
+			stats.Rebuilt = len(idx.Tips) > 0
+		}
+		if len(idx.Commits) > maxIndexedCommits {
+			idx.Commits = idx.Commits[:maxIndexedCommits]
+		}
+		stats.NewCommits = len(newCommits)
+		idx.Tips = tips
+	}
+
+	idx.UpdatedAt = time.Now()
+	if err := s.Save(idx); err != nil {
+		return nil, stats, err
+	}
+	return idx, stats, nil
+}
+
+// UpdateResult is the outcome of updating a single repository's index
+type UpdateResult struct {
+	Alias string
+	Index *RepoIndex
+	Stats UpdateStats
+	Error error
+}
+
+// UpdateAll updates the indexes of all given repositories concurrently
+func (s *Store) UpdateAll(gitMgr *git.Manager, repositories map[string]string, maxConcurrency int) []UpdateResult {
+	if maxConcurrency <= 0 {
+		maxConcurrency = 5
+	}
+
+	resultChan := make(chan UpdateResult, len(repositories))
+	semaphore := make(chan struct{}, maxConcurrency)
+	var wg sync.WaitGroup
+
+	for alias, path := range repositories {
+		wg.Add(1)
+		go func(alias, path string) {
+			defer wg.Done()
+			semaphore <- struct{}{}        // Acquire semaphore
+			defer func() { <-semaphore }() // Release semaphore
+
+			idx, stats, err := s.Update(gitMgr, alias, path)
+			resultChan <- UpdateResult{Alias: alias, Index: idx, Stats: stats, Error: err}
+		}(alias, path)
+	}
+
+	wg.Wait()
+	close(resultChan)
+
+	results := make([]UpdateResult, 0, len(repositories))
+	for result := range resultChan {
+		results = append(results, result)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Alias < results[j].Alias
+	})
+	return results
+}
+
+// SearchFiles matches file paths case-insensitively against pattern
+// (all files when pattern is empty)
+func SearchFiles(indexes []*RepoIndex, pattern string) []external.FileResult {
+	lowerPattern := strings.ToLower(pattern)
+	var results []external.FileResult
+
+	for _, idx := range indexes {
+		for _, file := range idx.Files {
+			if pattern != "" && !strings.Contains(strings.ToLower(file), lowerPattern) {
+				continue
+			}
+			results = append(results, external.FileResult{
+				RepoAlias:    idx.Alias,
+				RelativePath: file,
+				FullPath:     filepath.Join(idx.Path, file),
+				DisplayText:  fmt.Sprintf("%s:%s", idx.Alias, file),
+			})
+		}
+	}
+	return results
+}
+
+// CommitMatch is a commit search hit
+type CommitMatch struct {
+	RepoAlias string
+	RepoPath  string
+	Commit    CommitEntry
+}
+
+// SearchCommits matches commit subjects and authors case-insensitively
+// against pattern, returning at most limit matches per repository
+func SearchCommits(indexes []*RepoIndex, pattern string, limit int) []CommitMatch {
+	lowerPattern := strings.ToLower(pattern)
+	var matches []CommitMatch
+
+	for _, idx := range indexes {
+		count := 0
+		for _, commit := range idx.Commits {
+			if limit > 0 && count >= limit {
+				break
+			}
+			if pattern != "" &&
+				!strings.Contains(strings.ToLower(commit.Subject), lowerPattern) &&
+				!strings.Contains(strings.ToLower(commit.Author), lowerPattern) {
+				continue
+			}
+			matches = append(matches, CommitMatch{RepoAlias: idx.Alias, RepoPath: idx.Path, Commit: commit})
+			count++
+		}
+	}
+	return matches
+}
+
+// refTips returns the sorted, distinct commits HEAD and all refs point at:
+// the history 'git log --all' walks
+func refTips(gitMgr *git.Manager, path string) ([]string, error) {
+	output, err := gitMgr.RunCommand(path, "rev-list", "--no-walk", "--all")
+	if err != nil {
+		return nil, err
+	}
+
+	tips := splitLines(output)
+	sort.Strings(tips)
+	return tips, nil
+}
+
+// gitIndexPath returns the index file of the repository, which lives outside
+// path/.git for linked worktrees and separate git directories
+func gitIndexPath(gitMgr *git.Manager, path string) string {
+	indexPath, err := gitMgr.RunCommand(path, "rev-parse", "--git-path", "index")
+	if err != nil {
+		return filepath.Join(path, ".git", "index")
+	}
+	if !filepath.IsAbs(indexPath) {
+		indexPath = filepath.Join(path, indexPath)
+	}
+	return indexPath
+}
+
+// readCommits reads commit metadata for the given revisions, newest first
+func readCommits(gitMgr *git.Manager, path string, revisions ...string) ([]CommitEntry, error) {
+	args := append([]string{"log", logFormat, "-n", fmt.Sprintf("%d", maxIndexedCommits)}, revisions...)
+	output, err := gitMgr.RunCommand(path, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read commits: %w", err)
+	}
+
+	var commits []CommitEntry
+	for _, line := range splitLines(output) {
+		fields := strings.SplitN(line, "\x1f", 4)
+		if len(fields) < 4 {
+			continue
+		}
+		date, _ := time.Parse(time.RFC3339, fields[2])
+		commits = append(commits, CommitEntry{
+			Hash:    fields[0],
+			Author:  fields[1],
+			Date:    date,
+			Subject: fields[3],
+		})
+	}
+	return commits, nil
+}
+
+// splitLines splits command output into non-empty lines
+func splitLines(output string) []string {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
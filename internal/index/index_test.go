@@ -0,0 +1,155 @@
+package index
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"gman/internal/git"
+)
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, output)
+	}
+}
+
+func commitFile(t *testing.T, dir, name, message string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(message), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, dir, "add", name)
+	runGit(t, dir, "commit", "-m", message)
+}
+
+func setupRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	runGit(t, dir, "init")
+	runGit(t, dir, "config", "user.name", "Index Tester")
+	runGit(t, dir, "config", "user.email", "index@example.com")
+	commitFile(t, dir, "README.md", "initial commit")
+	return dir
+}
+
+func TestStoreUpdateIncremental(t *testing.T) {
+	repo := setupRepo(t)
+	store := NewStore(t.TempDir())
+	gitMgr := git.NewManager()
+
+	idx, stats, err := store.Update(gitMgr, "demo", repo)
+	if err != nil {
+		t.Fatalf("initial update failed: %v", err)
+	}
+	if len(idx.Files) != 1 || len(idx.Commits) != 1 || stats.Rebuilt {
+		t.Fatalf("unexpected initial index: files=%v commits=%d stats=%+v", idx.Files, len(idx.Commits), stats)
+	}
+
+	// Nothing changed
+	_, stats, err = store.Update(gitMgr, "demo", repo)
+	if err != nil || !stats.Unchanged {
+		t.Fatalf("expected unchanged index, got %+v (err %v)", stats, err)
+	}
+
+	// A new commit is appended without re-reading history
+	commitFile(t, repo, "config.yml", "add config loader")
+	idx, stats, err = store.Update(gitMgr, "demo", repo)
+	if err != nil {
+		t.Fatalf("incremental update failed: %v", err)
+	}
+	if stats.NewCommits != 1 || stats.Rebuilt || len(idx.Commits) != 2 || len(idx.Files) != 2 {
+		t.Fatalf("unexpected incremental result: commits=%d files=%d stats=%+v", len(idx.Commits), len(idx.Files), stats)
+	}
+	if idx.Commits[0].Subject != "add config loader" {
+		t.Errorf("newest commit should come first, got %q", idx.Commits[0].Subject)
+	}
+
+	// Rewriting history forces a rebuild
+	runGit(t, repo, "commit", "--amend", "-m", "add config parser")
+	idx, stats, err = store.Update(gitMgr, "demo", repo)
+	if err != nil {
+		t.Fatalf("update after amend failed: %v", err)
+	}
+	if !stats.Rebuilt || len(idx.Commits) != 2 || idx.Commits[0].Subject != "add config parser" {
+		t.Fatalf("expected rebuilt index, got commits=%v stats=%+v", idx.Commits, stats)
+	}
+
+	// The index survives a reload from disk
+	loaded, err := store.Load("demo")
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	if loaded.Head != idx.Head {
+		t.Errorf("loaded head %s, want %s", loaded.Head, idx.Head)
+	}
+}
+
+func TestStoreUpdateIndexesAllRefs(t *testing.T) {
+	repo := setupRepo(t)
+	store := NewStore(t.TempDir())
+	gitMgr := git.NewManager()
+
+	if _, _, err := store.Update(gitMgr, "demo", repo); err != nil {
+		t.Fatalf("initial update failed: %v", err)
+	}
+
+	// A commit on another branch is found like 'git log --all' finds it
+	runGit(t, repo, "checkout", "-b", "feature")
+	commitFile(t, repo, "feature.go", "add feature flag")
+	runGit(t, repo, "checkout", "-")
+	idx, stats, err := store.Update(gitMgr, "demo", repo)
+	if err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	if stats.NewCommits != 1 || stats.Rebuilt || len(idx.Commits) != 2 {
+		t.Fatalf("unexpected update: commits=%v stats=%+v", idx.Commits, stats)
+	}
+
+	// Deleting the branch drops its commit
+	runGit(t, repo, "branch", "-D", "feature")
+	idx, stats, err = store.Update(gitMgr, "demo", repo)
+	if err != nil {
+		t.Fatalf("update after branch deletion failed: %v", err)
+	}
+	if !stats.Rebuilt || len(idx.Commits) != 1 {
+		t.Fatalf("expected rebuilt index, got commits=%v stats=%+v", idx.Commits, stats)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	indexes := []*RepoIndex{
+		{
+			Alias: "api",
+			Path:  "/src/api",
+			Files: []string{"cmd/main.go", "config/Config.yml"},
+			Commits: []CommitEntry{
+				{Hash: "aaaaaaaaaa", Author: "Ada", Subject: "Fix config reload"},
+				{Hash: "bbbbbbbbbb", Author: "Bob", Subject: "Initial import"},
+			},
+		},
+	}
+
+	files := SearchFiles(indexes, "config")
+	if len(files) != 1 || files[0].FullPath != filepath.Join("/src/api", "config/Config.yml") {
+		t.Errorf("unexpected file matches: %+v", files)
+	}
+	if got := len(SearchFiles(indexes, "")); got != 2 {
+		t.Errorf("empty pattern should match all files, got %d", got)
+	}
+
+	commits := SearchCommits(indexes, "bob", 0)
+	if len(commits) != 1 || commits[0].Commit.ShortHash() != "bbbbbbb" {
+		t.Errorf("unexpected commit matches: %+v", commits)
+	}
+	if got := len(SearchCommits(indexes, "", 1)); got != 1 {
+		t.Errorf("limit not applied, got %d", got)
+	}
+}
@@ -0,0 +1,38 @@
+package interactive
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mattn/go-isatty"
+)
+
+// nonInteractive is set by the global --non-interactive flag
+var nonInteractive bool
+
+// SetNonInteractive forces non-interactive mode on or off
+func SetNonInteractive(enabled bool) {
+	nonInteractive = enabled
+}
+
+// NonInteractive reports whether prompts must be avoided: the flag or
+// GMAN_NON_INTERACTIVE=1 is set, or stdin is not a terminal (CI, pipes)
+func NonInteractive() bool {
+	if nonInteractive || os.Getenv("GMAN_NON_INTERACTIVE") == "1" {
+		return true
+	}
+	fd := os.Stdin.Fd()
+	return !isatty.IsTerminal(fd) && !isatty.IsCygwinTerminal(fd)
+}
+
+// ErrUnavailable is returned instead of prompting in non-interactive mode.
+// what describes the prompt, hint how to avoid it.
+func ErrUnavailable(what, hint string) error {
+	return fmt.Errorf("%s needs an interactive terminal (running non-interactively); %s", what, hint)
+}
+
+// NoteDefault tells the user which default a skipped prompt used. It prints
+// to stderr so machine-readable output on stdout stays clean.
+func NoteDefault(choice string) {
+	fmt.Fprintf(os.Stderr, "%s (non-interactive: using default)\n", choice)
+}
@@ -0,0 +1,29 @@
+package interactive
+
+import (
+	"os"
+	"testing"
+)
+
+func TestNonInteractive(t *testing.T) {
+	defer SetNonInteractive(false)
+
+	SetNonInteractive(true)
+	if !NonInteractive() {
+		t.Error("expected the flag to force non-interactive mode")
+	}
+
+	// A pipe is never a terminal
+	SetNonInteractive(false)
+	oldStdin := os.Stdin
+	r, w, _ := os.Pipe()
+	defer func() {
+		os.Stdin = oldStdin
+		r.Close()
+		w.Close()
+	}()
+	os.Stdin = r
+	if !NonInteractive() {
+		t.Error("expected piped stdin to be detected as non-interactive")
+	}
+}
@@ -66,44 +66,55 @@ func (rs *RepositorySelector) SelectRepository() (string, error) {
 	}
 
 	fmt.Println(strings.Repeat("─", 40))
-	fmt.Print("Enter number or alias (Ctrl+C to cancel): ")
 
-	// Read user input
+	// Ambiguous input narrows the candidates and asks again
 	reader := bufio.NewReader(os.Stdin)
-	input, err := reader.ReadString('\n')
-	if err != nil {
-		return "", fmt.Errorf("failed to read input: %w", err)
-	}
+	candidates := aliases
+	for {
+		fmt.Print("Enter number or alias (Ctrl+C to cancel): ")
+
+		input, err := reader.ReadString('\n')
+		if err != nil {
+			if len(candidates) < len(aliases) {
+				return "", fmt.Errorf("ambiguous selection, please be more specific")
+			}
+			return "", fmt.Errorf("failed to read input: %w", err)
+		}
 
-	selection := strings.TrimSpace(input)
-	if selection == "" {
-		return "", fmt.Errorf("no selection made")
-	}
+		selection := strings.TrimSpace(input)
+		if selection == "" {
+			return "", fmt.Errorf("no selection made")
+		}
 
-	// Try to parse as number first
-	if num, err := strconv.Atoi(selection); err == nil {
-		if num >= 1 && num <= len(aliases) {
-			return aliases[num-1], nil
+		// Try to parse as number first
+		if num, err := strconv.Atoi(selection); err == nil {
+			if num >= 1 && num <= len(candidates) {
+				return candidates[num-1], nil
+			}
+			return "", fmt.Errorf("invalid selection number: %d", num)
 		}
-		return "", fmt.Errorf("invalid selection number: %d", num)
-	}
 
-	// Try as alias
-	if _, exists := rs.repos[selection]; exists {
-		return selection, nil
-	}
+		// Try as alias
+		if _, exists := rs.repos[selection]; exists {
+			return selection, nil
+		}
 
-	// Try fuzzy matching
-	matches := rs.fuzzyMatch(selection, aliases)
-	if len(matches) == 1 {
-		fmt.Printf("Matched: %s\n", color.GreenString(matches[0]))
-		return matches[0], nil
-	} else if len(matches) > 1 {
-		fmt.Printf("Multiple matches found: %s\n", strings.Join(matches, ", "))
-		return "", fmt.Errorf("ambiguous selection, please be more specific")
-	}
+		// Try fuzzy matching
+		matches := rs.fuzzyMatch(selection, candidates)
+		if len(matches) == 1 {
+			fmt.Printf("Matched: %s\n", color.GreenString(matches[0]))
+			return matches[0], nil
+		} else if len(matches) > 1 {
+			fmt.Printf("Multiple matches found, narrowed to:\n")
+			for i, alias := range matches {
+				fmt.Printf("%s %s\n", color.YellowString("[%d]", i+1), color.GreenString(alias))
+			}
+			candidates = matches
+			continue
+		}
 
-	return "", fmt.Errorf("repository '%s' not found", selection)
+		return "", fmt.Errorf("repository '%s' not found", selection)
+	}
 }
 
 // fuzzyMatch performs simple fuzzy matching
@@ -123,6 +134,7 @@ func (rs *RepositorySelector) fuzzyMatch(input string, aliases []string) []strin
 // SwitchTargetSelector provides interactive selection for repositories and worktrees
 type SwitchTargetSelector struct {
 	targets []types.SwitchTarget
+	ranked  bool
 	mu      sync.RWMutex
 }
 
@@ -131,6 +143,12 @@ func NewSwitchTargetSelector(targets []types.SwitchTarget) *SwitchTargetSelector
 	return &SwitchTargetSelector{targets: targets}
 }
 
+// NewRankedSwitchTargetSelector creates a selector that keeps the order of
+// targets as given, e.g. when they are already ranked by frecency
+func NewRankedSwitchTargetSelector(targets []types.SwitchTarget) *SwitchTargetSelector {
+	return &SwitchTargetSelector{targets: targets, ranked: true}
+}
+
 // SelectTarget displays an interactive menu and returns the selected target
 func (sts *SwitchTargetSelector) SelectTarget() (*types.SwitchTarget, error) {
 	sts.mu.RLock()
@@ -145,7 +163,7 @@ func (sts *SwitchTargetSelector) SelectTarget() (*types.SwitchTarget, error) {
 	copy(sortedTargets, sts.targets)
 
 	// Simple sort: repositories first, then by alias
-	for i := 0; i < len(sortedTargets)-1; i++ {
+	for i := 0; !sts.ranked && i < len(sortedTargets)-1; i++ {
 		for j := i + 1; j < len(sortedTargets); j++ {
 			shouldSwap := false
 
@@ -167,9 +185,64 @@ func (sts *SwitchTargetSelector) SelectTarget() (*types.SwitchTarget, error) {
 
 	// Display the menu
 	fmt.Printf("\n%s\n", color.CyanString("Select a target:"))
+	printTargetMenu(sortedTargets)
+
+	// Ambiguous input narrows the menu to the matches and asks again, so the
+	// choice can be refined step by step instead of starting over
+	reader := bufio.NewReader(os.Stdin)
+	candidates := sortedTargets
+	for {
+		fmt.Print("Enter number or alias (Ctrl+C to cancel): ")
+
+		input, err := reader.ReadString('\n')
+		if err != nil {
+			if len(candidates) < len(sortedTargets) {
+				return nil, fmt.Errorf("ambiguous selection, please be more specific")
+			}
+			return nil, fmt.Errorf("failed to read input: %w", err)
+		}
+
+		selection := strings.TrimSpace(input)
+		if selection == "" {
+			return nil, fmt.Errorf("no selection made")
+		}
+
+		// Try to parse as number first
+		if num, err := strconv.Atoi(selection); err == nil {
+			if num >= 1 && num <= len(candidates) {
+				return &candidates[num-1], nil
+			}
+			return nil, fmt.Errorf("invalid selection number: %d", num)
+		}
+
+		// Try as exact alias match
+		for _, target := range candidates {
+			if target.Alias == selection {
+				return &target, nil
+			}
+		}
+
+		// Try fuzzy matching
+		matches := sts.fuzzyMatch(selection, candidates)
+		if len(matches) == 1 {
+			fmt.Printf("Matched: %s\n", color.GreenString(matches[0].Alias))
+			return &matches[0], nil
+		} else if len(matches) > 1 {
+			fmt.Printf("\n%s\n", color.CyanString("%d targets match '%s':", len(matches), selection))
+			printTargetMenu(matches)
+			candidates = matches
+			continue
+		}
+
+		return nil, fmt.Errorf("target '%s' not found", selection)
+	}
+}
+
+// printTargetMenu prints the numbered target list
+func printTargetMenu(targets []types.SwitchTarget) {
 	fmt.Println(strings.Repeat("─", 60))
 
-	for i, target := range sortedTargets {
+	for i, target := range targets {
 		var icon, typeLabel string
 		displayPath := target.Path
 
@@ -177,6 +250,9 @@ func (sts *SwitchTargetSelector) SelectTarget() (*types.SwitchTarget, error) {
 		if target.Type == "repository" {
 			icon = "📁"
 			typeLabel = color.BlueString("repo")
+		} else if target.Type == "bookmark" {
+			icon = "🔖"
+			typeLabel = color.CyanString("bookmark")
 		} else {
 			icon = "🌿"
 			typeLabel = color.MagentaString("worktree")
@@ -201,50 +277,6 @@ func (sts *SwitchTargetSelector) SelectTarget() (*types.SwitchTarget, error) {
 	}
 
 	fmt.Println(strings.Repeat("─", 60))
-	fmt.Print("Enter number or alias (Ctrl+C to cancel): ")
-
-	// Read user input
-	reader := bufio.NewReader(os.Stdin)
-	input, err := reader.ReadString('\n')
-	if err != nil {
-		return nil, fmt.Errorf("failed to read input: %w", err)
-	}
-
-	selection := strings.TrimSpace(input)
-	if selection == "" {
-		return nil, fmt.Errorf("no selection made")
-	}
-
-	// Try to parse as number first
-	if num, err := strconv.Atoi(selection); err == nil {
-		if num >= 1 && num <= len(sortedTargets) {
-			return &sortedTargets[num-1], nil
-		}
-		return nil, fmt.Errorf("invalid selection number: %d", num)
-	}
-
-	// Try as exact alias match
-	for _, target := range sortedTargets {
-		if target.Alias == selection {
-			return &target, nil
-		}
-	}
-
-	// Try fuzzy matching
-	matches := sts.fuzzyMatch(selection, sortedTargets)
-	if len(matches) == 1 {
-		fmt.Printf("Matched: %s\n", color.GreenString(matches[0].Alias))
-		return &matches[0], nil
-	} else if len(matches) > 1 {
-		var aliases []string
-		for _, match := range matches {
-			aliases = append(aliases, match.Alias)
-		}
-		fmt.Printf("Multiple matches found: %s\n", strings.Join(aliases, ", "))
-		return nil, fmt.Errorf("ambiguous selection, please be more specific")
-	}
-
-	return nil, fmt.Errorf("target '%s' not found", selection)
 }
 
 // fuzzyMatch performs simple fuzzy matching on switch targets
@@ -314,6 +314,14 @@ func TestSwitchTargetSelector_SelectTarget(t *testing.T) {
 			errorContains: "ambiguous selection",
 			description:   "Should fail with multiple matches (backend, backend-feature, backend-hotfix)",
 		},
+		{
+			name:          "ambiguous match narrows the menu",
+			input:         "back\n2\n", // Narrowed: backend(1), backend-feature(2), backend-hotfix(3)
+			expectError:   false,
+			expectedAlias: "backend-feature",
+			expectedType:  "worktree",
+			description:   "Should renumber the matches and select from them",
+		},
 		{
 			name:          "invalid numeric selection",
 			input:         "99\n",
@@ -0,0 +1,262 @@
+// Package logging configures gman's slog logger: diagnostics on stderr,
+// filtered by --debug, and an optional log file keeping every level.
+package logging
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// LogFileName is the name of the log file in the state directory
+	LogFileName = "gman.log"
+
+	// maxLogSize is the size at which the log file is rotated
+	maxLogSize = 1 << 20
+
+	// maxLogBackups is the number of rotated files kept (gman.log.1 ...)
+	maxLogBackups = 3
+)
+
+// logFile is the currently open log file, closed by Close
+var logFile *os.File
+
+// DefaultPath returns the log file location: $XDG_STATE_HOME/gman/gman.log,
+// falling back to ~/.local/state/gman/gman.log
+func DefaultPath() string {
+	stateDir := os.Getenv("XDG_STATE_HOME")
+	if stateDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return filepath.Join(os.TempDir(), "gman", LogFileName)
+		}
+		stateDir = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(stateDir, "gman", LogFileName)
+}
+
+// Setup installs the default slog logger. Messages at warning level and
+// above (debug and above when verbose) are printed to stderr; when logPath
+// is not empty every message is also appended to that file, which is
+// rotated once it grows past 1 MiB.
+func Setup(verbose bool, logPath string) error {
+	level := slog.LevelWarn
+	if verbose {
+		level = slog.LevelDebug
+	}
+
+	handlers := []slog.Handler{newConsoleHandler(os.Stderr, level)}
+
+	Close()
+	if logPath != "" {
+		file, err := openLogFile(logPath)
+		if err != nil {
+			slog.SetDefault(slog.New(handlers[0]))
+			return fmt.Errorf("failed to open log file: %w", err)
+		}
+		logFile = file
+		handlers = append(handlers, slog.NewJSONHandler(file, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	}
+
+	slog.SetDefault(slog.New(fanoutHandler(handlers)))
+	return nil
+}
+
+// Close closes the log file opened by Setup, if any
+func Close() {
+	if logFile != nil {
+		logFile.Close()
+		logFile = nil
+	}
+}
+
+// openLogFile opens path for appending, rotating it first when it is too large
+func openLogFile(path string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	if info, err := os.Stat(path); err == nil && info.Size() >= maxLogSize {
+		rotate(path)
+	}
+	return os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+}
+
+// rotate shifts gman.log to gman.log.1, gman.log.1 to gman.log.2 and so on,
+// dropping the oldest backup
+func rotate(path string) {
+	for i := maxLogBackups - 1; i >= 1; i-- {
+		os.Rename(fmt.Sprintf("%s.%d", path, i), fmt.Sprintf("%s.%d", path, i+1))
+	}
+	os.Rename(path, path+".1")
+}
+
+// consoleHandler prints records as short human-readable lines, e.g.
+// "Warning: failed to search repo=api error=..."
+type consoleHandler struct {
+	mu    *sync.Mutex
+	w     io.Writer
+	level slog.Level
+	attrs []slog.Attr
+}
+
+func newConsoleHandler(w io.Writer, level slog.Level) *consoleHandler {
+	return &consoleHandler{mu: &sync.Mutex{}, w: w, level: level}
+}
+
+func (h *consoleHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+func (h *consoleHandler) Handle(_ context.Context, record slog.Record) error {
+	var line strings.Builder
+	switch {
+	case record.Level >= slog.LevelError:
+		line.WriteString("Error: ")
+	case record.Level >= slog.LevelWarn:
+		line.WriteString("Warning: ")
+	case record.Level < slog.LevelInfo:
+		line.WriteString("debug: ")
+	}
+	line.WriteString(record.Message)
+
+	writeAttr := func(attr slog.Attr) bool {
+		fmt.Fprintf(&line, " %s=%v", attr.Key, attr.Value)
+		return true
+	}
+	for _, attr := range h.attrs {
+		writeAttr(attr)
+	}
+	record.Attrs(writeAttr)
+	line.WriteByte('\n')
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := io.WriteString(h.w, line.String())
+	return err
+}
+
+func (h *consoleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.attrs = append(append([]slog.Attr{}, h.attrs...), attrs...)
+	return &clone
+}
+
+// WithGroup is not needed by gman's messages; groups are flattened
+func (h *consoleHandler) WithGroup(string) slog.Handler {
+	return h
+}
+
+// fanoutHandler passes every record to all handlers that accept its level
+type fanoutHandler []slog.Handler
+
+func (f fanoutHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, handler := range f {
+		if handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f fanoutHandler) Handle(ctx context.Context, record slog.Record) error {
+	var firstErr error
+	for _, handler := range f {
+		if handler.Enabled(ctx, record.Level) {
+			if err := handler.Handle(ctx, record.Clone()); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+func (f fanoutHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make(fanoutHandler, len(f))
+	for i, handler := range f {
+		handlers[i] = handler.WithAttrs(attrs)
+	}
+	return handlers
+}
+
+func (f fanoutHandler) WithGroup(name string) slog.Handler {
+	handlers := make(fanoutHandler, len(f))
+	for i, handler := range f {
+		handlers[i] = handler.WithGroup(name)
+	}
+	return handlers
+}
+
+// Entry is one record of the log file
+type Entry struct {
+	Time    time.Time
+	Level   slog.Level
+	Message string
+	Attrs   map[string]any
+}
+
+// ReadEntries reads the log file, oldest entry first. Lines that are not
+// valid log records are skipped; a missing file yields no entries.
+func ReadEntries(path string) ([]Entry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+	defer file.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var fields map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &fields); err != nil {
+			continue
+		}
+
+		var entry Entry
+		if value, ok := fields[slog.TimeKey].(string); ok {
+			entry.Time, _ = time.Parse(time.RFC3339Nano, value)
+		}
+		if value, ok := fields[slog.LevelKey].(string); ok {
+			entry.Level.UnmarshalText([]byte(value))
+		}
+		entry.Message, _ = fields[slog.MessageKey].(string)
+		delete(fields, slog.TimeKey)
+		delete(fields, slog.LevelKey)
+		delete(fields, slog.MessageKey)
+		entry.Attrs = fields
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read log file: %w", err)
+	}
+	return entries, nil
+}
+
+// String renders the entry as one line, attributes sorted by key
+func (e Entry) String() string {
+	var line strings.Builder
+	fmt.Fprintf(&line, "%s %-5s %s", e.Time.Local().Format("2006-01-02 15:04:05"), e.Level, e.Message)
+
+	keys := make([]string, 0, len(e.Attrs))
+	for key := range e.Attrs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(&line, " %s=%v", key, e.Attrs[key])
+	}
+	return line.String()
+}
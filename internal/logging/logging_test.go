@@ -0,0 +1,77 @@
+package logging
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConsoleHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(newConsoleHandler(&buf, slog.LevelWarn))
+
+	logger.Info("hidden")
+	logger.Warn("failed to search", "repo", "api")
+	logger.With("repo", "web").Error("broken")
+
+	want := "Warning: failed to search repo=api\nError: broken repo=web\n"
+	if buf.String() != want {
+		t.Errorf("Console output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestSetupWritesAndReadsLogFile(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "state", LogFileName)
+	if err := Setup(false, logPath); err != nil {
+		t.Fatalf("Setup returned error: %v", err)
+	}
+	defer Close()
+
+	slog.Debug("git", "args", "status")
+	slog.Warn("repository path does not exist", "alias", "api")
+	Close()
+
+	entries, err := ReadEntries(logPath)
+	if err != nil {
+		t.Fatalf("ReadEntries returned error: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries (the file keeps debug messages), got %d", len(entries))
+	}
+	if entries[0].Level != slog.LevelDebug || entries[1].Level != slog.LevelWarn {
+		t.Errorf("Unexpected levels: %v, %v", entries[0].Level, entries[1].Level)
+	}
+	if !strings.HasSuffix(entries[1].String(), "WARN  repository path does not exist alias=api") {
+		t.Errorf("Unexpected entry rendering: %s", entries[1].String())
+	}
+}
+
+func TestRotate(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), LogFileName)
+	if err := os.WriteFile(logPath, bytes.Repeat([]byte("x"), maxLogSize), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := openLogFile(logPath)
+	if err != nil {
+		t.Fatalf("openLogFile returned error: %v", err)
+	}
+	file.Close()
+
+	if info, err := os.Stat(logPath); err != nil || info.Size() != 0 {
+		t.Errorf("Expected a fresh log file after rotation")
+	}
+	if _, err := os.Stat(logPath + ".1"); err != nil {
+		t.Errorf("Expected the old log to be kept as %s.1", LogFileName)
+	}
+}
+
+func TestReadEntriesMissingFile(t *testing.T) {
+	entries, err := ReadEntries(filepath.Join(t.TempDir(), "missing.log"))
+	if err != nil || len(entries) != 0 {
+		t.Errorf("Expected no entries and no error, got %d, %v", len(entries), err)
+	}
+}
@@ -0,0 +1,239 @@
+// Package notify reports repositories that failed to sync or fell far
+// behind their remote to Slack-compatible webhooks.
+package notify
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"gman/pkg/types"
+)
+
+// StateFile is the file inside the config directory remembering when each
+// problem was last reported
+const StateFile = "notifications.json"
+
+// Problem kinds
+const (
+	KindFailed = "failed"
+	KindBehind = "behind"
+)
+
+// Repository is a repository checked for problems
+type Repository struct {
+	Alias  string
+	Behind int    // Commits behind the remote
+	Error  string // Why syncing or reading the repository failed
+}
+
+// Problem is one reported problem of a repository
+type Problem struct {
+	Alias  string
+	Kind   string
+	Detail string
+}
+
+// Message is the Slack-compatible webhook payload
+type Message struct {
+	Text string `json:"text"`
+}
+
+// sentRecord is when a problem was last reported
+type sentRecord struct {
+	Alias string    `json:"alias"`
+	Time  time.Time `json:"time"`
+}
+
+// route is a webhook the problems of a repository go to
+type route struct {
+	url       string
+	threshold int
+}
+
+// Notifier sends the problems of repositories to the configured webhooks,
+// reporting each problem at most once per rate limit interval
+type Notifier struct {
+	settings  types.NotificationSettings
+	groups    map[string]types.Group
+	statePath string
+	client    *http.Client
+	now       func() time.Time
+}
+
+// New returns a notifier keeping its state in configDir
+func New(settings types.NotificationSettings, groups map[string]types.Group, configDir string) *Notifier {
+	return &Notifier{
+		settings:  settings,
+		groups:    groups,
+		statePath: filepath.Join(configDir, StateFile),
+		client:    &http.Client{Timeout: 10 * time.Second},
+		now:       time.Now,
+	}
+}
+
+// Notify reports the problems of repositories; source names what found
+// them, e.g. "sync". Problems reported within the rate limit are left out,
+// and problems that went away are forgotten, so that they are reported
+// again as soon as they return. It returns the number of problems sent.
+func (n *Notifier) Notify(source string, repositories []Repository) (int, error) {
+	interval, err := n.settings.Interval()
+	if err != nil {
+		return 0, err
+	}
+	sent := n.loadState()
+	now := n.now()
+
+	pending := make(map[string][]Problem)
+	active := make(map[string]bool)
+	checked := make(map[string]bool)
+	for _, repo := range repositories {
+		checked[repo.Alias] = true
+		for _, r := range n.routes(repo.Alias) {
+			for _, problem := range problems(repo, r.threshold) {
+				key := stateKey(r.url, problem)
+				active[key] = true
+				if last, exists := sent[key]; exists && now.Sub(last.Time) < interval {
+					continue
+				}
+				pending[r.url] = append(pending[r.url], problem)
+			}
+		}
+	}
+	for key, record := range sent {
+		if checked[record.Alias] && !active[key] {
+			delete(sent, key)
+		}
+	}
+
+	var count int
+	var errs []error
+	for _, url := range slices.Sorted(maps.Keys(pending)) {
+		if err := n.post(url, FormatMessage(source, pending[url])); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, problem := range pending[url] {
+			sent[stateKey(url, problem)] = sentRecord{Alias: problem.Alias, Time: now}
+		}
+		count += len(pending[url])
+	}
+	if err := n.saveState(sent); err != nil {
+		errs = append(errs, err)
+	}
+	return count, errors.Join(errs...)
+}
+
+// routes returns the webhooks for alias: those of the groups with
+// notification overrides containing it, otherwise the global one
+func (n *Notifier) routes(alias string) []route {
+	defaultThreshold := cmp.Or(n.settings.BehindThreshold, types.DefaultBehindThreshold)
+	var routes []route
+	grouped := false
+	for name, override := range n.settings.Groups {
+		if !slices.Contains(n.groups[name].Repositories, alias) {
+			continue
+		}
+		grouped = true
+		if url := cmp.Or(override.WebhookURL, n.settings.WebhookURL); url != "" {
+			routes = append(routes, route{url: url, threshold: cmp.Or(override.BehindThreshold, defaultThreshold)})
+		}
+	}
+	if !grouped && n.settings.WebhookURL != "" {
+		routes = append(routes, route{url: n.settings.WebhookURL, threshold: defaultThreshold})
+	}
+	return routes
+}
+
+// problems returns the problems of a repository; behind counts of at
+// least threshold are reported unless threshold is negative
+func problems(repo Repository, threshold int) []Problem {
+	var found []Problem
+	if repo.Error != "" {
+		// git's advice after the first line is too long for a chat message
+		detail, _, _ := strings.Cut(strings.TrimSpace(repo.Error), "\n")
+		found = append(found, Problem{Alias: repo.Alias, Kind: KindFailed, Detail: detail})
+	}
+	if threshold > 0 && repo.Behind >= threshold {
+		found = append(found, Problem{Alias: repo.Alias, Kind: KindBehind, Detail: fmt.Sprintf("%d commits behind the remote", repo.Behind)})
+	}
+	return found
+}
+
+// FormatMessage renders problems as a Slack message
+func FormatMessage(source string, problems []Problem) Message {
+	problems = slices.Clone(problems)
+	sort.Slice(problems, func(i, j int) bool {
+		if problems[i].Alias != problems[j].Alias {
+			return problems[i].Alias < problems[j].Alias
+		}
+		return problems[i].Kind < problems[j].Kind
+	})
+
+	aliases := make(map[string]bool)
+	var lines []string
+	for _, problem := range problems {
+		aliases[problem.Alias] = true
+		lines = append(lines, fmt.Sprintf("• *%s*: %s", problem.Alias, problem.Detail))
+	}
+	header := fmt.Sprintf("gman %s: %d repositories need attention", source, len(aliases))
+	if len(aliases) == 1 {
+		header = fmt.Sprintf("gman %s: 1 repository needs attention", source)
+	}
+	return Message{Text: header + "\n" + strings.Join(lines, "\n")}
+}
+
+// post sends message to a webhook
+func (n *Notifier) post(url string, message Message) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	resp, err := n.client.Post(url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to send notification: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to send notification: webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// stateKey identifies a problem reported to a webhook
+func stateKey(url string, problem Problem) string {
+	return strings.Join([]string{url, problem.Alias, problem.Kind}, " ")
+}
+
+// loadState reads when problems were last reported; a missing or broken
+// state means nothing was reported yet
+func (n *Notifier) loadState() map[string]sentRecord {
+	sent := make(map[string]sentRecord)
+	if data, err := os.ReadFile(n.statePath); err == nil {
+		_ = json.Unmarshal(data, &sent)
+	}
+	return sent
+}
+
+// saveState writes when problems were last reported. The state holds the
+// webhook URLs, which are secrets, so only the owner may read it.
+func (n *Notifier) saveState(sent map[string]sentRecord) error {
+	data, err := json.MarshalIndent(sent, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling notification state: %w", err)
+	}
+	if err := os.WriteFile(n.statePath, data, 0600); err != nil {
+		return fmt.Errorf("error writing notification state: %w", err)
+	}
+	return nil
+}
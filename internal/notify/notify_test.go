@@ -0,0 +1,103 @@
+package notify
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gman/pkg/types"
+)
+
+// webhook records the messages posted to it per path
+type webhook struct {
+	mu       sync.Mutex
+	messages map[string][]string
+}
+
+func newWebhook(t *testing.T) (*webhook, *httptest.Server) {
+	hook := &webhook{messages: make(map[string][]string)}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var message Message
+		if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+			t.Errorf("invalid payload: %v", err)
+		}
+		hook.mu.Lock()
+		hook.messages[r.URL.Path] = append(hook.messages[r.URL.Path], message.Text)
+		hook.mu.Unlock()
+	}))
+	t.Cleanup(server.Close)
+	return hook, server
+}
+
+func TestNotifierRoutesAndRateLimits(t *testing.T) {
+	hook, server := newWebhook(t)
+	settings := types.NotificationSettings{
+		WebhookURL: server.URL + "/all",
+		Groups: map[string]types.GroupNotification{
+			"backend": {WebhookURL: server.URL + "/backend", BehindThreshold: 10},
+		},
+	}
+	groups := map[string]types.Group{"backend": {Repositories: []string{"api"}}}
+	notifier := New(settings, groups, t.TempDir())
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	notifier.now = func() time.Time { return now }
+
+	repositories := []Repository{
+		{Alias: "api", Behind: 12},                  // Over the backend threshold
+		{Alias: "web", Behind: 12},                  // Under the default threshold
+		{Alias: "docs", Error: "fetch failed"},      // Global webhook
+		{Alias: "ops", Behind: 60, Error: "failed"}, // Both problems
+	}
+	count, err := notifier.Notify("sync", repositories)
+	if err != nil || count != 4 {
+		t.Fatalf("Notify() = %d, %v; want 4 problems", count, err)
+	}
+	if got := hook.messages["/backend"]; len(got) != 1 || !strings.Contains(got[0], "*api*: 12 commits behind") {
+		t.Errorf("backend messages = %q", got)
+	}
+	all := hook.messages["/all"]
+	if len(all) != 1 || !strings.Contains(all[0], "2 repositories need attention") ||
+		!strings.Contains(all[0], "*docs*: fetch failed") || strings.Contains(all[0], "web") {
+		t.Errorf("global messages = %q", all)
+	}
+
+	// Within the rate limit nothing is reported again
+	now = now.Add(30 * time.Minute)
+	if count, err := notifier.Notify("sync", repositories); err != nil || count != 0 {
+		t.Errorf("second Notify() = %d, %v; want nothing sent", count, err)
+	}
+
+	// A problem that went away is reported as soon as it returns
+	repositories[2].Error = ""
+	notifier.Notify("sync", repositories)
+	repositories[2].Error = "fetch failed again"
+	if count, err := notifier.Notify("daemon", repositories); err != nil || count != 1 {
+		t.Errorf("Notify() after recovery = %d, %v; want the returned problem", count, err)
+	}
+
+	// After the rate limit everything active is reported again
+	now = now.Add(2 * time.Hour)
+	if count, err := notifier.Notify("sync", repositories); err != nil || count != 4 {
+		t.Errorf("Notify() after the rate limit = %d, %v; want 4", count, err)
+	}
+}
+
+func TestNotifierFailedWebhookRetries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	notifier := New(types.NotificationSettings{WebhookURL: server.URL}, nil, t.TempDir())
+	repositories := []Repository{{Alias: "api", Error: "failed"}}
+	if _, err := notifier.Notify("sync", repositories); err == nil {
+		t.Fatal("Notify() error = nil; want the webhook failure")
+	}
+	if sent := notifier.loadState(); len(sent) != 0 {
+		t.Errorf("state = %v; unsent problems must not be rate limited", sent)
+	}
+}
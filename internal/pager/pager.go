@@ -0,0 +1,108 @@
+// Package pager pipes long command output through a pager, like git does.
+package pager
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+)
+
+// Annotation marks commands whose output is paged
+const Annotation = "gman-pager"
+
+// session is a running pager receiving stdout
+type session struct {
+	cmd         *exec.Cmd
+	pipe        *os.File
+	stdout      *os.File
+	colorOutput io.Writer
+}
+
+// active is the running pager, if any
+var active *session
+
+// Enable marks cmd so its output is paged when stdout is a terminal
+func Enable(cmd *cobra.Command) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
+	}
+	cmd.Annotations[Annotation] = "true"
+}
+
+// Enabled reports whether cmd asked for paging with Enable
+func Enabled(cmd *cobra.Command) bool {
+	return cmd.Annotations[Annotation] == "true"
+}
+
+// Command returns the pager to run: GMAN_PAGER, then PAGER, then less.
+// An empty result or "cat" disables paging.
+func Command() string {
+	if pager, ok := os.LookupEnv("GMAN_PAGER"); ok {
+		return strings.TrimSpace(pager)
+	}
+	if pager, ok := os.LookupEnv("PAGER"); ok {
+		return strings.TrimSpace(pager)
+	}
+	return "less"
+}
+
+// Start redirects stdout into the pager when stdout is a terminal. less is
+// run with -FRX unless LESS is set, so output that fits on the screen is
+// printed as usual. Failing to start the pager silently keeps stdout.
+func Start() {
+	if active != nil || !isatty.IsTerminal(os.Stdout.Fd()) {
+		return
+	}
+	command := Command()
+	if command == "" || command == "cat" {
+		return
+	}
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return
+	}
+
+	// PAGER may carry arguments, e.g. "less -S"
+	cmd := exec.Command("sh", "-c", command)
+	cmd.Stdin = reader
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+	if _, ok := os.LookupEnv("LESS"); !ok {
+		cmd.Env = append(cmd.Env, "LESS=FRX")
+	}
+	if _, ok := os.LookupEnv("LV"); !ok {
+		cmd.Env = append(cmd.Env, "LV=-c")
+	}
+
+	if err := cmd.Start(); err != nil {
+		reader.Close()
+		writer.Close()
+		return
+	}
+	reader.Close()
+
+	active = &session{cmd: cmd, pipe: writer, stdout: os.Stdout, colorOutput: color.Output}
+	os.Stdout = writer
+	color.Output = writer
+}
+
+// Stop hands the remaining output to the pager and waits until the user
+// quits it, then restores stdout
+func Stop() {
+	if active == nil {
+		return
+	}
+	active.pipe.Close()
+	active.cmd.Wait()
+
+	os.Stdout = active.stdout
+	color.Output = active.colorOutput
+	active = nil
+}
@@ -0,0 +1,40 @@
+package pager
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestCommand(t *testing.T) {
+	t.Setenv("PAGER", "more")
+	t.Setenv("GMAN_PAGER", "less -S")
+	if got := Command(); got != "less -S" {
+		t.Errorf("Expected GMAN_PAGER to win, got %q", got)
+	}
+
+	t.Setenv("GMAN_PAGER", "")
+	if got := Command(); got != "" {
+		t.Errorf("Expected an empty GMAN_PAGER to disable paging, got %q", got)
+	}
+}
+
+func TestEnable(t *testing.T) {
+	cmd := &cobra.Command{Use: "status"}
+	if Enabled(cmd) {
+		t.Error("Commands should not be paged unless enabled")
+	}
+	Enable(cmd)
+	if !Enabled(cmd) {
+		t.Error("Expected Enable to mark the command for paging")
+	}
+}
+
+func TestStartWithoutTerminal(t *testing.T) {
+	// Test output is not a terminal, so Start must leave stdout alone
+	Start()
+	defer Stop()
+	if active != nil {
+		t.Error("Pager should not start when stdout is not a terminal")
+	}
+}
@@ -0,0 +1,82 @@
+// Package plugin finds external gman plugins: executables named
+// gman-<name> on PATH, run as 'gman <name>' like git and kubectl plugins.
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Prefix is the file name prefix of plugin executables
+const Prefix = "gman-"
+
+// validName matches the plugin names gman dispatches to
+var validName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
+
+// Plugin is one plugin found on PATH
+type Plugin struct {
+	Name string `json:"name" yaml:"name"`
+	Path string `json:"path" yaml:"path"`
+	// Shadowed lists executables of the same name later on PATH, which
+	// never run
+	Shadowed []string `json:"shadowed,omitempty" yaml:"shadowed,omitempty" csv:"-"`
+}
+
+// Lookup returns the executable of plugin name on the search path
+// pathList (usually $PATH), or false when there is none
+func Lookup(name, pathList string) (string, bool) {
+	if !validName.MatchString(name) {
+		return "", false
+	}
+	for _, dir := range filepath.SplitList(pathList) {
+		if path := filepath.Join(dir, Prefix+name); isExecutable(path) {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// List returns the plugins on the search path pathList sorted by name. A
+// plugin is run from the first directory providing it.
+func List(pathList string) []Plugin {
+	byName := make(map[string]*Plugin)
+	for _, dir := range filepath.SplitList(pathList) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue // Missing PATH entries are common
+		}
+		for _, entry := range entries {
+			name, ok := strings.CutPrefix(entry.Name(), Prefix)
+			if !ok || !validName.MatchString(name) {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			if !isExecutable(path) {
+				continue
+			}
+			if plugin, exists := byName[name]; exists {
+				if plugin.Path != path {
+					plugin.Shadowed = append(plugin.Shadowed, path)
+				}
+				continue
+			}
+			byName[name] = &Plugin{Name: name, Path: path}
+		}
+	}
+
+	plugins := make([]Plugin, 0, len(byName))
+	for _, plugin := range byName {
+		plugins = append(plugins, *plugin)
+	}
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Name < plugins[j].Name })
+	return plugins
+}
+
+// isExecutable reports whether path is a regular file anyone may execute
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular() && info.Mode().Perm()&0111 != 0
+}
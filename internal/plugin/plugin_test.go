@@ -0,0 +1,45 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, path string, mode os.FileMode) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"), mode); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLookupAndList(t *testing.T) {
+	first, second := t.TempDir(), t.TempDir()
+	writeFile(t, filepath.Join(first, "gman-deploy"), 0755)
+	writeFile(t, filepath.Join(first, "gman-notes"), 0644) // Not executable
+	writeFile(t, filepath.Join(second, "gman-deploy"), 0755)
+	writeFile(t, filepath.Join(second, "gman-lint"), 0755)
+	writeFile(t, filepath.Join(second, "other-tool"), 0755)
+	pathList := strings.Join([]string{first, filepath.Join(first, "missing"), second}, string(os.PathListSeparator))
+
+	if path, ok := Lookup("deploy", pathList); !ok || path != filepath.Join(first, "gman-deploy") {
+		t.Errorf("Lookup(deploy) = %q, %v; want the first on the path", path, ok)
+	}
+	for _, name := range []string{"notes", "missing", "../gman-lint", ""} {
+		if path, ok := Lookup(name, pathList); ok {
+			t.Errorf("Lookup(%q) = %q; want no plugin", name, path)
+		}
+	}
+
+	plugins := List(pathList)
+	if len(plugins) != 2 {
+		t.Fatalf("List() = %+v; want deploy and lint", plugins)
+	}
+	if plugins[0].Name != "deploy" || len(plugins[0].Shadowed) != 1 || plugins[0].Shadowed[0] != filepath.Join(second, "gman-deploy") {
+		t.Errorf("deploy = %+v; want it shadowing the second gman-deploy", plugins[0])
+	}
+	if plugins[1].Name != "lint" || plugins[1].Path != filepath.Join(second, "gman-lint") {
+		t.Errorf("lint = %+v", plugins[1])
+	}
+}
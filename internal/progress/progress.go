@@ -9,6 +9,15 @@ import (
 	"github.com/fatih/color"
 )
 
+// animationsEnabled controls live redrawing; when disabled, progress is only
+// reported once on Finish (accessible mode, dumb terminals)
+var animationsEnabled = true
+
+// SetAnimations enables or disables live progress redrawing
+func SetAnimations(enabled bool) {
+	animationsEnabled = enabled
+}
+
 // Bar represents a progress bar
 type Bar struct {
 	total     int
@@ -51,13 +60,16 @@ func (b *Bar) Finish() {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 	b.current = b.total
+	if !animationsEnabled {
+		fmt.Printf("%s %d/%d done", b.prefix, b.current, b.total)
+	}
 	b.render()
 	fmt.Println() // Add newline after completion
 }
 
 // render displays the progress bar
 func (b *Bar) render() {
-	if b.total == 0 {
+	if b.total == 0 || !animationsEnabled {
 		return
 	}
 
@@ -167,19 +179,27 @@ func (mb *MultiBar) Finish() {
 	mb.mu.Lock()
 	defer mb.mu.Unlock()
 	mb.active = false
+	if !animationsEnabled {
+		mb.renderSummary()
+	}
 	mb.render()
 	fmt.Println() // Add final newline
 }
 
 // render displays the current status of all operations
 func (mb *MultiBar) render() {
-	if !mb.active {
+	if !mb.active || !animationsEnabled {
 		return
 	}
 
 	// Clear previous lines
 	fmt.Print("\r\033[K") // Clear current line
 
+	mb.renderSummary()
+}
+
+// renderSummary prints the one-line progress summary at the cursor position
+func (mb *MultiBar) renderSummary() {
 	completed := 0
 	failed := 0
 	running := 0
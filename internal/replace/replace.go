@@ -0,0 +1,176 @@
+package replace
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gman/internal/git"
+)
+
+// diffContext is the number of unchanged lines shown around each change
+const diffContext = 3
+
+// binarySniffLen is how much of a file is checked for NUL bytes
+const binarySniffLen = 8000
+
+// FileChange is the planned replacement in a single file
+type FileChange struct {
+	RelPath      string
+	FullPath     string
+	Replacements int
+	Diff         string // unified diff of the change
+
+	mode       os.FileMode
+	newContent []byte
+}
+
+// Plan computes the replacements in the tracked files of a repository.
+// The pattern is applied line by line, so matches never span lines.
+// skip reports files to leave alone (exclusions), and may be nil.
+func Plan(gitMgr *git.Manager, repoPath string, pattern *regexp.Regexp, replacement string, skip func(relPath string) bool) ([]FileChange, error) {
+	// -z keeps non-ASCII and special paths unquoted
+	output, err := gitMgr.RunCommand(repoPath, "ls-files", "-z")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tracked files: %w", err)
+	}
+
+	var changes []FileChange
+	for _, relPath := range strings.Split(output, "\x00") {
+		if relPath == "" || (skip != nil && skip(relPath)) {
+			continue
+		}
+
+		change, err := planFile(repoPath, relPath, pattern, replacement)
+		if err != nil {
+			return nil, err
+		}
+		if change != nil {
+			changes = append(changes, *change)
+		}
+	}
+	return changes, nil
+}
+
+// planFile computes the replacement for one file; nil means nothing to change
+func planFile(repoPath, relPath string, pattern *regexp.Regexp, replacement string) (*FileChange, error) {
+	fullPath := filepath.Join(repoPath, relPath)
+	info, err := os.Lstat(fullPath)
+	if err != nil || !info.Mode().IsRegular() {
+		// Deleted in the working tree, symlink or special file
+		return nil, nil
+	}
+
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", relPath, err)
+	}
+	if isBinary(content) {
+		return nil, nil
+	}
+
+	lines := strings.Split(string(content), "\n")
+	newLines := make([]string, len(lines))
+	var changed []int
+	replacements := 0
+
+	for i, line := range lines {
+		matches := pattern.FindAllStringIndex(line, -1)
+		// The element after a trailing newline is not a line of the file
+		if len(matches) == 0 || (i == len(lines)-1 && line == "") {
+			newLines[i] = line
+			continue
+		}
+		newLines[i] = pattern.ReplaceAllString(line, replacement)
+		if newLines[i] != line {
+			changed = append(changed, i)
+			replacements += len(matches)
+		}
+	}
+
+	if len(changed) == 0 {
+		return nil, nil
+	}
+
+	return &FileChange{
+		RelPath:      relPath,
+		FullPath:     fullPath,
+		Replacements: replacements,
+		Diff:         unifiedDiff(relPath, lines, newLines, changed),
+		mode:         info.Mode().Perm(),
+		newContent:   []byte(strings.Join(newLines, "\n")),
+	}, nil
+}
+
+// Apply writes the replaced content back to the file
+func (c FileChange) Apply() error {
+	if err := os.WriteFile(c.FullPath, c.newContent, c.mode); err != nil {
+		return fmt.Errorf("failed to write %s: %w", c.RelPath, err)
+	}
+	return nil
+}
+
+// isBinary reports whether content looks binary (contains a NUL byte early on)
+func isBinary(content []byte) bool {
+	sniff := content
+	if len(sniff) > binarySniffLen {
+		sniff = sniff[:binarySniffLen]
+	}
+	return bytes.IndexByte(sniff, 0) >= 0
+}
+
+// unifiedDiff renders a unified diff for line-wise replacements. Old and new
+// have the same number of entries; a new entry may hold several lines when
+// the replacement inserted newlines.
+func unifiedDiff(relPath string, oldLines, newLines []string, changed []int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- a/%s\n+++ b/%s\n", relPath, relPath)
+
+	// The final element after a trailing newline is not a real line
+	total := len(oldLines)
+	if total > 0 && oldLines[total-1] == "" {
+		total--
+	}
+
+	// offset tracks how many lines earlier replacements added to the new file
+	offset := 0
+	for start := 0; start < len(changed); {
+		// Merge changes whose context windows touch into one hunk
+		end := start
+		for end+1 < len(changed) && changed[end+1]-changed[end] <= 2*diffContext {
+			end++
+		}
+
+		first := max(changed[start]-diffContext, 0)
+		last := min(changed[end]+diffContext, total-1)
+
+		var body strings.Builder
+		oldCount, newCount := 0, 0
+		next := start
+		for i := first; i <= last; i++ {
+			if next <= end && changed[next] == i {
+				fmt.Fprintf(&body, "-%s\n", oldLines[i])
+				for _, line := range strings.Split(newLines[i], "\n") {
+					fmt.Fprintf(&body, "+%s\n", line)
+					newCount++
+				}
+				oldCount++
+				next++
+				continue
+			}
+			fmt.Fprintf(&body, " %s\n", oldLines[i])
+			oldCount++
+			newCount++
+		}
+
+		fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@\n", first+1, oldCount, first+1+offset, newCount)
+		b.WriteString(body.String())
+
+		offset += newCount - oldCount
+		start = end + 1
+	}
+	return b.String()
+}
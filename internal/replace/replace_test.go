@@ -0,0 +1,104 @@
+package replace
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"gman/internal/git"
+)
+
+func TestPlanFile(t *testing.T) {
+	dir := t.TempDir()
+	content := "alpha\nbeta\ngamma\ndelta\nepsilon\nzeta\neta\ntheta\niota\nkappa beta\n"
+	if err := os.WriteFile(filepath.Join(dir, "words.txt"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	change, err := planFile(dir, "words.txt", regexp.MustCompile(`beta`), "BETA")
+	if err != nil {
+		t.Fatalf("planFile failed: %v", err)
+	}
+	if change == nil {
+		t.Fatal("expected a change")
+	}
+	if change.Replacements != 2 {
+		t.Errorf("Replacements = %d, want 2", change.Replacements)
+	}
+
+	wantDiff := strings.Join([]string{
+		"--- a/words.txt",
+		"+++ b/words.txt",
+		"@@ -1,5 +1,5 @@",
+		" alpha",
+		"-beta",
+		"+BETA",
+		" gamma",
+		" delta",
+		" epsilon",
+		"@@ -7,4 +7,4 @@",
+		" eta",
+		" theta",
+		" iota",
+		"-kappa beta",
+		"+kappa BETA",
+		"",
+	}, "\n")
+	if change.Diff != wantDiff {
+		t.Errorf("unexpected diff:\n%s\nwant:\n%s", change.Diff, wantDiff)
+	}
+
+	if err := change.Apply(); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	written, _ := os.ReadFile(filepath.Join(dir, "words.txt"))
+	if string(written) != strings.ReplaceAll(content, "beta", "BETA") {
+		t.Errorf("unexpected file content after apply:\n%s", written)
+	}
+}
+
+func TestPlanFileSkipsBinaryAndUnchanged(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "blob.bin"), []byte("beta\x00beta"), 0644)
+	os.WriteFile(filepath.Join(dir, "other.txt"), []byte("alpha\n"), 0644)
+
+	for _, name := range []string{"blob.bin", "other.txt"} {
+		change, err := planFile(dir, name, regexp.MustCompile(`beta`), "BETA")
+		if err != nil || change != nil {
+			t.Errorf("%s: expected no change, got %+v (err %v)", name, change, err)
+		}
+	}
+}
+
+func TestPlanFindsPathsGitWouldQuote(t *testing.T) {
+	dir := t.TempDir()
+	names := []string{"café.txt", "with space.txt", "tab\there.txt"}
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("beta\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, args := range [][]string{{"init", "-q"}, {"add", "."}} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Skipf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+
+	changes, err := Plan(git.NewManager(), dir, regexp.MustCompile(`beta`), "BETA", nil)
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	if len(changes) != len(names) {
+		t.Fatalf("Plan found %d files, want %d: %+v", len(changes), len(names), changes)
+	}
+	for _, change := range changes {
+		if _, err := os.Stat(change.FullPath); err != nil {
+			t.Errorf("planned path %q does not exist: %v", change.RelPath, err)
+		}
+	}
+}
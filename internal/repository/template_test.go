@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCopyTemplate(t *testing.T) {
+	src := t.TempDir()
+	files := map[string]string{
+		"README.md":        "# Template\n",
+		"cmd/main.go":      "package main\n",
+		".git/HEAD":        "ref: refs/heads/main\n",
+		"scripts/build.sh": "#!/bin/sh\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(src, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Chmod(filepath.Join(src, "scripts/build.sh"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("README.md", filepath.Join(src, "README")); err != nil {
+		t.Fatal(err)
+	}
+
+	dst := filepath.Join(t.TempDir(), "project")
+	if err := CopyTemplate(src, dst); err != nil {
+		t.Fatalf("CopyTemplate() error = %v", err)
+	}
+
+	if data, err := os.ReadFile(filepath.Join(dst, "cmd/main.go")); err != nil || string(data) != "package main\n" {
+		t.Errorf("cmd/main.go = %q, %v", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(dst, ".git")); !os.IsNotExist(err) {
+		t.Errorf("template .git was copied")
+	}
+	if info, err := os.Stat(filepath.Join(dst, "scripts/build.sh")); err != nil || info.Mode().Perm() != 0755 {
+		t.Errorf("scripts/build.sh mode = %v, %v; want 0755", info, err)
+	}
+	if link, err := os.Readlink(filepath.Join(dst, "README")); err != nil || link != "README.md" {
+		t.Errorf("README link = %q, %v", link, err)
+	}
+
+	if err := CopyTemplate(filepath.Join(src, "README.md"), t.TempDir()); err == nil {
+		t.Error("CopyTemplate() of a file succeeded")
+	}
+}
+
+func TestCopyTemplateRejectsDestinationInsideTemplate(t *testing.T) {
+	src := t.TempDir()
+	if err := os.WriteFile(filepath.Join(src, "README.md"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, dst := range []string{src, filepath.Join(src, "project"), filepath.Join(src, "nested", "project")} {
+		if err := CopyTemplate(src, dst); err == nil {
+			t.Errorf("CopyTemplate(%s, %s) copied the template into itself", src, dst)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(src, "project")); !os.IsNotExist(err) {
+		t.Errorf("the rejected copy created the destination: %v", err)
+	}
+}
@@ -0,0 +1,230 @@
+// Package snapshot records the branch, commit and uncommitted changes of a
+// set of repositories under a name, and returns the repositories to that
+// state later.
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// StoreFile is the file name of the snapshot store inside the config directory
+const StoreFile = "snapshots.json"
+
+// Runner runs git commands; *git.Manager implements it
+type Runner interface {
+	RunCommand(path string, args ...string) (string, error)
+}
+
+// Repository is the recorded state of one repository
+type Repository struct {
+	Alias  string `json:"alias"`
+	Path   string `json:"path"`
+	Head   string `json:"head"`
+	Branch string `json:"branch"` // "HEAD" when detached
+	// Stash is the stash commit holding the uncommitted changes, if any
+	Stash string `json:"stash,omitempty"`
+}
+
+// Snapshot is the recorded state of a set of repositories
+type Snapshot struct {
+	Name         string       `json:"name"`
+	Created      time.Time    `json:"created"`
+	Group        string       `json:"group,omitempty"`
+	Repositories []Repository `json:"repositories"`
+}
+
+// Store is the list of saved snapshots, oldest first
+type Store struct {
+	Snapshots []Snapshot `json:"snapshots"`
+}
+
+// StorePath returns the store location inside the given config directory
+func StorePath(configDir string) string {
+	return filepath.Join(configDir, StoreFile)
+}
+
+// Load reads the store at path; a missing store is empty
+func Load(path string) (*Store, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Store{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var s Store
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("invalid snapshot store '%s': %w", path, err)
+	}
+	return &s, nil
+}
+
+// Save writes the store to path atomically
+func (s *Store) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creating snapshot directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling snapshots: %w", err)
+	}
+
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+		return fmt.Errorf("error writing temp snapshot file: %w", err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		os.Remove(tempPath) // Clean up on failure
+		return fmt.Errorf("error moving temp snapshot file: %w", err)
+	}
+	return nil
+}
+
+// Get returns the snapshot with the given name
+func (s *Store) Get(name string) (*Snapshot, bool) {
+	i := slices.IndexFunc(s.Snapshots, func(snap Snapshot) bool { return snap.Name == name })
+	if i < 0 {
+		return nil, false
+	}
+	return &s.Snapshots[i], true
+}
+
+// Put adds a snapshot, replacing one with the same name
+func (s *Store) Put(snap Snapshot) {
+	s.Delete(snap.Name)
+	s.Snapshots = append(s.Snapshots, snap)
+}
+
+// Delete removes the snapshot with the given name and reports whether it
+// existed
+func (s *Store) Delete(name string) bool {
+	n := len(s.Snapshots)
+	s.Snapshots = slices.DeleteFunc(s.Snapshots, func(snap Snapshot) bool { return snap.Name == name })
+	return len(s.Snapshots) != n
+}
+
+// StashMessage is the message of the stash holding a repository's changes,
+// which marks it as belonging to the snapshot in 'git stash list'
+func StashMessage(name string) string {
+	return "gman snapshot: " + name
+}
+
+// Capture records the state of the repository at path. Uncommitted changes,
+// untracked files included, are stashed so that the repository is clean
+// afterwards.
+func Capture(runner Runner, name, alias, path string) (Repository, error) {
+	head, err := runner.RunCommand(path, "rev-parse", "HEAD")
+	if err != nil {
+		return Repository{}, fmt.Errorf("failed to read HEAD: %w", err)
+	}
+	branch, err := runner.RunCommand(path, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return Repository{}, fmt.Errorf("failed to read current branch: %w", err)
+	}
+	repo := Repository{Alias: alias, Path: path, Head: head, Branch: branch}
+
+	status, err := runner.RunCommand(path, "status", "--porcelain")
+	if err != nil {
+		return Repository{}, fmt.Errorf("failed to check workspace: %w", err)
+	}
+	if status == "" {
+		return repo, nil
+	}
+	if _, err := runner.RunCommand(path, "stash", "push", "--include-untracked", "-m", StashMessage(name)); err != nil {
+		return Repository{}, fmt.Errorf("failed to stash changes: %w", err)
+	}
+	if repo.Stash, err = runner.RunCommand(path, "rev-parse", "--verify", "refs/stash"); err != nil {
+		return Repository{}, fmt.Errorf("failed to read stash: %w", err)
+	}
+	return repo, nil
+}
+
+// Restore returns the repository to its recorded state: it checks out the
+// recorded branch, or commit when detached, and applies the stashed changes.
+// The stash entry is kept so that a snapshot can be restored again.
+//
+// A branch that moved since the snapshot is checked out as it is now rather
+// than reset, so no commits are lost; the returned note says so.
+func Restore(runner Runner, repo Repository) (string, error) {
+	status, err := runner.RunCommand(repo.Path, "status", "--porcelain")
+	if err != nil {
+		return "", fmt.Errorf("failed to check workspace: %w", err)
+	}
+	if status != "" {
+		return "", fmt.Errorf("%s has uncommitted changes", repo.Alias)
+	}
+
+	var stashRef string
+	if repo.Stash != "" {
+		if stashRef, err = findStash(runner, repo.Path, repo.Stash); err != nil {
+			return "", err
+		}
+	}
+
+	target := repo.Branch
+	if target == "HEAD" {
+		target = repo.Head
+	}
+	if _, err := runner.RunCommand(repo.Path, "checkout", target); err != nil {
+		return "", fmt.Errorf("failed to check out %s: %w", target, err)
+	}
+
+	var note string
+	if head, err := runner.RunCommand(repo.Path, "rev-parse", "HEAD"); err == nil && head != repo.Head {
+		note = fmt.Sprintf("%s moved since the snapshot (%s, now %s)", repo.Branch, short(repo.Head), short(head))
+	}
+
+	if stashRef != "" {
+		if _, err := runner.RunCommand(repo.Path, "stash", "apply", stashRef); err != nil {
+			return note, fmt.Errorf("failed to apply stashed changes: %w", err)
+		}
+	}
+	return note, nil
+}
+
+// Drop removes the stash entry holding the repository's changes. A stash
+// that is already gone is not an error.
+func Drop(runner Runner, repo Repository) error {
+	if repo.Stash == "" {
+		return nil
+	}
+	ref, err := findStash(runner, repo.Path, repo.Stash)
+	if err != nil {
+		return nil
+	}
+	if _, err := runner.RunCommand(repo.Path, "stash", "drop", ref); err != nil {
+		return fmt.Errorf("failed to drop stash: %w", err)
+	}
+	return nil
+}
+
+// findStash returns the stash@{n} reference of the stash commit sha
+func findStash(runner Runner, path, sha string) (string, error) {
+	output, err := runner.RunCommand(path, "stash", "list", "--format=%H")
+	if err != nil {
+		return "", fmt.Errorf("failed to list stashes: %w", err)
+	}
+	if output != "" {
+		for i, line := range strings.Split(output, "\n") {
+			if strings.TrimSpace(line) == sha {
+				return fmt.Sprintf("stash@{%d}", i), nil
+			}
+		}
+	}
+	return "", fmt.Errorf("stashed changes %s are no longer in the stash", short(sha))
+}
+
+func short(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
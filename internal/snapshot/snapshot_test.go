@@ -0,0 +1,151 @@
+package snapshot
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gman/internal/git"
+)
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, output)
+	}
+	return strings.TrimSpace(string(output))
+}
+
+func commitFile(t *testing.T, dir, name, message string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(message), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, dir, "add", name)
+	runGit(t, dir, "commit", "-m", message)
+}
+
+func setupRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-b", "main")
+	runGit(t, dir, "config", "user.name", "Snapshot Tester")
+	runGit(t, dir, "config", "user.email", "snapshot@example.com")
+	commitFile(t, dir, "README.md", "initial commit")
+	return dir
+}
+
+func TestCaptureAndRestore(t *testing.T) {
+	dir := setupRepo(t)
+	gitMgr := git.NewManager()
+
+	runGit(t, dir, "checkout", "-b", "feature")
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("work in progress"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("untracked"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	repo, err := Capture(gitMgr, "task", "demo", dir)
+	if err != nil {
+		t.Fatalf("Capture failed: %v", err)
+	}
+	if repo.Branch != "feature" || repo.Stash == "" {
+		t.Fatalf("expected feature branch with a stash, got %+v", repo)
+	}
+	if status := runGit(t, dir, "status", "--porcelain"); status != "" {
+		t.Fatalf("expected a clean workspace after Capture, got %q", status)
+	}
+	if message := runGit(t, dir, "stash", "list"); !strings.Contains(message, StashMessage("task")) {
+		t.Errorf("expected the stash to be tagged, got %q", message)
+	}
+
+	runGit(t, dir, "checkout", "main")
+	note, err := Restore(gitMgr, repo)
+	if err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if note != "" {
+		t.Errorf("expected no note, got %q", note)
+	}
+	if branch := runGit(t, dir, "rev-parse", "--abbrev-ref", "HEAD"); branch != "feature" {
+		t.Errorf("expected feature to be checked out, got %s", branch)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "README.md")); string(data) != "work in progress" {
+		t.Errorf("expected the changes to be restored, got %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "notes.txt")); err != nil {
+		t.Errorf("expected the untracked file to be restored: %v", err)
+	}
+
+	// Uncommitted changes are never overwritten
+	if _, err := Restore(gitMgr, repo); err == nil {
+		t.Error("expected Restore to refuse a dirty workspace")
+	}
+
+	if err := Drop(gitMgr, repo); err != nil {
+		t.Fatalf("Drop failed: %v", err)
+	}
+	if stashes := runGit(t, dir, "stash", "list"); stashes != "" {
+		t.Errorf("expected the stash to be dropped, got %q", stashes)
+	}
+}
+
+func TestRestoreMovedBranch(t *testing.T) {
+	dir := setupRepo(t)
+	gitMgr := git.NewManager()
+
+	repo, err := Capture(gitMgr, "task", "demo", dir)
+	if err != nil {
+		t.Fatalf("Capture failed: %v", err)
+	}
+	if repo.Stash != "" {
+		t.Errorf("expected no stash for a clean repository, got %s", repo.Stash)
+	}
+	commitFile(t, dir, "LATER.md", "later commit")
+
+	note, err := Restore(gitMgr, repo)
+	if err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if !strings.Contains(note, "moved since the snapshot") {
+		t.Errorf("expected a note about the moved branch, got %q", note)
+	}
+}
+
+func TestStore(t *testing.T) {
+	path := StorePath(t.TempDir())
+	store, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	store.Put(Snapshot{Name: "a"})
+	store.Put(Snapshot{Name: "b", Group: "web"})
+	store.Put(Snapshot{Name: "a", Group: "api"})
+	if err := store.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(loaded.Snapshots) != 2 {
+		t.Fatalf("expected 2 snapshots, got %d", len(loaded.Snapshots))
+	}
+	if snap, ok := loaded.Get("a"); !ok || snap.Group != "api" {
+		t.Errorf("expected the replaced snapshot, got %+v", snap)
+	}
+	if !loaded.Delete("b") || loaded.Delete("b") {
+		t.Error("expected Delete to report whether the snapshot existed")
+	}
+}
@@ -0,0 +1,291 @@
+// Package undo keeps a journal of the repository state before and after bulk
+// operations, and restores the state recorded before an operation when
+// nothing changed since.
+package undo
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// JournalFile is the file name of the undo journal inside the config directory
+const JournalFile = "undo_journal.json"
+
+// maxEntries is the number of operations the journal keeps
+const maxEntries = 20
+
+// Runner runs git commands; *git.Manager implements it
+type Runner interface {
+	RunCommand(path string, args ...string) (string, error)
+}
+
+// State is what undo needs to know about a repository at one point in time
+type State struct {
+	Head   string `json:"head"`
+	Branch string `json:"branch"` // "HEAD" when detached
+	Stash  string `json:"stash,omitempty"`
+}
+
+// Capture reads the state of the repository at path
+func Capture(runner Runner, path string) (State, error) {
+	head, err := runner.RunCommand(path, "rev-parse", "HEAD")
+	if err != nil {
+		return State{}, fmt.Errorf("failed to read HEAD: %w", err)
+	}
+	branch, err := runner.RunCommand(path, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return State{}, fmt.Errorf("failed to read current branch: %w", err)
+	}
+	// No stash is not an error
+	stash, _ := runner.RunCommand(path, "rev-parse", "-q", "--verify", "refs/stash")
+	return State{Head: head, Branch: branch, Stash: stash}, nil
+}
+
+// Repository is one repository touched by an operation
+type Repository struct {
+	Alias  string `json:"alias"`
+	Path   string `json:"path"`
+	Before State  `json:"before"`
+	After  State  `json:"after"`
+	// DeletedBranches maps branches deleted by the operation to their tips
+	DeletedBranches map[string]string `json:"deleted_branches,omitempty"`
+}
+
+// Changed reports whether the operation changed anything undo can restore
+func (r Repository) Changed() bool {
+	return r.Before != r.After || len(r.DeletedBranches) > 0
+}
+
+// Entry is one recorded operation
+type Entry struct {
+	Operation    string       `json:"operation"`
+	Time         time.Time    `json:"time"`
+	Repositories []Repository `json:"repositories"`
+	Undone       bool         `json:"undone,omitempty"`
+}
+
+// Journal is the list of recorded operations, oldest first
+type Journal struct {
+	Entries []Entry `json:"entries"`
+}
+
+// JournalPath returns the journal location inside the given config directory
+func JournalPath(configDir string) string {
+	return filepath.Join(configDir, JournalFile)
+}
+
+// Load reads the journal at path; a missing journal is empty
+func Load(path string) (*Journal, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Journal{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var j Journal
+	if err := json.Unmarshal(data, &j); err != nil {
+		return nil, fmt.Errorf("invalid undo journal '%s': %w", path, err)
+	}
+	return &j, nil
+}
+
+// Save writes the journal to path atomically
+func (j *Journal) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creating journal directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling undo journal: %w", err)
+	}
+
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+		return fmt.Errorf("error writing temp journal file: %w", err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		os.Remove(tempPath) // Clean up on failure
+		return fmt.Errorf("error moving temp journal file: %w", err)
+	}
+	return nil
+}
+
+// Add appends an entry, dropping the oldest beyond maxEntries
+func (j *Journal) Add(entry Entry) {
+	j.Entries = append(j.Entries, entry)
+	if len(j.Entries) > maxEntries {
+		j.Entries = j.Entries[len(j.Entries)-maxEntries:]
+	}
+}
+
+// Last returns the index of the latest entry not undone yet, or -1
+func (j *Journal) Last() int {
+	for i := len(j.Entries) - 1; i >= 0; i-- {
+		if !j.Entries[i].Undone {
+			return i
+		}
+	}
+	return -1
+}
+
+// Recorder collects the repositories of one operation
+type Recorder struct {
+	runner       Runner
+	operation    string
+	started      time.Time
+	repositories map[string]*Repository
+	order        []string
+}
+
+// NewRecorder starts recording an operation
+func NewRecorder(runner Runner, operation string) *Recorder {
+	return &Recorder{
+		runner:       runner,
+		operation:    operation,
+		started:      time.Now(),
+		repositories: make(map[string]*Repository),
+	}
+}
+
+// Before records the state of a repository before the operation touches it.
+// Repositories whose state cannot be read are not recorded.
+func (r *Recorder) Before(alias, path string) {
+	if _, ok := r.repositories[alias]; ok {
+		return
+	}
+	state, err := Capture(r.runner, path)
+	if err != nil {
+		return
+	}
+	r.repositories[alias] = &Repository{Alias: alias, Path: path, Before: state}
+	r.order = append(r.order, alias)
+}
+
+// DeletingBranch records the tip of a branch the operation is about to delete
+func (r *Recorder) DeletingBranch(alias, branch string) {
+	repo, ok := r.repositories[alias]
+	if !ok {
+		return
+	}
+	tip, err := r.runner.RunCommand(repo.Path, "rev-parse", "--verify", "refs/heads/"+branch)
+	if err != nil {
+		return
+	}
+	if repo.DeletedBranches == nil {
+		repo.DeletedBranches = make(map[string]string)
+	}
+	repo.DeletedBranches[branch] = tip
+}
+
+// Entry reads the state after the operation and returns the entry of the
+// repositories that changed, or false when none did
+func (r *Recorder) Entry() (Entry, bool) {
+	entry := Entry{Operation: r.operation, Time: r.started}
+	for _, alias := range r.order {
+		repo := r.repositories[alias]
+		after, err := Capture(r.runner, repo.Path)
+		if err != nil {
+			continue
+		}
+		repo.After = after
+		for branch := range repo.DeletedBranches {
+			if _, err := r.runner.RunCommand(repo.Path, "rev-parse", "--verify", "refs/heads/"+branch); err == nil {
+				delete(repo.DeletedBranches, branch) // The deletion failed
+			}
+		}
+		if repo.Changed() {
+			entry.Repositories = append(entry.Repositories, *repo)
+		}
+	}
+	return entry, len(entry.Repositories) > 0
+}
+
+// Step is one git command that restores part of a repository's state
+type Step struct {
+	Description string
+	Args        []string
+}
+
+// Command returns the step as a git command line
+func (s Step) Command() string {
+	return "git " + strings.Join(s.Args, " ")
+}
+
+// Plan returns the steps restoring repo to its state before the operation.
+// It returns an error when the repository changed since the operation, or
+// when restoring would discard work.
+func Plan(runner Runner, repo Repository) ([]Step, error) {
+	current, err := Capture(runner, repo.Path)
+	if err != nil {
+		return nil, err
+	}
+	if current.Head != repo.After.Head || current.Branch != repo.After.Branch {
+		return nil, fmt.Errorf("%s changed since the operation", repo.Alias)
+	}
+
+	// Changes made since the operation, e.g. resolved conflicts, would be
+	// discarded or carried along
+	autostashLeft := repo.After.Stash != "" && repo.After.Stash != repo.Before.Stash
+	if autostashLeft || repo.Before.Head != repo.After.Head || repo.Before.Branch != repo.After.Branch {
+		status, err := runner.RunCommand(repo.Path, "status", "--porcelain")
+		if err != nil {
+			return nil, fmt.Errorf("failed to check workspace: %w", err)
+		}
+		if status != "" {
+			return nil, fmt.Errorf("%s has uncommitted changes", repo.Alias)
+		}
+	}
+
+	var steps []Step
+	// A pull --autostash whose changes conflicted leaves them in the stash
+	switch {
+	case autostashLeft:
+		if current.Stash != repo.After.Stash {
+			return nil, fmt.Errorf("%s: the stash changed since the operation", repo.Alias)
+		}
+		steps = append(steps,
+			Step{Description: "reset to " + short(repo.Before.Head), Args: []string{"reset", "--hard", repo.Before.Head}},
+			Step{Description: "re-apply stashed changes", Args: []string{"stash", "pop"}},
+		)
+	default:
+		switch {
+		case repo.Before.Branch != repo.After.Branch:
+			target := repo.Before.Branch
+			if target == "HEAD" {
+				target = repo.Before.Head
+			}
+			steps = append(steps, Step{Description: "check out " + target, Args: []string{"checkout", target}})
+		case repo.Before.Head != repo.After.Head:
+			steps = append(steps, Step{Description: "reset to " + short(repo.Before.Head), Args: []string{"reset", "--keep", repo.Before.Head}})
+		}
+	}
+
+	branches := make([]string, 0, len(repo.DeletedBranches))
+	for branch := range repo.DeletedBranches {
+		branches = append(branches, branch)
+	}
+	sort.Strings(branches)
+	for _, branch := range branches {
+		tip := repo.DeletedBranches[branch]
+		steps = append(steps, Step{
+			Description: fmt.Sprintf("recreate branch %s at %s", branch, short(tip)),
+			Args:        []string{"branch", branch, tip},
+		})
+	}
+	return steps, nil
+}
+
+func short(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -0,0 +1,175 @@
+package undo
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"gman/internal/git"
+)
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, output)
+	}
+}
+
+func commitFile(t *testing.T, dir, name, message string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(message), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, dir, "add", name)
+	runGit(t, dir, "commit", "-m", message)
+}
+
+func setupRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-b", "main")
+	runGit(t, dir, "config", "user.name", "Undo Tester")
+	runGit(t, dir, "config", "user.email", "undo@example.com")
+	commitFile(t, dir, "README.md", "initial commit")
+	return dir
+}
+
+func apply(t *testing.T, runner Runner, repo Repository) {
+	t.Helper()
+	steps, err := Plan(runner, repo)
+	if err != nil {
+		t.Fatalf("Plan failed: %v", err)
+	}
+	for _, step := range steps {
+		if _, err := runner.RunCommand(repo.Path, step.Args...); err != nil {
+			t.Fatalf("%s failed: %v", step.Description, err)
+		}
+	}
+}
+
+func TestUndoCommit(t *testing.T) {
+	dir := setupRepo(t)
+	gitMgr := git.NewManager()
+
+	recorder := NewRecorder(gitMgr, "replace --commit")
+	recorder.Before("demo", dir)
+	before, _ := Capture(gitMgr, dir)
+	commitFile(t, dir, "CHANGE.md", "replace commit")
+
+	entry, changed := recorder.Entry()
+	if !changed || len(entry.Repositories) != 1 {
+		t.Fatalf("expected one changed repository, got %+v", entry)
+	}
+	apply(t, gitMgr, entry.Repositories[0])
+
+	after, _ := Capture(gitMgr, dir)
+	if after.Head != before.Head {
+		t.Errorf("expected HEAD %s after undo, got %s", before.Head, after.Head)
+	}
+}
+
+func TestUndoBranchSwitchAndDelete(t *testing.T) {
+	dir := setupRepo(t)
+	gitMgr := git.NewManager()
+	runGit(t, dir, "branch", "feature")
+	runGit(t, dir, "branch", "stale")
+
+	recorder := NewRecorder(gitMgr, "find ref")
+	recorder.Before("demo", dir)
+	runGit(t, dir, "checkout", "feature")
+	recorder.DeletingBranch("demo", "stale")
+	runGit(t, dir, "branch", "-D", "stale")
+
+	entry, _ := recorder.Entry()
+	apply(t, gitMgr, entry.Repositories[0])
+
+	after, _ := Capture(gitMgr, dir)
+	if after.Branch != "main" {
+		t.Errorf("expected to be back on main, got %s", after.Branch)
+	}
+	if _, err := gitMgr.RunCommand(dir, "rev-parse", "--verify", "refs/heads/stale"); err != nil {
+		t.Errorf("expected branch stale to be recreated: %v", err)
+	}
+}
+
+func TestPlanRefusesChangedRepository(t *testing.T) {
+	dir := setupRepo(t)
+	gitMgr := git.NewManager()
+
+	recorder := NewRecorder(gitMgr, "sync")
+	recorder.Before("demo", dir)
+	commitFile(t, dir, "ONE.md", "first")
+	entry, _ := recorder.Entry()
+
+	// Work committed after the operation must not be reset away
+	commitFile(t, dir, "TWO.md", "second")
+	if _, err := Plan(gitMgr, entry.Repositories[0]); err == nil {
+		t.Error("expected Plan to refuse a repository that changed since the operation")
+	}
+}
+
+func TestPlanRefusesDirtyAutostash(t *testing.T) {
+	dir := setupRepo(t)
+	gitMgr := git.NewManager()
+
+	recorder := NewRecorder(gitMgr, "sync")
+	recorder.Before("demo", dir)
+	commitFile(t, dir, "ONE.md", "pulled")
+	// The autostashed changes conflicted and were left in the stash
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("local edit"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, dir, "stash")
+	entry, _ := recorder.Entry()
+
+	// Conflicts resolved by hand after the operation must not be reset away
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("resolved"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Plan(gitMgr, entry.Repositories[0]); err == nil {
+		t.Error("expected Plan to refuse a repository with uncommitted changes")
+	}
+}
+
+func TestRecorderSkipsUnchanged(t *testing.T) {
+	dir := setupRepo(t)
+	recorder := NewRecorder(git.NewManager(), "sync")
+	recorder.Before("demo", dir)
+
+	if _, changed := recorder.Entry(); changed {
+		t.Error("expected no entry for an unchanged repository")
+	}
+}
+
+func TestJournalSaveLoad(t *testing.T) {
+	path := JournalPath(t.TempDir())
+
+	journal, err := Load(path)
+	if err != nil || len(journal.Entries) != 0 {
+		t.Fatalf("expected empty journal, got %+v, %v", journal, err)
+	}
+	for i := 0; i < maxEntries+5; i++ {
+		journal.Add(Entry{Operation: "sync"})
+	}
+	journal.Entries[len(journal.Entries)-1].Undone = true
+	if err := journal.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(loaded.Entries) != maxEntries {
+		t.Errorf("expected %d entries, got %d", maxEntries, len(loaded.Entries))
+	}
+	if last := loaded.Last(); last != maxEntries-2 {
+		t.Errorf("expected latest entry not undone at %d, got %d", maxEntries-2, last)
+	}
+}
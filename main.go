@@ -1,12 +1,16 @@
 package main
 
 import (
+	"os"
+
 	"gman/cmd"
 	"gman/internal/errors"
 )
 
 func main() {
 	if err := cmd.Execute(); err != nil {
-		errors.Exit(err)
+		errors.Handle(err)
+		cmd.OfferRecovery(err)
+		os.Exit(errors.ExitCode(err))
 	}
 }
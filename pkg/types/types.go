@@ -7,19 +7,143 @@ import (
 
 // Config represents the gman configuration
 type Config struct {
-	Repositories   map[string]string `yaml:"repositories"`
-	CommandAliases map[string]string `yaml:"command_aliases,omitempty"`
-	Settings       Settings          `yaml:"settings,omitempty"`
-	RecentUsage    []RecentEntry     `yaml:"recent_usage,omitempty"`
-	Groups         map[string]Group  `yaml:"groups,omitempty"`
-	Tasks          map[string]Task   `yaml:"tasks,omitempty"`
+	Repositories      map[string]string      `yaml:"repositories"`
+	RepositoryOptions map[string]RepoOptions `yaml:"repository_options,omitempty"` // Per repository alias
+	CommandAliases    map[string]string      `yaml:"command_aliases,omitempty"`
+	Settings          Settings               `yaml:"settings,omitempty"`
+	RecentUsage       []RecentEntry          `yaml:"recent_usage,omitempty"`
+	Groups            map[string]Group       `yaml:"groups,omitempty"`
+	Tasks             map[string]Task        `yaml:"tasks,omitempty"`
+	Search            SearchSettings         `yaml:"search,omitempty"`
+	Hooks             map[string][]string    `yaml:"hooks,omitempty"` // Shell commands run on gman events, by event name
+	Notifications     NotificationSettings   `yaml:"notifications,omitempty"`
+	Bookmarks         map[string]Bookmark    `yaml:"bookmarks,omitempty"` // Files and directories inside repositories, by name
+	CodeWorkspaces    []CodeWorkspace        `yaml:"code_workspaces,omitempty"` // Generated VSCode workspaces kept up to date
+}
+
+// RepoOptions are the options of one repository
+type RepoOptions struct {
+	Protected      bool     `yaml:"protected,omitempty"`       // Refuse destructive git commands such as force pushes
+	DependsOn      []string `yaml:"depends_on,omitempty"`      // Aliases released before this repository by 'gman release'
+	VersionCommand string   `yaml:"version_command,omitempty"` // Shell command bumping version files for 'gman release'
+}
+
+// IsZero reports whether no option is set
+func (o RepoOptions) IsZero() bool {
+	return !o.Protected && len(o.DependsOn) == 0 && o.VersionCommand == ""
+}
+
+// IsProtected reports whether the repository alias is protected
+func (c *Config) IsProtected(alias string) bool {
+	return c.RepositoryOptions[alias].Protected
 }
 
 // Settings contains user preferences
 type Settings struct {
-	DefaultSyncMode string `yaml:"default_sync_mode,omitempty"`
-	ShowLastCommit  bool   `yaml:"show_last_commit"`
-	ParallelJobs    int    `yaml:"parallel_jobs"`
+	DefaultSyncMode      string            `yaml:"default_sync_mode,omitempty"`
+	ShowLastCommit       bool              `yaml:"show_last_commit"`
+	ParallelJobs         int               `yaml:"parallel_jobs"`
+	GitCommandLog        bool              `yaml:"git_command_log,omitempty"`        // Record every git command gman runs
+	AuditLog             bool              `yaml:"audit_log,omitempty"`              // Append every state-changing git command to an audit trail
+	Accessible           bool              `yaml:"accessible,omitempty"`             // No color, ASCII labels, no animations
+	SymbolBackend        string            `yaml:"symbol_backend,omitempty"`         // "ctags" (default) or "gopls"
+	TmuxMode             string            `yaml:"tmux_mode,omitempty"`              // "window" (default) or "session" for 'gman tmux open'
+	WorktreeBaseDir      string            `yaml:"worktree_base_dir,omitempty"`      // Where new worktrees are created (default: next to the repository)
+	WorktreeNameTemplate string            `yaml:"worktree_name_template,omitempty"` // Name of new worktrees, e.g. "{repo}-{branch}" (default: {dir}-{branch} next to the repository)
+	Emoji                *bool             `yaml:"emoji,omitempty"`                  // false replaces emoji with text labels (default: true)
+	LogFile              bool              `yaml:"log_file,omitempty"`               // Append all log messages to ~/.local/state/gman/gman.log
+	GitTimeout           string            `yaml:"git_timeout,omitempty"`            // Limit of a git command, e.g. "2m" (default: 2m)
+	GitTimeouts          map[string]string `yaml:"git_timeouts,omitempty"`           // Limits per git subcommand, e.g. fetch: 30s
+	SafeMode             bool              `yaml:"safe_mode,omitempty"`              // Refuse destructive git commands in every repository, like --safe
+}
+
+// EmojiEnabled reports whether output may use emoji; unset means yes
+func (s Settings) EmojiEnabled() bool {
+	return s.Emoji == nil || *s.Emoji
+}
+
+// CommandTimeouts parses git_timeout and git_timeouts. Unset values are
+// zero and the map only holds configured subcommands.
+func (s Settings) CommandTimeouts() (time.Duration, map[string]time.Duration, error) {
+	var defaultTimeout time.Duration
+	if s.GitTimeout != "" {
+		timeout, err := time.ParseDuration(s.GitTimeout)
+		if err != nil || timeout <= 0 {
+			return 0, nil, fmt.Errorf("invalid git_timeout '%s': use a positive duration such as 90s or 2m", s.GitTimeout)
+		}
+		defaultTimeout = timeout
+	}
+
+	overrides := make(map[string]time.Duration, len(s.GitTimeouts))
+	for subcommand, value := range s.GitTimeouts {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			return 0, nil, fmt.Errorf("invalid git_timeouts.%s '%s': use a positive duration such as 90s or 2m", subcommand, value)
+		}
+		overrides[subcommand] = timeout
+	}
+	return defaultTimeout, overrides, nil
+}
+
+// NotificationSettings configure the webhook notifications about
+// repositories that failed to sync or fell far behind their remote
+type NotificationSettings struct {
+	WebhookURL      string                       `yaml:"webhook_url,omitempty"`      // Slack-compatible incoming webhook
+	BehindThreshold int                          `yaml:"behind_threshold,omitempty"` // Commits behind that are reported (default: 50, negative: never)
+	RateLimit       string                       `yaml:"rate_limit,omitempty"`       // Minimum time between reports of the same problem (default: 1h)
+	Groups          map[string]GroupNotification `yaml:"groups,omitempty"`           // Overrides for the repositories of a group
+}
+
+// GroupNotification overrides the notification settings for a group; unset
+// values fall back to the global ones
+type GroupNotification struct {
+	WebhookURL      string `yaml:"webhook_url,omitempty"`
+	BehindThreshold int    `yaml:"behind_threshold,omitempty"`
+}
+
+// DefaultBehindThreshold and DefaultNotificationRateLimit apply when the
+// notification settings leave them unset
+const (
+	DefaultBehindThreshold       = 50
+	DefaultNotificationRateLimit = time.Hour
+)
+
+// Enabled reports whether any webhook is configured
+func (n NotificationSettings) Enabled() bool {
+	if n.WebhookURL != "" {
+		return true
+	}
+	for _, group := range n.Groups {
+		if group.WebhookURL != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Interval parses rate_limit
+func (n NotificationSettings) Interval() (time.Duration, error) {
+	if n.RateLimit == "" {
+		return DefaultNotificationRateLimit, nil
+	}
+	interval, err := time.ParseDuration(n.RateLimit)
+	if err != nil || interval < 0 {
+		return 0, fmt.Errorf("invalid notifications.rate_limit '%s': use a duration such as 30m or 6h", n.RateLimit)
+	}
+	return interval, nil
+}
+
+// SearchSettings controls what file and content searches skip
+type SearchSettings struct {
+	Exclude     []string            `yaml:"exclude,omitempty"`       // Globs skipped in every repository, e.g. node_modules
+	RepoExclude map[string][]string `yaml:"repo_exclude,omitempty"`  // Extra globs per repository alias
+	MaxFileSize string              `yaml:"max_file_size,omitempty"` // Larger files are skipped, e.g. "1M" or "512K"
+}
+
+// ExcludesFor returns the global and repository specific exclude globs for alias
+func (s SearchSettings) ExcludesFor(alias string) []string {
+	excludes := append([]string{}, s.Exclude...)
+	return append(excludes, s.RepoExclude[alias]...)
 }
 
 // WorkspaceStatus represents the status of a git workspace
@@ -44,6 +168,20 @@ func (w WorkspaceStatus) String() string {
 	}
 }
 
+// Label returns the workspace state as plain text without emoji
+func (w WorkspaceStatus) Label() string {
+	switch w {
+	case Clean:
+		return "CLEAN"
+	case Dirty:
+		return "DIRTY"
+	case Stashed:
+		return "STASHED"
+	default:
+		return "UNKNOWN"
+	}
+}
+
 // SyncStatus represents the sync status with remote
 type SyncStatus struct {
 	Ahead     int
@@ -67,6 +205,22 @@ func (s SyncStatus) String() string {
 	return "✅ UP-TO-DATE"
 }
 
+// Label returns the sync state as plain text without emoji or arrows
+func (s SyncStatus) Label() string {
+	if s.SyncError != nil {
+		return "SYNC FAILED"
+	}
+
+	if s.Ahead > 0 && s.Behind > 0 {
+		return fmt.Sprintf("%d AHEAD, %d BEHIND", s.Ahead, s.Behind)
+	} else if s.Ahead > 0 {
+		return fmt.Sprintf("%d AHEAD", s.Ahead)
+	} else if s.Behind > 0 {
+		return fmt.Sprintf("%d BEHIND", s.Behind)
+	}
+	return "UP-TO-DATE"
+}
+
 // RepoStatus represents the status of a single repository
 type RepoStatus struct {
 	Alias         string
@@ -83,11 +237,17 @@ type RepoStatus struct {
 	// Enhanced status information
 	RemoteURL       string        // URL of the remote origin
 	RemoteBranch    string        // Name of the tracking remote branch
+
+	// Extended status information, read only when requested (status --extended)
+	Extended        bool          // Whether the fields below were read
 	StashCount      int           // Number of stashes
 	TotalBranches   int           // Total number of branches (local + remote)
 	LocalBranches   int           // Number of local branches
 	RemoteBranches  int           // Number of remote branches
 	LastFetchTime   time.Time     // Time of last fetch operation
+
+	// Linked worktrees, read only when requested (status --worktrees)
+	Worktrees       []WorktreeStatus
 }
 
 // RecentEntry represents a recently used repository
@@ -96,6 +256,20 @@ type RecentEntry struct {
 	AccessTime time.Time `yaml:"access_time"`
 }
 
+// Bookmark is a file or directory inside a repository
+type Bookmark struct {
+	Repository string `yaml:"repository"`     // Repository alias
+	Path       string `yaml:"path,omitempty"` // Slash-separated path relative to the repository root
+}
+
+// CodeWorkspace is a generated VSCode multi-root workspace file, regenerated
+// when the repositories it was generated from change
+type CodeWorkspace struct {
+	Path      string `yaml:"path"`                // Absolute path of the .code-workspace file
+	Group     string `yaml:"group,omitempty"`     // Group of the repositories; empty for all
+	Worktrees bool   `yaml:"worktrees,omitempty"` // Whether the worktrees are included
+}
+
 // Group represents a collection of repositories
 type Group struct {
 	Name         string   `yaml:"name"`
@@ -111,14 +285,27 @@ type Worktree struct {
 	Commit     string `json:"commit"`
 	IsBare     bool   `json:"is_bare"`
 	IsDetached bool   `json:"is_detached"`
+	Prunable   string `json:"prunable,omitempty"` // Why git would prune the worktree, e.g. its directory is gone
+	IsLocked   bool   `json:"is_locked,omitempty"`
+	LockReason string `json:"lock_reason,omitempty"`
+}
+
+// WorktreeStatus represents the status of a linked worktree of a repository
+type WorktreeStatus struct {
+	Path         string
+	Branch       string
+	IsDetached   bool
+	Workspace    WorkspaceStatus // Clean or Dirty; stashes are shared by all worktrees
+	FilesChanged int
+	Error        error
 }
 
 // SwitchTarget represents a target that can be switched to (repository or worktree)
 type SwitchTarget struct {
 	Alias        string    `json:"alias"`        // Display name for the target
 	Path         string    `json:"path"`         // Actual filesystem path
-	Type         string    `json:"type"`         // "repository" or "worktree"
-	RepoAlias    string    `json:"repo_alias"`   // Parent repository alias (for worktrees)
+	Type         string    `json:"type"`         // "repository", "worktree" or "bookmark"
+	RepoAlias    string    `json:"repo_alias"`   // Parent repository alias (for worktrees and bookmarks)
 	Branch       string    `json:"branch,omitempty"` // Current branch (for worktrees)
 	Description  string    `json:"description,omitempty"` // Additional info
 	LastAccessed time.Time `json:"last_accessed,omitempty"` // For recent repository tracking
@@ -140,3 +327,29 @@ type Task struct {
 	CreatedAt   time.Time  `yaml:"created_at"`
 	UpdatedAt   time.Time  `yaml:"updated_at"`
 }
+
+// HealthFactor is one deduction from a repository's health score
+type HealthFactor struct {
+	Name    string `json:"name"`
+	Penalty int    `json:"penalty"`
+	Detail  string `json:"detail"`
+}
+
+// Health is the hygiene score of a repository from 0 to 100, with the
+// factors that lowered it
+type Health struct {
+	Score   int            `json:"score"`
+	Factors []HealthFactor `json:"factors,omitempty"`
+}
+
+// Grade describes the score in one word
+func (h Health) Grade() string {
+	switch {
+	case h.Score >= 90:
+		return "healthy"
+	case h.Score >= 70:
+		return "fair"
+	default:
+		return "poor"
+	}
+}
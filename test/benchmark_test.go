@@ -2,10 +2,10 @@ package test
 
 import (
 	"os"
-	"os/exec"
 	"path/filepath"
 	"testing"
 
+	"gman/internal/bench"
 	cmdutils "gman/internal/cmd"
 	"gman/internal/di"
 )
@@ -133,49 +133,9 @@ func BenchmarkConcurrentOperations(b *testing.B) {
 	}
 }
 
-// Helper function for simple benchmark repositories
+// createSimpleBenchmarkRepo creates a repository with one commit, shared
+// with 'gman bench'
 func createSimpleBenchmarkRepo(b testing.TB, repoPath, alias string) error {
 	b.Helper()
-	
-	if err := os.MkdirAll(repoPath, 0755); err != nil {
-		return err
-	}
-
-	// Initialize git repository quickly for benchmarking
-	if err := runBenchmarkGitCommand(repoPath, "init"); err != nil {
-		return err
-	}
-	
-	if err := runBenchmarkGitCommand(repoPath, "config", "user.name", "Benchmark User"); err != nil {
-		return err
-	}
-	
-	if err := runBenchmarkGitCommand(repoPath, "config", "user.email", "benchmark@test.com"); err != nil {
-		return err
-	}
-
-	// Create a simple file
-	readmePath := filepath.Join(repoPath, "README.md")
-	content := "# " + alias + "\nBenchmark repository"
-	if err := os.WriteFile(readmePath, []byte(content), 0644); err != nil {
-		return err
-	}
-
-	// Commit
-	if err := runBenchmarkGitCommand(repoPath, "add", "."); err != nil {
-		return err
-	}
-	
-	if err := runBenchmarkGitCommand(repoPath, "commit", "-m", "Initial commit"); err != nil {
-		return err
-	}
-
-	return nil
+	return bench.CreateRepository(repoPath, alias, "")
 }
-
-// Helper function to run git commands for benchmarks
-func runBenchmarkGitCommand(dir string, args ...string) error {
-	cmd := exec.Command("git", args...)
-	cmd.Dir = dir
-	return cmd.Run()
-}
\ No newline at end of file